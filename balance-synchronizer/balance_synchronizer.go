@@ -12,43 +12,107 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/freezer/tokenomics"
 	appCfg "github.com/ice-blockchain/wintr/config"
 	messagebroker "github.com/ice-blockchain/wintr/connectors/message_broker"
 	"github.com/ice-blockchain/wintr/connectors/storage/v3"
 	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
 )
 
 func init() {
-	appCfg.MustLoadFromKey(parentApplicationYamlKey, &cfg.Config)
+	tokenomics.MustLoadConfig(parentApplicationYamlKey, &cfg.Config)
 	appCfg.MustLoadFromKey(applicationYamlKey, &cfg)
 }
 
-func MustStartSynchronizingBalance(ctx context.Context) {
+func MustStartSynchronizingBalance(ctx context.Context, cancel context.CancelFunc) Client {
 	bs := &balanceSynchronizer{
-		mb: messagebroker.MustConnect(context.Background(), parentApplicationYamlKey),
+		mb:     messagebroker.MustConnect(context.Background(), parentApplicationYamlKey),
+		db:     tokenomics.MustConnectStorage(context.Background(), &cfg.Config, parentApplicationYamlKey, 1),
+		cancel: cancel,
+		wg:     new(sync.WaitGroup),
 	}
-	defer func() { log.Panic(errors.Wrap(bs.Close(), "failed to stop balanceSynchronizer")) }()
-
-	wg := new(sync.WaitGroup)
-	wg.Add(int(cfg.Workers))
-	defer wg.Wait()
+	model.MustMigrateAmounts(ctx, bs.db)
+	if cfg.Blockchain.Enabled {
+		bs.blockchain = mustNewEVMBlockchainAccountSynchronizer(ctx, &cfg.Blockchain)
+		bs.blockchainQueue = make(chan []*BlockchainAccount, blockchainQueueSize)
+		bs.confirmationTimeout = cfg.Blockchain.ConfirmationTimeout
+		if bs.confirmationTimeout == 0 {
+			bs.confirmationTimeout = defaultBlockchainConfirmationTimeout
+		}
+		bs.wg.Add(1)
+		go func() {
+			defer bs.wg.Done()
+			bs.synchronizeBlockchain(ctx)
+		}()
+	}
+	bs.wg.Add(int(cfg.Workers))
 
 	for workerNumber := int64(0); workerNumber < cfg.Workers; workerNumber++ {
 		go func(wn int64) {
-			defer wg.Done()
+			defer bs.wg.Done()
 			bs.synchronize(ctx, wn)
 		}(workerNumber)
 	}
+
+	return bs
 }
 
 func (bs *balanceSynchronizer) Close() error {
+	bs.cancel()
+	bs.wg.Wait()
+	var blockchainErr error
+	if bs.blockchain != nil {
+		blockchainErr = errors.Wrap(bs.blockchain.Close(), "failed to close blockchain account synchronizer")
+	}
+
 	return multierror.Append(
 		errors.Wrap(bs.mb.Close(), "failed to close mb"),
+		errors.Wrap(bs.db.Close(), "failed to close db"),
+		blockchainErr,
 	).ErrorOrNil()
 }
 
+func (bs *balanceSynchronizer) CheckHealth(ctx context.Context) error {
+	if err := bs.checkDBHealth(ctx); err != nil {
+		return err
+	}
+	type ts struct {
+		TS *time.Time `json:"ts"`
+	}
+	now := ts{TS: time.Now()}
+	bytes, err := json.MarshalContext(ctx, now)
+	if err != nil {
+		return errors.Wrapf(err, "[health-check] failed to marshal %#v", now)
+	}
+	responder := make(chan error, 1)
+	bs.mb.SendMessage(ctx, &messagebroker.Message{
+		Headers: map[string]string{"producer": "freezer"},
+		Key:     cfg.MessageBroker.Topics[0].Name,
+		Topic:   cfg.MessageBroker.Topics[0].Name,
+		Value:   bytes,
+	}, responder)
+
+	return errors.Wrapf(<-responder, "[health-check] failed to send health check message to broker")
+}
+
+func (bs *balanceSynchronizer) checkDBHealth(ctx context.Context) error {
+	if resp := bs.db.Ping(ctx); resp.Err() != nil || resp.Val() != "PONG" {
+		if resp.Err() == nil {
+			resp.SetErr(errors.Errorf("response `%v` is not `PONG`", resp.Val()))
+		}
+
+		return errors.Wrap(resp.Err(), "[health-check] failed to ping DB")
+	}
+	if !bs.db.IsRW(ctx) {
+		return errors.New("db is not writeable")
+	}
+
+	return nil
+}
+
 func (bs *balanceSynchronizer) synchronize(ctx context.Context, workerNumber int64) {
-	db := storage.MustConnect(context.Background(), parentApplicationYamlKey, 1)
+	db := tokenomics.MustConnectStorage(context.Background(), &cfg.Config, parentApplicationYamlKey, 1)
 	defer func() {
 		if err := recover(); err != nil {
 			log.Error(db.Close())
@@ -67,7 +131,7 @@ func (bs *balanceSynchronizer) synchronize(ctx context.Context, workerNumber int
 		msgs               = make([]*messagebroker.Message, 0, batchSize)
 		errs               = make([]error, 0, batchSize)
 		updatedUsers       = make([]redis.Z, 0, batchSize)
-		blockchainMessages = make([]*blockchainMessage, 0, batchSize)
+		blockchainAccounts = make([]*BlockchainAccount, 0, batchSize)
 	)
 	resetVars := func(success bool) {
 		if success && len(userResults) < int(batchSize) {
@@ -78,7 +142,7 @@ func (bs *balanceSynchronizer) synchronize(ctx context.Context, workerNumber int
 		userResults = userResults[:0]
 		msgs, errs = msgs[:0], errs[:0]
 		updatedUsers = updatedUsers[:0]
-		blockchainMessages = blockchainMessages[:0]
+		blockchainAccounts = blockchainAccounts[:0]
 	}
 	for ctx.Err() == nil {
 		/******************************************************************************************************************************************************
@@ -105,8 +169,8 @@ func (bs *balanceSynchronizer) synchronize(ctx context.Context, workerNumber int
 		for _, usr := range userResults {
 			updatedUsers = append(updatedUsers, GlobalRank(usr.ID, usr.BalanceTotalStandard+usr.BalanceTotalPreStaking))
 			msgs = append(msgs, BalanceUpdatedMessage(ctx, usr.UserID, usr.BalanceTotalStandard, usr.BalanceTotalPreStaking))
-			if msg := shouldSynchronizeBlockchainAccount(iteration, usr); msg != nil {
-				blockchainMessages = append(blockchainMessages, msg)
+			if account := shouldSynchronizeBlockchainAccount(iteration, usr); account != nil {
+				blockchainAccounts = append(blockchainAccounts, account)
 			}
 		}
 
@@ -147,39 +211,31 @@ func (bs *balanceSynchronizer) synchronize(ctx context.Context, workerNumber int
 		}
 
 		/******************************************************************************************************************************************************
-			5. Updating balances in the blockchain for that batch of users.
+			5. Queueing the balances of that batch of users to be updated in the blockchain.
 		******************************************************************************************************************************************************/
 
-		reqCtx, reqCancel = context.WithTimeout(context.Background(), requestDeadline)
-		if err := bs.synchronizeBlockchainAccounts(reqCtx, blockchainMessages); err != nil {
-			log.Error(errors.Wrapf(err, "[balanceSynchronizer] failed to synchronizeBlockchainAccount for batchNumer:%v,workerNumber:%v", batchNumber, workerNumber))
-			reqCancel()
-			resetVars(false)
-
-			continue
-		}
+		bs.enqueueBlockchainAccounts(blockchainAccounts)
 
 		batchNumber++
-		reqCancel()
 		resetVars(true)
 	}
 
 }
 
-func GlobalRank(id int64, totalBalance float64) redis.Z {
+func GlobalRank(id int64, totalBalance model.Amount) redis.Z {
 	return redis.Z{
-		Score:  totalBalance,
+		Score:  totalBalance.Float64(),
 		Member: model.SerializedUsersKey(id),
 	}
 }
 
 func BalanceUpdatedMessage(
-	ctx context.Context, userID string, totalStandardBalance, totalPreStakingBalance float64,
+	ctx context.Context, userID string, totalStandardBalance, totalPreStakingBalance model.Amount,
 ) *messagebroker.Message {
 	event := &BalanceUpdated{
 		UserID:     userID,
-		Standard:   totalStandardBalance,
-		PreStaking: totalPreStakingBalance,
+		Standard:   totalStandardBalance.Float64(),
+		PreStaking: totalPreStakingBalance.Float64(),
 	}
 	valueBytes, err := json.MarshalContext(ctx, event)
 	log.Panic(errors.Wrapf(err, "failed to marshal %#v", event))
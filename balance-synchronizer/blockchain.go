@@ -4,47 +4,68 @@ package balancesynchronizer
 
 import (
 	"context"
-	"strconv"
-	"strings"
+	"fmt"
 
-	"github.com/ice-blockchain/wintr/coin"
-)
+	"github.com/pkg/errors"
 
-type (
-	blockchainMessage struct { // TODO: delete this and use the actual one.
-		AccountAddress     string
-		ICEFlake           string
-		PreStakingICEFlake string
-	}
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
+	"github.com/ice-blockchain/wintr/log"
 )
 
-func shouldSynchronizeBlockchainAccount(iteration uint64, usr *user) *blockchainMessage {
+func shouldSynchronizeBlockchainAccount(iteration uint64, usr *user) *BlockchainAccount {
 	if usr.MiningBlockchainAccountAddress == "" || iteration%100 != 0 {
 		return nil
 	}
-	var standard, preStaking *coin.ICEFlake
-	if standardParts := strings.Split(strconv.FormatFloat(usr.BalanceTotalStandard, 'f', 9, 64), "."); len(standardParts) == 1 {
-		standard = coin.UnsafeParseAmount(standardParts[0]).MultiplyUint64(coin.Denomination)
-	} else if len(standardParts) == 2 {
-		standard = coin.UnsafeParseAmount(standardParts[0]).MultiplyUint64(coin.Denomination).Add(coin.UnsafeParseAmount(standardParts[1]))
-	}
-	if preStakingParts := strings.Split(strconv.FormatFloat(usr.BalanceTotalPreStaking, 'f', 9, 64), "."); len(preStakingParts) == 1 {
-		preStaking = coin.UnsafeParseAmount(preStakingParts[0]).MultiplyUint64(coin.Denomination)
-	} else if len(preStakingParts) == 2 {
-		preStaking = coin.UnsafeParseAmount(preStakingParts[0]).MultiplyUint64(coin.Denomination).Add(coin.UnsafeParseAmount(preStakingParts[1]))
+	return &BlockchainAccount{
+		AccountAddress: usr.MiningBlockchainAccountAddress,
+		Standard:       usr.BalanceTotalStandard.ICEFlake(),
+		PreStaking:     usr.BalanceTotalPreStaking.ICEFlake(),
 	}
+}
 
-	return &blockchainMessage{
-		AccountAddress:     usr.MiningBlockchainAccountAddress,
-		ICEFlake:           coin.ZeroICEFlakes().Add(standard).String(),
-		PreStakingICEFlake: coin.ZeroICEFlakes().Add(preStaking).String(),
+// The blockchain is synchronized in the background, so that a slow chain never holds back the batches (and their kafka & top_miners updates).
+// If it falls behind, the accounts are skipped; their balances are synchronized again on the next cycle anyway.
+func (bs *balanceSynchronizer) enqueueBlockchainAccounts(accounts []*BlockchainAccount) {
+	if len(accounts) == 0 || bs.blockchain == nil {
+		return
+	}
+	select {
+	case bs.blockchainQueue <- append(make([]*BlockchainAccount, 0, len(accounts)), accounts...):
+	default:
+		log.Error(errors.Errorf("[balanceSynchronizer] blockchain synchronization is falling behind, skipped %v accounts until the next cycle", len(accounts)))
 	}
 }
 
-func (bs *balanceSynchronizer) synchronizeBlockchainAccounts(ctx context.Context, msgs []*blockchainMessage) error {
-	if len(msgs) == 0 || ctx.Err() != nil {
-		return nil
+func (bs *balanceSynchronizer) synchronizeBlockchain(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case accounts := <-bs.blockchainQueue:
+			if err := bs.synchronizeBlockchainAccounts(ctx, accounts); err != nil {
+				log.Error(errors.Wrapf(err, "[balanceSynchronizer] failed to synchronize %v blockchain accounts", len(accounts)))
+			}
+		}
 	}
+}
 
-	return nil
+// The transaction is broadcasted only once. If it's not mined in time, we keep waiting for that same transaction instead of sending the balances again,
+// which would pay the gas twice and could apply them out of order. If it still isn't mined (or it reverted),
+// the accounts are synchronized again on the next cycle, with their balances at that time.
+func (bs *balanceSynchronizer) synchronizeBlockchainAccounts(ctx context.Context, accounts []*BlockchainAccount) error {
+	reqCtx, reqCancel := context.WithTimeout(ctx, requestDeadline)
+	hash, err := bs.blockchain.SynchronizeBlockchainAccounts(reqCtx, accounts)
+	reqCancel()
+	if err != nil || hash == "" {
+		return errors.Wrap(err, "failed to broadcast the balances")
+	}
+	for attempt := 1; ; attempt++ {
+		reqCtx, reqCancel = context.WithTimeout(ctx, bs.confirmationTimeout)
+		err = bs.blockchain.WaitSynchronized(reqCtx, hash)
+		reqCancel()
+		if err == nil || errors.Is(err, coindistribution.ErrTransactionFailed) || attempt == blockchainConfirmationAttempts || ctx.Err() != nil {
+			return errors.Wrapf(err, "failed to confirm transaction %v", hash)
+		}
+		log.Warn(fmt.Sprintf("[balanceSynchronizer] transaction %v is not mined yet, attempt %v/%v: %v", hash, attempt, blockchainConfirmationAttempts, err))
+	}
 }
@@ -3,21 +3,48 @@
 package balancesynchronizer
 
 import (
+	"context"
+	_ "embed"
+	"io"
+	"sync"
 	stdlibtime "time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
 	"github.com/ice-blockchain/freezer/model"
 	"github.com/ice-blockchain/freezer/tokenomics"
+	"github.com/ice-blockchain/wintr/coin"
 	messagebroker "github.com/ice-blockchain/wintr/connectors/message_broker"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
 )
 
 // Public API.
 
 type (
+	Client interface {
+		io.Closer
+		CheckHealth(context.Context) error
+	}
+	// BlockchainAccountSynchronizer writes the standard and pre-staking balances of the provided accounts to the blockchain.
+	BlockchainAccountSynchronizer interface {
+		io.Closer
+		// SynchronizeBlockchainAccounts broadcasts the balances and returns the hash of the transaction, without waiting for it to be mined.
+		// The hash is empty if there was nothing to broadcast.
+		SynchronizeBlockchainAccounts(ctx context.Context, accounts []*BlockchainAccount) (string, error)
+		// WaitSynchronized waits for the transaction returned by SynchronizeBlockchainAccounts to be mined.
+		WaitSynchronized(ctx context.Context, hash string) error
+	}
 	BalanceUpdated struct {
 		UserID     string  `json:"userId,omitempty"`
 		Standard   float64 `json:"standard,omitempty"`
 		PreStaking float64 `json:"preStaking,omitempty"`
 	}
+	BlockchainAccount struct {
+		Standard       *coin.ICEFlake
+		PreStaking     *coin.ICEFlake
+		AccountAddress string
+	}
 )
 
 // Private API.
@@ -26,6 +53,10 @@ const (
 	applicationYamlKey       = "balance-synchronizer"
 	parentApplicationYamlKey = "tokenomics"
 	requestDeadline          = 30 * stdlibtime.Second
+
+	defaultBlockchainConfirmationTimeout = 5 * stdlibtime.Minute
+	blockchainConfirmationAttempts       = 3
+	blockchainQueueSize                  = 10
 )
 
 // .
@@ -33,9 +64,12 @@ var (
 	//nolint:gochecknoglobals // Singleton & global config mounted only during bootstrap.
 	cfg struct {
 		tokenomics.Config `mapstructure:",squash"` //nolint:tagliatelle // Nope.
+		Blockchain        blockchainConfig         `yaml:"blockchain"`
 		Workers           int64                    `yaml:"workers"`
 		BatchSize         int64                    `yaml:"batchSize"`
 	}
+	//go:embed balances_abi.json
+	balancesContractABI string
 )
 
 type (
@@ -48,6 +82,28 @@ type (
 	}
 
 	balanceSynchronizer struct {
-		mb messagebroker.Client
+		mb                  messagebroker.Client
+		db                  storage.DB
+		blockchain          BlockchainAccountSynchronizer
+		blockchainQueue     chan []*BlockchainAccount
+		cancel              context.CancelFunc
+		wg                  *sync.WaitGroup
+		confirmationTimeout stdlibtime.Duration
+	}
+	evmBlockchainAccountSynchronizer struct {
+		transactor *coindistribution.Transactor
+		contract   *bind.BoundContract
+	}
+	blockchainConfig struct {
+		RPC             string `yaml:"rpc"`
+		PrivateKey      string `yaml:"privateKey"`
+		ContractAddress string `yaml:"contractAddress"`
+		ChainID         int64  `yaml:"chainId"`
+		GasLimit        uint64 `yaml:"gasLimit"`
+		// How long to wait for a transaction to be mined, per attempt, before giving up on it.
+		ConfirmationTimeout stdlibtime.Duration `yaml:"confirmationTimeout"`
+		// The EIP-1559 fees are used by default.
+		LegacyGasPrice bool `yaml:"legacyGasPrice"`
+		Enabled        bool `yaml:"enabled"`
 	}
 )
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package balancesynchronizer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
+	"github.com/ice-blockchain/wintr/log"
+)
+
+func (cfg *blockchainConfig) EnsureValid() {
+	if cfg.ChainID == 0 {
+		log.Panic("blockchain.chainID must be > 0")
+	}
+	if cfg.RPC == "" {
+		log.Panic("blockchain.rpc must not be empty")
+	}
+	if cfg.PrivateKey == "" {
+		log.Panic("blockchain.privateKey must not be empty")
+	}
+	_, err := crypto.HexToECDSA(cfg.PrivateKey)
+	log.Panic(errors.Wrap(err, "blockchain.privateKey is invalid")) //nolint:revive,nolintlint //.
+
+	if !common.IsHexAddress(cfg.ContractAddress) {
+		log.Panic("blockchain.contractAddress must be a valid address")
+	}
+}
+
+func mustNewEVMBlockchainAccountSynchronizer(ctx context.Context, cfg *blockchainConfig) BlockchainAccountSynchronizer {
+	cfg.EnsureValid()
+	rpcClient, err := ethclient.DialContext(ctx, cfg.RPC)
+	log.Panic(errors.Wrap(err, "failed to connect to ethereum RPC")) //nolint:revive,nolintlint //.
+	key, err := crypto.HexToECDSA(cfg.PrivateKey)
+	log.Panic(errors.Wrap(err, "failed to parse private key")) //nolint:revive,nolintlint //.
+	synchronizer, err := newEVMBlockchainAccountSynchronizer(rpcClient, key, cfg)
+	log.Panic(errors.Wrap(err, "failed to create contract instance")) //nolint:revive,nolintlint //.
+
+	return synchronizer
+}
+
+// The transactions are sent by the same client as the coin distributions (nonces, fees, retries), but with their own key.
+func newEVMBlockchainAccountSynchronizer(
+	rpcClient coindistribution.EthRPC, key *ecdsa.PrivateKey, cfg *blockchainConfig,
+) (*evmBlockchainAccountSynchronizer, error) {
+	parsed, err := abi.JSON(strings.NewReader(balancesContractABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse balances contract abi")
+	}
+
+	return &evmBlockchainAccountSynchronizer{
+		transactor: coindistribution.NewTransactor(rpcClient, key, big.NewInt(cfg.ChainID), cfg.GasLimit, cfg.LegacyGasPrice),
+		contract:   bind.NewBoundContract(common.HexToAddress(cfg.ContractAddress), parsed, rpcClient, rpcClient, rpcClient),
+	}, nil
+}
+
+func (e *evmBlockchainAccountSynchronizer) SynchronizeBlockchainAccounts(ctx context.Context, accounts []*BlockchainAccount) (string, error) {
+	addresses := make([]common.Address, 0, len(accounts))
+	standard := make([]*big.Int, 0, len(accounts))
+	preStaking := make([]*big.Int, 0, len(accounts))
+	for _, account := range accounts {
+		if !common.IsHexAddress(account.AccountAddress) {
+			log.Error(errors.Errorf("skipping invalid blockchain account address `%v`", account.AccountAddress))
+
+			continue
+		}
+		addresses = append(addresses, common.HexToAddress(account.AccountAddress))
+		standard = append(standard, account.Standard.BigInt())
+		preStaking = append(preStaking, account.PreStaking.BigInt())
+	}
+	if len(addresses) == 0 {
+		return "", nil
+	}
+	hash, err := e.transactor.Transact(ctx, e.contract, "setBalances", addresses, standard, preStaking)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to set balances for %v accounts", len(addresses))
+	}
+	log.Info(fmt.Sprintf("balance-synchronizer: new transaction: %v | accounts %v", hash, len(addresses)))
+
+	return hash, nil
+}
+
+func (e *evmBlockchainAccountSynchronizer) WaitSynchronized(ctx context.Context, hash string) error {
+	return errors.Wrapf(e.transactor.WaitMined(ctx, hash), "failed to wait for transaction %v", hash)
+}
+
+func (e *evmBlockchainAccountSynchronizer) Close() error {
+	return errors.Wrap(e.transactor.Close(), "failed to close transactor")
+}
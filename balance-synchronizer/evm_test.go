@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package balancesynchronizer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ice-blockchain/wintr/coin"
+)
+
+// A contract that accepts any call and emits the calldata as an anonymous log, so we can check what was sent to `setBalances`.
+// Runtime: CALLDATASIZE PUSH1 0 PUSH1 0 CALLDATACOPY CALLDATASIZE PUSH1 0 LOG0 STOP.
+const calldataLoggerBytecode = "0x600b600c600039600b6000f3" + "366000600037366000a000"
+
+func TestEVMBlockchainAccountSynchronizer(t *testing.T) { //nolint:funlen // .
+	t.Parallel()
+	backend, contractAddress, _, synchronizer := newSimulatedBlockchainAccountSynchronizer(t)
+	client := backend.Client()
+	ctx := context.Background()
+	defer commitContinuously(backend)()
+	accounts := []*BlockchainAccount{
+		{AccountAddress: "0x43aF1EbbA8B8B3cd5A0d0E5b4c1e9E0b8b4E8cE1", Standard: coin.NewAmountUint64(1_500_000_000), PreStaking: coin.ZeroICEFlakes()},
+		{AccountAddress: "bogus", Standard: coin.NewAmountUint64(1), PreStaking: coin.NewAmountUint64(1)},
+		{AccountAddress: "0x0000000000000000000000000000000000000001", Standard: coin.UnsafeParseAmount("21000000000000000000"), PreStaking: coin.NewAmountUint64(7)},
+	}
+	hash, err := synchronizer.SynchronizeBlockchainAccounts(ctx, accounts)
+	require.NoError(t, err)
+	require.NoError(t, synchronizer.WaitSynchronized(ctx, hash))
+
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{Addresses: []common.Address{contractAddress}})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	tx, _, err := client.TransactionByHash(ctx, logs[0].TxHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, contractAddress, *tx.To())
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Len(t, receipt.Logs, 1)
+
+	parsed, err := abi.JSON(strings.NewReader(balancesContractABI))
+	require.NoError(t, err)
+	calldata := receipt.Logs[0].Data
+	assert.Equal(t, parsed.Methods["setBalances"].ID, calldata[:4])
+	args, err := parsed.Methods["setBalances"].Inputs.Unpack(calldata[4:])
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{
+		common.HexToAddress("0x43aF1EbbA8B8B3cd5A0d0E5b4c1e9E0b8b4E8cE1"),
+		common.HexToAddress("0x0000000000000000000000000000000000000001"),
+	}, args[0])
+	assert.Equal(t, "[1500000000 21000000000000000000]", fmt.Sprint(args[1]))
+	assert.Equal(t, "[0 7]", fmt.Sprint(args[2]))
+
+	hash, err = synchronizer.SynchronizeBlockchainAccounts(ctx, accounts[1:2])
+	require.NoError(t, err)
+	assert.Empty(t, hash)
+	require.NoError(t, synchronizer.Close())
+}
+
+func TestBalanceSynchronizerWaitsForTheSameTransactionWhenItIsNotMinedInTime(t *testing.T) {
+	t.Parallel()
+	backend, contractAddress, sender, synchronizer := newSimulatedBlockchainAccountSynchronizer(t)
+	defer func() { require.NoError(t, synchronizer.Close()) }()
+	client := backend.Client()
+	ctx := context.Background()
+	bs := &balanceSynchronizer{blockchain: synchronizer, confirmationTimeout: 300 * time.Millisecond}
+	accounts := []*BlockchainAccount{
+		{AccountAddress: "0x0000000000000000000000000000000000000001", Standard: coin.NewAmountUint64(1), PreStaking: coin.NewAmountUint64(2)},
+	}
+
+	// Nothing gets mined, so every attempt times out, but the balances are broadcasted only once.
+	err := bs.synchronizeBlockchainAccounts(ctx, accounts)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	pendingNonce, err := client.PendingNonceAt(ctx, sender)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), pendingNonce)
+	nonce, err := client.NonceAt(ctx, sender, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), nonce)
+	backend.Commit()
+
+	// It gets mined while an attempt is still waiting, so a later attempt confirms it without broadcasting it again.
+	go func() {
+		time.Sleep(450 * time.Millisecond)
+		backend.Commit()
+	}()
+	require.NoError(t, bs.synchronizeBlockchainAccounts(ctx, accounts))
+	nonce, err = client.NonceAt(ctx, sender, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+	pendingNonce, err = client.PendingNonceAt(ctx, sender)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), pendingNonce)
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{Addresses: []common.Address{contractAddress}})
+	require.NoError(t, err)
+	assert.Len(t, logs, 2)
+}
+
+func TestBalanceSynchronizerEnqueuesACopyOfTheAccounts(t *testing.T) {
+	t.Parallel()
+	bs := &balanceSynchronizer{blockchain: new(evmBlockchainAccountSynchronizer), blockchainQueue: make(chan []*BlockchainAccount, 1)}
+	accounts := []*BlockchainAccount{{AccountAddress: "a"}, {AccountAddress: "b"}}
+	bs.enqueueBlockchainAccounts(accounts)
+	accounts[0] = &BlockchainAccount{AccountAddress: "c"}
+	bs.enqueueBlockchainAccounts(accounts)
+	bs.enqueueBlockchainAccounts(nil)
+
+	require.Len(t, bs.blockchainQueue, 1)
+	assert.Equal(t, []*BlockchainAccount{{AccountAddress: "a"}, {AccountAddress: "b"}}, <-bs.blockchainQueue)
+}
+
+// The contract is deployed by another account, so the nonces of the synchronizer start from 0.
+func newSimulatedBlockchainAccountSynchronizer(t *testing.T) (*simulated.Backend, common.Address, common.Address, *evmBlockchainAccountSynchronizer) { //nolint:lll // .
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender, deployer := crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(deployerKey.PublicKey)
+	balance := new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)
+	backend := simulated.NewBackend(types.GenesisAlloc{sender: {Balance: balance}, deployer: {Balance: balance}})
+	t.Cleanup(func() { require.NoError(t, backend.Close()) })
+	client := backend.Client()
+	chainID, err := client.ChainID(context.Background())
+	require.NoError(t, err)
+
+	opts, err := bind.NewKeyedTransactorWithChainID(deployerKey, chainID)
+	require.NoError(t, err)
+	contractAddress, _, _, err := bind.DeployContract(opts, abi.ABI{}, hexutil.MustDecode(calldataLoggerBytecode), client)
+	require.NoError(t, err)
+	backend.Commit()
+
+	synchronizer, err := newEVMBlockchainAccountSynchronizer(client, key, &blockchainConfig{
+		ContractAddress: contractAddress.Hex(),
+		ChainID:         chainID.Int64(),
+	})
+	require.NoError(t, err)
+
+	return backend, contractAddress, sender, synchronizer
+}
+
+// The synchronizer waits for the transactions to be mined.
+func commitContinuously(backend *simulated.Backend) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(100 * time.Millisecond):
+				backend.Commit()
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
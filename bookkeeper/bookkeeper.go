@@ -14,38 +14,80 @@ import (
 
 	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
 	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/freezer/tokenomics"
 	appCfg "github.com/ice-blockchain/wintr/config"
 	"github.com/ice-blockchain/wintr/connectors/storage/v3"
 	"github.com/ice-blockchain/wintr/log"
 )
 
 func init() {
-	appCfg.MustLoadFromKey(parentApplicationYamlKey, &cfg.Config)
+	tokenomics.MustLoadConfig(parentApplicationYamlKey, &cfg.Config)
 	appCfg.MustLoadFromKey(applicationYamlKey, &cfg)
 }
 
-func MustStartBookkeeping(ctx context.Context) {
-	bk := &bookkeeper{}
-	defer func() { log.Panic(errors.Wrap(bk.Close(), "failed to stop bookkeeper")) }()
-
-	wg := new(sync.WaitGroup)
-	wg.Add(int(cfg.Workers))
-	defer wg.Wait()
+func MustStartBookkeeping(ctx context.Context, cancel context.CancelFunc) Client {
+	bk := &bookkeeper{
+		db:        tokenomics.MustConnectStorage(context.Background(), &cfg.Config, parentApplicationYamlKey, 1),
+		dwhClient: dwh.MustConnect(context.Background(), applicationYamlKey, cfg.DWHTenant()),
+		cancel:    cancel,
+		wg:        new(sync.WaitGroup),
+	}
+	model.MustMigrateAmounts(ctx, bk.db)
+	bk.reconciler = newReconciler(bk.db, bk.dwhClient)
+	bk.wg.Add(int(cfg.Workers))
 
 	for workerNumber := int64(0); workerNumber < cfg.Workers; workerNumber++ {
 		go func(wn int64) {
-			defer wg.Done()
+			defer bk.wg.Done()
 			bk.bookKeep(ctx, wn)
 		}(workerNumber)
 	}
+	if cfg.Reconciliation.Enabled {
+		bk.wg.Add(1)
+		go func() {
+			defer bk.wg.Done()
+			bk.reconciler.reconcileContinuously(ctx)
+		}()
+	}
+
+	return bk
 }
 
 func (bk *bookkeeper) Close() error {
+	bk.cancel()
+	bk.wg.Wait()
+
+	return multierror.Append(
+		errors.Wrap(bk.db.Close(), "failed to close db"),
+		errors.Wrap(bk.dwhClient.Close(), "failed to close dwh"),
+	).ErrorOrNil()
+}
+
+func (bk *bookkeeper) CheckHealth(ctx context.Context) error {
+	if err := bk.dwhClient.Ping(ctx); err != nil {
+		return errors.Wrap(err, "[health-check] failed to ping dwh")
+	}
+
+	return bk.checkDBHealth(ctx)
+}
+
+func (bk *bookkeeper) checkDBHealth(ctx context.Context) error {
+	if resp := bk.db.Ping(ctx); resp.Err() != nil || resp.Val() != "PONG" {
+		if resp.Err() == nil {
+			resp.SetErr(errors.Errorf("response `%v` is not `PONG`", resp.Val()))
+		}
+
+		return errors.Wrap(resp.Err(), "[health-check] failed to ping DB")
+	}
+	if !bk.db.IsRW(ctx) {
+		return errors.New("db is not writeable")
+	}
+
 	return nil
 }
 
 func (bk *bookkeeper) bookKeep(ctx context.Context, workerNumber int64) {
-	db := storage.MustConnect(context.Background(), parentApplicationYamlKey, 1)
+	db := tokenomics.MustConnectStorage(context.Background(), &cfg.Config, parentApplicationYamlKey, 1)
 	defer func() {
 		if err := recover(); err != nil {
 			log.Error(db.Close())
@@ -53,7 +95,7 @@ func (bk *bookkeeper) bookKeep(ctx context.Context, workerNumber int64) {
 		}
 		log.Error(db.Close())
 	}()
-	dwhClient := dwh.MustConnect(context.Background(), applicationYamlKey)
+	dwhClient := dwh.MustConnect(context.Background(), applicationYamlKey, cfg.DWHTenant())
 	defer func() {
 		if err := recover(); err != nil {
 			log.Error(dwhClient.Close())
@@ -3,9 +3,62 @@
 package bookkeeper
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
 	stdlibtime "time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
 	"github.com/ice-blockchain/freezer/tokenomics"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+// Public API.
+
+type (
+	Client interface {
+		io.Closer
+		CheckHealth(context.Context) error
+		// MetricsHandler serves the reconciliation metrics, in the prometheus exposition format.
+		MetricsHandler() http.Handler
+	}
+	DriftKind string
+	Drift     struct {
+		SnapshotAt             *time.Time   `json:"snapshotAt,omitempty"`
+		BalanceLastUpdatedAt   *time.Time   `json:"balanceLastUpdatedAt,omitempty"`
+		Kind                   DriftKind    `json:"kind"`
+		UserID                 string       `json:"userId"`
+		ID                     int64        `json:"id"`
+		BalanceTotalStandard   model.Amount `json:"balanceTotalStandard"`
+		BalanceTotalPreStaking model.Amount `json:"balanceTotalPreStaking"`
+		SnapshotStandard       model.Amount `json:"snapshotStandard"`
+		SnapshotPreStaking     model.Amount `json:"snapshotPreStaking"`
+		// Redis total - (snapshot total + what was minted/slashed since the snapshot).
+		Delta    model.Amount `json:"delta"`
+		Repaired bool         `json:"repaired"`
+	}
+	DriftReport struct {
+		StartedAt         *time.Time   `json:"startedAt"`
+		EndedAt           *time.Time   `json:"endedAt"`
+		Drifts            []*Drift     `json:"drifts"`
+		UsersChecked      uint64       `json:"usersChecked"`
+		BalanceDrifts     uint64       `json:"balanceDrifts"`
+		MissingSnapshots  uint64       `json:"missingSnapshots"`
+		RepairedSnapshots uint64       `json:"repairedSnapshots"`
+		MaxAbsDelta       model.Amount `json:"maxAbsDelta"`
+		SampleRatio       float64      `json:"sampleRatio"`
+		Repair            bool         `json:"repair"`
+	}
+)
+
+const (
+	BalanceDriftKind         DriftKind = "balance"
+	MissingSnapshotDriftKind DriftKind = "missing_snapshot"
 )
 
 // Private API.
@@ -21,11 +74,46 @@ var (
 	//nolint:gochecknoglobals // Singleton & global config mounted only during bootstrap.
 	cfg struct {
 		tokenomics.Config `mapstructure:",squash"` //nolint:tagliatelle // Nope.
+		Reconciliation    reconciliationConfig     `yaml:"reconciliation"`
 		Workers           int64                    `yaml:"workers"`
 		BatchSize         int64                    `yaml:"batchSize"`
+		Development       bool                     `yaml:"development"`
 	}
 )
 
 type (
-	bookkeeper struct{}
+	bookkeeper struct {
+		db         storage.DB
+		dwhClient  dwh.Client
+		reconciler *reconciler
+		cancel     context.CancelFunc
+		wg         *sync.WaitGroup
+	}
+	reconciler struct {
+		db        storage.DB
+		dwhClient dwh.Client
+		metrics   *reconciliationMetrics
+	}
+	reconciliationMetrics struct {
+		registry           *prometheus.Registry
+		usersChecked       prometheus.Counter
+		repairedSnapshots  prometheus.Counter
+		drifts             *prometheus.CounterVec
+		lastRunDrifts      *prometheus.GaugeVec
+		lastRunMaxAbsDelta prometheus.Gauge
+		lastRunDuration    prometheus.Gauge
+		lastRunEndedAt     prometheus.Gauge
+	}
+	reconciliationConfig struct {
+		Interval stdlibtime.Duration `yaml:"interval"`
+		// The fraction of the users that get checked on each run; 0 or 1 means all of them.
+		SampleRatio float64 `yaml:"sampleRatio"`
+		// Differences up to this amount of ICE are not considered drifts.
+		Tolerance float64 `yaml:"tolerance"`
+		// How many drifts are included in the logged report. The metrics count all of them.
+		MaxReportedDrifts int  `yaml:"maxReportedDrifts"`
+		Enabled           bool `yaml:"enabled"`
+		// Re-inserts the missing snapshots of the current day into the history/bookkeeping storage.
+		Repair bool `yaml:"repair"`
+	}
 )
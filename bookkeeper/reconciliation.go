@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package bookkeeper
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/freezer/tokenomics"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+func newReconciler(db storage.DB, dwhClient dwh.Client) *reconciler {
+	return &reconciler{db: db, dwhClient: dwhClient, metrics: new(reconciliationMetrics).mustInit()}
+}
+
+func (m *reconciliationMetrics) mustInit() *reconciliationMetrics {
+	const namespace, subsystem = "freezer", "reconciliation"
+	m.registry = prometheus.NewRegistry()
+	m.usersChecked = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: "users_checked_total",
+		Help: "Number of users whose redis state was compared against the history/bookkeeping storage.",
+	})
+	m.repairedSnapshots = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: "repaired_snapshots_total",
+		Help: "Number of missing snapshots that were re-inserted into the history/bookkeeping storage.",
+	})
+	m.drifts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: "drifts_total",
+		Help: "Number of drifts found, by kind.",
+	}, []string{"kind"})
+	m.lastRunDrifts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: "last_run_drifts",
+		Help: "Number of drifts found by the last run, by kind.",
+	}, []string{"kind"})
+	m.lastRunMaxAbsDelta = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: "last_run_max_abs_delta",
+		Help: "The biggest absolute balance difference (in ICE) found by the last run.",
+	})
+	m.lastRunDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: "last_run_duration_seconds",
+		Help: "How long the last run took.",
+	})
+	m.lastRunEndedAt = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: "last_run_ended_at_seconds",
+		Help: "Unix timestamp of the end of the last run.",
+	})
+	m.registry.MustRegister(
+		m.usersChecked, m.repairedSnapshots, m.drifts, m.lastRunDrifts, m.lastRunMaxAbsDelta, m.lastRunDuration, m.lastRunEndedAt,
+	)
+
+	return m
+}
+
+func (m *reconciliationMetrics) collect(report *DriftReport) {
+	m.usersChecked.Add(float64(report.UsersChecked))
+	m.repairedSnapshots.Add(float64(report.RepairedSnapshots))
+	m.drifts.WithLabelValues(string(BalanceDriftKind)).Add(float64(report.BalanceDrifts))
+	m.drifts.WithLabelValues(string(MissingSnapshotDriftKind)).Add(float64(report.MissingSnapshots))
+	m.lastRunDrifts.WithLabelValues(string(BalanceDriftKind)).Set(float64(report.BalanceDrifts))
+	m.lastRunDrifts.WithLabelValues(string(MissingSnapshotDriftKind)).Set(float64(report.MissingSnapshots))
+	m.lastRunMaxAbsDelta.Set(report.MaxAbsDelta.Float64())
+	m.lastRunDuration.Set(report.EndedAt.Sub(*report.StartedAt.Time).Seconds())
+	m.lastRunEndedAt.Set(float64(report.EndedAt.Unix()))
+}
+
+func (bk *bookkeeper) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(bk.reconciler.metrics.registry, promhttp.HandlerOpts{})
+}
+
+func (r *reconciler) reconcileContinuously(ctx context.Context) {
+	interval := cfg.Reconciliation.Interval
+	if interval == 0 {
+		interval = stdlibtime.Hour
+	}
+	ticker := stdlibtime.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := r.reconcile(ctx)
+		if err != nil {
+			log.Error(errors.Wrap(err, "[reconciliation] failed to reconcile"))
+		}
+		if report != nil {
+			r.metrics.collect(report)
+			if len(report.Drifts) != 0 {
+				log.Warn("[reconciliation] drifts found", "report", report)
+			} else {
+				log.Info("[reconciliation] no drifts found", "report", report)
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Scans `users:*` and compares every (sampled) user against the latest snapshot it has in the history/bookkeeping storage.
+// It returns the (partial) report even if it fails midway.
+func (r *reconciler) reconcile(ctx context.Context) (*DriftReport, error) {
+	report := &DriftReport{
+		StartedAt:   time.Now(),
+		Drifts:      make([]*Drift, 0, cfg.Reconciliation.MaxReportedDrifts),
+		SampleRatio: cfg.Reconciliation.SampleRatio,
+		Repair:      cfg.Reconciliation.Repair,
+	}
+	defer func() {
+		report.EndedAt = time.Now()
+	}()
+	var (
+		cursor   uint64
+		scanned  []string
+		err      error
+		userKeys = make([]string, 0, cfg.BatchSize)
+	)
+	for ctx.Err() == nil {
+		reqCtx, reqCancel := context.WithTimeout(ctx, requestDeadline)
+		scanned, cursor, err = r.db.Scan(reqCtx, cursor, "users:*", cfg.BatchSize).Result()
+		reqCancel()
+		if err != nil {
+			return report, errors.Wrapf(err, "failed to scan users, cursor:%v", cursor)
+		}
+		for _, key := range scanned {
+			if id, pErr := strconv.ParseInt(strings.TrimPrefix(key, "users:"), 10, 64); pErr != nil || id <= 0 {
+				continue // It's the userID -> internal ID mapping.
+			}
+			if sampleRatio := cfg.Reconciliation.SampleRatio; sampleRatio > 0 && sampleRatio < 1 && rand.Float64() >= sampleRatio { //nolint:gosec // Not an issue.
+				continue
+			}
+			if userKeys = append(userKeys, key); len(userKeys) == int(cfg.BatchSize) {
+				if err = r.reconcileBatch(ctx, userKeys, report); err != nil {
+					return report, errors.Wrapf(err, "failed to reconcileBatch for %v users", len(userKeys))
+				}
+				userKeys = userKeys[:0]
+			}
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+	if err = ctx.Err(); err != nil {
+		return report, errors.Wrap(err, "reconciliation interrupted")
+	}
+
+	return report, errors.Wrapf(r.reconcileBatch(ctx, userKeys, report), "failed to reconcileBatch for %v users", len(userKeys))
+}
+
+func (r *reconciler) reconcileBatch(ctx context.Context, userKeys []string, report *DriftReport) error { //nolint:funlen // .
+	if len(userKeys) == 0 {
+		return nil
+	}
+	usrs := make([]*model.User, 0, len(userKeys))
+	reqCtx, reqCancel := context.WithTimeout(ctx, requestDeadline)
+	defer reqCancel()
+	if err := storage.Bind[model.User](reqCtx, r.db, userKeys, &usrs); err != nil {
+		return errors.Wrapf(err, "failed to get users")
+	}
+	ids := make([]int64, 0, len(usrs))
+	for _, usr := range usrs {
+		ids = append(ids, usr.ID)
+	}
+	latestBalances, err := r.dwhClient.SelectLatestBalances(reqCtx, ids)
+	if err != nil {
+		return errors.Wrapf(err, "failed to SelectLatestBalances for ids:%v", ids)
+	}
+	snapshots := make(map[int64]*dwh.LatestBalance, len(latestBalances))
+	for _, snapshot := range latestBalances {
+		snapshots[snapshot.ID] = snapshot
+	}
+	now := time.Now()
+	toRepair := make([]*model.User, 0, len(usrs))
+	repairedDrifts := make([]*Drift, 0, len(usrs))
+	for _, usr := range usrs {
+		report.UsersChecked++
+		drift := checkDrift(usr, snapshots[usr.ID], model.MustNewAmount(cfg.Reconciliation.Tolerance), snapshotPeriod())
+		if drift == nil {
+			continue
+		}
+		switch drift.Kind {
+		case BalanceDriftKind:
+			report.BalanceDrifts++
+			report.MaxAbsDelta = max(report.MaxAbsDelta, drift.Delta, -drift.Delta)
+		case MissingSnapshotDriftKind:
+			report.MissingSnapshots++
+			if cfg.Reconciliation.Repair && usr.BalanceLastUpdatedAt.Truncate(snapshotPeriod()).Equal(now.Truncate(snapshotPeriod())) {
+				toRepair = append(toRepair, rolloverSnapshot(usr))
+				repairedDrifts = append(repairedDrifts, drift)
+			}
+		}
+		if len(report.Drifts) < cfg.Reconciliation.MaxReportedDrifts {
+			report.Drifts = append(report.Drifts, drift)
+		}
+	}
+	if len(toRepair) == 0 {
+		return nil
+	}
+	columns, input := dwh.InsertDDL(len(toRepair))
+	if err = r.dwhClient.Insert(reqCtx, columns, input, toRepair); err != nil {
+		return errors.Wrapf(err, "failed to re-insert %v missing snapshots", len(toRepair))
+	}
+	for _, drift := range repairedDrifts {
+		drift.Repaired = true
+	}
+	report.RepairedSnapshots += uint64(len(toRepair))
+
+	return nil
+}
+
+// The history/bookkeeping storage has (at most) one snapshot per period, see dwh.Client.Insert.
+func snapshotPeriod() stdlibtime.Duration {
+	if cfg.Development {
+		return stdlibtime.Minute
+	}
+
+	return 24 * stdlibtime.Hour //nolint:gomnd // A day.
+}
+
+// The miner takes a snapshot the first time it processes a user in a new period, right before resetting balance_total_minted/slashed.
+// So, if the latest snapshot is for the current period, redis balance = snapshot balance + minted - slashed.
+func checkDrift(usr *model.User, snapshot *dwh.LatestBalance, tolerance model.Amount, period stdlibtime.Duration) *Drift {
+	if usr.BalanceLastUpdatedAt.IsNil() {
+		return nil
+	}
+	currentPeriod := usr.BalanceLastUpdatedAt.Truncate(period)
+	drift := &Drift{
+		BalanceLastUpdatedAt:   usr.BalanceLastUpdatedAt,
+		UserID:                 usr.UserID,
+		ID:                     usr.ID,
+		BalanceTotalStandard:   usr.BalanceTotalStandard,
+		BalanceTotalPreStaking: usr.BalanceTotalPreStaking,
+	}
+	if snapshot != nil && !snapshot.CreatedAt.IsNil() && !snapshot.CreatedAt.Before(currentPeriod) {
+		drift.Kind = BalanceDriftKind
+		drift.SnapshotAt = snapshot.CreatedAt
+		drift.SnapshotStandard, drift.SnapshotPreStaking = snapshot.BalanceTotalStandard, snapshot.BalanceTotalPreStaking
+		expected := snapshot.BalanceTotalStandard + snapshot.BalanceTotalPreStaking + usr.BalanceTotalMinted - usr.BalanceTotalSlashed
+		if drift.Delta = usr.BalanceTotalStandard + usr.BalanceTotalPreStaking - expected; max(drift.Delta, -drift.Delta) <= tolerance {
+			return nil
+		}
+
+		return drift
+	}
+	// If the session started in the current period as well, the user might have not been processed in a previous one,
+	// so there's nothing to snapshot yet.
+	if usr.BalanceTotalStandard+usr.BalanceTotalPreStaking <= 0 ||
+		usr.MiningSessionSoloStartedAt.IsNil() || !usr.MiningSessionSoloStartedAt.Before(currentPeriod) {
+		return nil
+	}
+	drift.Kind = MissingSnapshotDriftKind
+	if snapshot != nil {
+		drift.SnapshotAt = snapshot.CreatedAt
+		drift.SnapshotStandard, drift.SnapshotPreStaking = snapshot.BalanceTotalStandard, snapshot.BalanceTotalPreStaking
+	}
+
+	return drift
+}
+
+// Rebuilds the snapshot the miner should have taken at the beginning of the current period, by reverting what was minted/slashed since.
+// What was minted/slashed in the previous period is not known anymore, so those are left empty.
+func rolloverSnapshot(usr *model.User) *model.User {
+	snapshot := *usr
+	netAmount := usr.BalanceTotalMinted - usr.BalanceTotalSlashed
+	//nolint:gomnd // Same formula as tokenomics.ApplyPreStaking.
+	if factor := (100-usr.PreStakingAllocation)/100 + (100+usr.PreStakingBonus)*usr.PreStakingAllocation/10000; factor > 0 {
+		netStandard, netPreStaking := tokenomics.ApplyPreStaking(model.Amount(math.Round(float64(netAmount)/factor)), usr.PreStakingAllocation, usr.PreStakingBonus)
+		snapshot.BalanceTotalStandard -= netStandard
+		snapshot.BalanceTotalPreStaking -= netPreStaking
+	}
+	snapshot.BalanceTotalMinted, snapshot.BalanceTotalSlashed = 0, 0
+
+	return &snapshot
+}
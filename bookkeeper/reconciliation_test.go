@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package bookkeeper
+
+import (
+	"testing"
+	stdlibtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+func TestCheckDrift(t *testing.T) { //nolint:funlen // .
+	t.Parallel()
+
+	const period = 24 * stdlibtime.Hour
+	now := time.New(stdlibtime.Date(2024, 1, 2, 15, 0, 0, 0, stdlibtime.UTC))
+	startOfDay := time.New(now.Truncate(period))
+	yesterday := time.New(now.Add(-period))
+	usr := new(model.User)
+	usr.ID, usr.UserID = 1, "user1"
+	usr.BalanceLastUpdatedAt = now
+	usr.MiningSessionSoloStartedAt = yesterday
+	usr.BalanceTotalStandard, usr.BalanceTotalPreStaking = 80, 40
+	usr.BalanceTotalMinted, usr.BalanceTotalSlashed = 25, 5
+
+	snapshot := &dwh.LatestBalance{CreatedAt: startOfDay, ID: 1, BalanceTotalStandard: 70, BalanceTotalPreStaking: 30}
+	assert.Nil(t, checkDrift(usr, snapshot, 0, period))
+
+	snapshot.BalanceTotalPreStaking = 29
+	drift := checkDrift(usr, snapshot, 0, period)
+	require.NotNil(t, drift)
+	assert.Equal(t, BalanceDriftKind, drift.Kind)
+	assert.Equal(t, model.Amount(1), drift.Delta)
+	assert.Equal(t, startOfDay, drift.SnapshotAt)
+	assert.Nil(t, checkDrift(usr, snapshot, 1, period))
+
+	snapshot.CreatedAt = yesterday
+	drift = checkDrift(usr, snapshot, 0, period)
+	require.NotNil(t, drift)
+	assert.Equal(t, MissingSnapshotDriftKind, drift.Kind)
+	assert.Equal(t, yesterday, drift.SnapshotAt)
+	drift = checkDrift(usr, nil, 0, period)
+	require.NotNil(t, drift)
+	assert.Equal(t, MissingSnapshotDriftKind, drift.Kind)
+
+	usr.MiningSessionSoloStartedAt = time.New(startOfDay.Add(stdlibtime.Hour))
+	assert.Nil(t, checkDrift(usr, nil, 0, period))
+	usr.MiningSessionSoloStartedAt = yesterday
+	usr.BalanceTotalStandard, usr.BalanceTotalPreStaking = 0, 0
+	assert.Nil(t, checkDrift(usr, nil, 0, period))
+	usr.BalanceLastUpdatedAt = nil
+	assert.Nil(t, checkDrift(usr, nil, 0, period))
+}
+
+func TestRolloverSnapshot(t *testing.T) {
+	t.Parallel()
+
+	usr := new(model.User)
+	usr.ID, usr.UserID = 1, "user1"
+	usr.PreStakingAllocation, usr.PreStakingBonus = 50, 100
+	usr.BalanceTotalStandard, usr.BalanceTotalPreStaking = 100, 200
+	usr.BalanceTotalMinted, usr.BalanceTotalSlashed = 33, 3
+
+	snapshot := rolloverSnapshot(usr)
+	assert.Equal(t, model.Amount(90), snapshot.BalanceTotalStandard)
+	assert.Equal(t, model.Amount(180), snapshot.BalanceTotalPreStaking)
+	assert.Zero(t, snapshot.BalanceTotalMinted)
+	assert.Zero(t, snapshot.BalanceTotalSlashed)
+	assert.Equal(t, "user1", snapshot.UserID)
+	assert.Equal(t, model.Amount(33), usr.BalanceTotalMinted)
+
+	usr.PreStakingAllocation = 0
+	snapshot = rolloverSnapshot(usr)
+	assert.Equal(t, model.Amount(70), snapshot.BalanceTotalStandard)
+	assert.Equal(t, model.Amount(200), snapshot.BalanceTotalPreStaking)
+}
@@ -22,14 +22,61 @@ type (
 	Client interface {
 		io.Closer
 		Ping(ctx context.Context) error
+		// ForTenant returns a client, sharing the same connections, that stores and reads the data of the provided tenant.
+		ForTenant(tenant string) Client
 		Insert(ctx context.Context, columns *Columns, input InsertMetadata, usrs []*model.User) error
 		SelectBalanceHistory(ctx context.Context, id int64, createdAts []stdlibtime.Time) ([]*BalanceHistory, error)
+		SelectLatestBalances(ctx context.Context, ids []int64) ([]*LatestBalance, error)
 		SelectTotalCoins(ctx context.Context, createdAtTime stdlibtime.Time, parentInverval stdlibtime.Duration) ([]*TotalCoins, error)
+		// SelectTopMiners returns the users that minted the most since `since` or, if it's zero, the ones with the biggest balances.
+		// If `country` is provided, only users currently from that country are returned. Users that hide their ranking are never returned.
+		SelectTopMiners(ctx context.Context, since stdlibtime.Time, country string, limit, offset uint64) ([]*TopMiner, error)
+		// SelectTeamEarnings returns the daily snapshots of what `id` earned from its team since `since`, oldest first.
+		SelectTeamEarnings(ctx context.Context, id int64, since stdlibtime.Time) ([]*TeamEarnings, error)
+		// SelectReferrals returns the referrals of `id`, from the provided tier, that had a snapshot since `since`,
+		// ordered by how much they contributed to `id`. If `slashingOnly` is true, only the ones currently being slashed are returned.
+		SelectReferrals(ctx context.Context, id int64, tier ReferralTier, since stdlibtime.Time, slashingOnly bool, limit uint64) ([]*Referral, error)
 		DeleteUserInfo(ctx context.Context, id int64) error
+		InsertLedger(ctx context.Context, entries []*LedgerEntry) error
+		// SelectLedger returns the entries of `id` older than `before` (newest first); a zero `before` means from the latest one.
+		// Entries sharing the same timestamp are never split across pages, so it can return more than `limit` entries.
+		SelectLedger(ctx context.Context, id int64, before stdlibtime.Time, limit uint64) ([]*LedgerEntry, error)
+	}
+	LedgerReason string
+	LedgerEntry  struct {
+		CreatedAt *time.Time
+		Reason    LedgerReason
+		Source    string
+		UserID    string
+		ID        int64
+		Amount    model.Amount
 	}
 	BalanceHistory struct {
 		CreatedAt                               *time.Time
-		BalanceTotalMinted, BalanceTotalSlashed float64
+		BalanceTotalMinted, BalanceTotalSlashed model.Amount
+	}
+	LatestBalance struct {
+		CreatedAt                                    *time.Time
+		ID                                           int64
+		BalanceTotalStandard, BalanceTotalPreStaking model.Amount
+	}
+	TopMiner struct {
+		UserID string       `json:"userId"`
+		ID     int64        `json:"id"`
+		Amount model.Amount `json:"amount"`
+	}
+	TeamEarnings struct {
+		CreatedAt                       *time.Time
+		BalanceT0, BalanceT1, BalanceT2 model.Amount
+	}
+	ReferralTier uint8
+	Referral     struct {
+		UserID             string
+		Username           string
+		ProfilePictureName string
+		ID                 int64
+		Contribution       model.Amount
+		SlashingRate       model.Amount
 	}
 	TotalCoins struct {
 		CreatedAt              *time.Time `redis:"created_at"`
@@ -61,43 +108,44 @@ type (
 		miningBlockchainAccountAddress                         *proto.ColStr
 		blockchainAccountAddress                               *proto.ColStr
 		userID                                                 *proto.ColStr
+		tenant                                                 *proto.ColLowCardinality[string]
 		id                                                     *proto.ColInt64
 		idT0                                                   *proto.ColInt64
 		idTminus1                                              *proto.ColInt64
-		balanceTotalStandard                                   *proto.ColFloat64
-		balanceTotalPreStaking                                 *proto.ColFloat64
-		balanceTotalMinted                                     *proto.ColFloat64
-		balanceTotalSlashed                                    *proto.ColFloat64
-		balanceSoloPending                                     *proto.ColFloat64
-		balanceT1Pending                                       *proto.ColFloat64
-		balanceT2Pending                                       *proto.ColFloat64
-		balanceSoloPendingApplied                              *proto.ColFloat64
-		balanceT1PendingApplied                                *proto.ColFloat64
-		balanceT2PendingApplied                                *proto.ColFloat64
-		balanceSolo                                            *proto.ColFloat64
-		balanceT0                                              *proto.ColFloat64
-		balanceT1                                              *proto.ColFloat64
-		balanceT2                                              *proto.ColFloat64
-		balanceForT0                                           *proto.ColFloat64
-		balanceForTminus1                                      *proto.ColFloat64
-		balanceSoloEthereum                                    *proto.ColFloat64
-		balanceT0Ethereum                                      *proto.ColFloat64
-		balanceT1Ethereum                                      *proto.ColFloat64
-		balanceT2Ethereum                                      *proto.ColFloat64
-		balanceForT0Ethereum                                   *proto.ColFloat64
-		balanceForTMinus1Ethereum                              *proto.ColFloat64
-		balanceSoloEthereumMainnetRewardPoolContribution       *proto.ColFloat64
-		balanceT0EthereumMainnetRewardPoolContribution         *proto.ColFloat64
-		balanceT1EthereumMainnetRewardPoolContribution         *proto.ColFloat64
-		balanceT2EthereumMainnetRewardPoolContribution         *proto.ColFloat64
-		balanceForT0EthereumMainnetRewardPoolContribution      *proto.ColFloat64
-		balanceForTMinus1EthereumMainnetRewardPoolContribution *proto.ColFloat64
-		slashingRateSolo                                       *proto.ColFloat64
-		slashingRateT0                                         *proto.ColFloat64
-		slashingRateT1                                         *proto.ColFloat64
-		slashingRateT2                                         *proto.ColFloat64
-		slashingRateForT0                                      *proto.ColFloat64
-		slashingRateForTminus1                                 *proto.ColFloat64
+		balanceTotalStandard                                   *proto.ColDecimal64
+		balanceTotalPreStaking                                 *proto.ColDecimal64
+		balanceTotalMinted                                     *proto.ColDecimal64
+		balanceTotalSlashed                                    *proto.ColDecimal64
+		balanceSoloPending                                     *proto.ColDecimal64
+		balanceT1Pending                                       *proto.ColDecimal64
+		balanceT2Pending                                       *proto.ColDecimal64
+		balanceSoloPendingApplied                              *proto.ColDecimal64
+		balanceT1PendingApplied                                *proto.ColDecimal64
+		balanceT2PendingApplied                                *proto.ColDecimal64
+		balanceSolo                                            *proto.ColDecimal64
+		balanceT0                                              *proto.ColDecimal64
+		balanceT1                                              *proto.ColDecimal64
+		balanceT2                                              *proto.ColDecimal64
+		balanceForT0                                           *proto.ColDecimal64
+		balanceForTminus1                                      *proto.ColDecimal64
+		balanceSoloEthereum                                    *proto.ColDecimal64
+		balanceT0Ethereum                                      *proto.ColDecimal64
+		balanceT1Ethereum                                      *proto.ColDecimal64
+		balanceT2Ethereum                                      *proto.ColDecimal64
+		balanceForT0Ethereum                                   *proto.ColDecimal64
+		balanceForTMinus1Ethereum                              *proto.ColDecimal64
+		balanceSoloEthereumMainnetRewardPoolContribution       *proto.ColDecimal64
+		balanceT0EthereumMainnetRewardPoolContribution         *proto.ColDecimal64
+		balanceT1EthereumMainnetRewardPoolContribution         *proto.ColDecimal64
+		balanceT2EthereumMainnetRewardPoolContribution         *proto.ColDecimal64
+		balanceForT0EthereumMainnetRewardPoolContribution      *proto.ColDecimal64
+		balanceForTMinus1EthereumMainnetRewardPoolContribution *proto.ColDecimal64
+		slashingRateSolo                                       *proto.ColDecimal64
+		slashingRateT0                                         *proto.ColDecimal64
+		slashingRateT1                                         *proto.ColDecimal64
+		slashingRateT2                                         *proto.ColDecimal64
+		slashingRateForT0                                      *proto.ColDecimal64
+		slashingRateForTminus1                                 *proto.ColDecimal64
 		activeT1Referrals                                      *proto.ColInt32
 		activeT2Referrals                                      *proto.ColInt32
 		preStakingBonus                                        *proto.ColUInt16
@@ -116,10 +164,35 @@ type (
 	}
 )
 
+const (
+	MiningLedgerReason               LedgerReason = "mining"
+	SlashingLedgerReason             LedgerReason = "slashing"
+	WelcomeBonusLedgerReason         LedgerReason = "welcome_bonus"
+	T1PendingLedgerReason            LedgerReason = "t1_pending"
+	T2PendingLedgerReason            LedgerReason = "t2_pending"
+	ResurrectionLedgerReason         LedgerReason = "resurrection"
+	EthereumDistributionLedgerReason LedgerReason = "ethereum_distribution"
+	CompletedTasksLedgerReason       LedgerReason = "completed_tasks"
+)
+
+const (
+	T1ReferralTier ReferralTier = 1
+	T2ReferralTier ReferralTier = 2
+)
+
 // Private API.
 
 const (
-	tableName = "freezer_user_history"
+	tableName       = "freezer_user_history"
+	ledgerTableName = "freezer_user_ledger"
+
+	postponedLedgerKey = "postponed_ledger_entries"
+
+	// Every tenant has its own users_serial, so the ids are unique only within a tenant and every query must be scoped to it.
+	tenantCondition = "tenant = {tenant:String}"
+
+	// It's Decimal64(model.AmountDecimals), so the raw value is model.Amount.
+	amountColumnType proto.ColumnType = "Decimal(18, 6)"
 )
 
 // .
@@ -134,9 +207,10 @@ var (
 type (
 	db struct {
 		cfg          *config
+		currentIndex *uint64
+		tenant       string
 		pools        []*chpool.Pool
 		settings     []ch.Setting
-		currentIndex uint64
 	}
 	config struct {
 		Storage struct {
@@ -19,40 +19,40 @@ CREATE TABLE IF NOT EXISTS light.freezer_user_history
        for_tminus1_last_ethereum_coin_distribution_processed_at DateTime64(9,'UTC')  DEFAULT 0,
        balance_last_updated_at DateTime64(9,'UTC') DEFAULT 0,
        created_at DateTime('UTC')  DEFAULT 0,
-       balance_total_standard Float64  DEFAULT 0,
-       balance_total_pre_staking Float64  DEFAULT 0,
-       balance_total_minted Float64  DEFAULT 0,
-       balance_total_slashed Float64  DEFAULT 0,
-       balance_solo_pending Float64  DEFAULT 0,
-       balance_t1_pending Float64  DEFAULT 0,
-       balance_t2_pending Float64  DEFAULT 0,
-       balance_solo_pending_applied Float64  DEFAULT 0,
-       balance_t1_pending_applied Float64  DEFAULT 0,
-       balance_t2_pending_applied Float64  DEFAULT 0,
-       balance_solo Float64  DEFAULT 0,
-       balance_t0 Float64  DEFAULT 0,
-       balance_t1 Float64  DEFAULT 0,
-       balance_t2 Float64  DEFAULT 0,
-       balance_for_t0 Float64  DEFAULT 0,
-       balance_for_tminus1 Float64  DEFAULT 0,
-       balance_solo_ethereum Float64  DEFAULT 0,
-       balance_t0_ethereum Float64  DEFAULT 0,
-       balance_t1_ethereum Float64  DEFAULT 0,
-       balance_t2_ethereum Float64  DEFAULT 0,
-       balance_for_t0_ethereum Float64  DEFAULT 0,
-       balance_for_tminus1_ethereum Float64  DEFAULT 0,
-       balance_solo_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-       balance_t0_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-       balance_t1_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-       balance_t2_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-       balance_for_t0_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-       balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-       slashing_rate_solo Float64  DEFAULT 0,
-       slashing_rate_t0 Float64  DEFAULT 0,
-       slashing_rate_t1 Float64  DEFAULT 0,
-       slashing_rate_t2 Float64  DEFAULT 0,
-       slashing_rate_for_t0 Float64  DEFAULT 0,
-       slashing_rate_for_tminus1 Float64  DEFAULT 0,
+       balance_total_standard Decimal64(6)  DEFAULT 0,
+       balance_total_pre_staking Decimal64(6)  DEFAULT 0,
+       balance_total_minted Decimal64(6)  DEFAULT 0,
+       balance_total_slashed Decimal64(6)  DEFAULT 0,
+       balance_solo_pending Decimal64(6)  DEFAULT 0,
+       balance_t1_pending Decimal64(6)  DEFAULT 0,
+       balance_t2_pending Decimal64(6)  DEFAULT 0,
+       balance_solo_pending_applied Decimal64(6)  DEFAULT 0,
+       balance_t1_pending_applied Decimal64(6)  DEFAULT 0,
+       balance_t2_pending_applied Decimal64(6)  DEFAULT 0,
+       balance_solo Decimal64(6)  DEFAULT 0,
+       balance_t0 Decimal64(6)  DEFAULT 0,
+       balance_t1 Decimal64(6)  DEFAULT 0,
+       balance_t2 Decimal64(6)  DEFAULT 0,
+       balance_for_t0 Decimal64(6)  DEFAULT 0,
+       balance_for_tminus1 Decimal64(6)  DEFAULT 0,
+       balance_solo_ethereum Decimal64(6)  DEFAULT 0,
+       balance_t0_ethereum Decimal64(6)  DEFAULT 0,
+       balance_t1_ethereum Decimal64(6)  DEFAULT 0,
+       balance_t2_ethereum Decimal64(6)  DEFAULT 0,
+       balance_for_t0_ethereum Decimal64(6)  DEFAULT 0,
+       balance_for_tminus1_ethereum Decimal64(6)  DEFAULT 0,
+       balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+       balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+       balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+       balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+       balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+       balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+       slashing_rate_solo Decimal64(6)  DEFAULT 0,
+       slashing_rate_t0 Decimal64(6)  DEFAULT 0,
+       slashing_rate_t1 Decimal64(6)  DEFAULT 0,
+       slashing_rate_t2 Decimal64(6)  DEFAULT 0,
+       slashing_rate_for_t0 Decimal64(6)  DEFAULT 0,
+       slashing_rate_for_tminus1 Decimal64(6)  DEFAULT 0,
        id Int64  DEFAULT 0,
        id_t0 Int64  DEFAULT 0,
        id_tminus1 Int64  DEFAULT 0,
@@ -89,30 +89,30 @@ ALTER TABLE light.freezer_user_history
     ADD COLUMN IF NOT EXISTS for_tminus1_last_ethereum_coin_distribution_processed_at DateTime64(9,'UTC') DEFAULT 0 AFTER for_t0_last_ethereum_coin_distribution_processed_at;
 
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_solo_ethereum Float64 DEFAULT 0 AFTER balance_for_tminus1;
+    ADD COLUMN IF NOT EXISTS balance_solo_ethereum Decimal64(6) DEFAULT 0 AFTER balance_for_tminus1;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t0_ethereum Float64 DEFAULT 0 AFTER balance_solo_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t0_ethereum Decimal64(6) DEFAULT 0 AFTER balance_solo_ethereum;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t1_ethereum Float64 DEFAULT 0 AFTER balance_t0_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t1_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t0_ethereum;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t2_ethereum Float64 DEFAULT 0 AFTER balance_t1_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t2_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t1_ethereum;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum Float64 DEFAULT 0 AFTER balance_t2_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t2_ethereum;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum Float64 DEFAULT 0 AFTER balance_for_t0_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum Decimal64(6) DEFAULT 0 AFTER balance_for_t0_ethereum;
 
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_for_tminus1_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_for_tminus1_ethereum;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_solo_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_solo_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t0_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t0_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t1_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t1_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t2_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t2_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE light.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_for_t0_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_for_t0_ethereum_mainnet_reward_pool_contribution;
 
 ALTER TABLE light.freezer_user_history
     ADD COLUMN IF NOT EXISTS kyc_quiz_completed Bool DEFAULT FALSE AFTER kyc_step_blocked;
@@ -152,40 +152,40 @@ CREATE TABLE IF NOT EXISTS dark.freezer_user_history
       for_tminus1_last_ethereum_coin_distribution_processed_at DateTime64(9,'UTC')  DEFAULT 0,
       balance_last_updated_at DateTime64(9,'UTC') DEFAULT 0,
       created_at DateTime('UTC')  DEFAULT 0,
-      balance_total_standard Float64  DEFAULT 0,
-      balance_total_pre_staking Float64  DEFAULT 0,
-      balance_total_minted Float64  DEFAULT 0,
-      balance_total_slashed Float64  DEFAULT 0,
-      balance_solo_pending Float64  DEFAULT 0,
-      balance_t1_pending Float64  DEFAULT 0,
-      balance_t2_pending Float64  DEFAULT 0,
-      balance_solo_pending_applied Float64  DEFAULT 0,
-      balance_t1_pending_applied Float64  DEFAULT 0,
-      balance_t2_pending_applied Float64  DEFAULT 0,
-      balance_solo Float64  DEFAULT 0,
-      balance_t0 Float64  DEFAULT 0,
-      balance_t1 Float64  DEFAULT 0,
-      balance_t2 Float64  DEFAULT 0,
-      balance_for_t0 Float64  DEFAULT 0,
-      balance_for_tminus1 Float64  DEFAULT 0,
-      balance_solo_ethereum Float64  DEFAULT 0,
-      balance_t0_ethereum Float64  DEFAULT 0,
-      balance_t1_ethereum Float64  DEFAULT 0,
-      balance_t2_ethereum Float64  DEFAULT 0,
-      balance_for_t0_ethereum Float64  DEFAULT 0,
-      balance_for_tminus1_ethereum Float64  DEFAULT 0,
-      balance_solo_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-      balance_t0_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-      balance_t1_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-      balance_t2_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-      balance_for_t0_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-      balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-      slashing_rate_solo Float64  DEFAULT 0,
-      slashing_rate_t0 Float64  DEFAULT 0,
-      slashing_rate_t1 Float64  DEFAULT 0,
-      slashing_rate_t2 Float64  DEFAULT 0,
-      slashing_rate_for_t0 Float64  DEFAULT 0,
-      slashing_rate_for_tminus1 Float64  DEFAULT 0,
+      balance_total_standard Decimal64(6)  DEFAULT 0,
+      balance_total_pre_staking Decimal64(6)  DEFAULT 0,
+      balance_total_minted Decimal64(6)  DEFAULT 0,
+      balance_total_slashed Decimal64(6)  DEFAULT 0,
+      balance_solo_pending Decimal64(6)  DEFAULT 0,
+      balance_t1_pending Decimal64(6)  DEFAULT 0,
+      balance_t2_pending Decimal64(6)  DEFAULT 0,
+      balance_solo_pending_applied Decimal64(6)  DEFAULT 0,
+      balance_t1_pending_applied Decimal64(6)  DEFAULT 0,
+      balance_t2_pending_applied Decimal64(6)  DEFAULT 0,
+      balance_solo Decimal64(6)  DEFAULT 0,
+      balance_t0 Decimal64(6)  DEFAULT 0,
+      balance_t1 Decimal64(6)  DEFAULT 0,
+      balance_t2 Decimal64(6)  DEFAULT 0,
+      balance_for_t0 Decimal64(6)  DEFAULT 0,
+      balance_for_tminus1 Decimal64(6)  DEFAULT 0,
+      balance_solo_ethereum Decimal64(6)  DEFAULT 0,
+      balance_t0_ethereum Decimal64(6)  DEFAULT 0,
+      balance_t1_ethereum Decimal64(6)  DEFAULT 0,
+      balance_t2_ethereum Decimal64(6)  DEFAULT 0,
+      balance_for_t0_ethereum Decimal64(6)  DEFAULT 0,
+      balance_for_tminus1_ethereum Decimal64(6)  DEFAULT 0,
+      balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+      balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+      balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+      balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+      balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+      balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+      slashing_rate_solo Decimal64(6)  DEFAULT 0,
+      slashing_rate_t0 Decimal64(6)  DEFAULT 0,
+      slashing_rate_t1 Decimal64(6)  DEFAULT 0,
+      slashing_rate_t2 Decimal64(6)  DEFAULT 0,
+      slashing_rate_for_t0 Decimal64(6)  DEFAULT 0,
+      slashing_rate_for_tminus1 Decimal64(6)  DEFAULT 0,
       id Int64  DEFAULT 0,
       id_t0 Int64  DEFAULT 0,
       id_tminus1 Int64  DEFAULT 0,
@@ -222,30 +222,30 @@ ALTER TABLE dark.freezer_user_history
     ADD COLUMN IF NOT EXISTS for_tminus1_last_ethereum_coin_distribution_processed_at DateTime64(9,'UTC') DEFAULT 0 AFTER for_t0_last_ethereum_coin_distribution_processed_at;
 
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_solo_ethereum Float64 DEFAULT 0 AFTER balance_for_tminus1;
+    ADD COLUMN IF NOT EXISTS balance_solo_ethereum Decimal64(6) DEFAULT 0 AFTER balance_for_tminus1;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t0_ethereum Float64 DEFAULT 0 AFTER balance_solo_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t0_ethereum Decimal64(6) DEFAULT 0 AFTER balance_solo_ethereum;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t1_ethereum Float64 DEFAULT 0 AFTER balance_t0_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t1_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t0_ethereum;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t2_ethereum Float64 DEFAULT 0 AFTER balance_t1_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t2_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t1_ethereum;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum Float64 DEFAULT 0 AFTER balance_t2_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t2_ethereum;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum Float64 DEFAULT 0 AFTER balance_for_t0_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum Decimal64(6) DEFAULT 0 AFTER balance_for_t0_ethereum;
 
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_for_tminus1_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_for_tminus1_ethereum;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_solo_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_solo_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t0_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t0_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t1_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t1_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t2_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t2_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE dark.freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_for_t0_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_for_t0_ethereum_mainnet_reward_pool_contribution;
 
 ALTER TABLE dark.freezer_user_history
     ADD COLUMN IF NOT EXISTS kyc_quiz_completed Bool DEFAULT FALSE AFTER kyc_step_blocked;
@@ -285,40 +285,40 @@ CREATE TABLE IF NOT EXISTS freezer_user_history
      for_tminus1_last_ethereum_coin_distribution_processed_at DateTime64(9,'UTC')  DEFAULT 0,
      balance_last_updated_at DateTime64(9,'UTC') DEFAULT 0,
      created_at DateTime('UTC')  DEFAULT 0,
-     balance_total_standard Float64  DEFAULT 0,
-     balance_total_pre_staking Float64  DEFAULT 0,
-     balance_total_minted Float64  DEFAULT 0,
-     balance_total_slashed Float64  DEFAULT 0,
-     balance_solo_pending Float64  DEFAULT 0,
-     balance_t1_pending Float64  DEFAULT 0,
-     balance_t2_pending Float64  DEFAULT 0,
-     balance_solo_pending_applied Float64  DEFAULT 0,
-     balance_t1_pending_applied Float64  DEFAULT 0,
-     balance_t2_pending_applied Float64  DEFAULT 0,
-     balance_solo Float64  DEFAULT 0,
-     balance_t0 Float64  DEFAULT 0,
-     balance_t1 Float64  DEFAULT 0,
-     balance_t2 Float64  DEFAULT 0,
-     balance_for_t0 Float64  DEFAULT 0,
-     balance_for_tminus1 Float64  DEFAULT 0,
-     balance_solo_ethereum Float64  DEFAULT 0,
-     balance_t0_ethereum Float64  DEFAULT 0,
-     balance_t1_ethereum Float64  DEFAULT 0,
-     balance_t2_ethereum Float64  DEFAULT 0,
-     balance_for_t0_ethereum Float64  DEFAULT 0,
-     balance_for_tminus1_ethereum Float64  DEFAULT 0,
-     balance_solo_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-     balance_t0_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-     balance_t1_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-     balance_t2_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-     balance_for_t0_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-     balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Float64  DEFAULT 0,
-     slashing_rate_solo Float64  DEFAULT 0,
-     slashing_rate_t0 Float64  DEFAULT 0,
-     slashing_rate_t1 Float64  DEFAULT 0,
-     slashing_rate_t2 Float64  DEFAULT 0,
-     slashing_rate_for_t0 Float64  DEFAULT 0,
-     slashing_rate_for_tminus1 Float64  DEFAULT 0,
+     balance_total_standard Decimal64(6)  DEFAULT 0,
+     balance_total_pre_staking Decimal64(6)  DEFAULT 0,
+     balance_total_minted Decimal64(6)  DEFAULT 0,
+     balance_total_slashed Decimal64(6)  DEFAULT 0,
+     balance_solo_pending Decimal64(6)  DEFAULT 0,
+     balance_t1_pending Decimal64(6)  DEFAULT 0,
+     balance_t2_pending Decimal64(6)  DEFAULT 0,
+     balance_solo_pending_applied Decimal64(6)  DEFAULT 0,
+     balance_t1_pending_applied Decimal64(6)  DEFAULT 0,
+     balance_t2_pending_applied Decimal64(6)  DEFAULT 0,
+     balance_solo Decimal64(6)  DEFAULT 0,
+     balance_t0 Decimal64(6)  DEFAULT 0,
+     balance_t1 Decimal64(6)  DEFAULT 0,
+     balance_t2 Decimal64(6)  DEFAULT 0,
+     balance_for_t0 Decimal64(6)  DEFAULT 0,
+     balance_for_tminus1 Decimal64(6)  DEFAULT 0,
+     balance_solo_ethereum Decimal64(6)  DEFAULT 0,
+     balance_t0_ethereum Decimal64(6)  DEFAULT 0,
+     balance_t1_ethereum Decimal64(6)  DEFAULT 0,
+     balance_t2_ethereum Decimal64(6)  DEFAULT 0,
+     balance_for_t0_ethereum Decimal64(6)  DEFAULT 0,
+     balance_for_tminus1_ethereum Decimal64(6)  DEFAULT 0,
+     balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+     balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+     balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+     balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+     balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+     balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6)  DEFAULT 0,
+     slashing_rate_solo Decimal64(6)  DEFAULT 0,
+     slashing_rate_t0 Decimal64(6)  DEFAULT 0,
+     slashing_rate_t1 Decimal64(6)  DEFAULT 0,
+     slashing_rate_t2 Decimal64(6)  DEFAULT 0,
+     slashing_rate_for_t0 Decimal64(6)  DEFAULT 0,
+     slashing_rate_for_tminus1 Decimal64(6)  DEFAULT 0,
      id Int64  DEFAULT 0,
      id_t0 Int64  DEFAULT 0,
      id_tminus1 Int64  DEFAULT 0,
@@ -353,30 +353,30 @@ ALTER TABLE freezer_user_history
     ADD COLUMN IF NOT EXISTS for_tminus1_last_ethereum_coin_distribution_processed_at DateTime64(9,'UTC') DEFAULT 0 AFTER for_t0_last_ethereum_coin_distribution_processed_at;
 
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_solo_ethereum Float64 DEFAULT 0 AFTER balance_for_tminus1;
+    ADD COLUMN IF NOT EXISTS balance_solo_ethereum Decimal64(6) DEFAULT 0 AFTER balance_for_tminus1;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t0_ethereum Float64 DEFAULT 0 AFTER balance_solo_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t0_ethereum Decimal64(6) DEFAULT 0 AFTER balance_solo_ethereum;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t1_ethereum Float64 DEFAULT 0 AFTER balance_t0_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t1_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t0_ethereum;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t2_ethereum Float64 DEFAULT 0 AFTER balance_t1_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_t2_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t1_ethereum;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum Float64 DEFAULT 0 AFTER balance_t2_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum Decimal64(6) DEFAULT 0 AFTER balance_t2_ethereum;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum Float64 DEFAULT 0 AFTER balance_for_t0_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum Decimal64(6) DEFAULT 0 AFTER balance_for_t0_ethereum;
 
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_for_tminus1_ethereum;
+    ADD COLUMN IF NOT EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_for_tminus1_ethereum;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_solo_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_solo_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t0_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t0_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t1_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t1_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_t2_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_t2_ethereum_mainnet_reward_pool_contribution;
 ALTER TABLE freezer_user_history
-    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Float64 DEFAULT 0 AFTER balance_for_t0_ethereum_mainnet_reward_pool_contribution;
+    ADD COLUMN IF NOT EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0 AFTER balance_for_t0_ethereum_mainnet_reward_pool_contribution;
 
 ALTER TABLE freezer_user_history
     ADD COLUMN IF NOT EXISTS kyc_quiz_completed Bool DEFAULT FALSE AFTER kyc_step_blocked;
@@ -398,3 +398,162 @@ ALTER TABLE freezer_user_history
 
 ALTER TABLE freezer_user_history
     ADD COLUMN IF NOT EXISTS balance_last_updated_at DateTime64(9,'UTC') DEFAULT 0 AFTER for_tminus1_last_ethereum_coin_distribution_processed_at;
+
+CREATE TABLE IF NOT EXISTS light.freezer_user_ledger
+(
+       created_at DateTime64(9,'UTC'),
+       amount Decimal64(6),
+       id Int64,
+       reason LowCardinality(String),
+       source String,
+       user_id String
+) ENGINE=ReplicatedMergeTree('/clickhouse/tables/{cluster}/{shard_light}/freezer_user_ledger', '{replica_light}')
+  PARTITION BY toYYYYMM(created_at)
+  PRIMARY KEY (id, created_at);
+CREATE TABLE IF NOT EXISTS dark.freezer_user_ledger
+(
+       created_at DateTime64(9,'UTC'),
+       amount Decimal64(6),
+       id Int64,
+       reason LowCardinality(String),
+       source String,
+       user_id String
+) ENGINE=ReplicatedMergeTree('/clickhouse/tables/{cluster}/{shard_dark}/freezer_user_ledger', '{replica_dark}')
+  PARTITION BY toYYYYMM(created_at)
+  PRIMARY KEY (id, created_at);
+CREATE TABLE IF NOT EXISTS freezer_user_ledger
+(
+       created_at DateTime64(9,'UTC'),
+       amount Decimal64(6),
+       id Int64,
+       reason LowCardinality(String),
+       source String,
+       user_id String
+) ENGINE = Distributed('{cluster}', '', 'freezer_user_ledger', id);
+
+-- The amounts used to be Float64 ICE; Decimal64(6) stores them as fixed-point micro ICE, i.e. model.Amount.
+ALTER TABLE light.freezer_user_history
+    MODIFY COLUMN IF EXISTS balance_total_standard Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_pre_staking Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_minted Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_slashed Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_solo Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t2 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_for_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_for_tminus1 Decimal64(6) DEFAULT 0;
+ALTER TABLE dark.freezer_user_history
+    MODIFY COLUMN IF EXISTS balance_total_standard Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_pre_staking Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_minted Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_slashed Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_solo Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t2 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_for_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_for_tminus1 Decimal64(6) DEFAULT 0;
+ALTER TABLE freezer_user_history
+    MODIFY COLUMN IF EXISTS balance_total_standard Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_pre_staking Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_minted Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_total_slashed Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_pending Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_pending_applied Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1_ethereum Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_solo_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_t2_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_t0_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS balance_for_tminus1_ethereum_mainnet_reward_pool_contribution Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_solo Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t1 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_t2 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_for_t0 Decimal64(6) DEFAULT 0,
+    MODIFY COLUMN IF EXISTS slashing_rate_for_tminus1 Decimal64(6) DEFAULT 0;
+ALTER TABLE light.freezer_user_ledger
+    MODIFY COLUMN IF EXISTS amount Decimal64(6) DEFAULT 0;
+ALTER TABLE dark.freezer_user_ledger
+    MODIFY COLUMN IF EXISTS amount Decimal64(6) DEFAULT 0;
+ALTER TABLE freezer_user_ledger
+    MODIFY COLUMN IF EXISTS amount Decimal64(6) DEFAULT 0;
+
+-- Every tenant has its own users_serial, so the rows are scoped to the tenant they belong to; '' is the tenant that existed before tenants did.
+ALTER TABLE light.freezer_user_history
+    ADD COLUMN IF NOT EXISTS tenant LowCardinality(String) DEFAULT '' AFTER user_id;
+ALTER TABLE dark.freezer_user_history
+    ADD COLUMN IF NOT EXISTS tenant LowCardinality(String) DEFAULT '' AFTER user_id;
+ALTER TABLE freezer_user_history
+    ADD COLUMN IF NOT EXISTS tenant LowCardinality(String) DEFAULT '' AFTER user_id;
+ALTER TABLE light.freezer_user_ledger
+    ADD COLUMN IF NOT EXISTS tenant LowCardinality(String) DEFAULT '' AFTER user_id;
+ALTER TABLE dark.freezer_user_ledger
+    ADD COLUMN IF NOT EXISTS tenant LowCardinality(String) DEFAULT '' AFTER user_id;
+ALTER TABLE freezer_user_ledger
+    ADD COLUMN IF NOT EXISTS tenant LowCardinality(String) DEFAULT '' AFTER user_id;
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	stdlibtime "time"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/goccy/go-json"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+// PostponeLedger keeps the entries in redis, so that FlushPostponedLedger inserts them later. It's used when InsertLedger fails,
+// after the balance mutations the entries are for were already persisted, so that they don't get lost.
+func PostponeLedger(ctx context.Context, db redis.Cmdable, entries []*LedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	values := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		val, err := json.MarshalContext(ctx, entry)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal ledger entry %#v", entry)
+		}
+		values = append(values, val)
+	}
+
+	return errors.Wrapf(db.RPush(ctx, postponedLedgerKey, values...).Err(), "failed to postpone %v ledger entries", len(entries))
+}
+
+// FlushPostponedLedger inserts up to `count` of the entries postponed by PostponeLedger. They're postponed again if that fails.
+func FlushPostponedLedger(ctx context.Context, db redis.Cmdable, client Client, count int) error {
+	vals, err := db.LPopCount(ctx, postponedLedgerKey, count).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			err = nil
+		}
+
+		return errors.Wrap(err, "failed to pop postponed ledger entries")
+	}
+	entries, mErr := make([]*LedgerEntry, 0, len(vals)), new(multierror.Error)
+	for _, val := range vals {
+		entry := new(LedgerEntry)
+		if err = json.UnmarshalContext(ctx, []byte(val), entry); err != nil {
+			mErr = multierror.Append(mErr, errors.Wrapf(err, "dropping invalid postponed ledger entry %v", val))
+
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err = client.InsertLedger(ctx, entries); err != nil {
+		mErr = multierror.Append(mErr,
+			errors.Wrapf(err, "failed to insert %v postponed ledger entries", len(entries)),
+			PostponeLedger(ctx, db, entries))
+	}
+
+	return mErr.ErrorOrNil() //nolint:wrapcheck // .
+}
+
+func (db *db) InsertLedger(ctx context.Context, entries []*LedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var (
+		createdAt = &proto.ColDateTime64{Data: make([]proto.DateTime64, 0, len(entries)), Location: stdlibtime.UTC, Precision: proto.PrecisionMax, PrecisionSet: true} //nolint:lll // .
+		amount    = make(proto.ColDecimal64, 0, len(entries))
+		id        = make(proto.ColInt64, 0, len(entries))
+		reason    = new(proto.ColStr).LowCardinality()
+		source    = new(proto.ColStr)
+		userID    = new(proto.ColStr)
+		tenant    = new(proto.ColStr).LowCardinality()
+	)
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.CreatedAt.IsNil() {
+			createdAt.Append(*now.Time)
+		} else {
+			createdAt.Append(*entry.CreatedAt.Time)
+		}
+		amount.Append(proto.Decimal64(entry.Amount))
+		id.Append(entry.ID)
+		reason.Append(string(entry.Reason))
+		source.Append(entry.Source)
+		userID.Append(entry.UserID)
+		tenant.Append(db.tenant)
+	}
+	input := proto.Input{
+		{Name: "created_at", Data: createdAt},
+		{Name: "amount", Data: proto.Alias(&amount, amountColumnType)},
+		{Name: "id", Data: &id},
+		{Name: "reason", Data: reason},
+		{Name: "source", Data: source},
+		{Name: "user_id", Data: userID},
+		{Name: "tenant", Data: tenant},
+	}
+
+	return db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body:     input.Into(ledgerTableName),
+		Input:    input,
+		Settings: db.settings,
+	})
+}
+
+func (db *db) SelectLedger(ctx context.Context, id int64, before stdlibtime.Time, limit uint64) ([]*LedgerEntry, error) {
+	var (
+		createdAt = proto.ColDateTime64{Data: make([]proto.DateTime64, 0, limit), Location: stdlibtime.UTC, Precision: proto.PrecisionMax, PrecisionSet: true}
+		amount    = make(proto.ColDecimal64, 0, limit)
+		reason    = new(proto.ColStr).LowCardinality()
+		source    = proto.ColStr{}
+		userID    = proto.ColStr{}
+		res       = make([]*LedgerEntry, 0, limit)
+	)
+	beforeCondition := ""
+	if !before.IsZero() {
+		beforeCondition = fmt.Sprintf("AND created_at < fromUnixTimestamp64Nano(%v, 'UTC')", before.UnixNano())
+	}
+	if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body: fmt.Sprintf(`SELECT created_at,
+								  amount,
+								  reason,
+								  source,
+								  user_id
+						   FROM %[1]v
+						   WHERE id = %[2]v
+						     AND %[5]v
+						     %[3]v
+						   ORDER BY created_at DESC
+						   LIMIT %[4]v WITH TIES`, ledgerTableName, id, beforeCondition, limit, tenantCondition),
+		Parameters: db.parameters(nil),
+		Result: append(make(proto.Results, 0, 5),
+			proto.ResultColumn{Name: "created_at", Data: &createdAt},
+			proto.ResultColumn{Name: "amount", Data: proto.Alias(&amount, amountColumnType)},
+			proto.ResultColumn{Name: "reason", Data: reason},
+			proto.ResultColumn{Name: "source", Data: &source},
+			proto.ResultColumn{Name: "user_id", Data: &userID}),
+		OnResult: func(_ context.Context, block proto.Block) error {
+			for ix := 0; ix < block.Rows; ix++ {
+				res = append(res, &LedgerEntry{
+					CreatedAt: time.New((&createdAt).Row(ix)),
+					Reason:    LedgerReason(reason.Row(ix)),
+					Source:    (&source).Row(ix),
+					UserID:    (&userID).Row(ix),
+					ID:        id,
+					Amount:    model.Amount((&amount).Row(ix)),
+				})
+			}
+			(&createdAt).Reset()
+			(&amount).Reset()
+			reason.Reset()
+			(&source).Reset()
+			(&userID).Reset()
+
+			return nil
+		},
+		Secret:      "",
+		InitialUser: "",
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
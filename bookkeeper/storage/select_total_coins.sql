@@ -11,6 +11,7 @@ WITH req_dates AS (
                 created_at, id, id_t0, id_tminus1, pre_staking_allocation, pre_staking_bonus, balance_solo, balance_solo_ethereum, balance_t0, balance_t0_ethereum, balance_for_t0, balance_t1_ethereum
             FROM %[1]v
             WHERE created_at >= '%[2]v' AND created_at < '%[6]v'
+            AND %[7]v
             AND kyc_step_passed >= %[3]v
             AND (kyc_step_blocked = 0 OR kyc_step_blocked >= %[3]v + 1)
         ) t, req_dates
@@ -22,10 +23,12 @@ WITH req_dates AS (
                   created_at,
                   id, id_t0, id_tminus1, pre_staking_allocation, pre_staking_bonus, balance_solo, balance_solo_ethereum, balance_t0, balance_t0_ethereum, balance_for_t0, balance_t1_ethereum
               FROM %[1]v
-              WHERE (id, created_at) GLOBAL IN (
+              WHERE %[7]v
+                AND (id, created_at) GLOBAL IN (
                   SELECT id, max(created_at)
                   FROM %[1]v
-                  WHERE kyc_step_passed >= %[3]v
+                  WHERE %[7]v
+                    AND kyc_step_passed >= %[3]v
                     AND (kyc_step_blocked = 0 OR kyc_step_blocked >= %[3]v + 1)
                   GROUP BY id
                   HAVING max(created_at) < '%[5]v')) t, req_dates WHERE t.created_at < req_dates.req_date
@@ -40,9 +43,9 @@ WITH req_dates AS (
     )
 SELECT
         u.created_at                                                                                                                                  AS created_at,
-        SUM(((u.balance_solo+IF(t0.id != 0, u.balance_t0, 0)+t1.balance_t1) * (100.0 - u.pre_staking_allocation)) / 100.0)                            AS balance_total_standard,
-        SUM(((u.balance_solo+IF(t0.id != 0, u.balance_t0, 0)+t1.balance_t1) * (100.0 + u.pre_staking_bonus) * u.pre_staking_allocation) / 10000.0)    AS balance_total_pre_staking,
-        SUM(u.balance_solo_ethereum+u.balance_t0_ethereum+u.balance_t1_ethereum)                                                                      AS balance_total_ethereum
+        toFloat64(SUM((toDecimal128(u.balance_solo+IF(t0.id != 0, u.balance_t0, 0)+t1.balance_t1, 6) * (100 - u.pre_staking_allocation)) / 100))                          AS balance_total_standard,
+        toFloat64(SUM((toDecimal128(u.balance_solo+IF(t0.id != 0, u.balance_t0, 0)+t1.balance_t1, 6) * (100 + u.pre_staking_bonus) * u.pre_staking_allocation) / 10000))  AS balance_total_pre_staking,
+        toFloat64(SUM(toDecimal128(u.balance_solo_ethereum+u.balance_t0_ethereum+u.balance_t1_ethereum, 6)))                                                               AS balance_total_ethereum
 FROM valid_users u
 
     GLOBAL LEFT JOIN valid_users t0
@@ -5,6 +5,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -24,8 +25,10 @@ import (
 	"github.com/ice-blockchain/wintr/time"
 )
 
+// MustConnect connects to the dwh, storing and reading the data of the provided tenant (see Client.ForTenant).
+//
 //nolint:gomnd,funlen // Default configs.
-func MustConnect(ctx context.Context, applicationYAMLKey string) Client {
+func MustConnect(ctx context.Context, applicationYAMLKey, tenant string) Client {
 	var cfg config
 	appCfg.MustLoadFromKey(applicationYAMLKey, &cfg)
 	logger, err := zap.Config{
@@ -42,6 +45,8 @@ func MustConnect(ctx context.Context, applicationYAMLKey string) Client {
 	log.Panic(err)
 	cl := new(db)
 	cl.cfg = &cfg
+	cl.tenant = tenant
+	cl.currentIndex = new(uint64)
 	cl.settings = append(make([]ch.Setting, 0, 3),
 		ch.SettingInt("async_insert", 1),
 		ch.SettingInt("wait_for_async_insert", 1))
@@ -80,6 +85,22 @@ func MustConnect(ctx context.Context, applicationYAMLKey string) Client {
 	return cl
 }
 
+func (db *db) ForTenant(tenant string) Client {
+	cl := *db
+	cl.tenant = tenant
+
+	return &cl
+}
+
+func (db *db) parameters(params map[string]any) []proto.Parameter {
+	if params == nil {
+		params = make(map[string]any, 1)
+	}
+	params["tenant"] = db.tenant
+
+	return ch.Parameters(params)
+}
+
 func (db *db) Close() error {
 	for _, pool := range db.pools {
 		pool.Close()
@@ -200,43 +221,44 @@ func (db *db) Insert(ctx context.Context, columns *Columns, input InsertMetadata
 		columns.miningBlockchainAccountAddress.Append(usr.MiningBlockchainAccountAddress)
 		columns.blockchainAccountAddress.Append(usr.BlockchainAccountAddress)
 		columns.userID.Append(usr.UserID)
+		columns.tenant.Append(db.tenant)
 		columns.id.Append(usr.ID)
 		columns.idT0.Append(usr.IDT0)
 		columns.idTminus1.Append(usr.IDTMinus1)
-		columns.balanceTotalStandard.Append(usr.BalanceTotalStandard)
-		columns.balanceTotalPreStaking.Append(usr.BalanceTotalPreStaking)
-		columns.balanceTotalMinted.Append(usr.BalanceTotalMinted)
-		columns.balanceTotalSlashed.Append(usr.BalanceTotalSlashed)
-		columns.balanceSoloPending.Append(usr.BalanceSoloPending)
-		columns.balanceT1Pending.Append(usr.BalanceT1Pending)
-		columns.balanceT2Pending.Append(usr.BalanceT2Pending)
-		columns.balanceSoloPendingApplied.Append(usr.BalanceSoloPendingApplied)
-		columns.balanceT1PendingApplied.Append(usr.BalanceT1PendingApplied)
-		columns.balanceT2PendingApplied.Append(usr.BalanceT2PendingApplied)
-		columns.balanceSolo.Append(usr.BalanceSolo)
-		columns.balanceT0.Append(usr.BalanceT0)
-		columns.balanceT1.Append(usr.BalanceT1)
-		columns.balanceT2.Append(usr.BalanceT2)
-		columns.balanceForT0.Append(usr.BalanceForT0)
-		columns.balanceForTminus1.Append(usr.BalanceForTMinus1)
-		columns.balanceSoloEthereum.Append(usr.BalanceSoloEthereum)
-		columns.balanceT0Ethereum.Append(usr.BalanceT0Ethereum)
-		columns.balanceT1Ethereum.Append(usr.BalanceT1Ethereum)
-		columns.balanceT2Ethereum.Append(usr.BalanceT2Ethereum)
-		columns.balanceForT0Ethereum.Append(usr.BalanceForT0Ethereum)
-		columns.balanceForTMinus1Ethereum.Append(usr.BalanceForTMinus1Ethereum)
-		columns.balanceSoloEthereumMainnetRewardPoolContribution.Append(usr.BalanceSoloEthereumMainnetRewardPoolContribution)
-		columns.balanceT0EthereumMainnetRewardPoolContribution.Append(usr.BalanceT0EthereumMainnetRewardPoolContribution)
-		columns.balanceT1EthereumMainnetRewardPoolContribution.Append(usr.BalanceT1EthereumMainnetRewardPoolContribution)
-		columns.balanceT2EthereumMainnetRewardPoolContribution.Append(usr.BalanceT2EthereumMainnetRewardPoolContribution)
-		columns.balanceForT0EthereumMainnetRewardPoolContribution.Append(usr.BalanceForT0EthereumMainnetRewardPoolContribution)
-		columns.balanceForTMinus1EthereumMainnetRewardPoolContribution.Append(usr.BalanceForTMinus1EthereumMainnetRewardPoolContribution)
-		columns.slashingRateSolo.Append(usr.SlashingRateSolo)
-		columns.slashingRateT0.Append(usr.SlashingRateT0)
-		columns.slashingRateT1.Append(usr.SlashingRateT1)
-		columns.slashingRateT2.Append(usr.SlashingRateT2)
-		columns.slashingRateForT0.Append(usr.SlashingRateForT0)
-		columns.slashingRateForTminus1.Append(usr.SlashingRateForTMinus1)
+		columns.balanceTotalStandard.Append(proto.Decimal64(usr.BalanceTotalStandard))
+		columns.balanceTotalPreStaking.Append(proto.Decimal64(usr.BalanceTotalPreStaking))
+		columns.balanceTotalMinted.Append(proto.Decimal64(usr.BalanceTotalMinted))
+		columns.balanceTotalSlashed.Append(proto.Decimal64(usr.BalanceTotalSlashed))
+		columns.balanceSoloPending.Append(proto.Decimal64(usr.BalanceSoloPending))
+		columns.balanceT1Pending.Append(proto.Decimal64(usr.BalanceT1Pending))
+		columns.balanceT2Pending.Append(proto.Decimal64(usr.BalanceT2Pending))
+		columns.balanceSoloPendingApplied.Append(proto.Decimal64(usr.BalanceSoloPendingApplied))
+		columns.balanceT1PendingApplied.Append(proto.Decimal64(usr.BalanceT1PendingApplied))
+		columns.balanceT2PendingApplied.Append(proto.Decimal64(usr.BalanceT2PendingApplied))
+		columns.balanceSolo.Append(proto.Decimal64(usr.BalanceSolo))
+		columns.balanceT0.Append(proto.Decimal64(usr.BalanceT0))
+		columns.balanceT1.Append(proto.Decimal64(usr.BalanceT1))
+		columns.balanceT2.Append(proto.Decimal64(usr.BalanceT2))
+		columns.balanceForT0.Append(proto.Decimal64(usr.BalanceForT0))
+		columns.balanceForTminus1.Append(proto.Decimal64(usr.BalanceForTMinus1))
+		columns.balanceSoloEthereum.Append(proto.Decimal64(usr.BalanceSoloEthereum))
+		columns.balanceT0Ethereum.Append(proto.Decimal64(usr.BalanceT0Ethereum))
+		columns.balanceT1Ethereum.Append(proto.Decimal64(usr.BalanceT1Ethereum))
+		columns.balanceT2Ethereum.Append(proto.Decimal64(usr.BalanceT2Ethereum))
+		columns.balanceForT0Ethereum.Append(proto.Decimal64(usr.BalanceForT0Ethereum))
+		columns.balanceForTMinus1Ethereum.Append(proto.Decimal64(usr.BalanceForTMinus1Ethereum))
+		columns.balanceSoloEthereumMainnetRewardPoolContribution.Append(proto.Decimal64(usr.BalanceSoloEthereumMainnetRewardPoolContribution))
+		columns.balanceT0EthereumMainnetRewardPoolContribution.Append(proto.Decimal64(usr.BalanceT0EthereumMainnetRewardPoolContribution))
+		columns.balanceT1EthereumMainnetRewardPoolContribution.Append(proto.Decimal64(usr.BalanceT1EthereumMainnetRewardPoolContribution))
+		columns.balanceT2EthereumMainnetRewardPoolContribution.Append(proto.Decimal64(usr.BalanceT2EthereumMainnetRewardPoolContribution))
+		columns.balanceForT0EthereumMainnetRewardPoolContribution.Append(proto.Decimal64(usr.BalanceForT0EthereumMainnetRewardPoolContribution))
+		columns.balanceForTMinus1EthereumMainnetRewardPoolContribution.Append(proto.Decimal64(usr.BalanceForTMinus1EthereumMainnetRewardPoolContribution))
+		columns.slashingRateSolo.Append(proto.Decimal64(usr.SlashingRateSolo))
+		columns.slashingRateT0.Append(proto.Decimal64(usr.SlashingRateT0))
+		columns.slashingRateT1.Append(proto.Decimal64(usr.SlashingRateT1))
+		columns.slashingRateT2.Append(proto.Decimal64(usr.SlashingRateT2))
+		columns.slashingRateForT0.Append(proto.Decimal64(usr.SlashingRateForT0))
+		columns.slashingRateForTminus1.Append(proto.Decimal64(usr.SlashingRateForTMinus1))
 		columns.activeT1Referrals.Append(usr.ActiveT1Referrals)
 		columns.activeT2Referrals.Append(usr.ActiveT2Referrals)
 		columns.preStakingBonus.Append(uint16(usr.PreStakingBonus))
@@ -274,7 +296,7 @@ func (db *db) Insert(ctx context.Context, columns *Columns, input InsertMetadata
 		columns.kycStepsLastUpdatedAt.Append(kycStepsLastUpdatedAt)
 	}
 
-	return db.pools[atomic.AddUint64(&db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+	return db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
 		Body:     input.Into(tableName),
 		Input:    input,
 		Settings: db.settings,
@@ -304,43 +326,44 @@ func InsertDDL(rows int) (*Columns, proto.Input) {
 		miningBlockchainAccountAddress                         = &proto.ColStr{Buf: make([]byte, 0, 50*rows), Pos: make([]proto.Position, 0, rows)}
 		blockchainAccountAddress                               = &proto.ColStr{Buf: make([]byte, 0, 50*rows), Pos: make([]proto.Position, 0, rows)}
 		userID                                                 = &proto.ColStr{Buf: make([]byte, 0, 40*rows), Pos: make([]proto.Position, 0, rows)}
+		tenant                                                 = new(proto.ColStr).LowCardinality()
 		id                                                     = make(proto.ColInt64, 0, rows)
 		idT0                                                   = make(proto.ColInt64, 0, rows)
 		idTminus1                                              = make(proto.ColInt64, 0, rows)
-		balanceTotalStandard                                   = make(proto.ColFloat64, 0, rows)
-		balanceTotalPreStaking                                 = make(proto.ColFloat64, 0, rows)
-		balanceTotalMinted                                     = make(proto.ColFloat64, 0, rows)
-		balanceTotalSlashed                                    = make(proto.ColFloat64, 0, rows)
-		balanceSoloPending                                     = make(proto.ColFloat64, 0, rows)
-		balanceT1Pending                                       = make(proto.ColFloat64, 0, rows)
-		balanceT2Pending                                       = make(proto.ColFloat64, 0, rows)
-		balanceSoloPendingApplied                              = make(proto.ColFloat64, 0, rows)
-		balanceT1PendingApplied                                = make(proto.ColFloat64, 0, rows)
-		balanceT2PendingApplied                                = make(proto.ColFloat64, 0, rows)
-		balanceSolo                                            = make(proto.ColFloat64, 0, rows)
-		balanceT0                                              = make(proto.ColFloat64, 0, rows)
-		balanceT1                                              = make(proto.ColFloat64, 0, rows)
-		balanceT2                                              = make(proto.ColFloat64, 0, rows)
-		balanceForT0                                           = make(proto.ColFloat64, 0, rows)
-		balanceForTminus1                                      = make(proto.ColFloat64, 0, rows)
-		balanceSoloEthereum                                    = make(proto.ColFloat64, 0, rows)
-		balanceT0Ethereum                                      = make(proto.ColFloat64, 0, rows)
-		balanceT1Ethereum                                      = make(proto.ColFloat64, 0, rows)
-		balanceT2Ethereum                                      = make(proto.ColFloat64, 0, rows)
-		balanceForT0Ethereum                                   = make(proto.ColFloat64, 0, rows)
-		balanceForTMinus1Ethereum                              = make(proto.ColFloat64, 0, rows)
-		balanceSoloEthereumMainnetRewardPoolContribution       = make(proto.ColFloat64, 0, rows)
-		balanceT0EthereumMainnetRewardPoolContribution         = make(proto.ColFloat64, 0, rows)
-		balanceT1EthereumMainnetRewardPoolContribution         = make(proto.ColFloat64, 0, rows)
-		balanceT2EthereumMainnetRewardPoolContribution         = make(proto.ColFloat64, 0, rows)
-		balanceForT0EthereumMainnetRewardPoolContribution      = make(proto.ColFloat64, 0, rows)
-		balanceForTMinus1EthereumMainnetRewardPoolContribution = make(proto.ColFloat64, 0, rows)
-		slashingRateSolo                                       = make(proto.ColFloat64, 0, rows)
-		slashingRateT0                                         = make(proto.ColFloat64, 0, rows)
-		slashingRateT1                                         = make(proto.ColFloat64, 0, rows)
-		slashingRateT2                                         = make(proto.ColFloat64, 0, rows)
-		slashingRateForT0                                      = make(proto.ColFloat64, 0, rows)
-		slashingRateForTminus1                                 = make(proto.ColFloat64, 0, rows)
+		balanceTotalStandard                                   = make(proto.ColDecimal64, 0, rows)
+		balanceTotalPreStaking                                 = make(proto.ColDecimal64, 0, rows)
+		balanceTotalMinted                                     = make(proto.ColDecimal64, 0, rows)
+		balanceTotalSlashed                                    = make(proto.ColDecimal64, 0, rows)
+		balanceSoloPending                                     = make(proto.ColDecimal64, 0, rows)
+		balanceT1Pending                                       = make(proto.ColDecimal64, 0, rows)
+		balanceT2Pending                                       = make(proto.ColDecimal64, 0, rows)
+		balanceSoloPendingApplied                              = make(proto.ColDecimal64, 0, rows)
+		balanceT1PendingApplied                                = make(proto.ColDecimal64, 0, rows)
+		balanceT2PendingApplied                                = make(proto.ColDecimal64, 0, rows)
+		balanceSolo                                            = make(proto.ColDecimal64, 0, rows)
+		balanceT0                                              = make(proto.ColDecimal64, 0, rows)
+		balanceT1                                              = make(proto.ColDecimal64, 0, rows)
+		balanceT2                                              = make(proto.ColDecimal64, 0, rows)
+		balanceForT0                                           = make(proto.ColDecimal64, 0, rows)
+		balanceForTminus1                                      = make(proto.ColDecimal64, 0, rows)
+		balanceSoloEthereum                                    = make(proto.ColDecimal64, 0, rows)
+		balanceT0Ethereum                                      = make(proto.ColDecimal64, 0, rows)
+		balanceT1Ethereum                                      = make(proto.ColDecimal64, 0, rows)
+		balanceT2Ethereum                                      = make(proto.ColDecimal64, 0, rows)
+		balanceForT0Ethereum                                   = make(proto.ColDecimal64, 0, rows)
+		balanceForTMinus1Ethereum                              = make(proto.ColDecimal64, 0, rows)
+		balanceSoloEthereumMainnetRewardPoolContribution       = make(proto.ColDecimal64, 0, rows)
+		balanceT0EthereumMainnetRewardPoolContribution         = make(proto.ColDecimal64, 0, rows)
+		balanceT1EthereumMainnetRewardPoolContribution         = make(proto.ColDecimal64, 0, rows)
+		balanceT2EthereumMainnetRewardPoolContribution         = make(proto.ColDecimal64, 0, rows)
+		balanceForT0EthereumMainnetRewardPoolContribution      = make(proto.ColDecimal64, 0, rows)
+		balanceForTMinus1EthereumMainnetRewardPoolContribution = make(proto.ColDecimal64, 0, rows)
+		slashingRateSolo                                       = make(proto.ColDecimal64, 0, rows)
+		slashingRateT0                                         = make(proto.ColDecimal64, 0, rows)
+		slashingRateT1                                         = make(proto.ColDecimal64, 0, rows)
+		slashingRateT2                                         = make(proto.ColDecimal64, 0, rows)
+		slashingRateForT0                                      = make(proto.ColDecimal64, 0, rows)
+		slashingRateForTminus1                                 = make(proto.ColDecimal64, 0, rows)
 		activeT1Referrals                                      = make(proto.ColInt32, 0, rows)
 		activeT2Referrals                                      = make(proto.ColInt32, 0, rows)
 		preStakingBonus                                        = make(proto.ColUInt16, 0, rows)
@@ -357,7 +380,7 @@ func InsertDDL(rows int) (*Columns, proto.Input) {
 		kycStepsCreatedAt                                      = proto.NewArray[stdlibtime.Time](&proto.ColDateTime64{Data: make([]proto.DateTime64, 0, 6), Location: stdlibtime.UTC, Precision: proto.PrecisionMax, PrecisionSet: true}) //nolint:lll // .
 		kycStepsLastUpdatedAt                                  = proto.NewArray[stdlibtime.Time](&proto.ColDateTime64{Data: make([]proto.DateTime64, 0, 6), Location: stdlibtime.UTC, Precision: proto.PrecisionMax, PrecisionSet: true}) //nolint:lll // .
 	)
-	input := append(make(proto.Input, 0, 72),
+	input := append(make(proto.Input, 0, 73),
 		proto.InputColumn{Name: "mining_session_solo_last_started_at", Data: miningSessionSoloLastStartedAt},
 		proto.InputColumn{Name: "mining_session_solo_started_at", Data: miningSessionSoloStartedAt},
 		proto.InputColumn{Name: "mining_session_solo_ended_at", Data: miningSessionSoloEndedAt},
@@ -379,40 +402,41 @@ func InsertDDL(rows int) (*Columns, proto.Input) {
 		proto.InputColumn{Name: "mining_blockchain_account_address", Data: miningBlockchainAccountAddress},
 		proto.InputColumn{Name: "blockchain_account_address", Data: blockchainAccountAddress},
 		proto.InputColumn{Name: "user_id", Data: userID},
-		proto.InputColumn{Name: "balance_total_standard", Data: &balanceTotalStandard},
-		proto.InputColumn{Name: "balance_total_pre_staking", Data: &balanceTotalPreStaking},
-		proto.InputColumn{Name: "balance_total_minted", Data: &balanceTotalMinted},
-		proto.InputColumn{Name: "balance_total_slashed", Data: &balanceTotalSlashed},
-		proto.InputColumn{Name: "balance_solo_pending", Data: &balanceSoloPending},
-		proto.InputColumn{Name: "balance_t1_pending", Data: &balanceT1Pending},
-		proto.InputColumn{Name: "balance_t2_pending", Data: &balanceT2Pending},
-		proto.InputColumn{Name: "balance_solo_pending_applied", Data: &balanceSoloPendingApplied},
-		proto.InputColumn{Name: "balance_t1_pending_applied", Data: &balanceT1PendingApplied},
-		proto.InputColumn{Name: "balance_t2_pending_applied", Data: &balanceT2PendingApplied},
-		proto.InputColumn{Name: "balance_solo", Data: &balanceSolo},
-		proto.InputColumn{Name: "balance_t0", Data: &balanceT0},
-		proto.InputColumn{Name: "balance_t1", Data: &balanceT1},
-		proto.InputColumn{Name: "balance_t2", Data: &balanceT2},
-		proto.InputColumn{Name: "balance_for_t0", Data: &balanceForT0},
-		proto.InputColumn{Name: "balance_for_tminus1", Data: &balanceForTminus1},
-		proto.InputColumn{Name: "balance_solo_ethereum", Data: &balanceSoloEthereum},
-		proto.InputColumn{Name: "balance_t0_ethereum", Data: &balanceT0Ethereum},
-		proto.InputColumn{Name: "balance_t1_ethereum", Data: &balanceT1Ethereum},
-		proto.InputColumn{Name: "balance_t2_ethereum", Data: &balanceT2Ethereum},
-		proto.InputColumn{Name: "balance_for_t0_ethereum", Data: &balanceForT0Ethereum},
-		proto.InputColumn{Name: "balance_for_tminus1_ethereum", Data: &balanceForTMinus1Ethereum},
-		proto.InputColumn{Name: "balance_solo_ethereum_mainnet_reward_pool_contribution", Data: &balanceSoloEthereumMainnetRewardPoolContribution},
-		proto.InputColumn{Name: "balance_t0_ethereum_mainnet_reward_pool_contribution", Data: &balanceT0EthereumMainnetRewardPoolContribution},
-		proto.InputColumn{Name: "balance_t1_ethereum_mainnet_reward_pool_contribution", Data: &balanceT1EthereumMainnetRewardPoolContribution},
-		proto.InputColumn{Name: "balance_t2_ethereum_mainnet_reward_pool_contribution", Data: &balanceT2EthereumMainnetRewardPoolContribution},
-		proto.InputColumn{Name: "balance_for_t0_ethereum_mainnet_reward_pool_contribution", Data: &balanceForT0EthereumMainnetRewardPoolContribution},
-		proto.InputColumn{Name: "balance_for_tminus1_ethereum_mainnet_reward_pool_contribution", Data: &balanceForTMinus1EthereumMainnetRewardPoolContribution},
-		proto.InputColumn{Name: "slashing_rate_solo", Data: &slashingRateSolo},
-		proto.InputColumn{Name: "slashing_rate_t0", Data: &slashingRateT0},
-		proto.InputColumn{Name: "slashing_rate_t1", Data: &slashingRateT1},
-		proto.InputColumn{Name: "slashing_rate_t2", Data: &slashingRateT2},
-		proto.InputColumn{Name: "slashing_rate_for_t0", Data: &slashingRateForT0},
-		proto.InputColumn{Name: "slashing_rate_for_tminus1", Data: &slashingRateForTminus1},
+		proto.InputColumn{Name: "tenant", Data: tenant},
+		proto.InputColumn{Name: "balance_total_standard", Data: proto.Alias(&balanceTotalStandard, amountColumnType)},
+		proto.InputColumn{Name: "balance_total_pre_staking", Data: proto.Alias(&balanceTotalPreStaking, amountColumnType)},
+		proto.InputColumn{Name: "balance_total_minted", Data: proto.Alias(&balanceTotalMinted, amountColumnType)},
+		proto.InputColumn{Name: "balance_total_slashed", Data: proto.Alias(&balanceTotalSlashed, amountColumnType)},
+		proto.InputColumn{Name: "balance_solo_pending", Data: proto.Alias(&balanceSoloPending, amountColumnType)},
+		proto.InputColumn{Name: "balance_t1_pending", Data: proto.Alias(&balanceT1Pending, amountColumnType)},
+		proto.InputColumn{Name: "balance_t2_pending", Data: proto.Alias(&balanceT2Pending, amountColumnType)},
+		proto.InputColumn{Name: "balance_solo_pending_applied", Data: proto.Alias(&balanceSoloPendingApplied, amountColumnType)},
+		proto.InputColumn{Name: "balance_t1_pending_applied", Data: proto.Alias(&balanceT1PendingApplied, amountColumnType)},
+		proto.InputColumn{Name: "balance_t2_pending_applied", Data: proto.Alias(&balanceT2PendingApplied, amountColumnType)},
+		proto.InputColumn{Name: "balance_solo", Data: proto.Alias(&balanceSolo, amountColumnType)},
+		proto.InputColumn{Name: "balance_t0", Data: proto.Alias(&balanceT0, amountColumnType)},
+		proto.InputColumn{Name: "balance_t1", Data: proto.Alias(&balanceT1, amountColumnType)},
+		proto.InputColumn{Name: "balance_t2", Data: proto.Alias(&balanceT2, amountColumnType)},
+		proto.InputColumn{Name: "balance_for_t0", Data: proto.Alias(&balanceForT0, amountColumnType)},
+		proto.InputColumn{Name: "balance_for_tminus1", Data: proto.Alias(&balanceForTminus1, amountColumnType)},
+		proto.InputColumn{Name: "balance_solo_ethereum", Data: proto.Alias(&balanceSoloEthereum, amountColumnType)},
+		proto.InputColumn{Name: "balance_t0_ethereum", Data: proto.Alias(&balanceT0Ethereum, amountColumnType)},
+		proto.InputColumn{Name: "balance_t1_ethereum", Data: proto.Alias(&balanceT1Ethereum, amountColumnType)},
+		proto.InputColumn{Name: "balance_t2_ethereum", Data: proto.Alias(&balanceT2Ethereum, amountColumnType)},
+		proto.InputColumn{Name: "balance_for_t0_ethereum", Data: proto.Alias(&balanceForT0Ethereum, amountColumnType)},
+		proto.InputColumn{Name: "balance_for_tminus1_ethereum", Data: proto.Alias(&balanceForTMinus1Ethereum, amountColumnType)},
+		proto.InputColumn{Name: "balance_solo_ethereum_mainnet_reward_pool_contribution", Data: proto.Alias(&balanceSoloEthereumMainnetRewardPoolContribution, amountColumnType)},
+		proto.InputColumn{Name: "balance_t0_ethereum_mainnet_reward_pool_contribution", Data: proto.Alias(&balanceT0EthereumMainnetRewardPoolContribution, amountColumnType)},
+		proto.InputColumn{Name: "balance_t1_ethereum_mainnet_reward_pool_contribution", Data: proto.Alias(&balanceT1EthereumMainnetRewardPoolContribution, amountColumnType)},
+		proto.InputColumn{Name: "balance_t2_ethereum_mainnet_reward_pool_contribution", Data: proto.Alias(&balanceT2EthereumMainnetRewardPoolContribution, amountColumnType)},
+		proto.InputColumn{Name: "balance_for_t0_ethereum_mainnet_reward_pool_contribution", Data: proto.Alias(&balanceForT0EthereumMainnetRewardPoolContribution, amountColumnType)},
+		proto.InputColumn{Name: "balance_for_tminus1_ethereum_mainnet_reward_pool_contribution", Data: proto.Alias(&balanceForTMinus1EthereumMainnetRewardPoolContribution, amountColumnType)},
+		proto.InputColumn{Name: "slashing_rate_solo", Data: proto.Alias(&slashingRateSolo, amountColumnType)},
+		proto.InputColumn{Name: "slashing_rate_t0", Data: proto.Alias(&slashingRateT0, amountColumnType)},
+		proto.InputColumn{Name: "slashing_rate_t1", Data: proto.Alias(&slashingRateT1, amountColumnType)},
+		proto.InputColumn{Name: "slashing_rate_t2", Data: proto.Alias(&slashingRateT2, amountColumnType)},
+		proto.InputColumn{Name: "slashing_rate_for_t0", Data: proto.Alias(&slashingRateForT0, amountColumnType)},
+		proto.InputColumn{Name: "slashing_rate_for_tminus1", Data: proto.Alias(&slashingRateForTminus1, amountColumnType)},
 		proto.InputColumn{Name: "id", Data: &id},
 		proto.InputColumn{Name: "id_t0", Data: &idT0},
 		proto.InputColumn{Name: "id_tminus1", Data: &idTminus1},
@@ -454,6 +478,7 @@ func InsertDDL(rows int) (*Columns, proto.Input) {
 		miningBlockchainAccountAddress:                    miningBlockchainAccountAddress,
 		blockchainAccountAddress:                          blockchainAccountAddress,
 		userID:                                            userID,
+		tenant:                                            tenant,
 		id:                                                &id,
 		idT0:                                              &idT0,
 		idTminus1:                                         &idTminus1,
@@ -512,8 +537,8 @@ func InsertDDL(rows int) (*Columns, proto.Input) {
 func (db *db) SelectBalanceHistory(ctx context.Context, id int64, createdAts []stdlibtime.Time) ([]*BalanceHistory, error) {
 	var (
 		createdAt           = proto.ColDateTime{Data: make([]proto.DateTime, 0, len(createdAts)), Location: stdlibtime.UTC}
-		balanceTotalMinted  = make(proto.ColFloat64, 0, len(createdAts))
-		balanceTotalSlashed = make(proto.ColFloat64, 0, len(createdAts))
+		balanceTotalMinted  = make(proto.ColDecimal64, 0, len(createdAts))
+		balanceTotalSlashed = make(proto.ColDecimal64, 0, len(createdAts))
 		res                 = make([]*BalanceHistory, 0, len(createdAts))
 	)
 	createdAtArray := make([]string, 0, len(createdAts))
@@ -521,13 +546,15 @@ func (db *db) SelectBalanceHistory(ctx context.Context, id int64, createdAts []s
 		format := date.UTC().Format(stdlibtime.RFC3339)
 		createdAtArray = append(createdAtArray, format[0:len(format)-1])
 	}
-	if err := db.pools[atomic.AddUint64(&db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+	if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
 		Body: fmt.Sprintf(`SELECT created_at,
 								  balance_total_minted, 
 								  balance_total_slashed 
 						   FROM %[1]v
 						   WHERE id = %[2]v
-						     AND created_at IN ['%[3]v']`, tableName, id, strings.Join(createdAtArray, "','")),
+						     AND %[4]v
+						     AND created_at IN ['%[3]v']`, tableName, id, strings.Join(createdAtArray, "','"), tenantCondition),
+		Parameters: db.parameters(nil),
 		Result: append(make(proto.Results, 0, 3),
 			proto.ResultColumn{Name: "created_at", Data: &createdAt},
 			proto.ResultColumn{Name: "balance_total_minted", Data: &balanceTotalMinted},
@@ -536,8 +563,8 @@ func (db *db) SelectBalanceHistory(ctx context.Context, id int64, createdAts []s
 			for ix := 0; ix < block.Rows; ix++ {
 				res = append(res, &BalanceHistory{
 					CreatedAt:           time.New((&createdAt).Row(ix)),
-					BalanceTotalMinted:  (&balanceTotalMinted).Row(ix),
-					BalanceTotalSlashed: (&balanceTotalSlashed).Row(ix),
+					BalanceTotalMinted:  model.Amount((&balanceTotalMinted).Row(ix)),
+					BalanceTotalSlashed: model.Amount((&balanceTotalSlashed).Row(ix)),
 				})
 			}
 			(&createdAt).Reset()
@@ -570,6 +597,61 @@ func (db *db) SelectBalanceHistory(ctx context.Context, id int64, createdAts []s
 	return res, nil
 }
 
+func (db *db) SelectLatestBalances(ctx context.Context, ids []int64) ([]*LatestBalance, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var (
+		id                     = make(proto.ColInt64, 0, len(ids))
+		createdAt              = proto.ColDateTime{Data: make([]proto.DateTime, 0, len(ids)), Location: stdlibtime.UTC}
+		balanceTotalStandard   = make(proto.ColDecimal64, 0, len(ids))
+		balanceTotalPreStaking = make(proto.ColDecimal64, 0, len(ids))
+		res                    = make([]*LatestBalance, 0, len(ids))
+	)
+	idArray := make([]string, 0, len(ids))
+	for _, val := range ids {
+		idArray = append(idArray, strconv.FormatInt(val, 10))
+	}
+	if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body: fmt.Sprintf(`SELECT id,
+								  max(created_at) AS latest_created_at,
+								  argMax(balance_total_standard, created_at) AS latest_balance_total_standard,
+								  argMax(balance_total_pre_staking, created_at) AS latest_balance_total_pre_staking
+						   FROM %[1]v
+						   WHERE id IN [%[2]v]
+						     AND %[3]v
+						   GROUP BY id`, tableName, strings.Join(idArray, ","), tenantCondition),
+		Parameters: db.parameters(nil),
+		Result: append(make(proto.Results, 0, 4),
+			proto.ResultColumn{Name: "id", Data: &id},
+			proto.ResultColumn{Name: "latest_created_at", Data: &createdAt},
+			proto.ResultColumn{Name: "latest_balance_total_standard", Data: &balanceTotalStandard},
+			proto.ResultColumn{Name: "latest_balance_total_pre_staking", Data: &balanceTotalPreStaking}),
+		OnResult: func(_ context.Context, block proto.Block) error {
+			for ix := 0; ix < block.Rows; ix++ {
+				res = append(res, &LatestBalance{
+					CreatedAt:              time.New((&createdAt).Row(ix)),
+					ID:                     (&id).Row(ix),
+					BalanceTotalStandard:   model.Amount((&balanceTotalStandard).Row(ix)),
+					BalanceTotalPreStaking: model.Amount((&balanceTotalPreStaking).Row(ix)),
+				})
+			}
+			(&id).Reset()
+			(&createdAt).Reset()
+			(&balanceTotalStandard).Reset()
+			(&balanceTotalPreStaking).Reset()
+
+			return nil
+		},
+		Secret:      "",
+		InitialUser: "",
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (db *db) SelectTotalCoins(ctx context.Context, createdAtTime stdlibtime.Time, parentInverval stdlibtime.Duration) ([]*TotalCoins, error) {
 	var (
 		createdAt              = proto.ColDateTime{Data: make([]proto.DateTime, 0, 1), Location: stdlibtime.UTC}
@@ -582,9 +664,10 @@ func (db *db) SelectTotalCoins(ctx context.Context, createdAtTime stdlibtime.Tim
 	createdAtDate := formatCreatedAt[0 : len(formatCreatedAt)-1]
 	formatNotAfter := createdAtTime.UTC().Add(parentInverval).Format(stdlibtime.RFC3339)
 	notAfterDate := formatNotAfter[0 : len(formatNotAfter)-1]
-	sql := fmt.Sprintf(selectTotalCoinsSQL, tableName, createdAtDate, users.NoneKYCStep, createdAtDate, createdAtDate, notAfterDate)
-	if err := db.pools[atomic.AddUint64(&db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
-		Body: sql,
+	sql := fmt.Sprintf(selectTotalCoinsSQL, tableName, createdAtDate, users.NoneKYCStep, createdAtDate, createdAtDate, notAfterDate, tenantCondition)
+	if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body:       sql,
+		Parameters: db.parameters(nil),
 		Result: append(make(proto.Results, 0, 4),
 			proto.ResultColumn{Name: "created_at", Data: &createdAt},
 			proto.ResultColumn{Name: "balance_total_standard", Data: &balanceTotalStandard},
@@ -619,8 +702,9 @@ func (db *db) SelectTotalCoins(ctx context.Context, createdAtTime stdlibtime.Tim
 }
 
 func (db *db) DeleteUserInfo(ctx context.Context, id int64) error {
-	errDark := db.pools[atomic.AddUint64(&db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
-		Body: fmt.Sprintf(`DELETE FROM dark.%[1]v WHERE id = %[2]v`, tableName, id),
+	errDark := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body:       fmt.Sprintf(`DELETE FROM dark.%[1]v WHERE id = %[2]v AND %[3]v`, tableName, id, tenantCondition),
+		Parameters: db.parameters(nil),
 		OnResult: func(_ context.Context, block proto.Block) error {
 			return nil
 		},
@@ -630,8 +714,22 @@ func (db *db) DeleteUserInfo(ctx context.Context, id int64) error {
 	if errDark != nil {
 		return errors.Wrapf(errDark, "failed to delete user %v from clickhouse dark", id)
 	}
-	return errors.Wrapf(db.pools[atomic.AddUint64(&db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
-		Body: fmt.Sprintf(`DELETE FROM light.%[1]v WHERE id = %[2]v`, tableName, id),
+	for _, database := range []string{"dark", "light"} {
+		if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+			Body:       fmt.Sprintf(`DELETE FROM %[1]v.%[2]v WHERE id = %[3]v AND %[4]v`, database, ledgerTableName, id, tenantCondition),
+			Parameters: db.parameters(nil),
+			OnResult: func(_ context.Context, block proto.Block) error {
+				return nil
+			},
+			Secret:      "",
+			InitialUser: "",
+		}); err != nil {
+			return errors.Wrapf(err, "failed to delete user %v ledger from clickhouse %v", id, database)
+		}
+	}
+	return errors.Wrapf(db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body:       fmt.Sprintf(`DELETE FROM light.%[1]v WHERE id = %[2]v AND %[3]v`, tableName, id, tenantCondition),
+		Parameters: db.parameters(nil),
 		OnResult: func(_ context.Context, block proto.Block) error {
 			return nil
 		},
@@ -16,7 +16,7 @@ import (
 )
 
 func TestStorage(t *testing.T) {
-	cl := MustConnect(context.Background(), "self")
+	cl := MustConnect(context.Background(), "self", "")
 	defer func() {
 		if err := recover(); err != nil {
 			cl.Close()
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	stdlibtime "time"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+func (db *db) SelectTeamEarnings(ctx context.Context, id int64, since stdlibtime.Time) ([]*TeamEarnings, error) {
+	var (
+		createdAt = proto.ColDateTime{Data: make([]proto.DateTime, 0, 0), Location: stdlibtime.UTC}
+		balanceT0 = make(proto.ColDecimal64, 0, 0)
+		balanceT1 = make(proto.ColDecimal64, 0, 0)
+		balanceT2 = make(proto.ColDecimal64, 0, 0)
+		res       = make([]*TeamEarnings, 0, 0)
+	)
+	if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body: fmt.Sprintf(`SELECT created_at,
+								  any(balance_t0) AS balance_t0,
+								  any(balance_t1) AS balance_t1,
+								  any(balance_t2) AS balance_t2
+						   FROM %[1]v
+						   WHERE id = %[2]v
+							 AND %[4]v
+							 AND created_at >= toDateTime(%[3]v, 'UTC')
+						   GROUP BY created_at
+						   ORDER BY created_at`, tableName, id, since.Unix(), tenantCondition),
+		Parameters: db.parameters(nil),
+		Result: append(make(proto.Results, 0, 4),
+			proto.ResultColumn{Name: "created_at", Data: &createdAt},
+			proto.ResultColumn{Name: "balance_t0", Data: &balanceT0},
+			proto.ResultColumn{Name: "balance_t1", Data: &balanceT1},
+			proto.ResultColumn{Name: "balance_t2", Data: &balanceT2}),
+		OnResult: func(_ context.Context, block proto.Block) error {
+			for ix := 0; ix < block.Rows; ix++ {
+				res = append(res, &TeamEarnings{
+					CreatedAt: time.New((&createdAt).Row(ix)),
+					BalanceT0: model.Amount((&balanceT0).Row(ix)),
+					BalanceT1: model.Amount((&balanceT1).Row(ix)),
+					BalanceT2: model.Amount((&balanceT2).Row(ix)),
+				})
+			}
+			(&createdAt).Reset()
+			(&balanceT0).Reset()
+			(&balanceT1).Reset()
+			(&balanceT2).Reset()
+
+			return nil
+		},
+		Secret:      "",
+		InitialUser: "",
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+//nolint:funlen // .
+func (db *db) SelectReferrals(
+	ctx context.Context, id int64, tier ReferralTier, since stdlibtime.Time, slashingOnly bool, limit uint64,
+) ([]*Referral, error) {
+	var idColumn, contributionColumn, slashingRateColumn string
+	switch tier {
+	case T1ReferralTier:
+		idColumn, contributionColumn, slashingRateColumn = "id_t0", "balance_for_t0", "slashing_rate_for_t0"
+	case T2ReferralTier:
+		idColumn, contributionColumn, slashingRateColumn = "id_tminus1", "balance_for_tminus1", "slashing_rate_for_tminus1"
+	default:
+		return nil, errors.Errorf("unsupported referral tier %v", tier)
+	}
+	var slashingCondition string
+	if slashingOnly {
+		slashingCondition = "HAVING slashing_rate > 0"
+	}
+	var (
+		referralID         = make(proto.ColInt64, 0, limit)
+		userID             = proto.ColStr{}
+		username           = proto.ColStr{}
+		profilePictureName = proto.ColStr{}
+		contribution       = make(proto.ColDecimal64, 0, limit)
+		slashingRate       = make(proto.ColDecimal64, 0, limit)
+		res                = make([]*Referral, 0, limit)
+	)
+	// Negative t0/t-1 ids are referrals that haven't started mining yet, so they're part of the team as well.
+	if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body: fmt.Sprintf(`SELECT id,
+								  argMax(user_id, created_at) AS latest_user_id,
+								  argMax(username, created_at) AS latest_username,
+								  argMax(profile_picture_name, created_at) AS latest_profile_picture_name,
+								  argMax(%[3]v, created_at) AS contribution,
+								  argMax(%[4]v, created_at) AS slashing_rate
+						   FROM %[1]v
+						   WHERE %[2]v IN (%[5]v, -%[5]v)
+							 AND %[9]v
+							 AND created_at >= toDateTime(%[6]v, 'UTC')
+						   GROUP BY id
+						   %[7]v
+						   ORDER BY contribution DESC, id
+						   LIMIT %[8]v`, tableName, idColumn, contributionColumn, slashingRateColumn, id, since.Unix(), slashingCondition, limit, tenantCondition), //nolint:lll // .
+		Parameters: db.parameters(nil),
+		Result: append(make(proto.Results, 0, 6),
+			proto.ResultColumn{Name: "id", Data: &referralID},
+			proto.ResultColumn{Name: "latest_user_id", Data: &userID},
+			proto.ResultColumn{Name: "latest_username", Data: &username},
+			proto.ResultColumn{Name: "latest_profile_picture_name", Data: &profilePictureName},
+			proto.ResultColumn{Name: "contribution", Data: &contribution},
+			proto.ResultColumn{Name: "slashing_rate", Data: &slashingRate}),
+		OnResult: func(_ context.Context, block proto.Block) error {
+			for ix := 0; ix < block.Rows; ix++ {
+				res = append(res, &Referral{
+					UserID:             (&userID).Row(ix),
+					Username:           (&username).Row(ix),
+					ProfilePictureName: (&profilePictureName).Row(ix),
+					ID:                 (&referralID).Row(ix),
+					Contribution:       model.Amount((&contribution).Row(ix)),
+					SlashingRate:       model.Amount((&slashingRate).Row(ix)),
+				})
+			}
+			(&referralID).Reset()
+			(&userID).Reset()
+			(&username).Reset()
+			(&profilePictureName).Reset()
+			(&contribution).Reset()
+			(&slashingRate).Reset()
+
+			return nil
+		},
+		Secret:      "",
+		InitialUser: "",
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
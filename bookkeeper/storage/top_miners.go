@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	stdlibtime "time"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+
+	"github.com/ice-blockchain/freezer/model"
+)
+
+// The history can have duplicates for the same id & created_at, so we dedupe them first.
+const selectTopMinersSQL = `SELECT id,
+								   argMax(latest_user_id, created_at) AS user_id,
+								   %[2]v AS amount
+							FROM (SELECT id,
+										 created_at,
+										 any(user_id) AS latest_user_id,
+										 any(country) AS latest_country,
+										 any(hide_ranking) AS latest_hide_ranking,
+										 any(balance_total_minted) AS minted,
+										 any(balance_total_standard) + any(balance_total_pre_staking) AS total
+								  FROM %[1]v
+								  WHERE created_at >= toDateTime(%[3]v, 'UTC')
+								    AND %[7]v
+								  GROUP BY id, created_at)
+							GROUP BY id
+							HAVING NOT argMax(latest_hide_ranking, created_at)
+							   AND amount > 0
+							   %[4]v
+							ORDER BY amount DESC, id
+							LIMIT %[5]v OFFSET %[6]v`
+
+func (db *db) SelectTopMiners(ctx context.Context, since stdlibtime.Time, country string, limit, offset uint64) ([]*TopMiner, error) {
+	var (
+		id     = make(proto.ColInt64, 0, limit)
+		userID = proto.ColStr{}
+		amount = make(proto.ColDecimal64, 0, limit)
+		res    = make([]*TopMiner, 0, limit)
+	)
+	body, parameters := db.selectTopMinersQuery(since, country, limit, offset)
+	if err := db.pools[atomic.AddUint64(db.currentIndex, 1)%uint64(len(db.pools))].Do(ctx, ch.Query{
+		Body:       body,
+		Parameters: parameters,
+		Result: append(make(proto.Results, 0, 3),
+			proto.ResultColumn{Name: "id", Data: &id},
+			proto.ResultColumn{Name: "user_id", Data: &userID},
+			proto.ResultColumn{Name: "amount", Data: &amount}),
+		OnResult: func(_ context.Context, block proto.Block) error {
+			for ix := 0; ix < block.Rows; ix++ {
+				res = append(res, &TopMiner{
+					UserID: (&userID).Row(ix),
+					ID:     (&id).Row(ix),
+					Amount: model.Amount((&amount).Row(ix)),
+				})
+			}
+			(&id).Reset()
+			(&userID).Reset()
+			(&amount).Reset()
+
+			return nil
+		},
+		Secret:      "",
+		InitialUser: "",
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// The ids are unique only within a tenant, so the ranking is built from the history of the tenant of the client only,
+// otherwise they'd be resolved to the wrong users.
+func (db *db) selectTopMinersQuery(since stdlibtime.Time, country string, limit, offset uint64) (string, []proto.Parameter) {
+	amountExpression, sinceCondition := "argMax(total, created_at)", int64(0)
+	if !since.IsZero() {
+		amountExpression, sinceCondition = "toDecimal64(sum(minted), 6)", since.Unix()
+	}
+	var countryCondition string
+	parameters := make(map[string]any, 1+1)
+	if country != "" {
+		countryCondition = "AND argMax(latest_country, created_at) = {country:String}"
+		parameters["country"] = country
+	}
+
+	return fmt.Sprintf(selectTopMinersSQL, tableName, amountExpression, sinceCondition, countryCondition, limit, offset, tenantCondition),
+		db.parameters(parameters)
+}
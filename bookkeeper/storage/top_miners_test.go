@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	stdlibtime "time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ice-blockchain/freezer/model"
+)
+
+func TestSelectTopMinersQueryIsScopedToTenant(t *testing.T) {
+	t.Parallel()
+
+	cl := &db{currentIndex: new(uint64)}
+	body, parameters := cl.selectTopMinersQuery(stdlibtime.Time{}, "", 10, 0)
+	assert.Contains(t, body, "AND tenant = {tenant:String}")
+	assert.Equal(t, []proto.Parameter{{Key: "tenant", Value: "''"}}, parameters)
+
+	tenantCl := cl.ForTenant("doctorx").(*db) //nolint:forcetypeassert // We know it.
+	body, parameters = tenantCl.selectTopMinersQuery(stdlibtime.Unix(1, 0), "RO", 10, 20)
+	assert.Contains(t, body, "AND tenant = {tenant:String}")
+	assert.Contains(t, body, "= {country:String}")
+	assert.Equal(t, []proto.Parameter{{Key: "country", Value: "'RO'"}, {Key: "tenant", Value: "'doctorx'"}}, parameters)
+	assert.Empty(t, cl.tenant)
+	assert.Same(t, cl.currentIndex, tenantCl.currentIndex)
+}
+
+func TestSelectTopMinersOfTenantsSharingIDs(t *testing.T) {
+	cl := MustConnect(context.Background(), "self", "")
+	defer func() {
+		if err := recover(); err != nil {
+			cl.Close()
+			panic(err)
+		}
+		cl.Close()
+	}()
+	now := stdlibtime.Now().UTC()
+	id, suffix := now.UnixNano(), strings.ReplaceAll(now.Format(stdlibtime.RFC3339Nano), ":", "")
+	tenants := map[string]string{"tenant-a-" + suffix: "a-" + suffix, "tenant-b-" + suffix: "b-" + suffix}
+	for tenant, userID := range tenants {
+		columns, input := InsertDDL(1)
+		require.NoError(t, cl.ForTenant(tenant).Insert(context.Background(), columns, input, []*model.User{{
+			UserIDField:               model.UserIDField{UserID: userID},
+			DeserializedUsersKey:      model.DeserializedUsersKey{ID: id},
+			BalanceTotalStandardField: model.BalanceTotalStandardField{BalanceTotalStandard: model.Amount(now.Unix())},
+		}}))
+	}
+	for tenant, userID := range tenants {
+		topMiners, err := cl.ForTenant(tenant).SelectTopMiners(context.Background(), stdlibtime.Time{}, "", 1, 0)
+		require.NoError(t, err)
+		require.Len(t, topMiners, 1)
+		assert.Equal(t, id, topMiners[0].ID)
+		assert.Equal(t, userID, topMiners[0].UserID)
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	balancesynchronizer "github.com/ice-blockchain/freezer/balance-synchronizer"
+	appCfg "github.com/ice-blockchain/wintr/config"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/server"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const pkgName = "cmd/freezer-balance-synchronizer"
+
+	var cfg struct{ Version string }
+	appCfg.MustLoadFromKey(pkgName, &cfg)
+
+	log.Info(fmt.Sprintf("starting version `%v`...", cfg.Version))
+
+	server.New(new(service), pkgName, "").ListenAndServe(ctx, cancel)
+}
+
+type (
+	// | service implements server.State and is responsible for managing the state and lifecycle of the package.
+	service struct{ balanceSynchronizer balancesynchronizer.Client }
+)
+
+func (s *service) RegisterRoutes(_ *server.Router) {}
+
+func (s *service) Init(ctx context.Context, cancel context.CancelFunc) {
+	s.balanceSynchronizer = balancesynchronizer.MustStartSynchronizingBalance(ctx, cancel)
+}
+
+func (s *service) Close(_ context.Context) error {
+	return errors.Wrap(s.balanceSynchronizer.Close(), "could not close service")
+}
+
+func (s *service) CheckHealth(ctx context.Context) error {
+	log.Debug("checking health...", "package", "balance-synchronizer")
+
+	return errors.Wrap(s.balanceSynchronizer.CheckHealth(ctx), "failed to check balance synchronizer's health")
+}
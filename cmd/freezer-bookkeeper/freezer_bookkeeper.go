@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/freezer/bookkeeper"
+	appCfg "github.com/ice-blockchain/wintr/config"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/server"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const pkgName = "cmd/freezer-bookkeeper"
+
+	var cfg struct{ Version string }
+	appCfg.MustLoadFromKey(pkgName, &cfg)
+
+	log.Info(fmt.Sprintf("starting version `%v`...", cfg.Version))
+
+	server.New(new(service), pkgName, "").ListenAndServe(ctx, cancel)
+}
+
+type (
+	// | service implements server.State and is responsible for managing the state and lifecycle of the package.
+	service struct{ bookkeeper bookkeeper.Client }
+)
+
+func (s *service) RegisterRoutes(router *server.Router) {
+	router.GET("/metrics", gin.WrapH(s.bookkeeper.MetricsHandler()))
+}
+
+func (s *service) Init(ctx context.Context, cancel context.CancelFunc) {
+	s.bookkeeper = bookkeeper.MustStartBookkeeping(ctx, cancel)
+}
+
+func (s *service) Close(_ context.Context) error {
+	return errors.Wrap(s.bookkeeper.Close(), "could not close service")
+}
+
+func (s *service) CheckHealth(ctx context.Context) error {
+	log.Debug("checking health...", "package", "bookkeeper")
+
+	return errors.Wrap(s.bookkeeper.CheckHealth(ctx), "failed to check bookkeeper's health")
+}
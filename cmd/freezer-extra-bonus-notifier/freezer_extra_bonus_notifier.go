@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	extrabonusnotifier "github.com/ice-blockchain/freezer/extra-bonus-notifier"
+	appCfg "github.com/ice-blockchain/wintr/config"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/server"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const pkgName = "cmd/freezer-extra-bonus-notifier"
+
+	var cfg struct{ Version string }
+	appCfg.MustLoadFromKey(pkgName, &cfg)
+
+	log.Info(fmt.Sprintf("starting version `%v`...", cfg.Version))
+
+	server.New(new(service), pkgName, "").ListenAndServe(ctx, cancel)
+}
+
+type (
+	// | service implements server.State and is responsible for managing the state and lifecycle of the package.
+	service struct{ extraBonusNotifier extrabonusnotifier.Client }
+)
+
+func (s *service) RegisterRoutes(_ *server.Router) {}
+
+func (s *service) Init(ctx context.Context, cancel context.CancelFunc) {
+	s.extraBonusNotifier = extrabonusnotifier.MustStartNotifyingExtraBonusAvailability(ctx, cancel)
+}
+
+func (s *service) Close(_ context.Context) error {
+	return errors.Wrap(s.extraBonusNotifier.Close(), "could not close service")
+}
+
+func (s *service) CheckHealth(ctx context.Context) error {
+	log.Debug("checking health...", "package", "extra-bonus-notifier")
+
+	return errors.Wrap(s.extraBonusNotifier.CheckHealth(ctx), "failed to check extra bonus notifier's health")
+}
@@ -78,7 +78,7 @@ const docTemplate = `{
         },
         "/v1r/tokenomics-statistics/top-miners": {
             "get": {
-                "description": "Returns the paginated leaderboard with top miners.",
+                "description": "Returns the paginated leaderboard with top miners, either all-time or for a specific period and/or country.",
                 "consumes": [
                     "application/json"
                 ],
@@ -103,6 +103,24 @@ const docTemplate = `{
                         "name": "keyword",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly",
+                            "all-time"
+                        ],
+                        "type": "string",
+                        "description": "the period the leaderboard is for. Default is ` + "`" + `all-time` + "`" + `. It can't be combined with ` + "`" + `keyword` + "`" + `.",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the ISO 3166 country code the leaderboard is for. It can't be combined with ` + "`" + `keyword` + "`" + `.",
+                        "name": "country",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "max number of elements to return. Default is ` + "`" + `10` + "`" + `.",
@@ -732,6 +750,192 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1w/cancelPendingCoinDistributionTransaction": {
+            "post": {
+                "description": "Asks the coin distributer to cancel the transactions it is waiting for. The coin distributions of the cancelled transactions are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CoinDistribution"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the type of the client calling this API. I.E. ` + "`" + `web` + "`" + `",
+                        "name": "x_client_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "if there is no pending transaction",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1w/exportCoinDistributions": {
+            "get": {
+                "description": "Streams the selected coin distributions, either pending review, reviewed or pending (with their ` + "`" + `eth_tx` + "`" + `), as CSV or NDJSON.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "CoinDistribution"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the type of the client calling this API. I.E. ` + "`" + `web` + "`" + `",
+                        "name": "x_client_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending-review",
+                            "reviewed",
+                            "pending"
+                        ],
+                        "type": "string",
+                        "description": "which coin distributions to export",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "the format of the export, ` + "`" + `csv` + "`" + ` by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to export the ones with usernames starting with keyword. Not supported for ` + "`" + `pending` + "`" + `.",
+                        "name": "usernameKeyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to export the ones with referredByUsernames starting with keyword. Not supported for ` + "`" + `pending` + "`" + `.",
+                        "name": "referredByUsernameKeyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "to export the ones with at least this ice amount",
+                        "name": "minIce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "to export the ones with at most this ice amount",
+                        "name": "maxIce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to export the ones from this day (inclusive), i.e. ` + "`" + `2024-01-01` + "`" + `. It's the review day for ` + "`" + `reviewed` + "`" + `.",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to export the ones up to this day (inclusive), i.e. ` + "`" + `2024-01-31` + "`" + `. It's the review day for ` + "`" + `reviewed` + "`" + `.",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the exported coin distributions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/v1w/getCoinDistributionsForReview": {
             "post": {
                 "description": "Fetches data of pending coin distributions for review.",
@@ -813,6 +1017,16 @@ const docTemplate = `{
                         "name": "referredByUsernameOrderBy",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "if u want to order by the number of anomaly flags",
+                        "name": "flagsOrderBy",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "if u want to find usernames starting with keyword",
@@ -824,6 +1038,35 @@ const docTemplate = `{
                         "description": "if u want to find referredByUsernames starting with keyword",
                         "name": "referredByUsernameKeyword",
                         "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "if u want to find the ones with at least this ice amount",
+                        "name": "minIce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "if u want to find the ones with at most this ice amount",
+                        "name": "maxIce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "amount-above-average",
+                                "shared-eth-address",
+                                "eth-address-changed",
+                                "referral-concentration",
+                                "newly-verified"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "if u want to find the ones with all of these anomaly flags",
+                        "name": "flags",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -866,9 +1109,182 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1w/getHeldCoinDistributions": {
+            "post": {
+                "description": "Fetches the coin distributions that breached one of the caps (total ICE per cycle, ICE per user per cycle or deviation from the previous cycle) and are held, out of the review, until they are released.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CoinDistribution"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the type of the client calling this API. I.E. ` + "`" + `web` + "`" + `",
+                        "name": "x_client_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "current cursor to fetch data from",
+                        "name": "cursor",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count of records in response, 5000 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/coindistribution.HeldCoinDistributions"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1w/releaseHeldCoinDistributions": {
+            "post": {
+                "description": "Releases the held coin distributions of the provided users, or all of them, back to review. They are not held again. The ones with a coin distribution pending review for the same day and user are kept held and counted as ` + "`" + `conflicts` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CoinDistribution"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the type of the client calling this API. I.E. ` + "`" + `web` + "`" + `",
+                        "name": "x_client_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "why the held coin distributions are released",
+                        "name": "reason",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "the user IDs to release, required unless ` + "`" + `all` + "`" + ` is set",
+                        "name": "userIds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "releases all of them, required unless ` + "`" + `userIds` + "`" + ` are provided",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/coindistribution.ReleasedCoinDistributions"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/v1w/reviewDistributions": {
             "post": {
-                "description": "Reviews Coin Distributions.",
+                "description": "Reviews Coin Distributions. If any of ` + "`" + `userIds` + "`" + `, ` + "`" + `usernameKeyword` + "`" + `, ` + "`" + `referredByUsernameKeyword` + "`" + `, ` + "`" + `minIce` + "`" + `, ` + "`" + `maxIce` + "`" + ` or ` + "`" + `flags` + "`" + ` is provided, the decision is applied only to the selected ones, the rest stay pending. Otherwise it's applied to all of them. Approvals are executed only once the configured quorum of admins approved the same snapshot of the selected coin distributions; denials are executed immediately.",
                 "consumes": [
                     "application/json"
                 ],
@@ -904,11 +1320,87 @@ const docTemplate = `{
                         "name": "decision",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "push",
+                            "merkle-claim"
+                        ],
+                        "type": "string",
+                        "description": "how the approved coin distributions are distributed, ` + "`" + `push` + "`" + ` by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "why the decision was made, required if only a selection is reviewed",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "the user IDs to review",
+                        "name": "userIds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to review the ones with usernames starting with keyword",
+                        "name": "usernameKeyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to review the ones with referredByUsernames starting with keyword",
+                        "name": "referredByUsernameKeyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "to review the ones with at least this ice amount",
+                        "name": "minIce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "to review the ones with at most this ice amount",
+                        "name": "maxIce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "amount-above-average",
+                                "shared-eth-address",
+                                "eth-address-changed",
+                                "referral-concentration",
+                                "newly-verified"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "to review the ones with all of these anomaly flags",
+                        "name": "flags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the snapshot of the reviewed coin distributions, as returned by ` + "`" + `getCoinDistributionsForReview` + "`" + ` with the same filters",
+                        "name": "snapshot",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/coindistribution.ReviewResult"
+                        }
                     },
                     "401": {
                         "description": "if not authorized",
@@ -922,6 +1414,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "if the coin distributions changed since the snapshot was taken",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
                     "422": {
                         "description": "if syntax fails",
                         "schema": {
@@ -1427,6 +1925,94 @@ const docTemplate = `{
                         "$ref": "#/definitions/coindistribution.PendingReview"
                     }
                 },
+                "flagCounts": {
+                    "description": "The number of the selected coin distributions that have each flag.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "snapshot": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "totalIce": {
+                    "type": "number",
+                    "example": 5065.3
+                },
+                "totalRows": {
+                    "type": "integer",
+                    "example": 5065
+                }
+            }
+        },
+        "coindistribution.HeldCoinDistribution": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string",
+                    "example": "2022-01-03"
+                },
+                "ethAddress": {
+                    "type": "string",
+                    "example": "0x43...."
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shared-eth-address"
+                    ]
+                },
+                "heldAt": {
+                    "type": "string",
+                    "example": "2022-01-03T16:20:52.156534Z"
+                },
+                "ice": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "iceflakes": {
+                    "type": "string",
+                    "example": "100000000000000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "user total exceeds the per-user cap of 100000 ICE"
+                },
+                "referredByUsername": {
+                    "type": "string",
+                    "example": "myrefusername"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "2022-01-03T16:20:52.156534Z"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "12746386-03de-44d7-91c7-856fa66b6ed6"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "myusername"
+                }
+            }
+        },
+        "coindistribution.HeldCoinDistributions": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "integer",
+                    "example": 5065
+                },
+                "distributions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/coindistribution.HeldCoinDistribution"
+                    }
+                },
                 "totalIce": {
                     "type": "number",
                     "example": 5065.3
@@ -1444,6 +2030,15 @@ const docTemplate = `{
                     "type": "string",
                     "example": "0x43...."
                 },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shared-eth-address"
+                    ]
+                },
                 "ice": {
                     "type": "number",
                     "example": 1000
@@ -1470,6 +2065,44 @@ const docTemplate = `{
                 }
             }
         },
+        "coindistribution.ReleasedCoinDistributions": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "ice": {
+                    "type": "number",
+                    "example": 5065.3
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 5065
+                }
+            }
+        },
+        "coindistribution.ReviewResult": {
+            "type": "object",
+            "properties": {
+                "approvals": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "executed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "quorum": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "snapshot": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                }
+            }
+        },
         "main.FinalizeMiningBoostUpgradeRequestBody": {
             "type": "object",
             "properties": {
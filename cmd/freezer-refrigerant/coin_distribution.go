@@ -4,19 +4,30 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"strings"
+	stdlibtime "time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 
 	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
+	"github.com/ice-blockchain/wintr/auth"
+	"github.com/ice-blockchain/wintr/log"
 	"github.com/ice-blockchain/wintr/server"
+	"github.com/ice-blockchain/wintr/time"
 )
 
 func (s *service) setupCoinDistributionRoutes(router *server.Router) {
 	router.
 		Group("/v1w").
 		POST("/getCoinDistributionsForReview", server.RootHandler(s.GetCoinDistributionsForReview)).
-		POST("/reviewDistributions", server.RootHandler(s.ReviewCoinDistributions))
+		POST("/reviewDistributions", server.RootHandler(s.ReviewCoinDistributions)).
+		POST("/cancelPendingCoinDistributionTransaction", server.RootHandler(s.CancelPendingCoinDistributionTransaction)).
+		POST("/getHeldCoinDistributions", server.RootHandler(s.GetHeldCoinDistributions)).
+		POST("/releaseHeldCoinDistributions", server.RootHandler(s.ReleaseHeldCoinDistributions)).
+		GET("/exportCoinDistributions", s.ExportCoinDistributions)
 }
 
 // GetCoinDistributionsForReview godoc
@@ -34,8 +45,12 @@ func (s *service) setupCoinDistributionRoutes(router *server.Router) {
 //	@Param			iceOrderBy					query		string	false	"if u want to order by ice amount"								Enums(asc,desc)
 //	@Param			usernameOrderBy				query		string	false	"if u want to order by username lexicographically"				Enums(asc,desc)
 //	@Param			referredByUsernameOrderBy	query		string	false	"if u want to order by referredByUsername lexicographically"	Enums(asc,desc)
+//	@Param			flagsOrderBy				query		string	false	"if u want to order by the number of anomaly flags"				Enums(asc,desc)
 //	@Param			usernameKeyword				query		string	false	"if u want to find usernames starting with keyword"
 //	@Param			referredByUsernameKeyword	query		string	false	"if u want to find referredByUsernames starting with keyword"
+//	@Param			minIce						query		number	false	"if u want to find the ones with at least this ice amount"
+//	@Param			maxIce						query		number	false	"if u want to find the ones with at most this ice amount"
+//	@Param			flags						query		[]string	false	"if u want to find the ones with all of these anomaly flags"	collectionFormat(multi)	Enums(amount-above-average,shared-eth-address,eth-address-changed,referral-concentration,newly-verified)
 //	@Success		200							{object}	coindistribution.CoinDistributionsForReview
 //	@Failure		401							{object}	server.ErrorResponse	"if not authorized"
 //	@Failure		403							{object}	server.ErrorResponse	"if not allowed"
@@ -65,6 +80,15 @@ func (s *service) GetCoinDistributionsForReview( //nolint:gocritic // .
 	if req.Data.ReferredByUsernameOrderBy != "" && !strings.EqualFold(req.Data.ReferredByUsernameOrderBy, "desc") && !strings.EqualFold(req.Data.ReferredByUsernameOrderBy, "asc") { //nolint:lll // .
 		return nil, server.UnprocessableEntity(errors.Errorf("`referredByUsernameOrderBy` has to be `asc` or `desc`"), "invalid params")
 	}
+	if req.Data.FlagsOrderBy != "" && !strings.EqualFold(req.Data.FlagsOrderBy, "desc") && !strings.EqualFold(req.Data.FlagsOrderBy, "asc") {
+		return nil, server.UnprocessableEntity(errors.Errorf("`flagsOrderBy` has to be `asc` or `desc`"), "invalid params")
+	}
+	if err := validateAnomalyFlags(req.Data.Flags); err != nil {
+		return nil, server.UnprocessableEntity(err, "invalid params")
+	}
+	if req.Data.MinIce < 0 || req.Data.MaxIce < 0 || (req.Data.MaxIce != 0 && req.Data.MinIce > req.Data.MaxIce) {
+		return nil, server.UnprocessableEntity(errors.Errorf("`minIce` and `maxIce` have to be a valid range"), "invalid params")
+	}
 	resp, err := s.coinDistributionRepository.GetCoinDistributionsForReview(ctx, req.Data)
 	if err != nil {
 		return nil, server.Unexpected(errors.Wrapf(err, "failed to GetCoinDistributionsForReview for %#v", req.Data))
@@ -76,26 +100,34 @@ func (s *service) GetCoinDistributionsForReview( //nolint:gocritic // .
 // ReviewCoinDistributions godoc
 //
 //	@Schemes
-//	@Description	Reviews Coin Distributions.
+//	@Description	Reviews Coin Distributions. If any of `userIds`, `usernameKeyword`, `referredByUsernameKeyword`, `minIce`, `maxIce` or `flags` is provided, the decision is applied only to the selected ones, the rest stay pending. Otherwise it's applied to all of them. Approvals are executed only once the configured quorum of admins approved the same snapshot of the selected coin distributions; denials are executed immediately.
 //	@Tags			CoinDistribution
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@Param			x_client_type	query	string	false	"the type of the client calling this API. I.E. `web`"
 //	@Param			decision		query	string	true	"the decision for the current coin distributions"	Enums(approve,approve-and-process-immediately,deny)
-//	@Success		200				"OK"
+//	@Param			mode			query	string	false	"how the approved coin distributions are distributed, `push` by default"	Enums(push,merkle-claim)
+//	@Param			reason			query	string	false	"why the decision was made, required if only a selection is reviewed"
+//	@Param			userIds			query	[]string	false	"the user IDs to review"	collectionFormat(multi)
+//	@Param			usernameKeyword	query	string	false	"to review the ones with usernames starting with keyword"
+//	@Param			referredByUsernameKeyword	query	string	false	"to review the ones with referredByUsernames starting with keyword"
+//	@Param			minIce			query	number	false	"to review the ones with at least this ice amount"
+//	@Param			maxIce			query	number	false	"to review the ones with at most this ice amount"
+//	@Param			flags			query	[]string	false	"to review the ones with all of these anomaly flags"	collectionFormat(multi)	Enums(amount-above-average,shared-eth-address,eth-address-changed,referral-concentration,newly-verified)
+//	@Param			snapshot		query	string	false	"the snapshot of the reviewed coin distributions, as returned by `getCoinDistributionsForReview` with the same filters"
+//	@Success		200				{object}	coindistribution.ReviewResult
 //	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
 //	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		409				{object}	server.ErrorResponse	"if the coin distributions changed since the snapshot was taken"
 //	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
 //	@Failure		500				{object}	server.ErrorResponse
 //	@Failure		504				{object}	server.ErrorResponse	"if request times out"
 //	@Router			/v1w/reviewDistributions [POST].
 func (s *service) ReviewCoinDistributions( //nolint:gocritic // .
 	ctx context.Context,
-	req *server.Request[struct {
-		Decision string `form:"decision" required:"true" swaggerignore:"true" enums:"approve,approve-and-process-immediately,deny"`
-	}, any],
-) (*server.Response[any], *server.Response[server.ErrorResponse]) {
+	req *server.Request[coindistribution.ReviewCoinDistributionsArg, coindistribution.ReviewResult],
+) (*server.Response[coindistribution.ReviewResult], *server.Response[server.ErrorResponse]) {
 	if req.AuthenticatedUser.Role != adminRole {
 		return nil, server.Forbidden(errors.Errorf("insufficient role: %v, admin role required", req.AuthenticatedUser.Role))
 	}
@@ -104,9 +136,256 @@ func (s *service) ReviewCoinDistributions( //nolint:gocritic // .
 		!strings.EqualFold(req.Data.Decision, "deny") {
 		return nil, server.UnprocessableEntity(errors.Errorf("`decision` has to be `approve`, `approve-and-process-immediately` or `deny`"), "invalid params")
 	}
-	if err := s.coinDistributionRepository.ReviewCoinDistributions(ctx, req.AuthenticatedUser.UserID, req.Data.Decision); err != nil {
-		return nil, server.Unexpected(errors.Wrapf(err, "failed to ReviewCoinDistributions for adminUserID:%v,decision:%v", req.AuthenticatedUser.UserID, req.Data.Decision)) //nolint:lll // .
+	if req.Data.Mode != "" &&
+		!strings.EqualFold(req.Data.Mode, coindistribution.PushDistributionMode) &&
+		!strings.EqualFold(req.Data.Mode, coindistribution.MerkleClaimDistributionMode) {
+		return nil, server.UnprocessableEntity(errors.Errorf("`mode` has to be `push` or `merkle-claim`"), "invalid params")
+	}
+	if req.Data.Partial() && strings.TrimSpace(req.Data.Reason) == "" {
+		return nil, server.UnprocessableEntity(errors.Errorf("`reason` is required when reviewing a selection"), "invalid params")
+	}
+	if req.Data.MinIce < 0 || req.Data.MaxIce < 0 || (req.Data.MaxIce != 0 && req.Data.MinIce > req.Data.MaxIce) {
+		return nil, server.UnprocessableEntity(errors.Errorf("`minIce` and `maxIce` have to be a valid range"), "invalid params")
+	}
+	if err := validateAnomalyFlags(req.Data.Flags); err != nil {
+		return nil, server.UnprocessableEntity(err, "invalid params")
+	}
+	result, err := s.coinDistributionRepository.ReviewCoinDistributions(ctx, req.AuthenticatedUser.UserID, req.Data)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to ReviewCoinDistributions for adminUserID:%v,arg:%#v", req.AuthenticatedUser.UserID, req.Data)
+		if errors.Is(err, coindistribution.ErrReviewSnapshotMismatch) {
+			return nil, server.Conflict(err, reviewSnapshotMismatchErrorCode)
+		}
+
+		return nil, server.Unexpected(err)
+	}
+
+	return server.OK(result), nil
+}
+
+func validateAnomalyFlags(flags []string) error {
+	for _, flag := range flags {
+		if !coindistribution.IsAnomalyFlag(flag) {
+			return errors.Errorf("unknown flag:`%v`", flag)
+		}
+	}
+
+	return nil
+}
+
+// CancelPendingCoinDistributionTransaction godoc
+//
+//	@Schemes
+//	@Description	Asks the coin distributer to cancel the transactions it is waiting for. The coin distributions of the cancelled transactions are rejected.
+//	@Tags			CoinDistribution
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			x_client_type	query	string	false	"the type of the client calling this API. I.E. `web`"
+//	@Success		200				"OK"
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		404				{object}	server.ErrorResponse	"if there is no pending transaction"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/v1w/cancelPendingCoinDistributionTransaction [POST].
+func (s *service) CancelPendingCoinDistributionTransaction( //nolint:gocritic // .
+	ctx context.Context,
+	req *server.Request[struct{}, any],
+) (*server.Response[any], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("insufficient role: %v, admin role required", req.AuthenticatedUser.Role))
+	}
+	if err := s.coinDistributionRepository.CancelPendingCoinDistributionTransaction(ctx, req.AuthenticatedUser.UserID); err != nil {
+		if errors.Is(err, coindistribution.ErrNoPendingTransaction) {
+			return nil, server.NotFound(err, noPendingCoinDistributionTransactionErrorCode)
+		}
+
+		return nil, server.Unexpected(errors.Wrapf(err, "failed to CancelPendingCoinDistributionTransaction for adminUserID:%v", req.AuthenticatedUser.UserID))
 	}
 
 	return server.OK[any](), nil
 }
+
+// GetHeldCoinDistributions godoc
+//
+//	@Schemes
+//	@Description	Fetches the coin distributions that breached one of the caps (total ICE per cycle, ICE per user per cycle or deviation from the previous cycle) and are held, out of the review, until they are released.
+//	@Tags			CoinDistribution
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			x_client_type	query		string	false	"the type of the client calling this API. I.E. `web`"
+//	@Param			cursor			query		uint64	true	"current cursor to fetch data from"	default(0)
+//	@Param			limit			query		uint64	false	"count of records in response, 5000 by default"
+//	@Success		200				{object}	coindistribution.HeldCoinDistributions
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/v1w/getHeldCoinDistributions [POST].
+func (s *service) GetHeldCoinDistributions( //nolint:gocritic // .
+	ctx context.Context,
+	req *server.Request[coindistribution.GetHeldCoinDistributionsArg, coindistribution.HeldCoinDistributions],
+) (*server.Response[coindistribution.HeldCoinDistributions], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("insufficient role: %v, admin role required", req.AuthenticatedUser.Role))
+	}
+	if req.Data.Limit == 0 {
+		req.Data.Limit = defaultDistributionLimit
+	}
+	resp, err := s.coinDistributionRepository.GetHeldCoinDistributions(ctx, req.Data)
+	if err != nil {
+		return nil, server.Unexpected(errors.Wrapf(err, "failed to GetHeldCoinDistributions for %#v", req.Data))
+	}
+
+	return server.OK(resp), nil
+}
+
+// ReleaseHeldCoinDistributions godoc
+//
+//	@Schemes
+//	@Description	Releases the held coin distributions of the provided users, or all of them, back to review. They are not held again. The ones with a coin distribution pending review for the same day and user are kept held and counted as `conflicts`.
+//	@Tags			CoinDistribution
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string		true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			x_client_type	query		string		false	"the type of the client calling this API. I.E. `web`"
+//	@Param			reason			query		string		true	"why the held coin distributions are released"
+//	@Param			userIds			query		[]string	false	"the user IDs to release, required unless `all` is set"	collectionFormat(multi)
+//	@Param			all				query		bool		false	"releases all of them, required unless `userIds` are provided"
+//	@Success		200				{object}	coindistribution.ReleasedCoinDistributions
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/v1w/releaseHeldCoinDistributions [POST].
+func (s *service) ReleaseHeldCoinDistributions( //nolint:gocritic // .
+	ctx context.Context,
+	req *server.Request[coindistribution.ReleaseHeldCoinDistributionsArg, coindistribution.ReleasedCoinDistributions],
+) (*server.Response[coindistribution.ReleasedCoinDistributions], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("insufficient role: %v, admin role required", req.AuthenticatedUser.Role))
+	}
+	if strings.TrimSpace(req.Data.Reason) == "" {
+		return nil, server.UnprocessableEntity(errors.Errorf("`reason` is required"), "invalid params")
+	}
+	if req.Data.All == (len(req.Data.UserIDs) != 0) {
+		return nil, server.UnprocessableEntity(errors.Errorf("either `userIds` or `all` is required"), "invalid params")
+	}
+	resp, err := s.coinDistributionRepository.ReleaseHeldCoinDistributions(ctx, req.AuthenticatedUser.UserID, req.Data)
+	if err != nil {
+		return nil, server.Unexpected(errors.Wrapf(err, "failed to ReleaseHeldCoinDistributions for adminUserID:%v,arg:%#v", req.AuthenticatedUser.UserID, req.Data))
+	}
+
+	return server.OK(resp), nil
+}
+
+// ExportCoinDistributions godoc
+//
+//	@Schemes
+//	@Description	Streams the selected coin distributions, either pending review, reviewed or pending (with their `eth_tx`), as CSV or NDJSON.
+//	@Tags			CoinDistribution
+//	@Accept			json
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Param			Authorization				header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			x_client_type				query	string	false	"the type of the client calling this API. I.E. `web`"
+//	@Param			source						query	string	true	"which coin distributions to export"	Enums(pending-review,reviewed,pending)
+//	@Param			format						query	string	false	"the format of the export, `csv` by default"	Enums(csv,ndjson)
+//	@Param			usernameKeyword				query	string	false	"to export the ones with usernames starting with keyword. Not supported for `pending`."
+//	@Param			referredByUsernameKeyword	query	string	false	"to export the ones with referredByUsernames starting with keyword. Not supported for `pending`."
+//	@Param			minIce						query	number	false	"to export the ones with at least this ice amount"
+//	@Param			maxIce						query	number	false	"to export the ones with at most this ice amount"
+//	@Param			from						query	string	false	"to export the ones from this day (inclusive), i.e. `2024-01-01`. It's the review day for `reviewed`."
+//	@Param			to							query	string	false	"to export the ones up to this day (inclusive), i.e. `2024-01-31`. It's the review day for `reviewed`."
+//	@Success		200							{string}	string	"the exported coin distributions"
+//	@Failure		401							{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403							{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		422							{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500							{object}	server.ErrorResponse
+//	@Router			/v1w/exportCoinDistributions [GET].
+func (s *service) ExportCoinDistributions(ginCtx *gin.Context) {
+	ctx := ginCtx.Request.Context()
+	arg := new(coindistribution.ExportCoinDistributionsArg)
+	if failure := authorizeAdmin(ctx, ginCtx); failure != nil {
+		log.Error(errors.Wrap(failure.Data.InternalErr(), "endpoint authentication failed"), fmt.Sprintf("%[1]T", arg), "Response", failure)
+		ginCtx.JSON(failure.Code, failure.Data)
+
+		return
+	}
+	if err := ginCtx.ShouldBindQuery(arg); err != nil {
+		ginCtx.JSON(http.StatusUnprocessableEntity, server.UnprocessableEntity(errors.Wrap(err, "failed to bind query"), "invalid params").Data)
+
+		return
+	}
+	if failure := validateExportCoinDistributionsArg(arg); failure != nil {
+		ginCtx.JSON(failure.Code, failure.Data)
+
+		return
+	}
+	contentType, extension := "text/csv", coindistribution.CSVExportFormat
+	if strings.EqualFold(arg.Format, coindistribution.NDJSONExportFormat) {
+		contentType, extension = "application/x-ndjson", coindistribution.NDJSONExportFormat
+	}
+	ginCtx.Header("Content-Type", contentType)
+	ginCtx.Header("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="coin-distributions-%v-%v.%v"`, strings.ToLower(arg.Source), time.Now().Format(stdlibtime.DateOnly), extension))
+	ginCtx.Status(http.StatusOK)
+	if err := s.coinDistributionRepository.ExportCoinDistributions(ctx, arg, ginCtx.Writer); err != nil {
+		err = errors.Wrapf(err, "failed to ExportCoinDistributions for %#v", arg)
+		log.Error(err)
+		// Once the first rows are sent, the status can't be changed anymore, so the client sees a truncated export.
+		if !ginCtx.Writer.Written() {
+			ginCtx.JSON(http.StatusInternalServerError, server.Unexpected(err).Data)
+		}
+		ginCtx.Abort()
+	}
+}
+
+// The export is streamed directly to the response, so it can't use server.RootHandler; it authenticates the same way.
+func authorizeAdmin(ctx context.Context, ginCtx *gin.Context) *server.Response[server.ErrorResponse] {
+	token, err := server.Auth(ctx).VerifyToken(ctx, strings.TrimPrefix(ginCtx.GetHeader("Authorization"), "Bearer "))
+	if err != nil {
+		if errors.Is(err, auth.ErrForbidden) {
+			return server.Forbidden(err)
+		}
+
+		return server.Unauthorized(err)
+	}
+	if token, err = server.Auth(ctx).ModifyTokenWithMetadata(token, ginCtx.GetHeader("X-Account-Metadata")); err != nil {
+		return server.Unauthorized(err)
+	}
+	if token.Role != adminRole {
+		return server.Forbidden(errors.Errorf("insufficient role: %v, admin role required", token.Role))
+	}
+
+	return nil
+}
+
+func validateExportCoinDistributionsArg(arg *coindistribution.ExportCoinDistributionsArg) *server.Response[server.ErrorResponse] {
+	switch strings.ToLower(arg.Source) {
+	case coindistribution.PendingReviewExportSource, coindistribution.ReviewedExportSource:
+	case coindistribution.PendingExportSource:
+		if arg.UsernameKeyword != "" || arg.ReferredByUsernameKeyword != "" {
+			return server.UnprocessableEntity(errors.Errorf("`usernameKeyword` and `referredByUsernameKeyword` are not supported for `pending`"), "invalid params")
+		}
+	default:
+		return server.UnprocessableEntity(errors.Errorf("`source` has to be `pending-review`, `reviewed` or `pending`"), "invalid params")
+	}
+	if arg.Format != "" && !strings.EqualFold(arg.Format, coindistribution.CSVExportFormat) && !strings.EqualFold(arg.Format, coindistribution.NDJSONExportFormat) {
+		return server.UnprocessableEntity(errors.Errorf("`format` has to be `csv` or `ndjson`"), "invalid params")
+	}
+	if arg.MinIce < 0 || arg.MaxIce < 0 || (arg.MaxIce != 0 && arg.MinIce > arg.MaxIce) {
+		return server.UnprocessableEntity(errors.Errorf("`minIce` and `maxIce` have to be a valid range"), "invalid params")
+	}
+	for _, day := range []string{arg.From, arg.To} {
+		if _, err := stdlibtime.Parse(stdlibtime.DateOnly, day); day != "" && err != nil {
+			return server.UnprocessableEntity(errors.Wrapf(err, "`from` and `to` have to be days, i.e. `2024-01-31`"), "invalid params")
+		}
+	}
+
+	return nil
+}
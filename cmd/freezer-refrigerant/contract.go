@@ -67,10 +67,10 @@ const (
 	noPendingMiningBoostUpgradeFoundErrorCode     = "NO_PENDING_MINING_BOOST_UPGRADE_FOUND"
 	invalidMiningBoostUpgradeTransactionErrorCode = "INVALID_MINING_BOOST_UPGRADE_TRANSACTION"
 	transactionAlreadyUsed                        = "TRANSACTION_ALREADY_USED"
+	noPendingCoinDistributionTransactionErrorCode = "NO_PENDING_COIN_DISTRIBUTION_TRANSACTION"
+	reviewSnapshotMismatchErrorCode               = "REVIEW_SNAPSHOT_MISMATCH"
 
 	defaultDistributionLimit = 5000
-
-	doctorXTenant = "doctorx"
 )
 
 // .
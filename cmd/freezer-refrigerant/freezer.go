@@ -48,6 +48,8 @@ type (
 	}
 	GetTopMinersArg struct {
 		Keyword string `form:"keyword" example:"jdoe"`
+		Period  string `form:"period" example:"weekly" enums:"daily,weekly,monthly,all-time"`
+		Country string `form:"country" example:"US"`
 		// Default is 10.
 		Limit  uint64 `form:"limit" maximum:"1000" example:"10"`
 		Offset uint64 `form:"offset" example:"0"`
@@ -76,20 +78,22 @@ func (s *service) registerReadRoutes(router *server.Router) {
 func (s *service) setupStatisticsRoutes(router *server.Router) {
 	router.
 		Group("/v1r").
-		GET("/tokenomics-statistics/top-miners", server.RootHandler(s.GetTopMiners)).
-		GET("/tokenomics-statistics/adoption", server.RootHandler(s.GetAdoption)).
-		GET("/tokenomics-statistics/total-coins", server.RootHandler(s.GetTotalCoins))
+		GET("/tokenomics-statistics/top-miners", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetTopMiners))).
+		GET("/tokenomics-statistics/adoption", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetAdoption))).
+		GET("/tokenomics-statistics/total-coins", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetTotalCoins)))
 }
 
 // GetTopMiners godoc
 //
 //	@Schemes
-//	@Description	Returns the paginated leaderboard with top miners.
+//	@Description	Returns the paginated leaderboard with top miners, either all-time or for a specific period and/or country.
 //	@Tags			Statistics
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@Param			keyword			query		string	false	"a keyword to look for in the user's username or firstname/lastname"
+//	@Param			period			query		string	false	"the period the leaderboard is for. Default is `all-time`. It can't be combined with `keyword`."	Enums(daily,weekly,monthly,all-time)
+//	@Param			country			query		string	false	"the ISO 3166 country code the leaderboard is for. It can't be combined with `keyword`."
 //	@Param			limit			query		uint64	false	"max number of elements to return. Default is `10`."
 //	@Param			offset			query		uint64	false	"number of elements to skip before starting to fetch data"
 //	@Success		200				{array}		tokenomics.Miner
@@ -111,7 +115,22 @@ func (s *service) GetTopMiners( //nolint:gocritic // False negative.
 	if req.Data.Limit > maxLimit {
 		req.Data.Limit = maxLimit
 	}
-	resp, nextOffset, err := s.tokenomicsProcessor.GetTopMiners(ctx, req.Data.Keyword, req.Data.Limit, req.Data.Offset)
+	var (
+		resp       []*tokenomics.Miner
+		nextOffset uint64
+		err        error
+	)
+	if req.Data.Country, err = tokenomics.ValidateTopMinersFilters(req.Data.Keyword, tokenomics.TopMinersPeriod(req.Data.Period), req.Data.Country); err != nil {
+		return nil, server.UnprocessableEntity(errors.Wrapf(err, "validations failed for %#v", req.Data), invalidPropertiesErrorCode)
+	}
+	if period := tokenomics.TopMinersPeriod(req.Data.Period); (period != "" && period != tokenomics.AllTimeTopMinersPeriod) || req.Data.Country != "" {
+		if period == "" {
+			period = tokenomics.AllTimeTopMinersPeriod
+		}
+		resp, nextOffset, err = s.tokenomicsProcessor.GetHistoricalTopMiners(ctx, period, req.Data.Country, req.Data.Limit, req.Data.Offset)
+	} else {
+		resp, nextOffset, err = s.tokenomicsProcessor.GetTopMiners(ctx, req.Data.Keyword, req.Data.Limit, req.Data.Offset)
+	}
 	if err != nil {
 		return nil, server.Unexpected(errors.Wrapf(err, "failed to get top miners for userID:%v & req:%#v", req.AuthenticatedUser.UserID, req.Data))
 	}
@@ -194,12 +213,12 @@ func (s *service) GetTotalCoins( //nolint:gocritic // False negative.
 func (s *service) setupTokenomicsReadRoutes(router *server.Router) {
 	router.
 		Group("/v1r").
-		GET("/tokenomics/:userId/mining-boost-summary", server.RootHandler(s.GetMiningBoostSummary)).
-		GET("/tokenomics/:userId/mining-summary", server.RootHandler(s.GetMiningSummary)).
-		GET("/tokenomics/:userId/pre-staking-summary", server.RootHandler(s.GetPreStakingSummary)).
-		GET("/tokenomics/:userId/balance-summary", server.RootHandler(s.GetBalanceSummary)).
-		GET("/tokenomics/:userId/balance-history", server.RootHandler(s.GetBalanceHistory)).
-		GET("/tokenomics/:userId/ranking-summary", server.RootHandler(s.GetRankingSummary))
+		GET("/tokenomics/:userId/mining-boost-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetMiningBoostSummary))).
+		GET("/tokenomics/:userId/mining-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetMiningSummary))).
+		GET("/tokenomics/:userId/pre-staking-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetPreStakingSummary))).
+		GET("/tokenomics/:userId/balance-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetBalanceSummary))).
+		GET("/tokenomics/:userId/balance-history", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetBalanceHistory))).
+		GET("/tokenomics/:userId/ranking-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.GetRankingSummary)))
 }
 
 // GetMiningBoostSummary godoc
@@ -268,7 +287,7 @@ func (s *service) GetMiningSummary( //nolint:gocritic // False negative.
 
 		return nil, server.Unexpected(err)
 	}
-	if cfg.Tenant == doctorXTenant {
+	if s.tokenomicsProcessor.TenantCapabilities(ctx).MiningRatesHidden {
 		mining.MiningRates.Type = tokenomics.NoneMiningRateType
 		mining.MiningRates.Total.Amount = "0.00"
 	}
@@ -15,11 +15,11 @@ import (
 func (s *service) setupTokenomicsRoutes(router *server.Router) {
 	router.
 		Group("/v1w").
-		PUT("/tokenomics/:userId/mining-boosts", server.RootHandler(s.InitializeMiningBoostUpgrade)).
-		PATCH("/tokenomics/:userId/mining-boosts", server.RootHandler(s.FinalizeMiningBoostUpgrade)).
-		POST("/tokenomics/:userId/mining-sessions", server.RootHandler(s.StartNewMiningSession)).
-		POST("/tokenomics/:userId/extra-bonus-claims", server.RootHandler(s.ClaimExtraBonus)).
-		PUT("/tokenomics/:userId/pre-staking", server.RootHandler(s.StartOrUpdatePreStaking))
+		PUT("/tokenomics/:userId/mining-boosts", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.InitializeMiningBoostUpgrade))).
+		PATCH("/tokenomics/:userId/mining-boosts", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.FinalizeMiningBoostUpgrade))).
+		POST("/tokenomics/:userId/mining-sessions", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.StartNewMiningSession))).
+		POST("/tokenomics/:userId/extra-bonus-claims", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.ClaimExtraBonus))).
+		PUT("/tokenomics/:userId/pre-staking", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsProcessor, s.StartOrUpdatePreStaking)))
 }
 
 // InitializeMiningBoostUpgrade godoc
@@ -46,7 +46,7 @@ func (s *service) InitializeMiningBoostUpgrade( //nolint:gocritic // False negat
 	ctx context.Context,
 	req *server.Request[InitializeMiningBoostUpgradeRequestBody, tokenomics.PendingMiningBoostUpgrade],
 ) (*server.Response[tokenomics.PendingMiningBoostUpgrade], *server.Response[server.ErrorResponse]) {
-	if cfg.Tenant == doctorXTenant {
+	if s.tokenomicsProcessor.TenantCapabilities(ctx).MiningBoostDisabled {
 		return nil, server.Forbidden(errMiningBoostDisabled)
 	}
 	resp, err := s.tokenomicsProcessor.InitializeMiningBoostUpgrade(ctx, *req.Data.MiningBoostLevelIndex, req.Data.UserID)
@@ -86,7 +86,7 @@ func (s *service) FinalizeMiningBoostUpgrade( //nolint:gocritic // False negativ
 	ctx context.Context,
 	req *server.Request[FinalizeMiningBoostUpgradeRequestBody, tokenomics.PendingMiningBoostUpgrade],
 ) (*server.Response[tokenomics.PendingMiningBoostUpgrade], *server.Response[server.ErrorResponse]) {
-	if cfg.Tenant == doctorXTenant {
+	if s.tokenomicsProcessor.TenantCapabilities(ctx).MiningBoostDisabled {
 		return nil, server.Forbidden(errMiningBoostDisabled)
 	}
 	resp, err := s.tokenomicsProcessor.FinalizeMiningBoostUpgrade(ctx, req.Data.Network, req.Data.TXHash, req.Data.UserID)
@@ -133,7 +133,7 @@ func (s *service) StartNewMiningSession( //nolint:gocritic // False negative.
 	ctx context.Context,
 	req *server.Request[StartNewMiningSessionRequestBody, tokenomics.MiningSummary],
 ) (*server.Response[tokenomics.MiningSummary], *server.Response[server.ErrorResponse]) {
-	if cfg.Tenant == doctorXTenant {
+	if s.tokenomicsProcessor.TenantCapabilities(ctx).MiningDisabled {
 		return nil, server.Forbidden(errMiningDisabled)
 	}
 	ms := &tokenomics.MiningSummary{MiningSession: &tokenomics.MiningSession{UserID: &req.Data.UserID}}
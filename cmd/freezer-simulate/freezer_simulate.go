@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"strconv"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ice-blockchain/freezer/miner"
+	"github.com/ice-blockchain/wintr/log"
+)
+
+func main() {
+	scenarioPath := flag.String("scenario", "", "path to the yaml scenario to simulate")
+	format := flag.String("format", "csv", "output format: csv|json")
+	outputPath := flag.String("output", "", "path to the file to write the result to (defaults to stdout)")
+	flag.Parse()
+
+	scenario, err := loadScenario(*scenarioPath)
+	log.Panic(errors.Wrapf(err, "failed to load scenario `%v`", *scenarioPath)) //nolint:revive // That's intended.
+	ticks, err := miner.Simulate(scenario)
+	log.Panic(errors.Wrapf(err, "failed to simulate scenario `%v`", *scenarioPath))
+
+	output := io.Writer(os.Stdout)
+	if *outputPath != "" {
+		file, fErr := os.Create(*outputPath)
+		log.Panic(errors.Wrapf(fErr, "failed to create output file `%v`", *outputPath))
+		defer func() {
+			log.Panic(errors.Wrapf(file.Close(), "failed to close output file `%v`", *outputPath))
+		}()
+		output = file
+	}
+	switch *format {
+	case "csv":
+		log.Panic(errors.Wrap(writeCSV(output, ticks), "failed to write csv"))
+	case "json":
+		log.Panic(errors.Wrap(writeJSON(output, ticks), "failed to write json"))
+	default:
+		log.Panic(errors.Errorf("unsupported format `%v`", *format))
+	}
+}
+
+func loadScenario(path string) (*miner.SimulationScenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+	scenario := new(miner.SimulationScenario)
+	if err = yaml.Unmarshal(content, scenario); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal yaml")
+	}
+
+	return scenario, nil
+}
+
+func writeJSON(output io.Writer, ticks []*miner.SimulationTick) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	return errors.Wrap(encoder.Encode(ticks), "failed to encode ticks")
+}
+
+func writeCSV(output io.Writer, ticks []*miner.SimulationTick) error {
+	writer := csv.NewWriter(output)
+	if err := writer.Write([]string{
+		"now", "userId", "mining", "baseMiningRate",
+		"balanceTotalStandard", "balanceTotalPreStaking", "balanceTotalMinted", "balanceTotalSlashed",
+		"balanceSolo", "balanceT0", "balanceT1", "balanceT2", "balanceForT0", "balanceForTMinus1",
+		"balanceSoloPending", "balanceT1Pending", "balanceT2Pending",
+		"balanceSoloEthereum", "balanceT0Ethereum", "balanceT1Ethereum", "balanceForT0Ethereum", "ethereumDistributed",
+		"slashingRateSolo", "slashingRateT0", "slashingRateForT0", "slashingRateForTMinus1",
+		"pendingAmountForT0", "pendingAmountForTMinus1",
+		"activeT1Referrals", "activeT2Referrals",
+	}); err != nil {
+		return errors.Wrap(err, "failed to write header")
+	}
+	for _, tick := range ticks {
+		if err := writer.Write([]string{
+			tick.Now.Format(stdlibtime.RFC3339Nano), tick.UserID, strconv.FormatBool(tick.Mining), formatFloat(tick.BaseMiningRate),
+			formatFloat(tick.BalanceTotalStandard), formatFloat(tick.BalanceTotalPreStaking), formatFloat(tick.BalanceTotalMinted), formatFloat(tick.BalanceTotalSlashed),
+			formatFloat(tick.BalanceSolo), formatFloat(tick.BalanceT0), formatFloat(tick.BalanceT1), formatFloat(tick.BalanceT2),
+			formatFloat(tick.BalanceForT0), formatFloat(tick.BalanceForTMinus1),
+			formatFloat(tick.BalanceSoloPending), formatFloat(tick.BalanceT1Pending), formatFloat(tick.BalanceT2Pending),
+			formatFloat(tick.BalanceSoloEthereum), formatFloat(tick.BalanceT0Ethereum), formatFloat(tick.BalanceT1Ethereum),
+			formatFloat(tick.BalanceForT0Ethereum), formatFloat(tick.EthereumDistributed),
+			formatFloat(tick.SlashingRateSolo), formatFloat(tick.SlashingRateT0), formatFloat(tick.SlashingRateForT0), formatFloat(tick.SlashingRateForTMinus1),
+			formatFloat(tick.PendingAmountForT0), formatFloat(tick.PendingAmountForTMinus1),
+			strconv.FormatInt(int64(tick.ActiveT1Referrals), 10), strconv.FormatInt(int64(tick.ActiveT2Referrals), 10),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to write tick %#v", tick)
+		}
+	}
+	writer.Flush()
+
+	return errors.Wrap(writer.Error(), "failed to flush csv")
+}
+
+func formatFloat(val float64) string {
+	return strconv.FormatFloat(val, 'f', -1, 64)
+}
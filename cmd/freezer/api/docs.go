@@ -20,6 +20,174 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/coin-distribution/{userId}/claim-proof": {
+            "get": {
+                "description": "Returns the merkle proofs the user can claim its coins with, for every confirmed merkle-claim distribution cycle, newest first. Available to the user itself and to admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CoinDistribution"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/coindistribution.ClaimProof"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "if validations fail",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/coin-distribution/{userId}/history": {
+            "get": {
+                "description": "Returns the user's coin distribution cycles, newest first: the amount, who contributed it, the review decision, the on-chain status and the transaction that delivered it. Available to the user itself and to admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CoinDistribution"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of elements to return. Default is ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of elements to skip before starting to fetch data",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/coindistribution.CoinDistributionHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "if validations fail",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/tokenomics-statistics/adoption": {
             "get": {
                 "description": "Returns the current adoption information.",
@@ -78,7 +246,7 @@ const docTemplate = `{
         },
         "/tokenomics-statistics/top-miners": {
             "get": {
-                "description": "Returns the paginated leaderboard with top miners.",
+                "description": "Returns the paginated leaderboard with top miners, either all-time or for a specific period and/or country.",
                 "consumes": [
                     "application/json"
                 ],
@@ -103,6 +271,24 @@ const docTemplate = `{
                         "name": "keyword",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly",
+                            "all-time"
+                        ],
+                        "type": "string",
+                        "description": "the period the leaderboard is for. Default is ` + "`" + `all-time` + "`" + `. It can't be combined with ` + "`" + `keyword` + "`" + `.",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the ISO 3166 country code the leaderboard is for. It can't be combined with ` + "`" + `keyword` + "`" + `.",
+                        "name": "country",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "max number of elements to return. Default is ` + "`" + `10` + "`" + `.",
@@ -414,9 +600,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tokenomics/{userId}/mining-boost-summary": {
+        "/tokenomics/{userId}/debug": {
             "get": {
-                "description": "Returns the mining boost related information.",
+                "description": "Returns the raw tokenomics state of the user, alongside the values derived from it. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -441,13 +627,19 @@ const docTemplate = `{
                         "name": "userId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the type of the client calling this API. I.E. ` + "`" + `web` + "`" + `",
+                        "name": "x_client_type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tokenomics.MiningBoostSummary"
+                            "$ref": "#/definitions/tokenomics.UserDebugSummary"
                         }
                     },
                     "400": {
@@ -495,9 +687,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tokenomics/{userId}/mining-summary": {
+        "/tokenomics/{userId}/ledger": {
             "get": {
-                "description": "Returns the mining related information.",
+                "description": "Returns the balance mutations of the user, newest first. Available to the user itself and to admins.",
                 "consumes": [
                     "application/json"
                 ],
@@ -522,13 +714,25 @@ const docTemplate = `{
                         "name": "userId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the cursor returned by the previous page. Default is ` + "`" + `0` + "`" + `, meaning the latest entries.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of elements to return. Default is ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tokenomics.MiningSummary"
+                            "$ref": "#/definitions/tokenomics.Ledger"
                         }
                     },
                     "400": {
@@ -576,9 +780,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tokenomics/{userId}/pre-staking-summary": {
+        "/tokenomics/{userId}/mining-boost-summary": {
             "get": {
-                "description": "Returns the pre-staking related information.",
+                "description": "Returns the mining boost related information.",
                 "consumes": [
                     "application/json"
                 ],
@@ -609,7 +813,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tokenomics.PreStakingSummary"
+                            "$ref": "#/definitions/tokenomics.MiningBoostSummary"
                         }
                     },
                     "400": {
@@ -657,9 +861,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tokenomics/{userId}/ranking-summary": {
+        "/tokenomics/{userId}/mining-summary": {
             "get": {
-                "description": "Returns the ranking related information.",
+                "description": "Returns the mining related information.",
                 "consumes": [
                     "application/json"
                 ],
@@ -690,7 +894,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tokenomics.RankingSummary"
+                            "$ref": "#/definitions/tokenomics.MiningSummary"
                         }
                     },
                     "400": {
@@ -706,7 +910,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "if hidden by the user",
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "if not found",
                         "schema": {
                             "$ref": "#/definitions/server.ErrorResponse"
                         }
@@ -731,57 +941,628 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "server.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "SOMETHING_NOT_FOUND"
-                },
-                "data": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
-                "error": {
-                    "type": "string",
-                    "example": "something is missing"
-                }
-            }
         },
-        "tokenomics.AdoptionSummary": {
-            "type": "object",
-            "properties": {
-                "milestones": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "achievedAt": {
-                                "type": "string",
-                                "example": "2022-01-03T16:20:52.156534Z"
-                            },
-                            "baseMiningRate": {
-                                "type": "string",
-                                "example": "1,243.02"
-                            },
-                            "milestone": {
-                                "type": "integer",
-                                "example": 1
-                            },
-                            "totalActiveUsers": {
-                                "type": "integer",
-                                "example": 1
-                            }
-                        }
-                    }
-                },
-                "totalActiveUsers": {
-                    "type": "integer",
-                    "example": 11
-                }
+        "/tokenomics/{userId}/pre-staking-summary": {
+            "get": {
+                "description": "Returns the pre-staking related information.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokenomics"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokenomics.PreStakingSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "if validations fail",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "if not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokenomics/{userId}/ranking-summary": {
+            "get": {
+                "description": "Returns the ranking related information.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokenomics"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokenomics.RankingSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "if validations fail",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if hidden by the user",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokenomics/{userId}/team-summary": {
+            "get": {
+                "description": "Returns what the user's T1/T2 referrals earned it: referral counts, daily earnings, top contributors and the referrals being slashed. Available to the user itself and to admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokenomics"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of days in the past to return the earnings for. Default is ` + "`" + `7` + "`" + `. Max is ` + "`" + `30` + "`" + `.",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokenomics.TeamSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "if validations fail",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "if not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "if not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "if not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "if syntax fails",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "if request times out",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "coindistribution.ClaimProof": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2022-01-03T16:20:52.156534Z"
+                },
+                "ethAddress": {
+                    "type": "string",
+                    "example": "0x43...."
+                },
+                "iceflakes": {
+                    "type": "string",
+                    "example": "100000000000000"
+                },
+                "merkleRoot": {
+                    "type": "string",
+                    "example": "0x3f2b2a1e4bd6e5d0c7a8b7f7b0c3e4a8c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6"
+                },
+                "proof": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0x9e1c4b2d5a6f7e8d9c0b1a2f3e4d5c6b7a8f9e0d1c2b3a4f5e6d7c8b9a0f1e2d"
+                    ]
+                }
+            }
+        },
+        "coindistribution.CoinDistributionContribution": {
+            "type": "object",
+            "properties": {
+                "earnerUserId": {
+                    "type": "string",
+                    "example": "did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"
+                },
+                "ice": {
+                    "type": "number",
+                    "example": 100
+                }
+            }
+        },
+        "coindistribution.CoinDistributionHistory": {
+            "type": "object",
+            "properties": {
+                "contributions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/coindistribution.CoinDistributionContribution"
+                    }
+                },
+                "day": {
+                    "type": "string",
+                    "example": "2022-01-03"
+                },
+                "decision": {
+                    "description": "Only for the reviewed ones.",
+                    "type": "string",
+                    "enum": [
+                        "approve",
+                        "approve-and-process-immediately",
+                        "deny",
+                        "deny due to incomplete data",
+                        "deny due to invalid eth address"
+                    ],
+                    "example": "approve"
+                },
+                "ethAddress": {
+                    "type": "string",
+                    "example": "0x43...."
+                },
+                "ethStatus": {
+                    "description": "Only for the approved ones. Once the transaction is confirmed, it stays ACCEPTED.",
+                    "type": "string",
+                    "enum": [
+                        "NEW",
+                        "PENDING",
+                        "ACCEPTED",
+                        "REJECTED"
+                    ],
+                    "example": "ACCEPTED"
+                },
+                "ethTx": {
+                    "type": "string",
+                    "example": "0x43...."
+                },
+                "ice": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "reviewedAt": {
+                    "type": "string",
+                    "example": "2022-01-03T16:20:52.156534Z"
+                },
+                "stage": {
+                    "type": "string",
+                    "enum": [
+                        "collecting",
+                        "held",
+                        "pending-review",
+                        "reviewed"
+                    ],
+                    "example": "reviewed"
+                }
+            }
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "activeT1Referrals": {
+                    "type": "integer"
+                },
+                "activeT2Referrals": {
+                    "type": "integer"
+                },
+                "balanceForT0": {
+                    "type": "number"
+                },
+                "balanceForT0Ethereum": {
+                    "type": "number"
+                },
+                "balanceForT0EthereumMainnetRewardPoolContribution": {
+                    "type": "number"
+                },
+                "balanceForTMinus1": {
+                    "type": "number"
+                },
+                "balanceForTMinus1Ethereum": {
+                    "type": "number"
+                },
+                "balanceForTMinus1EthereumMainnetRewardPoolContribution": {
+                    "type": "number"
+                },
+                "balanceLastUpdatedAt": {
+                    "type": "string"
+                },
+                "balanceSolo": {
+                    "type": "number"
+                },
+                "balanceSoloEthereum": {
+                    "type": "number"
+                },
+                "balanceSoloEthereumMainnetRewardPoolContribution": {
+                    "type": "number"
+                },
+                "balanceSoloPending": {
+                    "type": "number"
+                },
+                "balanceSoloPendingApplied": {
+                    "type": "number"
+                },
+                "balanceT0": {
+                    "type": "number"
+                },
+                "balanceT0Ethereum": {
+                    "type": "number"
+                },
+                "balanceT0EthereumMainnetRewardPoolContribution": {
+                    "type": "number"
+                },
+                "balanceT1": {
+                    "type": "number"
+                },
+                "balanceT1Ethereum": {
+                    "type": "number"
+                },
+                "balanceT1EthereumMainnetRewardPoolContribution": {
+                    "type": "number"
+                },
+                "balanceT1Pending": {
+                    "type": "number"
+                },
+                "balanceT1PendingApplied": {
+                    "type": "number"
+                },
+                "balanceT2": {
+                    "type": "number"
+                },
+                "balanceT2Ethereum": {
+                    "type": "number"
+                },
+                "balanceT2EthereumMainnetRewardPoolContribution": {
+                    "type": "number"
+                },
+                "balanceT2Pending": {
+                    "type": "number"
+                },
+                "balanceT2PendingApplied": {
+                    "type": "number"
+                },
+                "balanceTotalMinted": {
+                    "type": "number"
+                },
+                "balanceTotalPreStaking": {
+                    "type": "number"
+                },
+                "balanceTotalSlashed": {
+                    "type": "number"
+                },
+                "balanceTotalStandard": {
+                    "type": "number"
+                },
+                "blockchainAccountAddress": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "extraBonus": {
+                    "type": "number"
+                },
+                "extraBonusDaysClaimNotAvailable": {
+                    "type": "integer"
+                },
+                "extraBonusLastClaimAvailableAt": {
+                    "type": "string"
+                },
+                "extraBonusStartedAt": {
+                    "type": "string"
+                },
+                "forT0LastEthereumCoinDistributionProcessedAt": {
+                    "type": "string"
+                },
+                "forTMinus1LastEthereumCoinDistributionProcessedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idt0": {
+                    "type": "integer"
+                },
+                "idtminus1": {
+                    "type": "integer"
+                },
+                "kycQuizCompleted": {
+                    "type": "boolean"
+                },
+                "kycQuizDisabled": {
+                    "type": "boolean"
+                },
+                "kycStepBlocked": {
+                    "$ref": "#/definitions/users.KYCStep"
+                },
+                "kycStepPassed": {
+                    "$ref": "#/definitions/users.KYCStep"
+                },
+                "kycStepsCreatedAt": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kycStepsLastUpdatedAt": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "miningBlockchainAccountAddress": {
+                    "type": "string"
+                },
+                "miningSessionSoloDayOffLastAwardedAt": {
+                    "type": "string"
+                },
+                "miningSessionSoloEndedAt": {
+                    "type": "string"
+                },
+                "miningSessionSoloLastStartedAt": {
+                    "type": "string"
+                },
+                "miningSessionSoloPreviouslyEndedAt": {
+                    "type": "string"
+                },
+                "miningSessionSoloStartedAt": {
+                    "type": "string"
+                },
+                "newsSeen": {
+                    "type": "integer"
+                },
+                "preStakingAllocation": {
+                    "type": "number"
+                },
+                "preStakingBonus": {
+                    "type": "number"
+                },
+                "profilePictureUrl": {
+                    "type": "string"
+                },
+                "resurrectSoloUsedAt": {
+                    "type": "string"
+                },
+                "resurrectT0UsedAt": {
+                    "type": "string"
+                },
+                "resurrectTMinus1UsedAt": {
+                    "type": "string"
+                },
+                "slashingRateForT0": {
+                    "type": "number"
+                },
+                "slashingRateForTMinus1": {
+                    "type": "number"
+                },
+                "slashingRateSolo": {
+                    "type": "number"
+                },
+                "slashingRateT0": {
+                    "type": "number"
+                },
+                "slashingRateT1": {
+                    "type": "number"
+                },
+                "slashingRateT2": {
+                    "type": "number"
+                },
+                "soloLastEthereumCoinDistributionProcessedAt": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "utcoffset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SOMETHING_NOT_FOUND"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "error": {
+                    "type": "string",
+                    "example": "something is missing"
+                }
+            }
+        },
+        "tokenomics.AdoptionSummary": {
+            "type": "object",
+            "properties": {
+                "milestones": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "achievedAt": {
+                                "type": "string",
+                                "example": "2022-01-03T16:20:52.156534Z"
+                            },
+                            "baseMiningRate": {
+                                "type": "string",
+                                "example": "1,243.02"
+                            },
+                            "milestone": {
+                                "type": "integer",
+                                "example": 1
+                            },
+                            "totalActiveUsers": {
+                                "type": "integer",
+                                "example": 1
+                            }
+                        }
+                    }
+                },
+                "totalActiveUsers": {
+                    "type": "integer",
+                    "example": 11
+                }
             }
         },
         "tokenomics.BalanceHistoryBalanceDiff": {
@@ -875,6 +1656,45 @@ const docTemplate = `{
                 }
             }
         },
+        "tokenomics.Ledger": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "Pass it back to get the next (older) page. It's 0 if there's nothing left.",
+                    "type": "integer",
+                    "example": 1641226852156534000
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokenomics.LedgerEntry"
+                    }
+                }
+            }
+        },
+        "tokenomics.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -1.5
+                },
+                "reason": {
+                    "description": "One of ` + "`" + `mining` + "`" + `, ` + "`" + `slashing` + "`" + `, ` + "`" + `welcome_bonus` + "`" + `, ` + "`" + `t1_pending` + "`" + `, ` + "`" + `t2_pending` + "`" + `, ` + "`" + `resurrection` + "`" + `, ` + "`" + `ethereum_distribution` + "`" + `, ` + "`" + `completed_tasks` + "`" + `.",
+                    "type": "string",
+                    "example": "completed_tasks"
+                },
+                "source": {
+                    "description": "The message, user or day that caused the balance mutation.",
+                    "type": "string",
+                    "example": "did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "2022-01-03T16:20:52.156534Z"
+                }
+            }
+        },
         "tokenomics.Miner": {
             "type": "object",
             "properties": {
@@ -1098,6 +1918,116 @@ const docTemplate = `{
                 }
             }
         },
+        "tokenomics.TeamEarningsEntry": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2022-01-03T00:00:00Z"
+                },
+                "t1": {
+                    "type": "string",
+                    "example": "12.34"
+                },
+                "t2": {
+                    "type": "string",
+                    "example": "1.23"
+                }
+            }
+        },
+        "tokenomics.TeamReferral": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "description": "How much the referral earned for the user so far.",
+                    "type": "string",
+                    "example": "1234.12"
+                },
+                "profilePictureUrl": {
+                    "type": "string",
+                    "example": "https://somecdn.com/p1.jpg"
+                },
+                "slashingRate": {
+                    "description": "The rate at which that contribution is currently being slashed. It's 0 if the referral is not slashed.",
+                    "type": "string",
+                    "example": "1.23"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "jdoe"
+                }
+            }
+        },
+        "tokenomics.TeamSummary": {
+            "type": "object",
+            "properties": {
+                "activeT1Referrals": {
+                    "description": "How many T1 referrals actually count for the user's mining rate, capped by its mining boost level.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "activeT2Referrals": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "earnings": {
+                    "description": "What the team earned the user each day, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokenomics.TeamEarningsEntry"
+                    }
+                },
+                "maxT1Referrals": {
+                    "description": "The T1 referrals cap of the user's mining boost level. It's missing if there's no cap.",
+                    "type": "integer",
+                    "example": 20
+                },
+                "slashingT1Referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokenomics.TeamReferral"
+                    }
+                },
+                "slashingT2Referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokenomics.TeamReferral"
+                    }
+                },
+                "t1": {
+                    "type": "string",
+                    "example": "1234.12"
+                },
+                "t2": {
+                    "type": "string",
+                    "example": "123.12"
+                },
+                "topT1Referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokenomics.TeamReferral"
+                    }
+                },
+                "topT2Referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokenomics.TeamReferral"
+                    }
+                },
+                "totalT1Referrals": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "totalT2Referrals": {
+                    "type": "integer",
+                    "example": 22
+                }
+            }
+        },
         "tokenomics.TotalCoinsSummary": {
             "type": "object",
             "properties": {
@@ -1153,6 +2083,114 @@ const docTemplate = `{
                 }
             }
         },
+        "tokenomics.UserDebugSummary": {
+            "type": "object",
+            "properties": {
+                "baseMiningRate": {
+                    "type": "number",
+                    "example": 16
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2022-01-03T16:20:52.156534Z"
+                },
+                "eligibleForEthereumDistribution": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "eligibleForEthereumDistributionNow": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "ethereumDistributionBalance": {
+                    "type": "number",
+                    "example": 100.5
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "latestDevice": {
+                    "type": "string",
+                    "example": "android:1.2.3"
+                },
+                "maxMiningSessionDuration": {
+                    "type": "string",
+                    "example": "24h0m0s"
+                },
+                "mining": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "miningBoostLevelIndex": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "miningStreak": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "negativeMiningRate": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "nextKycStep": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/users.KYCStep"
+                        }
+                    ],
+                    "example": 3
+                },
+                "remainingFreeMiningSessions": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slashing": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "slashingDisabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "t0": {
+                    "$ref": "#/definitions/tokenomics.UserDebugSummaryReferral"
+                },
+                "tMinus1": {
+                    "$ref": "#/definitions/tokenomics.UserDebugSummaryReferral"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                }
+            }
+        },
+        "tokenomics.UserDebugSummaryReferral": {
+            "type": "object",
+            "properties": {
+                "confirmed": {
+                    "description": "Negative IDs are the ones the miner hasn't processed yet.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "mining": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "jdoe"
+                }
+            }
+        },
         "users.KYCStep": {
             "type": "integer",
             "enum": [
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package main
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
+	"github.com/ice-blockchain/wintr/server"
+)
+
+func (s *service) setupCoinDistributionRoutes(router *server.Router) {
+	router.
+		Group("/v1r").
+		GET("/coin-distribution/:userId/claim-proof", server.RootHandler(s.GetClaimProofs)).
+		GET("/coin-distribution/:userId/history", server.RootHandler(s.GetCoinDistributionHistory))
+}
+
+// GetClaimProofs godoc
+//
+//	@Schemes
+//	@Description	Returns the merkle proofs the user can claim its coins with, for every confirmed merkle-claim distribution cycle, newest first. Available to the user itself and to admins.
+//	@Tags			CoinDistribution
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			userId			path		string	true	"ID of the user"
+//	@Success		200				{array}		coindistribution.ClaimProof
+//	@Failure		400				{object}	server.ErrorResponse	"if validations fail"
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/coin-distribution/{userId}/claim-proof [GET].
+func (s *service) GetClaimProofs( //nolint:gocritic // False negative.
+	ctx context.Context,
+	req *server.Request[GetClaimProofsArg, []*coindistribution.ClaimProof],
+) (*server.Response[[]*coindistribution.ClaimProof], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.UserID != req.Data.UserID && req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("not allowed to see the claim proofs of userID:%v", req.Data.UserID))
+	}
+	proofs, err := s.coinDistributionRepository.GetClaimProofs(ctx, req.Data.UserID)
+	if err != nil {
+		return nil, server.Unexpected(errors.Wrapf(err, "failed to get claim proofs for userID:%v", req.Data.UserID))
+	}
+
+	return server.OK(&proofs), nil
+}
+
+// GetCoinDistributionHistory godoc
+//
+//	@Schemes
+//	@Description	Returns the user's coin distribution cycles, newest first: the amount, who contributed it, the review decision, the on-chain status and the transaction that delivered it. Available to the user itself and to admins.
+//	@Tags			CoinDistribution
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			userId			path		string	true	"ID of the user"
+//	@Param			limit			query		uint64	false	"max number of elements to return. Default is `100`."
+//	@Param			offset			query		uint64	false	"number of elements to skip before starting to fetch data"
+//	@Success		200				{array}		coindistribution.CoinDistributionHistory
+//	@Failure		400				{object}	server.ErrorResponse	"if validations fail"
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/coin-distribution/{userId}/history [GET].
+func (s *service) GetCoinDistributionHistory( //nolint:gocritic // False negative.
+	ctx context.Context,
+	req *server.Request[GetCoinDistributionHistoryArg, []*coindistribution.CoinDistributionHistory],
+) (*server.Response[[]*coindistribution.CoinDistributionHistory], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.UserID != req.Data.UserID && req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("not allowed to see the coin distribution history of userID:%v", req.Data.UserID))
+	}
+	const defaultLimit, maxLimit = 100, 1000
+	if req.Data.Limit > maxLimit {
+		req.Data.Limit = maxLimit
+	}
+	if req.Data.Limit == 0 {
+		req.Data.Limit = defaultLimit
+	}
+	history, err := s.coinDistributionRepository.GetCoinDistributionHistory(ctx, req.Data.UserID, req.Data.Limit, req.Data.Offset)
+	if err != nil {
+		return nil, server.Unexpected(errors.Wrapf(err, "failed to get coin distribution history for userID:%v, data:%#v", req.Data.UserID, req.Data))
+	}
+
+	return server.OK(&history), nil
+}
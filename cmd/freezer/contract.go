@@ -5,6 +5,7 @@ package main
 import (
 	stdlibtime "time"
 
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
 	"github.com/ice-blockchain/freezer/tokenomics"
 )
 
@@ -34,11 +35,37 @@ type (
 		Limit  uint64 `form:"limit" maximum:"1000" example:"24"`
 		Offset uint64 `form:"offset" example:"0"`
 	}
+	GetUserDebugSummaryArg struct {
+		UserID      string `uri:"userId" allowForbiddenGet:"true" required:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		XClientType string `form:"x_client_type" swaggerignore:"true" required:"false" example:"web"`
+	}
+	GetLedgerArg struct {
+		UserID string `uri:"userId" allowForbiddenGet:"true" required:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		// Default is 100.
+		Limit  uint64 `form:"limit" maximum:"1000" example:"100"`
+		Cursor uint64 `form:"cursor" example:"1641226852156534000"`
+	}
+	GetTeamSummaryArg struct {
+		UserID string `uri:"userId" allowForbiddenGet:"true" required:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		// Default is 7.
+		Days uint64 `form:"days" maximum:"30" example:"7"`
+	}
+	GetClaimProofsArg struct {
+		UserID string `uri:"userId" allowForbiddenGet:"true" required:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+	}
+	GetCoinDistributionHistoryArg struct {
+		UserID string `uri:"userId" allowForbiddenGet:"true" required:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		// Default is 100.
+		Limit  uint64 `form:"limit" maximum:"1000" example:"100"`
+		Offset uint64 `form:"offset" example:"0"`
+	}
 	GetRankingSummaryArg struct {
 		UserID string `uri:"userId" allowForbiddenGet:"true" required:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
 	}
 	GetTopMinersArg struct {
 		Keyword string `form:"keyword" example:"jdoe"`
+		Period  string `form:"period" example:"weekly" enums:"daily,weekly,monthly,all-time"`
+		Country string `form:"country" example:"US"`
 		// Default is 10.
 		Limit  uint64 `form:"limit" maximum:"1000" example:"10"`
 		Offset uint64 `form:"offset" example:"0"`
@@ -63,12 +90,15 @@ const (
 	userPreStakingNotEnabledErrorCode = "PRE_STAKING_NOT_ENABLED"
 	globalRankHiddenErrorCode         = "GLOBAL_RANK_HIDDEN"
 	invalidPropertiesErrorCode        = "INVALID_PROPERTIES"
+
+	adminRole = "admin"
 )
 
 type (
 	// | service implements server.State and is responsible for managing the state and lifecycle of the package.
 	service struct {
-		tokenomicsRepository tokenomics.Repository
+		tokenomicsRepository       tokenomics.Repository
+		coinDistributionRepository coindistribution.Repository
 	}
 	config struct {
 		Host    string `yaml:"host"`
@@ -6,9 +6,11 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 
 	"github.com/ice-blockchain/freezer/cmd/freezer/api"
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
 	"github.com/ice-blockchain/freezer/tokenomics"
 	appCfg "github.com/ice-blockchain/wintr/config"
 	"github.com/ice-blockchain/wintr/log"
@@ -37,10 +39,12 @@ func main() {
 func (s *service) RegisterRoutes(router *server.Router) {
 	s.setupTokenomicsRoutes(router)
 	s.setupStatisticsRoutes(router)
+	s.setupCoinDistributionRoutes(router)
 }
 
 func (s *service) Init(ctx context.Context, cancel context.CancelFunc) {
 	s.tokenomicsRepository = tokenomics.New(ctx, cancel)
+	s.coinDistributionRepository = coindistribution.NewRepository(ctx, cancel)
 }
 
 func (s *service) Close(ctx context.Context) error {
@@ -48,13 +52,19 @@ func (s *service) Close(ctx context.Context) error {
 		return errors.Wrap(ctx.Err(), "could not close repository because context ended")
 	}
 
-	return errors.Wrap(s.tokenomicsRepository.Close(), "could not close repository")
+	return multierror.Append(
+		errors.Wrap(s.tokenomicsRepository.Close(), "could not close repository"),
+		errors.Wrap(s.coinDistributionRepository.Close(), "could not close coindistribution repository"),
+	).ErrorOrNil() //nolint:wrapcheck // .
 }
 
 func (s *service) CheckHealth(ctx context.Context) error {
 	log.Debug("checking health...", "package", "tokenomics")
 
-	return errors.Wrap(s.tokenomicsRepository.CheckHealth(ctx), "check health failed")
+	return multierror.Append(
+		errors.Wrap(s.tokenomicsRepository.CheckHealth(ctx), "check health failed"),
+		errors.Wrap(s.coinDistributionRepository.CheckHealth(ctx), "failed to check coindistribution repository health"),
+	).ErrorOrNil() //nolint:wrapcheck // .
 }
 
 func contextWithHashCode[REQ, RESP any](ctx context.Context, req *server.Request[REQ, RESP]) context.Context {
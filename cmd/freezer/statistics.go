@@ -18,20 +18,22 @@ import (
 func (s *service) setupStatisticsRoutes(router *server.Router) {
 	router.
 		Group("/v1r").
-		GET("/tokenomics-statistics/top-miners", server.RootHandler(s.GetTopMiners)).
-		GET("/tokenomics-statistics/adoption", server.RootHandler(s.GetAdoption)).
-		GET("/tokenomics-statistics/total-coins", server.RootHandler(s.GetTotalCoins))
+		GET("/tokenomics-statistics/top-miners", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetTopMiners))).
+		GET("/tokenomics-statistics/adoption", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetAdoption))).
+		GET("/tokenomics-statistics/total-coins", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetTotalCoins)))
 }
 
 // GetTopMiners godoc
 //
 //	@Schemes
-//	@Description	Returns the paginated leaderboard with top miners.
+//	@Description	Returns the paginated leaderboard with top miners, either all-time or for a specific period and/or country.
 //	@Tags			Statistics
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@Param			keyword			query		string	false	"a keyword to look for in the user's username or firstname/lastname"
+//	@Param			period			query		string	false	"the period the leaderboard is for. Default is `all-time`. It can't be combined with `keyword`."	Enums(daily,weekly,monthly,all-time)
+//	@Param			country			query		string	false	"the ISO 3166 country code the leaderboard is for. It can't be combined with `keyword`."
 //	@Param			limit			query		uint64	false	"max number of elements to return. Default is `10`."
 //	@Param			offset			query		uint64	false	"number of elements to skip before starting to fetch data"
 //	@Success		200				{array}		tokenomics.Miner
@@ -53,7 +55,22 @@ func (s *service) GetTopMiners( //nolint:gocritic // False negative.
 	if req.Data.Limit > maxLimit {
 		req.Data.Limit = maxLimit
 	}
-	resp, nextOffset, err := s.tokenomicsRepository.GetTopMiners(ctx, req.Data.Keyword, req.Data.Limit, req.Data.Offset)
+	var (
+		resp       []*tokenomics.Miner
+		nextOffset uint64
+		err        error
+	)
+	if req.Data.Country, err = tokenomics.ValidateTopMinersFilters(req.Data.Keyword, tokenomics.TopMinersPeriod(req.Data.Period), req.Data.Country); err != nil {
+		return nil, server.UnprocessableEntity(errors.Wrapf(err, "validations failed for %#v", req.Data), invalidPropertiesErrorCode)
+	}
+	if period := tokenomics.TopMinersPeriod(req.Data.Period); (period != "" && period != tokenomics.AllTimeTopMinersPeriod) || req.Data.Country != "" {
+		if period == "" {
+			period = tokenomics.AllTimeTopMinersPeriod
+		}
+		resp, nextOffset, err = s.tokenomicsRepository.GetHistoricalTopMiners(ctx, period, req.Data.Country, req.Data.Limit, req.Data.Offset)
+	} else {
+		resp, nextOffset, err = s.tokenomicsRepository.GetTopMiners(ctx, req.Data.Keyword, req.Data.Limit, req.Data.Offset)
+	}
 	if err != nil {
 		return nil, server.Unexpected(errors.Wrapf(err, "failed to get top miners for userID:%v & req:%#v", req.AuthenticatedUser.UserID, req.Data))
 	}
@@ -18,12 +18,15 @@ import (
 func (s *service) setupTokenomicsRoutes(router *server.Router) {
 	router.
 		Group("/v1r").
-		GET("/tokenomics/:userId/mining-boost-summary", server.RootHandler(s.GetMiningBoostSummary)).
-		GET("/tokenomics/:userId/mining-summary", server.RootHandler(s.GetMiningSummary)).
-		GET("/tokenomics/:userId/pre-staking-summary", server.RootHandler(s.GetPreStakingSummary)).
-		GET("/tokenomics/:userId/balance-summary", server.RootHandler(s.GetBalanceSummary)).
-		GET("/tokenomics/:userId/balance-history", server.RootHandler(s.GetBalanceHistory)).
-		GET("/tokenomics/:userId/ranking-summary", server.RootHandler(s.GetRankingSummary))
+		GET("/tokenomics/:userId/mining-boost-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetMiningBoostSummary))).
+		GET("/tokenomics/:userId/mining-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetMiningSummary))).
+		GET("/tokenomics/:userId/pre-staking-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetPreStakingSummary))).
+		GET("/tokenomics/:userId/balance-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetBalanceSummary))).
+		GET("/tokenomics/:userId/balance-history", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetBalanceHistory))).
+		GET("/tokenomics/:userId/ranking-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetRankingSummary))).
+		GET("/tokenomics/:userId/debug", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetUserDebugSummary))).
+		GET("/tokenomics/:userId/ledger", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetLedger))).
+		GET("/tokenomics/:userId/team-summary", server.RootHandler(tokenomics.TenantHandler(s.tokenomicsRepository, s.GetTeamSummary)))
 }
 
 // GetMiningBoostSummary godoc
@@ -258,3 +261,139 @@ func (s *service) GetRankingSummary( //nolint:gocritic // False negative.
 
 	return server.OK(ranking), nil
 }
+
+// GetUserDebugSummary godoc
+//
+//	@Schemes
+//	@Description	Returns the raw tokenomics state of the user, alongside the values derived from it. Admin only.
+//	@Tags			Tokenomics
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			userId			path		string	true	"ID of the user"
+//	@Param			x_client_type	query		string	false	"the type of the client calling this API. I.E. `web`"
+//	@Success		200				{object}	tokenomics.UserDebugSummary
+//	@Failure		400				{object}	server.ErrorResponse	"if validations fail"
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		404				{object}	server.ErrorResponse	"if not found"
+//	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/tokenomics/{userId}/debug [GET].
+func (s *service) GetUserDebugSummary( //nolint:gocritic // False negative.
+	ctx context.Context,
+	req *server.Request[GetUserDebugSummaryArg, tokenomics.UserDebugSummary],
+) (*server.Response[tokenomics.UserDebugSummary], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("insufficient role: %v, admin role required", req.AuthenticatedUser.Role))
+	}
+	collectorSettings, err := s.coinDistributionRepository.GetCollectorSettings(ctx)
+	if err != nil {
+		return nil, server.Unexpected(errors.Wrap(err, "failed to GetCollectorSettings"))
+	}
+	summary, err := s.tokenomicsRepository.GetUserDebugSummary(tokenomics.ContextWithClientType(ctx, req.Data.XClientType), req.Data.UserID, collectorSettings) //nolint:lll // .
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get user's debug summary for userID:%v", req.Data.UserID)
+		if errors.Is(err, tokenomics.ErrRelationNotFound) || errors.Is(err, tokenomics.ErrNotFound) {
+			return nil, server.NotFound(err, userNotFoundErrorCode)
+		}
+
+		return nil, server.Unexpected(err)
+	}
+
+	return server.OK(summary), nil
+}
+
+// GetLedger godoc
+//
+//	@Schemes
+//	@Description	Returns the balance mutations of the user, newest first. Available to the user itself and to admins.
+//	@Tags			Tokenomics
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			userId			path		string	true	"ID of the user"
+//	@Param			cursor			query		uint64	false	"the cursor returned by the previous page. Default is `0`, meaning the latest entries."
+//	@Param			limit			query		uint64	false	"max number of elements to return. Default is `100`."
+//	@Success		200				{object}	tokenomics.Ledger
+//	@Failure		400				{object}	server.ErrorResponse	"if validations fail"
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		404				{object}	server.ErrorResponse	"if not found"
+//	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/tokenomics/{userId}/ledger [GET].
+func (s *service) GetLedger( //nolint:gocritic // False negative.
+	ctx context.Context,
+	req *server.Request[GetLedgerArg, tokenomics.Ledger],
+) (*server.Response[tokenomics.Ledger], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.UserID != req.Data.UserID && req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("not allowed to see the ledger of userID:%v", req.Data.UserID))
+	}
+	const defaultLimit, maxLimit = 100, 1000
+	if req.Data.Limit > maxLimit {
+		req.Data.Limit = maxLimit
+	}
+	if req.Data.Limit == 0 {
+		req.Data.Limit = defaultLimit
+	}
+	ledger, err := s.tokenomicsRepository.GetLedger(ctx, req.Data.UserID, req.Data.Cursor, req.Data.Limit)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get user's ledger for userID:%v, data:%#v", req.Data.UserID, req.Data)
+		if errors.Is(err, tokenomics.ErrNotFound) {
+			return nil, server.NotFound(err, userNotFoundErrorCode)
+		}
+
+		return nil, server.Unexpected(err)
+	}
+
+	return server.OK(ledger), nil
+}
+
+// GetTeamSummary godoc
+//
+//	@Schemes
+//	@Description	Returns what the user's T1/T2 referrals earned it: referral counts, daily earnings, top contributors and the referrals being slashed. Available to the user itself and to admins.
+//	@Tags			Tokenomics
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			userId			path		string	true	"ID of the user"
+//	@Param			days			query		uint64	false	"number of days in the past to return the earnings for. Default is `7`. Max is `30`."
+//	@Success		200				{object}	tokenomics.TeamSummary
+//	@Failure		400				{object}	server.ErrorResponse	"if validations fail"
+//	@Failure		401				{object}	server.ErrorResponse	"if not authorized"
+//	@Failure		403				{object}	server.ErrorResponse	"if not allowed"
+//	@Failure		404				{object}	server.ErrorResponse	"if not found"
+//	@Failure		422				{object}	server.ErrorResponse	"if syntax fails"
+//	@Failure		500				{object}	server.ErrorResponse
+//	@Failure		504				{object}	server.ErrorResponse	"if request times out"
+//	@Router			/tokenomics/{userId}/team-summary [GET].
+func (s *service) GetTeamSummary( //nolint:gocritic // False negative.
+	ctx context.Context,
+	req *server.Request[GetTeamSummaryArg, tokenomics.TeamSummary],
+) (*server.Response[tokenomics.TeamSummary], *server.Response[server.ErrorResponse]) {
+	if req.AuthenticatedUser.UserID != req.Data.UserID && req.AuthenticatedUser.Role != adminRole {
+		return nil, server.Forbidden(errors.Errorf("not allowed to see the team summary of userID:%v", req.Data.UserID))
+	}
+	const defaultDays, maxDays = 7, 30
+	if req.Data.Days == 0 {
+		req.Data.Days = defaultDays
+	}
+	if req.Data.Days > maxDays {
+		req.Data.Days = maxDays
+	}
+	summary, err := s.tokenomicsRepository.GetTeamSummary(ctx, req.Data.UserID, req.Data.Days)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get user's team summary for userID:%v, data:%#v", req.Data.UserID, req.Data)
+		if errors.Is(err, tokenomics.ErrRelationNotFound) || errors.Is(err, tokenomics.ErrNotFound) {
+			return nil, server.NotFound(err, userNotFoundErrorCode)
+		}
+
+		return nil, server.Unexpected(err)
+	}
+
+	return server.OK(summary), nil
+}
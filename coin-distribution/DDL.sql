@@ -27,6 +27,11 @@ CREATE TABLE IF NOT EXISTS pending_coin_distributions  (
                     eth_tx                    text,
                     PRIMARY KEY(day, user_id))
                     WITH (FILLFACTOR = 70);
+ALTER TABLE pending_coin_distributions ADD COLUMN IF NOT EXISTS eth_tx_nonce bigint;
+ALTER TABLE pending_coin_distributions ADD COLUMN IF NOT EXISTS eth_tx_replaced text[] NOT NULL DEFAULT '{}';
+ALTER TABLE pending_coin_distributions ADD COLUMN IF NOT EXISTS eth_tx_cancel text[] NOT NULL DEFAULT '{}';
+ALTER TABLE pending_coin_distributions ADD COLUMN IF NOT EXISTS merkle_root text;
+ALTER TABLE pending_coin_distributions ADD COLUMN IF NOT EXISTS batch_id text;
 
 CREATE INDEX IF NOT EXISTS pending_coin_distributions_worker_number_ix ON pending_coin_distributions (eth_status, (internal_id % 10), created_at ASC);
 CREATE INDEX IF NOT EXISTS pending_coin_distributions_eth_status_tx_ix ON pending_coin_distributions (eth_status, eth_tx);
@@ -49,11 +54,47 @@ INSERT INTO global (key,value)
                    ('coin_collector_denied_countries',''),
                    ('coin_distributer_gas_limit_units','30000000'),
                    ('coin_distributer_gas_price_override','3000000000'),
+                   ('coin_distributer_legacy_gas_price_enabled','true'),
+                   ('coin_distributer_max_fee_per_gas_cap','100000000000'),
+                   ('coin_distributer_max_priority_fee_per_gas_cap','3000000000'),
+                   ('coin_distributer_cancel_pending_transaction','false'),
+                   ('coin_distributer_merkle_claim_mode_enabled','false'),
+                   ('coin_distributer_eth_address_deny_list',''),
+                   ('coin_distributer_preflight_enabled','false'),
+                   ('coin_distributer_batch_size_min','50'),
+                   ('coin_distributer_batch_size_max','700'),
+                   ('coin_distributer_batch_gas_safety_margin_percent','20'),
+                   ('coin_distributer_cap_per_cycle_ice','0'),
+                   ('coin_distributer_cap_per_user_ice','0'),
+                   ('coin_distributer_cap_cycle_deviation_percent','0'),
+                   ('coin_distributer_flag_average_cycles','5'),
+                   ('coin_distributer_flag_average_multiplier','3'),
+                   ('coin_distributer_flag_shared_eth_address_users','3'),
+                   ('coin_distributer_flag_referral_concentration_percent','80'),
                    ('coin_distributer_msg_sent_online_date', '2023-01-01T00:00:00Z'),
                    ('coin_distributer_msg_sent_offline_date', '2023-01-01T00:00:00Z'),
                    ('coin_distributer_msg_sent_finished_date', '2023-01-01T00:00:00Z')
          ON CONFLICT(key) DO NOTHING;
 
+CREATE TABLE IF NOT EXISTS coin_distribution_claim_cycles  (
+                    created_at                timestamp NOT NULL,
+                    confirmed_at              timestamp,
+                    iceflakes                 uint256,
+                    recipients                bigint    NOT NULL,
+                    merkle_root               text      NOT NULL primary key,
+                    eth_tx                    text      NOT NULL)
+                    WITH (FILLFACTOR = 70);
+
+CREATE TABLE IF NOT EXISTS coin_distribution_claim_proofs  (
+                    created_at                timestamp NOT NULL,
+                    iceflakes                 uint256,
+                    merkle_root               text      NOT NULL REFERENCES coin_distribution_claim_cycles(merkle_root) ON DELETE CASCADE,
+                    eth_address               text      NOT NULL,
+                    user_ids                  text[]    NOT NULL,
+                    proof                     text[]    NOT NULL,
+                    PRIMARY KEY(merkle_root, eth_address));
+CREATE INDEX IF NOT EXISTS coin_distribution_claim_proofs_user_ids_ix ON coin_distribution_claim_proofs USING GIN (user_ids);
+
 CREATE TABLE IF NOT EXISTS coin_distributions_by_earner (
                     created_at                timestamp NOT NULL,
                     internal_id               bigint    NOT NULL,
@@ -69,6 +110,66 @@ CREATE TABLE IF NOT EXISTS coin_distributions_by_earner (
                     WITH (FILLFACTOR = 70);
 ALTER TABLE coin_distributions_by_earner ADD COLUMN IF NOT EXISTS verified boolean NOT NULL DEFAULT FALSE;
 
+CREATE TABLE IF NOT EXISTS coin_distribution_batches (
+                    created_at                timestamp NOT NULL,
+                    size                      bigint    NOT NULL,
+                    records                   bigint    NOT NULL,
+                    recipients                bigint    NOT NULL,
+                    gas_limit                 bigint    NOT NULL,
+                    gas_per_recipient         bigint    NOT NULL,
+                    safety_margin_percent     bigint    NOT NULL,
+                    gas_used                  bigint,
+                    batch_id                  text      NOT NULL primary key,
+                    eth_tx                    text      NOT NULL,
+                    mined_eth_tx              text,
+                    rationale                 text      NOT NULL);
+CREATE INDEX IF NOT EXISTS coin_distribution_batches_created_at_ix ON coin_distribution_batches (created_at);
+
+CREATE TABLE IF NOT EXISTS coin_distribution_reconciliations (
+                    created_at                timestamp NOT NULL,
+                    day                       date      NOT NULL,
+                    expected_iceflakes        uint256,
+                    actual_iceflakes          uint256,
+                    recipients                bigint    NOT NULL,
+                    transfers                 bigint    NOT NULL,
+                    discrepancies             bigint    NOT NULL,
+                    status                    text      NOT NULL DEFAULT 'RECONCILED',
+                    error                     text,
+                    eth_tx                    text      NOT NULL primary key);
+CREATE INDEX IF NOT EXISTS coin_distribution_reconciliations_day_ix ON coin_distribution_reconciliations (day);
+
+CREATE TABLE IF NOT EXISTS coin_distribution_reconciliation_discrepancies (
+                    created_at                timestamp NOT NULL,
+                    expected_iceflakes        uint256,
+                    actual_iceflakes          uint256,
+                    eth_tx                    text      NOT NULL,
+                    eth_address               text      NOT NULL,
+                    kind                      text      NOT NULL,
+                    user_ids                  text[]    NOT NULL DEFAULT '{}',
+                    PRIMARY KEY(eth_tx, eth_address));
+
+CREATE OR REPLACE VIEW coin_distribution_reconciliation_cycles AS
+    SELECT day,
+           count(*)                 AS transactions,
+           sum(recipients)          AS recipients,
+           sum(transfers)           AS transfers,
+           sum(discrepancies)       AS discrepancies,
+           sum(expected_iceflakes)  AS expected_iceflakes,
+           sum(actual_iceflakes)    AS actual_iceflakes,
+           max(created_at)          AS reconciled_at,
+           count(*) FILTER (WHERE status = 'APPROXIMATE') AS approximate_transactions,
+           count(*) FILTER (WHERE status = 'FAILED')      AS failed_transactions
+    FROM coin_distribution_reconciliations
+    GROUP BY day;
+
+CREATE TABLE IF NOT EXISTS coin_distribution_contributions (
+                    created_at                timestamp NOT NULL,
+                    balance                   bigint    NOT NULL,
+                    day                       date      NOT NULL,
+                    user_id                   text      NOT NULL,
+                    earner_user_id            text      NOT NULL,
+                    PRIMARY KEY(user_id, day, earner_user_id));
+
 CREATE TABLE IF NOT EXISTS coin_distributions_pending_review  (
                     created_at                timestamp ,
                     internal_id               bigint    ,
@@ -82,6 +183,7 @@ CREATE TABLE IF NOT EXISTS coin_distributions_pending_review  (
                     verified                  boolean   NOT NULL DEFAULT FALSE,
                     PRIMARY KEY(day, user_id));
 ALTER TABLE coin_distributions_pending_review ADD COLUMN IF NOT EXISTS verified boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE coin_distributions_pending_review ADD COLUMN IF NOT EXISTS flags text[] NOT NULL DEFAULT '{}';
 
 CREATE INDEX IF NOT EXISTS coin_distributions_pending_review_internal_id_ix ON coin_distributions_pending_review (internal_id NULLS FIRST);
 CREATE INDEX IF NOT EXISTS coin_distributions_pending_review_created_at_ix ON coin_distributions_pending_review (created_at);
@@ -94,6 +196,27 @@ CREATE INDEX IF NOT EXISTS coin_distributions_pending_review_lookup3_ix ON coin_
 CREATE INDEX IF NOT EXISTS coin_distributions_pending_review_lookup4_ix ON coin_distributions_pending_review (ice,username,internal_id);
 CREATE INDEX IF NOT EXISTS coin_distributions_pending_review_lookup5_ix ON coin_distributions_pending_review (referred_by_username,internal_id);
 CREATE INDEX IF NOT EXISTS coin_distributions_pending_review_lookup6_ix ON coin_distributions_pending_review (ice,referred_by_username,internal_id);
+CREATE INDEX IF NOT EXISTS coin_distributions_pending_review_flags_ix ON coin_distributions_pending_review USING GIN (flags);
+
+CREATE TABLE IF NOT EXISTS held_coin_distributions  (
+                    held_at                   timestamp NOT NULL,
+                    released_at               timestamp,
+                    created_at                timestamp ,
+                    internal_id               bigint    ,
+                    ice                       bigint    NOT NULL,
+                    day                       date      NOT NULL,
+                    iceflakes                 uint256           ,
+                    username                  text      NOT NULL,
+                    referred_by_username      text      NOT NULL,
+                    user_id                   text      NOT NULL,
+                    eth_address               text      NOT NULL,
+                    reason                    text      NOT NULL,
+                    released_by_user_id       text,
+                    release_reason            text,
+                    verified                  boolean   NOT NULL DEFAULT FALSE,
+                    PRIMARY KEY(day, user_id));
+ALTER TABLE held_coin_distributions ADD COLUMN IF NOT EXISTS flags text[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS held_coin_distributions_released_at_ix ON held_coin_distributions (released_at NULLS FIRST, held_at DESC);
 
 CREATE TABLE IF NOT EXISTS reviewed_coin_distributions  (
                     reviewed_at               timestamp NOT NULL,
@@ -112,6 +235,16 @@ CREATE TABLE IF NOT EXISTS reviewed_coin_distributions  (
                     verified                  boolean   NOT NULL DEFAULT FALSE,
                     PRIMARY KEY(user_id, day, review_day));
 ALTER TABLE reviewed_coin_distributions ADD COLUMN IF NOT EXISTS verified boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE reviewed_coin_distributions ADD COLUMN IF NOT EXISTS reason text;
+ALTER TABLE reviewed_coin_distributions ADD COLUMN IF NOT EXISTS eth_tx text;
+
+CREATE TABLE IF NOT EXISTS coin_distribution_review_approvals  (
+                    created_at                timestamp NOT NULL,
+                    snapshot                  text      NOT NULL,
+                    reviewer_user_id          text      NOT NULL,
+                    decision                  text      NOT NULL,
+                    mode                      text      NOT NULL,
+                    PRIMARY KEY(snapshot, reviewer_user_id, decision, mode));
 create or replace function approve_coin_distributions(reviewer_user_id text, process_immediately boolean, nested boolean)
     returns RECORD
 language plpgsql
@@ -195,6 +328,13 @@ BEGIN
                 from coin_distributions_by_earner
                 group by day,user_id,verified) AS X;
 
+    insert into coin_distribution_contributions(created_at, balance, day, user_id, earner_user_id)
+        select created_at, balance, day, user_id, earner_user_id
+        from coin_distributions_by_earner
+    ON CONFLICT (user_id, day, earner_user_id) DO UPDATE
+        SET created_at = EXCLUDED.created_at,
+            balance = EXCLUDED.balance;
+
     delete from coin_distributions_by_earner where 1=1;
 
     WITH del as (
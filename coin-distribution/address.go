@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/log"
+)
+
+//nolint:gochecknoglobals // It's a static set.
+var (
+	ethAddressFormat = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	burnEthAddresses = map[common.Address]struct{}{
+		common.HexToAddress("0x000000000000000000000000000000000000dEaD"): {},
+		common.HexToAddress("0xdEAD000000000000000042069420694206942069"): {},
+		common.HexToAddress("0x0000000000000000000000000000000000000001"): {},
+	}
+)
+
+// The cheap checks, that don't need any IO, so that they can be used while mining too.
+func ethAddressRejectionReason(ethAddress string) string {
+	if !ethAddressFormat.MatchString(ethAddress) {
+		return invalidFormatEthAddressRejectionReason
+	}
+	address := common.HexToAddress(ethAddress)
+	if hexPart := ethAddress[2:]; hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) && address.Hex() != ethAddress {
+		return invalidChecksumEthAddressRejectionReason
+	}
+	if address == (common.Address{}) {
+		return zeroEthAddressRejectionReason
+	}
+	if _, burn := burnEthAddresses[address]; burn {
+		return burnEthAddressRejectionReason
+	}
+
+	return ""
+}
+
+func newEthAddressScreener(ctx context.Context, conf *config, db *storage.DB) *ethAddressScreener {
+	screener := &ethAddressScreener{DenyList: &databaseDenyList{DB: db}}
+	if conf.EthAddressDenyListFile != "" {
+		screener.DenyList = &fileDenyList{Path: conf.EthAddressDenyListFile}
+	}
+	if conf.Ethereum.RPC != "" {
+		rpcClient, err := ethclient.DialContext(ctx, conf.Ethereum.RPC)
+		log.Panic(errors.Wrap(err, "failed to connect to ethereum RPC")) //nolint:revive,nolintlint //.
+		screener.Code = &rpcCodeGetter{Client: rpcClient}
+	}
+
+	return screener
+}
+
+// Contracts checks which of the addresses are contracts. It needs the RPC, so it's meant to be used before the review transaction.
+// The addresses that fail the cheap checks are skipped, they're rejected anyway. Without an RPC configured, nothing is checked.
+func (s *ethAddressScreener) Contracts(ctx context.Context, ethAddresses []string) (map[common.Address]bool, error) {
+	if s.Code == nil {
+		return nil, nil //nolint:nilnil // Nothing to check.
+	}
+	accounts := make([]common.Address, 0, len(ethAddresses))
+	for _, ethAddress := range ethAddresses {
+		if ethAddressRejectionReason(ethAddress) == "" {
+			accounts = append(accounts, common.HexToAddress(ethAddress))
+		}
+	}
+	contracts := make(map[common.Address]bool, len(accounts))
+	for len(accounts) > 0 {
+		chunk := accounts[:min(rpcBatchSize, len(accounts))]
+		accounts = accounts[len(chunk):]
+		codes, err := s.Code.CodesAt(ctx, chunk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the code of %v accounts", len(chunk))
+		}
+		for ix, account := range chunk {
+			contracts[account] = len(codes[ix]) > 0
+		}
+	}
+
+	return contracts, nil
+}
+
+// Screen returns the rejection reason of every address that must not receive any coins.
+// The contracts are the ones checked beforehand with Contracts, every valid address must be there, unless there's no RPC configured.
+func (s *ethAddressScreener) Screen(ctx context.Context, ethAddresses []string, contracts map[common.Address]bool) (map[string]string, error) {
+	denied, err := s.DenyList.DeniedEthAddresses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the eth address deny list")
+	}
+	rejected := make(map[string]string)
+	for _, ethAddress := range ethAddresses {
+		if reason := ethAddressRejectionReason(ethAddress); reason != "" {
+			rejected[ethAddress] = reason
+
+			continue
+		}
+		address := common.HexToAddress(ethAddress)
+		if reason, found := denied[address]; found {
+			rejected[ethAddress] = deniedEthAddressRejectionReason
+			if reason != "" {
+				rejected[ethAddress] += ": " + reason
+			}
+
+			continue
+		}
+		if s.Code == nil {
+			continue
+		}
+		if contract, checked := contracts[address]; !checked {
+			return nil, errors.Wrapf(errEthAddressNotScreened, "%v", ethAddress)
+		} else if contract {
+			rejected[ethAddress] = contractEthAddressRejectionReason
+		}
+	}
+
+	return rejected, nil
+}
+
+func (s *ethAddressScreener) Close() {
+	if closer, ok := s.Code.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
+
+func (g *rpcCodeGetter) CodesAt(ctx context.Context, accounts []common.Address) ([][]byte, error) {
+	codes := make([]hexutil.Bytes, len(accounts))
+	elements := make([]rpc.BatchElem, len(accounts))
+	for ix, account := range accounts {
+		elements[ix] = rpc.BatchElem{Method: "eth_getCode", Args: []any{account, "latest"}, Result: &codes[ix]}
+	}
+	if _, err := maybeRetryRPCRequest(ctx, func() (bool, error) {
+		if err := g.Client.Client().BatchCallContext(ctx, elements); err != nil {
+			return false, err //nolint:wrapcheck // .
+		}
+		for ix := range elements {
+			if elements[ix].Error != nil {
+				return false, elements[ix].Error //nolint:wrapcheck // .
+			}
+		}
+
+		return true, nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to batch eth_getCode")
+	}
+	result := make([][]byte, len(codes))
+	for ix := range codes {
+		result[ix] = codes[ix]
+	}
+
+	return result, nil
+}
+
+func (g *rpcCodeGetter) Close() {
+	g.Client.Close()
+}
+
+func (f *fileDenyList) DeniedEthAddresses(context.Context) (map[common.Address]string, error) {
+	content, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %v", f.Path)
+	}
+
+	return parseEthAddressDenyList(string(content))
+}
+
+func (d *databaseDenyList) DeniedEthAddresses(ctx context.Context) (map[common.Address]string, error) {
+	val, err := storage.ExecOne[struct{ Value string }](ctx, d.DB, "SELECT value FROM global WHERE key = $1", configKeyCoinDistributerDenyList)
+	if err != nil {
+		if storage.IsErr(err, storage.ErrNotFound) {
+			return nil, nil //nolint:nilnil // Nothing is denied.
+		}
+
+		return nil, errors.Wrapf(err, "failed to get %v", configKeyCoinDistributerDenyList)
+	}
+
+	return parseEthAddressDenyList(val.Value)
+}
+
+// One address per line, optionally followed by a comma and the reason it's denied for. Empty lines and `#` comments are ignored.
+func parseEthAddressDenyList(content string) (map[common.Address]string, error) {
+	denied := make(map[common.Address]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		entry, _, _ := strings.Cut(scanner.Text(), "#")
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		ethAddress, reason, _ := strings.Cut(entry, ",")
+		if ethAddress = strings.TrimSpace(ethAddress); !ethAddressFormat.MatchString(ethAddress) {
+			return nil, errors.Errorf("invalid eth address `%v` at line %v of the deny list", ethAddress, line)
+		}
+		denied[common.HexToAddress(ethAddress)] = strings.TrimSpace(reason)
+	}
+
+	return denied, errors.Wrap(scanner.Err(), "failed to parse the deny list")
+}
+
+// The addresses of the coin distributions about to be prepared for review, and of the ones already pending review, are checked for contract code
+// before the review transaction, so that no RPC call is made while it holds its locks.
+func screenEthAddressContracts(ctx context.Context, db storage.Querier, screener *ethAddressScreener) (map[common.Address]bool, error) {
+	if screener == nil {
+		return nil, nil //nolint:nilnil // Nothing to screen.
+	}
+	rows, err := storage.Select[struct{ EthAddress string }](ctx, db,
+		`SELECT DISTINCT COALESCE(eth_address,'') AS eth_address FROM coin_distributions_by_earner WHERE internal_id IS DISTINCT FROM $1
+		 UNION
+		 SELECT COALESCE(eth_address,'') AS eth_address FROM coin_distributions_pending_review WHERE internal_id IS DISTINCT FROM $1`, rewardPoolInternalID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select the eth addresses to screen")
+	}
+	ethAddresses := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ethAddresses = append(ethAddresses, row.EthAddress)
+	}
+
+	return screener.Contracts(ctx, ethAddresses)
+}
+
+// The rejected coin distributions pending review are moved to the reviewed ones, denied by the system, together with the reason.
+// The mainnet reward pool is exempted, it's configured by us and it's expected to be a contract.
+func screenCoinDistributionsPendingReview(
+	ctx context.Context, conn storage.QueryExecer, screener *ethAddressScreener, contracts map[common.Address]bool,
+) error {
+	if screener == nil {
+		return nil
+	}
+	rows, err := storage.Select[struct{ EthAddress string }](ctx, conn,
+		`SELECT DISTINCT eth_address FROM coin_distributions_pending_review WHERE internal_id IS DISTINCT FROM $1`, rewardPoolInternalID)
+	if err != nil {
+		return errors.Wrap(err, "failed to select the eth addresses pending review")
+	}
+	ethAddresses := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ethAddresses = append(ethAddresses, row.EthAddress)
+	}
+	rejected, err := screener.Screen(ctx, ethAddresses, contracts)
+	if err != nil || len(rejected) == 0 {
+		return errors.Wrap(err, "failed to screen the eth addresses pending review")
+	}
+	rejectedAddresses, reasons := make([]string, 0, len(rejected)), make([]string, 0, len(rejected))
+	for ethAddress, reason := range rejected {
+		rejectedAddresses = append(rejectedAddresses, ethAddress)
+		reasons = append(reasons, reason)
+	}
+	sql := `WITH rejected AS (
+				SELECT * FROM unnest($1::text[], $2::text[]) AS r(eth_address, reason)
+			), del AS (
+				DELETE FROM coin_distributions_pending_review p
+				USING rejected
+				WHERE p.eth_address = rejected.eth_address
+				  AND p.internal_id IS DISTINCT FROM $3
+				RETURNING p.*, rejected.reason
+			)
+			INSERT INTO reviewed_coin_distributions(reviewed_at, created_at, internal_id, ice, day, review_day, iceflakes, username, referred_by_username, user_id, eth_address, reviewer_user_id, decision, verified, reason)
+			SELECT current_timestamp, COALESCE(created_at,to_timestamp(0)), COALESCE(internal_id,0), ice, day, current_date, iceflakes, username, referred_by_username, user_id, eth_address, 'system', $4, verified, reason
+			FROM del`
+	denied, err := storage.Exec(ctx, conn, sql, rejectedAddresses, reasons, rewardPoolInternalID, invalidEthAddressDecision)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deny the coin distributions pending review of %v rejected eth addresses", len(rejected))
+	}
+	log.Info(fmt.Sprintf("denied %v coin distributions pending review of %v rejected eth addresses", denied, len(rejected)))
+
+	return nil
+}
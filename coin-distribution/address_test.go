@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	mockCodeGetter struct {
+		contracts map[common.Address]struct{}
+		calls     int
+	}
+	mockDenyList struct {
+		denied map[common.Address]string
+	}
+)
+
+func (m *mockCodeGetter) CodesAt(_ context.Context, accounts []common.Address) ([][]byte, error) {
+	m.calls++
+	codes := make([][]byte, len(accounts))
+	for ix, account := range accounts {
+		if _, found := m.contracts[account]; found {
+			codes[ix] = []byte{0x60, 0x80}
+		}
+	}
+
+	return codes, nil
+}
+
+func (m *mockDenyList) DeniedEthAddresses(context.Context) (map[common.Address]string, error) {
+	return m.denied, nil
+}
+
+func TestEthAddressRejectionReason(t *testing.T) {
+	t.Parallel()
+
+	const checksummed = "0x4B73C58370AEfcEf86A6021afCDe5673511376B2"
+	assert.Empty(t, ethAddressRejectionReason(checksummed))
+	assert.Empty(t, ethAddressRejectionReason(strings.ToLower(checksummed)))
+	assert.Empty(t, ethAddressRejectionReason("0x"+strings.ToUpper(checksummed[2:])))
+	assert.Equal(t, invalidChecksumEthAddressRejectionReason, ethAddressRejectionReason("0x4b73C58370AEfcEf86A6021afCDe5673511376B2"))
+	assert.Equal(t, invalidFormatEthAddressRejectionReason, ethAddressRejectionReason(""))
+	assert.Equal(t, invalidFormatEthAddressRejectionReason, ethAddressRejectionReason("bogusInvalidAddress"))
+	assert.Equal(t, invalidFormatEthAddressRejectionReason, ethAddressRejectionReason(checksummed[2:]))
+	assert.Equal(t, invalidFormatEthAddressRejectionReason, ethAddressRejectionReason(checksummed+"0"))
+	assert.Equal(t, zeroEthAddressRejectionReason, ethAddressRejectionReason("0x0000000000000000000000000000000000000000"))
+	assert.Equal(t, burnEthAddressRejectionReason, ethAddressRejectionReason("0x000000000000000000000000000000000000dead"))
+
+	assert.True(t, isEthereumAddressValid(SkipEthereumAddressValidation))
+	assert.True(t, isEthereumAddressValid(checksummed))
+	assert.True(t, isEthereumAddressValid("0x0000000000000000000000000000000000000000"))
+}
+
+func TestParseEthAddressDenyList(t *testing.T) {
+	t.Parallel()
+
+	denied, err := parseEthAddressDenyList(`
+# sanctioned
+0x4B73C58370AEfcEf86A6021afCDe5673511376B2, OFAC
+0x8589427373d6d84e98730d7795d8f6f8731fda16 # no reason
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[common.Address]string{
+		common.HexToAddress("0x4B73C58370AEfcEf86A6021afCDe5673511376B2"): "OFAC",
+		common.HexToAddress("0x8589427373d6d84e98730d7795d8f6f8731fda16"): "",
+	}, denied)
+
+	_, err = parseEthAddressDenyList("0x4B73C58370AEfcEf86A6021afCDe5673511376B2\nbogus")
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestEthAddressScreenerScreen(t *testing.T) {
+	t.Parallel()
+
+	const (
+		valid    = "0x4B73C58370AEfcEf86A6021afCDe5673511376B2"
+		denied   = "0x8589427373D6D84E98730D7795D8f6f8731FDA16"
+		contract = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	)
+	code := &mockCodeGetter{contracts: map[common.Address]struct{}{common.HexToAddress(contract): {}}}
+	screener := &ethAddressScreener{
+		Code:     code,
+		DenyList: &mockDenyList{denied: map[common.Address]string{common.HexToAddress(denied): "OFAC"}},
+	}
+	ethAddresses := []string{valid, denied, contract, "0x0", ""}
+	contracts, err := screener.Contracts(context.Background(), ethAddresses)
+	require.NoError(t, err)
+	assert.Equal(t, 1, code.calls)
+	assert.Equal(t, map[common.Address]bool{
+		common.HexToAddress(valid): false, common.HexToAddress(denied): false, common.HexToAddress(contract): true,
+	}, contracts)
+	rejected, err := screener.Screen(context.Background(), ethAddresses, contracts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		denied:   deniedEthAddressRejectionReason + ": OFAC",
+		contract: contractEthAddressRejectionReason,
+		"0x0":    invalidFormatEthAddressRejectionReason,
+		"":       invalidFormatEthAddressRejectionReason,
+	}, rejected)
+
+	_, err = screener.Screen(context.Background(), []string{valid, "0x8589427373d6d84e98730d7795d8f6f8731fda17"}, contracts)
+	require.ErrorIs(t, err, errEthAddressNotScreened)
+
+	screener.Code = nil
+	rejected, err = screener.Screen(context.Background(), []string{valid, contract}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, rejected)
+}
@@ -5,6 +5,8 @@ package coindistribution
 import (
 	"fmt"
 	"math/big"
+	"slices"
+	stdlibtime "time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -56,15 +58,81 @@ func (b *batch) Users() []string {
 	return users
 }
 
+// Keys returns the primary keys (day, user_id) of the records, because a user can have records of several days,
+// which are not necessarily all in the same batch (e.g. after the preflight split it).
+func (b *batch) Keys() (days, users []string) {
+	days, users = make([]string, len(b.Records)), make([]string, len(b.Records)) //nolint:makezero //.
+	for idx := range b.Records {
+		days[idx], users[idx] = b.Records[idx].Day.Format(stdlibtime.DateOnly), b.Records[idx].UserID
+	}
+
+	return days, users
+}
+
 func (b *batch) SetStatus(status ethApiStatus) {
 	for idx := range b.Records {
 		b.Records[idx].EthStatus = status
 	}
 }
 
-func (b *batch) SetAccepted(txHash string) {
+func (b *batch) SetAccepted(tx *ethTransaction) {
+	nonce := int64(tx.Nonce)
 	for idx := range b.Records {
 		b.Records[idx].EthStatus = ethApiStatusAccepted
-		b.Records[idx].EthTX = &txHash
+		b.Records[idx].EthTX = &tx.Hash
+		b.Records[idx].EthTXNonce = &nonce
+	}
+}
+
+func (b *batch) IsCancelled() bool {
+	return len(b.Cancel) > 0
+}
+
+// LatestTX is the transaction that was broadcasted last for the batch's nonce.
+func (b *batch) LatestTX() string {
+	if b.IsCancelled() {
+		return b.Cancel[len(b.Cancel)-1]
+	}
+
+	return b.TX
+}
+
+// Hashes are all the transactions broadcasted for the batch's nonce, only one of them can ever be mined.
+func (b *batch) Hashes() []*string {
+	hashes := make([]*string, 0, 1+len(b.Replaced)+len(b.Cancel))
+	hashes = append(hashes, &b.TX)
+	for idx := range b.Replaced {
+		hashes = append(hashes, &b.Replaced[idx])
+	}
+	for idx := range b.Cancel {
+		hashes = append(hashes, &b.Cancel[idx])
+	}
+
+	return hashes
+}
+
+func (b *batch) SetReplaced(txHash string) {
+	b.Replaced = append(b.Replaced, b.TX)
+	b.TX = txHash
+	for idx := range b.Records {
+		b.Records[idx].EthTX = &b.TX
+		b.Records[idx].EthTXReplaced = b.Replaced
+	}
+}
+
+func (b *batch) AddCancel(txHash string) {
+	b.Cancel = append(b.Cancel, txHash)
+	for idx := range b.Records {
+		b.Records[idx].EthTXCancel = b.Cancel
+	}
+}
+
+// SetMined makes the mined transaction (one of the replaced ones) the batch's transaction.
+func (b *batch) SetMined(txHash string) {
+	b.Replaced = append(slices.DeleteFunc(b.Replaced, func(hash string) bool { return hash == txHash }), b.TX)
+	b.TX = txHash
+	for idx := range b.Records {
+		b.Records[idx].EthTX = &b.TX
+		b.Records[idx].EthTXReplaced = b.Replaced
 	}
 }
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+// SizeBatch picks how many records the next batch can have, from the gas used per recipient by the recent airdrops,
+// plus the safety margin, so that the batch fits into the gas limit without wasting it. Until there are receipts, the max size is used.
+func (proc *coinProcessor) SizeBatch(ctx context.Context) (*batchSizing, error) {
+	const stmt = `
+select
+	gas_used,
+	recipients
+from
+	coin_distribution_batches
+where
+	gas_used > 0 and
+	recipients > 0
+order by
+	created_at DESC
+limit $1
+`
+	sizing := new(batchSizing)
+	var err error
+	if sizing.GasLimit, err = proc.GetGasLimit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to get the gas limit")
+	}
+	if sizing.MinSize, sizing.MaxSize, err = proc.GetBatchSizeBounds(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to get the batch size bounds")
+	}
+	if sizing.SafetyMarginPercent, err = proc.GetBatchGasSafetyMarginPercent(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to get the batch gas safety margin")
+	}
+	receipts, err := storage.Select[batchReceipt](ctx, proc.DB, stmt, batchSizeReceipts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select the recent batch receipts")
+	}
+	sizing.size(receipts)
+	if sizing.Size < sizing.MinSize {
+		log.Error(errors.Errorf("batch size %v is below the min size %v: %v", sizing.Size, sizing.MinSize, sizing.Rationale))
+		log.Error(errors.Wrap(sendBatchSizeBelowMinSizeSlackMessage(ctx, sizing), "failed to sendBatchSizeBelowMinSizeSlackMessage"))
+	}
+
+	return sizing, nil
+}
+
+// The highest gas per recipient of the receipts is used, because it depends on the recipients (a new holder costs more storage than an existing one).
+// The gas limit takes precedence over the min size: a batch that doesn't fit would just fail, so it's made smaller (at least one record) and alerted.
+func (s *batchSizing) size(receipts []*batchReceipt) {
+	s.Receipts = len(receipts)
+	for _, receipt := range receipts {
+		s.GasPerRecipient = max(s.GasPerRecipient, receipt.GasUsed/receipt.Recipients)
+	}
+	if s.GasPerRecipient == 0 {
+		s.Size = s.MaxSize
+		s.Rationale = fmt.Sprintf("no receipts yet, max size %v", s.MaxSize)
+
+		return
+	}
+	withMargin := s.GasPerRecipient * (100 + s.SafetyMarginPercent) / 100 //nolint:mnd,gomnd // Percents.
+	fit := s.GasLimit / withMargin
+	s.Size = min(max(fit, 1), s.MaxSize)
+	s.Rationale = fmt.Sprintf("%v gas per recipient (max of the last %v receipts), %v with the %v%% margin, %v fit into the gas limit %v, bounded to [1, %v] (min size %v)",
+		s.GasPerRecipient, s.Receipts, withMargin, s.SafetyMarginPercent, fit, s.GasLimit, s.MaxSize, s.MinSize)
+}
+
+// The size of the broadcasted batch and why it was chosen are kept along with marking it accepted (so they can't go missing),
+// the gas it used is added once it's mined.
+func recordBatchSizing(ctx context.Context, conn storage.Execer, data *batch) error {
+	const stmt = `
+insert into coin_distribution_batches
+	(created_at, size, records, recipients, gas_limit, gas_per_recipient, safety_margin_percent, batch_id, eth_tx, rationale)
+values
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+on conflict (batch_id) do nothing
+`
+	if data.Sizing == nil {
+		return nil
+	}
+	recipients, _ := data.Prepare()
+	_, err := storage.Exec(ctx, conn, stmt,
+		time.Now().Time,
+		data.Sizing.Size,
+		len(data.Records),
+		len(recipients),
+		data.Sizing.GasLimit,
+		data.Sizing.GasPerRecipient,
+		data.Sizing.SafetyMarginPercent,
+		data.ID,
+		data.TX,
+		data.Sizing.Rationale,
+	)
+
+	return errors.Wrapf(err, "failed to record the sizing of batch %v", data.ID)
+}
+
+// RecordBatchGasUsed stores the gas used by the mined transaction (which can be a replacement of the broadcasted one), for sizing the next batches.
+func (proc *coinProcessor) RecordBatchGasUsed(ctx context.Context, hash string) error {
+	const stmt = `
+update coin_distribution_batches
+set
+	mined_eth_tx = $1,
+	gas_used = $2
+where
+	batch_id = (select batch_id from pending_coin_distributions where eth_status = 'ACCEPTED' and eth_tx = $1 and batch_id is not null limit 1)
+`
+	gasUsed, err := proc.Client.TransactionGasUsed(ctx, hash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the gas used by transaction %v", hash)
+	}
+	if _, err = storage.Exec(ctx, proc.DB, stmt, hash, gasUsed); err != nil {
+		return errors.Wrapf(err, "failed to record the gas used by transaction %v", hash)
+	}
+	log.Info(fmt.Sprintf("transaction: %v: gas used: %v", hash, gasUsed))
+
+	return nil
+}
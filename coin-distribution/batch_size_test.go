@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSizing(t *testing.T) {
+	t.Parallel()
+
+	newSizing := func() *batchSizing {
+		return &batchSizing{GasLimit: 3_000_000, MinSize: 10, MaxSize: 700, SafetyMarginPercent: 20}
+	}
+
+	t.Run("no receipts", func(t *testing.T) {
+		t.Parallel()
+
+		sizing := newSizing()
+		sizing.size(nil)
+		require.EqualValues(t, 700, sizing.Size)
+		require.Zero(t, sizing.GasPerRecipient)
+		require.Contains(t, sizing.Rationale, "no receipts yet")
+	})
+
+	t.Run("the highest gas per recipient is used", func(t *testing.T) {
+		t.Parallel()
+
+		sizing := newSizing()
+		sizing.size([]*batchReceipt{{GasUsed: 2_000_000, Recipients: 100}, {GasUsed: 2_500_000, Recipients: 100}, {GasUsed: 1_000_000, Recipients: 80}})
+		require.EqualValues(t, 25_000, sizing.GasPerRecipient)
+		require.EqualValues(t, 3, sizing.Receipts)
+		require.EqualValues(t, 100, sizing.Size) // 3_000_000 / 30_000.
+		require.Contains(t, sizing.Rationale, "25000 gas per recipient (max of the last 3 receipts), 30000 with the 20% margin, 100 fit")
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		t.Parallel()
+
+		sizing := newSizing()
+		sizing.size([]*batchReceipt{{GasUsed: 1_000_000, Recipients: 1}})
+		require.EqualValues(t, 2, sizing.Size) // The gas limit takes precedence over the min size.
+		require.Contains(t, sizing.Rationale, "(min size 10)")
+
+		sizing = newSizing()
+		sizing.size([]*batchReceipt{{GasUsed: 5_000_000, Recipients: 1}})
+		require.EqualValues(t, 1, sizing.Size)
+
+		sizing = newSizing()
+		sizing.size([]*batchReceipt{{GasUsed: 1_000, Recipients: 1}})
+		require.EqualValues(t, 700, sizing.Size)
+	})
+}
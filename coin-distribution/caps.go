@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+// The coin distributions pending review that breach any of the caps are held, out of the review, until an admin releases them.
+// A breach of a cycle's caps holds the whole cycle, a breach of the per-user cap only holds that user's distribution.
+// The released ones are not held again, and a cap of 0 is not enforced.
+func holdCoinDistributionsExceedingCaps(ctx context.Context, conn storage.QueryExecer) error {
+	const cyclesSQL = `
+SELECT p.day,
+	   sum(p.ice) AS ice,
+	   coalesce((SELECT sum(c.balance)
+				 FROM coin_distribution_contributions c
+				 WHERE c.day = (SELECT max(day) FROM coin_distribution_contributions WHERE day < p.day)), 0) AS previous_ice
+FROM coin_distributions_pending_review p
+GROUP BY p.day`
+	caps, err := getCoinDistributionCaps(ctx, conn)
+	if err != nil || (caps.CycleIce == 0 && caps.CycleDeviationPercent == 0 && caps.UserIce == 0) {
+		return errors.Wrap(err, "failed to get the coin distribution caps")
+	}
+	if caps.CycleIce != 0 || caps.CycleDeviationPercent != 0 {
+		cycles, sErr := storage.Select[coinDistributionCycle](ctx, conn, cyclesSQL)
+		if sErr != nil {
+			return errors.Wrap(sErr, "failed to select the totals of the cycles pending review")
+		}
+		for _, cycle := range cycles {
+			if reason := caps.cycleBreach(cycle.Ice, cycle.PreviousIce); reason != "" {
+				if err = holdCoinDistributions(ctx, conn, reason, "p.day = $3", cycle.Day); err != nil {
+					return errors.Wrapf(err, "failed to hold the cycle %v", cycle.Day.Format(stdlibtime.DateOnly))
+				}
+			}
+		}
+	}
+	if caps.UserIce != 0 {
+		reason := fmt.Sprintf("user total exceeds the per-user cap of %v ICE", caps.UserIce)
+		if err = holdCoinDistributions(ctx, conn, reason, "p.ice > $3 AND p.internal_id IS DISTINCT FROM $4", caps.UserIce*100, rewardPoolInternalID); err != nil {
+			return errors.Wrap(err, "failed to hold the users exceeding the per-user cap")
+		}
+	}
+
+	return nil
+}
+
+func getCoinDistributionCaps(ctx context.Context, conn storage.Querier) (*coinDistributionCaps, error) {
+	caps := new(coinDistributionCaps)
+	for key, val := range map[string]*uint64{
+		configKeyCoinDistributerCycleCap:     &caps.CycleIce,
+		configKeyCoinDistributerUserCap:      &caps.UserIce,
+		configKeyCoinDistributerDeviationCap: &caps.CycleDeviationPercent,
+	} {
+		if err := databaseGetValue(ctx, conn, key, val); err != nil {
+			return nil, err
+		}
+	}
+
+	return caps, nil
+}
+
+// The ice amounts are in hundredths, like the ice columns. Without a previous cycle, there's no deviation to check.
+func (c *coinDistributionCaps) cycleBreach(ice, previousIce uint64) string {
+	if c.CycleIce != 0 && ice > c.CycleIce*100 {
+		return fmt.Sprintf("cycle total %.2f ICE exceeds the per-cycle cap of %v ICE", float64(ice)/100, c.CycleIce)
+	}
+	if c.CycleDeviationPercent != 0 && previousIce != 0 {
+		deviation := max(ice, previousIce) - min(ice, previousIce)
+		if deviation*100 > previousIce*c.CycleDeviationPercent {
+			return fmt.Sprintf("cycle total %.2f ICE deviates more than %v%% from the previous cycle total %.2f ICE",
+				float64(ice)/100, c.CycleDeviationPercent, float64(previousIce)/100)
+		}
+	}
+
+	return ""
+}
+
+//nolint:revive // .
+func holdCoinDistributions(ctx context.Context, conn storage.QueryExecer, reason, condition string, args ...any) error {
+	sql := fmt.Sprintf(`WITH held AS (
+							DELETE FROM coin_distributions_pending_review p
+							WHERE %v
+							  AND NOT EXISTS (SELECT 1
+											  FROM held_coin_distributions h
+											  WHERE h.day = p.day
+												AND h.user_id = p.user_id
+												AND h.released_at IS NOT NULL)
+							RETURNING p.*
+						),
+						ins AS (
+							INSERT INTO held_coin_distributions(held_at, created_at, internal_id, ice, day, iceflakes, username, referred_by_username, user_id, eth_address, verified, flags, reason)
+							SELECT $1, created_at, internal_id, ice, day, iceflakes, username, referred_by_username, user_id, eth_address, verified, flags, $2
+							FROM held
+							ON CONFLICT (day, user_id) DO UPDATE
+								SET held_at = EXCLUDED.held_at,
+									created_at = EXCLUDED.created_at,
+									internal_id = EXCLUDED.internal_id,
+									ice = EXCLUDED.ice,
+									iceflakes = EXCLUDED.iceflakes,
+									username = EXCLUDED.username,
+									referred_by_username = EXCLUDED.referred_by_username,
+									eth_address = EXCLUDED.eth_address,
+									verified = EXCLUDED.verified,
+									flags = EXCLUDED.flags,
+									reason = EXCLUDED.reason
+						)
+						SELECT count(1) AS rows, coalesce(sum(ice), 0) AS ice FROM held`, condition)
+	totals, err := storage.ExecOne[struct {
+		Rows uint64
+		Ice  uint64
+	}](ctx, conn, sql, append([]any{time.Now().Time, reason}, args...)...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to hold the coin distributions: %v", reason)
+	}
+	if totals.Rows == 0 {
+		return nil
+	}
+	log.Info(fmt.Sprintf("held %v coin distributions (%.2f coins): %v", totals.Rows, float64(totals.Ice)/100, reason))
+
+	return errors.Wrap(sendCoinDistributionsHeldSlackMessage(ctx, reason, totals.Rows, float64(totals.Ice)/100),
+		"failed to sendCoinDistributionsHeldSlackMessage")
+}
+
+func (r *repository) GetHeldCoinDistributions(ctx context.Context, arg *GetHeldCoinDistributionsArg) (*HeldCoinDistributions, error) {
+	const (
+		sql = `SELECT held_at, day, reason, created_at, ice, iceflakes, username, referred_by_username, user_id, eth_address, verified, flags
+			   FROM held_coin_distributions
+			   WHERE released_at IS NULL
+			   ORDER BY held_at DESC, day, user_id
+			   LIMIT $2 OFFSET $1`
+		totalsSQL = `SELECT count(1) AS rows, coalesce(sum(ice), 0) AS ice FROM held_coin_distributions WHERE released_at IS NULL`
+	)
+	result, err := storage.Select[struct {
+		*PendingReview
+		HeldAt *time.Time
+		Reason string
+		Day    stdlibtime.Time
+	}](ctx, r.db, sql, arg.Cursor, arg.Limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select held_coin_distributions for %#v", arg)
+	}
+	distributions := make([]*HeldCoinDistribution, 0, len(result))
+	for _, d := range result {
+		d.PendingReview.Ice = float64(d.PendingReview.IceInternal) / 100
+		distributions = append(distributions, &HeldCoinDistribution{
+			PendingReview: d.PendingReview,
+			HeldAt:        d.HeldAt,
+			Day:           d.Day.Format(stdlibtime.DateOnly),
+			Reason:        d.Reason,
+		})
+	}
+	totals, err := storage.ExecOne[struct {
+		Rows uint64
+		Ice  uint64
+	}](ctx, r.db, totalsSQL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select held_coin_distributions totals")
+	}
+	nextCursor := uint64(0)
+	if len(result) == int(arg.Limit) {
+		nextCursor = arg.Cursor + arg.Limit
+	}
+
+	return &HeldCoinDistributions{
+		Distributions: distributions,
+		Cursor:        nextCursor,
+		TotalRows:     totals.Rows,
+		TotalIce:      float64(totals.Ice) / 100,
+	}, nil
+}
+
+// ReleaseHeldCoinDistributions puts the selected held coin distributions (or all of them) back to review, where they have to be approved like the rest.
+// The ones that have a coin distribution pending review for the same day and user (collected again meanwhile) are kept held and counted as conflicts,
+// so that none of the amounts are lost or doubled. They can be released once the one pending review is reviewed.
+func (r *repository) ReleaseHeldCoinDistributions(
+	ctx context.Context, adminUserID string, arg *ReleaseHeldCoinDistributionsArg,
+) (*ReleasedCoinDistributions, error) {
+	if !arg.All && len(arg.UserIDs) == 0 {
+		return nil, errors.New("either userIds or all must be provided")
+	}
+	var condition string
+	args := []any{time.Now().Time, adminUserID, arg.Reason}
+	if len(arg.UserIDs) != 0 {
+		condition = "AND h.user_id = ANY($4)"
+		args = append(args, arg.UserIDs)
+	}
+	sql := fmt.Sprintf(`WITH conflicting AS (
+							SELECT 1
+							FROM held_coin_distributions h
+								JOIN coin_distributions_pending_review p
+									ON p.day = h.day
+								   AND p.user_id = h.user_id
+							WHERE h.released_at IS NULL %[1]v
+						),
+						released AS (
+							UPDATE held_coin_distributions h
+							SET released_at = $1,
+								released_by_user_id = $2,
+								release_reason = $3
+							WHERE h.released_at IS NULL %[1]v
+							  AND NOT EXISTS (SELECT 1
+											  FROM coin_distributions_pending_review p
+											  WHERE p.day = h.day
+												AND p.user_id = h.user_id)
+							RETURNING h.*
+						),
+						review AS (
+							INSERT INTO coin_distributions_pending_review(created_at, internal_id, ice, day, iceflakes, username, referred_by_username, user_id, eth_address, verified, flags)
+							SELECT created_at, internal_id, ice, day, iceflakes, username, referred_by_username, user_id, eth_address, verified, flags
+							FROM released
+						)
+						SELECT (SELECT count(1) FROM released) 				AS rows,
+							   (SELECT coalesce(sum(ice), 0) FROM released) AS ice,
+							   (SELECT count(1) FROM conflicting) 			AS conflicts`, condition)
+	totals, err := storage.ExecOne[struct {
+		Rows      uint64
+		Ice       uint64
+		Conflicts uint64
+	}](ctx, r.db, sql, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to release the held coin distributions for %#v", arg)
+	}
+	released := &ReleasedCoinDistributions{Rows: totals.Rows, Ice: float64(totals.Ice) / 100, Conflicts: totals.Conflicts}
+	if released.Rows == 0 && released.Conflicts == 0 {
+		return released, nil
+	}
+	log.Error(errors.Wrap(r.sendHeldCoinDistributionsReleasedSlackMessage(ctx, adminUserID, arg.Reason, strings.Join(arg.UserIDs, ","), released),
+		"failed to sendHeldCoinDistributionsReleasedSlackMessage"))
+
+	return released, nil
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinDistributionCapsCycleBreach(t *testing.T) {
+	t.Parallel()
+
+	noCaps := new(coinDistributionCaps)
+	require.Empty(t, noCaps.cycleBreach(1_000_000_000_00, 1_00))
+
+	caps := &coinDistributionCaps{CycleIce: 1_000, CycleDeviationPercent: 50}
+	require.Empty(t, caps.cycleBreach(1_000_00, 800_00))
+	require.Equal(t, "cycle total 1000.01 ICE exceeds the per-cycle cap of 1000 ICE", caps.cycleBreach(1_000_01, 800_00))
+	require.Empty(t, caps.cycleBreach(150_00, 100_00))
+	require.Empty(t, caps.cycleBreach(50_00, 100_00))
+	require.Equal(t, "cycle total 150.01 ICE deviates more than 50% from the previous cycle total 100.00 ICE", caps.cycleBreach(150_01, 100_00))
+	require.Equal(t, "cycle total 49.99 ICE deviates more than 50% from the previous cycle total 100.00 ICE", caps.cycleBreach(49_99, 100_00))
+	require.Empty(t, caps.cycleBreach(900_00, 0), "without a previous cycle")
+}
+
+func TestReleaseHeldCoinDistributionsRequiresSelection(t *testing.T) {
+	t.Parallel()
+
+	released, err := new(repository).ReleaseHeldCoinDistributions(context.Background(), "admin", &ReleaseHeldCoinDistributionsArg{Reason: "expected"})
+	require.EqualError(t, err, "either userIds or all must be provided")
+	require.Nil(t, released)
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+// RunClaimCycle publishes a single merkle root for all the approved distributions, instead of pushing the coins to every address.
+// The users claim their coins themselves, using the proofs stored for the cycle.
+func (proc *coinProcessor) RunClaimCycle(ctx context.Context, notify chan<- *batch) error {
+	log.Info("distribution: merkle claim cycle")
+	b, err := proc.DoClaimCycle(context.WithoutCancel(ctx))
+	if err != nil {
+		if errors.Is(err, errNotEnoughData) {
+			err = nil
+		}
+
+		return err
+	}
+
+	return proc.FinishBatch(ctx, b, notify)
+}
+
+//nolint:funlen // .
+func (proc *coinProcessor) DoClaimCycle(ctx context.Context) (*batch, error) {
+	data, err := proc.batchPrepareFetch(ctx, claimCycleMaxRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, amounts := data.Prepare()
+	leaves := make([]common.Hash, 0, len(recipients))
+	for ix := range recipients {
+		leaves = append(leaves, merkleLeaf(recipients[ix], amounts[ix]))
+	}
+	tree := newMerkleTree(leaves)
+	log.Info(fmt.Sprintf("claim cycle %v: merkle root %v for %v record(s) and %v address(es)", data.ID, tree.Root().String(), len(data.Records), len(leaves)))
+
+	tx, err := proc.Client.PublishMerkleRoot(ctx, big.NewInt(proc.Conf.Ethereum.ChainID), proc, tree.Root())
+	if err != nil {
+		err = errors.Wrapf(err, "failed to publish merkle root %v of claim cycle %v", tree.Root().String(), data.ID)
+		log.Error(err)
+		if err2 := proc.BatchRollback(ctx, data); err2 != nil {
+			log.Error(errors.Wrapf(err2, "failed to rollback claim cycle %v", data.ID))
+		}
+
+		return data, err
+	}
+
+	data.TX, data.Nonce = tx.Hash, tx.Nonce
+	if err = proc.ClaimCycleMarkAccepted(ctx, data, tx, tree, recipients, amounts); err != nil {
+		log.Error(errors.Wrapf(err, "failed to mark claim cycle %v as accepted", data.ID))
+
+		return data, err
+	}
+
+	return data, nil
+}
+
+//nolint:funlen // .
+func (proc *coinProcessor) ClaimCycleMarkAccepted(
+	ctx context.Context, data *batch, tx *ethTransaction, tree *merkleTree, recipients []common.Address, amounts []*big.Int,
+) error {
+	const (
+		insertCycleStmt = `
+insert into coin_distribution_claim_cycles
+	(created_at, iceflakes, recipients, merkle_root, eth_tx)
+values
+	($1, $2, $3, $4, $5)
+`
+		markAcceptedStmt = `
+update pending_coin_distributions
+set
+	eth_status = 'ACCEPTED',
+	eth_tx = $1,
+	eth_tx_nonce = $2,
+	merkle_root = $3
+where
+	eth_status = 'PENDING' and
+	user_id = ANY($4)
+`
+		columns = 6
+	)
+	userIDs := make(map[common.Address][]string, len(recipients))
+	for _, record := range data.Records {
+		userIDs[record.Address()] = append(userIDs[record.Address()], record.UserID)
+	}
+	total := big.NewInt(0)
+	for _, amount := range amounts {
+		total.Add(total, amount)
+	}
+	now, root := time.Now(), tree.Root().String()
+
+	err := storage.DoInTransaction(ctx, proc.DB, func(conn storage.QueryExecer) error {
+		if _, err := storage.Exec(ctx, conn, insertCycleStmt, now.Time, total.String(), len(recipients), root, tx.Hash); err != nil {
+			return errors.Wrapf(err, "failed to insert claim cycle %v", root)
+		}
+		for start := 0; start < len(recipients); start += claimProofsChunkSize {
+			end := min(start+claimProofsChunkSize, len(recipients))
+			values := make([]string, 0, end-start)
+			args := make([]any, 0, (end-start)*columns)
+			for ix := start; ix < end; ix++ {
+				proof, _ := tree.Proof(merkleLeaf(recipients[ix], amounts[ix]))
+				hexProof := make([]string, 0, len(proof))
+				for _, node := range proof {
+					hexProof = append(hexProof, node.String())
+				}
+				values = append(values, generateValuesSQLParams(ix-start, columns))
+				args = append(args, now.Time, amounts[ix].String(), root, recipients[ix].String(), userIDs[recipients[ix]], hexProof)
+			}
+			sql := fmt.Sprintf(`insert into coin_distribution_claim_proofs(created_at, iceflakes, merkle_root, eth_address, user_ids, proof)
+								values %v`, strings.Join(values, ",\n"))
+			if _, err := storage.Exec(ctx, conn, sql, args...); err != nil {
+				return errors.Wrapf(err, "failed to insert claim proofs [%v,%v) of claim cycle %v", start, end, root)
+			}
+		}
+		_, err := storage.Exec(ctx, conn, markAcceptedStmt, tx.Hash, int64(tx.Nonce), root, data.Users())
+
+		return errors.Wrapf(err, "failed to mark claim cycle %v with TX %v as accepted", root, tx.Hash)
+	})
+	data.SetAccepted(tx)
+
+	return err //nolint:wrapcheck // .
+}
+
+// ConfirmClaimCycle makes the proofs of the claim cycle (if any) published by the transaction available to the users.
+func (proc *coinProcessor) ConfirmClaimCycle(ctx context.Context, hash string) error {
+	const stmt = `
+update coin_distribution_claim_cycles
+set
+	confirmed_at = $2,
+	eth_tx = $1
+where
+	merkle_root = (select merkle_root from pending_coin_distributions where eth_status = 'ACCEPTED' and eth_tx = $1 and merkle_root is not null limit 1)
+`
+
+	_, err := storage.Exec(ctx, proc.DB, stmt, hash, time.Now().Time)
+
+	return errors.Wrapf(err, "failed to confirm claim cycle of transaction %v", hash)
+}
+
+func (r *repository) GetClaimProofs(ctx context.Context, userID string) ([]*ClaimProof, error) {
+	const sql = `
+SELECT p.created_at,
+	   p.merkle_root,
+	   p.eth_address,
+	   p.iceflakes::text AS iceflakes,
+	   p.proof
+FROM coin_distribution_claim_proofs p
+	JOIN coin_distribution_claim_cycles c
+		ON c.merkle_root = p.merkle_root
+	   AND c.confirmed_at IS NOT NULL
+WHERE p.user_ids @> ARRAY[$1]
+ORDER BY p.created_at DESC
+`
+	proofs, err := storage.Select[ClaimProof](ctx, r.db, sql, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select claim proofs for userID:%v", userID)
+	}
+	if proofs == nil {
+		proofs = make([]*ClaimProof, 0)
+	}
+
+	return proofs, nil
+}
@@ -14,12 +14,15 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
@@ -28,25 +31,51 @@ import (
 	"github.com/ice-blockchain/wintr/log"
 )
 
-func mustNewEthClient(ctx context.Context, endpoint, privateKey, contract string) *ethClientImpl {
-	key, err := crypto.HexToECDSA(privateKey)
-	log.Panic(errors.Wrap(err, "failed to parse private key")) //nolint:revive,nolintlint //.
-
+func mustNewEthClient(ctx context.Context, endpoint string, signer ethSigner, contract, claimContractAddress string) *ethClientImpl {
 	rpcClient, err := ethclient.DialContext(ctx, endpoint)
 	log.Panic(errors.Wrap(err, "failed to connect to ethereum RPC")) //nolint:revive,nolintlint //.
 
 	distributor, err := coindistribution.NewCoindistribution(common.HexToAddress(contract), rpcClient)
 	log.Panic(errors.Wrap(err, "failed to create contract instance")) //nolint:revive,nolintlint //.
 
+	client := newEthClient(rpcClient, distributor, signer)
+	client.Contract = common.HexToAddress(contract)
+	if claimContractAddress != "" {
+		client.Claimer = mustNewClaimContract(common.HexToAddress(claimContractAddress), rpcClient)
+	}
+
+	return client
+}
+
+func mustNewClaimContract(address common.Address, backend bind.ContractBackend) *claimContract {
+	parsed, err := abi.JSON(strings.NewReader(claimContractABI))
+	log.Panic(errors.Wrap(err, "failed to parse claim contract ABI")) //nolint:revive,nolintlint //.
+
+	return &claimContract{BoundContract: bind.NewBoundContract(address, parsed, backend, backend, backend)}
+}
+
+func (c *claimContract) SetMerkleRoot(opts *bind.TransactOpts, root common.Hash) (*types.Transaction, error) {
+	return c.Transact(opts, "setMerkleRoot", root) //nolint:wrapcheck // .
+}
+
+func newEthClient(rpcClient EthRPC, distributor airDropper, signer ethSigner) *ethClientImpl {
+	token, _ := distributor.(tokenBalancer) //nolint:errcheck // The mocked ones don't have it.
+
 	return &ethClientImpl{
 		RPC:        rpcClient,
 		AirDropper: distributor,
-		Key:        key,
+		Token:      token,
+		Signer:     signer,
 		Mutex:      new(sync.Mutex),
+		Nonces:     newNonceManager(rpcClient, signer.Address()),
 	}
 }
 
 func handleRPCError(ctx context.Context, target error) (retryAfter time.Duration) {
+	if errors.Is(target, errMaybeBroadcasted) {
+		return 0
+	}
+
 	var sysErr *syscall.Errno
 	if errors.As(target, &sysErr) {
 		return time.Second
@@ -136,17 +165,83 @@ func (ec *ethClientImpl) SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	})
 }
 
-func (ec *ethClientImpl) AirdropToWallets(opts *bind.TransactOpts, recipients []common.Address, amounts []*big.Int) (*types.Transaction, error) {
-	// The slow zone, we **must** have `nonce` as a linear sequence, **without** gaps.
+func (ec *ethClientImpl) FeeHistory(
+	ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64,
+) (*ethereum.FeeHistory, error) {
+	return maybeRetryRPCRequest(ctx, func() (*ethereum.FeeHistory, error) {
+		return ec.RPC.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles) //nolint:wrapcheck //.
+	})
+}
+
+// The slow zone, we **must** have `nonce` as a linear sequence, **without** gaps.
+func (ec *ethClientImpl) transactWithNextNonce(
+	opts *bind.TransactOpts, transact func(*bind.TransactOpts) (*types.Transaction, error),
+) (*types.Transaction, error) {
 	ec.Mutex.Lock()
 	defer ec.Mutex.Unlock()
 
-	tx, err := ec.AirDropper.AirdropToWallets(opts, recipients, amounts)
+	nonce, err := ec.Nonces.Next(opts.Context)
+	if err != nil {
+		return nil, multierror.Append(errNotBroadcasted, err)
+	}
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	// The transaction is only packed and signed by the contract binding, so that its errors can't be confused with the broadcasting ones.
+	opts.NoSend = true
+	tx, err := transact(opts)
+	if err != nil {
+		ec.Nonces.Reset()
+
+		return nil, multierror.Append(errNotBroadcasted, err)
+	}
+	if err = ec.sendTransaction(opts.Context, tx); err != nil {
+		ec.Nonces.Reset()
+
+		return nil, err
+	}
+	ec.Nonces.Commit()
+
+	return tx, nil
+}
+
+// A failed broadcast is errNotBroadcasted only if the node doesn't know the transaction and its nonce is still unused,
+// otherwise it's errMaybeBroadcasted, unless the transaction turns out to be known, in which case it's not a failure at all.
+func (ec *ethClientImpl) sendTransaction(ctx context.Context, tx *types.Transaction) error {
+	sendErr := ec.RPC.SendTransaction(ctx, tx)
+	if sendErr == nil {
+		return nil
+	}
+	// The checks must run even if the broadcast failed because of the context.
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), requestDeadline)
+	defer cancel()
+	if _, _, err := ec.RPC.TransactionByHash(checkCtx, tx.Hash()); err == nil {
+		log.Warn(fmt.Sprintf("transaction %v was broadcasted despite: %v", tx.Hash().String(), sendErr))
+
+		return nil
+	} else if !errors.Is(err, ethereum.NotFound) {
+		return multierror.Append(errMaybeBroadcasted, sendErr, errors.Wrapf(err, "failed to get transaction %v", tx.Hash().String()))
+	}
+	pendingNonce, err := ec.RPC.PendingNonceAt(checkCtx, ec.Signer.Address())
+	if err != nil {
+		return multierror.Append(errMaybeBroadcasted, sendErr, errors.Wrap(err, "failed to get the pending nonce"))
+	}
+	if pendingNonce <= tx.Nonce() {
+		return multierror.Append(errNotBroadcasted, sendErr)
+	}
+
+	return multierror.Append(errMaybeBroadcasted, sendErr, errors.Errorf("nonce %v is already used", tx.Nonce()))
+}
+
+func (ec *ethClientImpl) AirdropToWallets(opts *bind.TransactOpts, recipients []common.Address, amounts []*big.Int) (*types.Transaction, error) {
+	tx, err := ec.transactWithNextNonce(opts, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return ec.AirDropper.AirdropToWallets(opts, recipients, amounts) //nolint:wrapcheck // .
+	})
 	if err == nil && opts.Context.Err() == nil {
-		log.Info(fmt.Sprintf("airdropper: new transaction: %v | nonce %v | gas %v | cost %v | limit %v | recipients %v",
+		log.Info(fmt.Sprintf("airdropper: new transaction: %v | type %v | nonce %v | gas %v | tip %v | cost %v | limit %v | recipients %v",
 			tx.Hash().String(),
+			tx.Type(),
 			tx.Nonce(),
 			tx.GasPrice().String(),
+			tx.GasTipCap().String(),
 			tx.Cost().String(),
 			tx.Gas(),
 			len(recipients),
@@ -156,36 +251,357 @@ func (ec *ethClientImpl) AirdropToWallets(opts *bind.TransactOpts, recipients []
 	return tx, err //nolint:wrapcheck //.
 }
 
-func (ec *ethClientImpl) CreateTransactionOpts(ctx context.Context, gasPrice, chanID *big.Int, gasLimit uint64) *bind.TransactOpts {
-	opts, err := bind.NewKeyedTransactorWithChainID(ec.Key, chanID)
-	log.Panic(errors.Wrap(err, "failed to create transaction options")) //nolint:revive,nolintlint //.
-	opts.Context = ctx
-	opts.Value = big.NewInt(0)
-	opts.GasLimit = gasLimit
-	opts.GasPrice = gasPrice
+func (ec *ethClientImpl) CreateTransactionOpts(ctx context.Context, gas *gasOptions, chanID *big.Int) *bind.TransactOpts {
+	from := ec.Signer.Address()
+
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
 
-	return opts
+			return ec.Signer.SignTx(ctx, tx, chanID) //nolint:wrapcheck //.
+		},
+		Context:   ctx,
+		Value:     big.NewInt(0),
+		GasLimit:  gas.GasLimit,
+		GasPrice:  gas.GasPrice,
+		GasFeeCap: gas.GasFeeCap,
+		GasTipCap: gas.GasTipCap,
+	}
 }
 
-func (ec *ethClientImpl) Airdrop(ctx context.Context, chanID *big.Int, gas gasGetter, recipients []common.Address, amounts []*big.Int) (string, error) {
-	fn := func() (string, error) {
-		gasPrice, gasLimit, err := gas.GetGasOptions(ctx)
+func (ec *ethClientImpl) Airdrop(
+	ctx context.Context, chanID *big.Int, gas gasGetter, recipients []common.Address, amounts []*big.Int,
+) (*ethTransaction, error) {
+	fn := func() (*ethTransaction, error) {
+		gasOpts, err := gas.GetGasOptions(ctx)
 		if err != nil {
-			return "", errors.Wrap(err, "failed to get gas options")
+			return nil, multierror.Append(errNotBroadcasted, errors.Wrap(err, "failed to get gas options"))
 		}
 
-		opts := ec.CreateTransactionOpts(ctx, gasPrice, chanID, gasLimit)
+		opts := ec.CreateTransactionOpts(ctx, gasOpts, chanID)
 		tx, err := ec.AirdropToWallets(opts, recipients, amounts)
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+
+		return &ethTransaction{Hash: tx.Hash().String(), Nonce: tx.Nonce()}, nil
+	}
+
+	return maybeRetryRPCRequest(ctx, fn)
+}
+
+// EstimateAirdropGas simulates the airdrop (eth_estimateGas runs it like eth_call), without signing or broadcasting anything.
+func (ec *ethClientImpl) EstimateAirdropGas(ctx context.Context, recipients []common.Address, amounts []*big.Int) (uint64, error) {
+	parsed, err := coindistribution.CoindistributionMetaData.GetAbi()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse the contract ABI")
+	}
+	data, err := parsed.Pack("airdropToWallets", recipients, amounts)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to pack the airdrop call")
+	}
+	msg := ethereum.CallMsg{From: ec.Signer.Address(), To: &ec.Contract, Data: data}
+	var reverted error
+	gas, err := maybeRetryRPCRequest(ctx, func() (uint64, error) {
+		gas, eErr := ec.RPC.EstimateGas(ctx, msg)
+		if eErr != nil && isExecutionReverted(eErr) {
+			reverted = eErr
+
+			return 0, nil
+		}
+
+		return gas, eErr //nolint:wrapcheck //.
+	})
+	if reverted != nil {
+		return 0, multierror.Append(errAirdropWouldRevert, reverted)
+	}
+
+	return gas, err
+}
+
+// Transfers returns the Transfer events the contract emitted in the (mined) transaction.
+// The airdrops of our token are minted without emitting any event, so, if there are none,
+// the transfers are derived from the balance changes of the recipients in the transaction's block.
+// Those aren't scoped to the transaction (other transactions of the block count too), so they're reported as approximate.
+func (ec *ethClientImpl) Transfers( //nolint:funlen // .
+	ctx context.Context, hash string, recipients []common.Address,
+) (transfers []*erc20Transfer, approximate bool, err error) {
+	filterer, err := coindistribution.NewCoindistributionFilterer(ec.Contract, nil)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to create the contract filterer")
+	}
+	receipt, err := maybeRetryRPCRequest(ctx, func() (*types.Receipt, error) {
+		return ec.RPC.TransactionReceipt(ctx, common.HexToHash(hash)) //nolint:wrapcheck //.
+	})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to get the receipt of transaction %v", hash)
+	}
+	transferTopic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	transfers = make([]*erc20Transfer, 0, len(receipt.Logs))
+	for _, vLog := range receipt.Logs {
+		if vLog.Address != ec.Contract || len(vLog.Topics) == 0 || vLog.Topics[0] != transferTopic {
+			continue
+		}
+		event, pErr := filterer.ParseTransfer(*vLog)
+		if pErr != nil {
+			return nil, false, errors.Wrapf(pErr, "failed to parse the transfer log #%v of transaction %v", vLog.Index, hash)
+		}
+		transfers = append(transfers, &erc20Transfer{From: event.From, To: event.To, Value: event.Value})
+	}
+	if len(transfers) != 0 || ec.Token == nil {
+		return transfers, false, nil
+	}
+	before := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	for len(recipients) > 0 {
+		chunk := recipients[:min(rpcBatchSize/2, len(recipients))] //nolint:gomnd // Before and after.
+		recipients = recipients[len(chunk):]
+		deltas, bErr := ec.balanceDeltas(ctx, chunk, before, receipt.BlockNumber)
+		if bErr != nil {
+			return nil, false, errors.Wrapf(bErr, "failed to get the balance changes of %v recipients in transaction %v", len(chunk), hash)
+		}
+		for ix, recipient := range chunk {
+			if deltas[ix].Sign() != 0 {
+				transfers = append(transfers, &erc20Transfer{To: recipient, Value: deltas[ix]})
+			}
+		}
+	}
+
+	return transfers, true, nil
+}
+
+// The balances before and after are fetched with a single batch request, unless the RPC client doesn't support them.
+func (ec *ethClientImpl) balanceDeltas(ctx context.Context, accounts []common.Address, before, after *big.Int) ([]*big.Int, error) {
+	raw, ok := ec.RPC.(rawRPCProvider)
+	if !ok {
+		return ec.balanceDeltasOneByOne(ctx, accounts, before, after)
+	}
+	parsed, err := coindistribution.CoindistributionMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the contract ABI")
+	}
+	blocks := []string{hexutil.EncodeBig(before), hexutil.EncodeBig(after)}
+	results := make([]hexutil.Bytes, len(accounts)*len(blocks))
+	elements := make([]rpc.BatchElem, 0, len(results))
+	for _, account := range accounts {
+		data, pErr := parsed.Pack("balanceOf", account)
+		if pErr != nil {
+			return nil, errors.Wrapf(pErr, "failed to pack the balanceOf call of %v", account)
+		}
+		for _, block := range blocks {
+			elements = append(elements, rpc.BatchElem{
+				Method: "eth_call",
+				Args:   []any{map[string]any{"to": ec.Contract, "data": hexutil.Bytes(data)}, block},
+				Result: &results[len(elements)],
+			})
+		}
+	}
+	if _, err = maybeRetryRPCRequest(ctx, func() (bool, error) {
+		if bErr := raw.Client().BatchCallContext(ctx, elements); bErr != nil {
+			return false, bErr //nolint:wrapcheck // .
+		}
+		for ix := range elements {
+			if elements[ix].Error != nil {
+				return false, elements[ix].Error //nolint:wrapcheck // .
+			}
+		}
+
+		return true, nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to batch eth_call balanceOf")
+	}
+	deltas := make([]*big.Int, len(accounts))
+	for ix := range accounts {
+		balances := make([]*big.Int, 0, len(blocks))
+		for _, result := range results[ix*len(blocks) : (ix+1)*len(blocks)] {
+			out, uErr := parsed.Unpack("balanceOf", result)
+			if uErr != nil {
+				return nil, errors.Wrapf(uErr, "failed to unpack the balance of %v", accounts[ix])
+			}
+			balances = append(balances, abi.ConvertType(out[0], new(big.Int)).(*big.Int)) //nolint:forcetypeassert // As per the ABI.
+		}
+		deltas[ix] = balances[1].Sub(balances[1], balances[0])
+	}
+
+	return deltas, nil
+}
+
+func (ec *ethClientImpl) balanceDeltasOneByOne(ctx context.Context, accounts []common.Address, before, after *big.Int) ([]*big.Int, error) {
+	deltas := make([]*big.Int, 0, len(accounts))
+	for _, account := range accounts {
+		balances := make([]*big.Int, 0, 2) //nolint:gomnd // Before and after.
+		for _, block := range []*big.Int{before, after} {
+			balance, err := maybeRetryRPCRequest(ctx, func() (*big.Int, error) {
+				return ec.Token.BalanceOf(&bind.CallOpts{Context: ctx, BlockNumber: block}, account) //nolint:wrapcheck //.
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get the balance of %v at block %v", account, block)
+			}
+			balances = append(balances, balance)
+		}
+		deltas = append(deltas, balances[1].Sub(balances[1], balances[0]))
+	}
+
+	return deltas, nil
+}
+
+// TokenBalance is the balance of the distributer's account, the airdropped coins are taken from.
+func (ec *ethClientImpl) TokenBalance(ctx context.Context) (*big.Int, error) {
+	if ec.Token == nil {
+		return nil, errors.New("token contract is not configured")
+	}
+
+	return maybeRetryRPCRequest(ctx, func() (*big.Int, error) {
+		return ec.Token.BalanceOf(&bind.CallOpts{Context: ctx}, ec.Signer.Address()) //nolint:wrapcheck //.
+	})
+}
+
+func (ec *ethClientImpl) PublishMerkleRoot(ctx context.Context, chanID *big.Int, gas gasGetter, root common.Hash) (*ethTransaction, error) {
+	if ec.Claimer == nil {
+		return nil, errors.New("claim contract address is not configured")
+	}
+	fn := func() (*ethTransaction, error) {
+		gasOpts, err := gas.GetGasOptions(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get gas options")
 		}
 
-		return tx.Hash().String(), nil
+		opts := ec.CreateTransactionOpts(ctx, gasOpts, chanID)
+		tx, err := ec.transactWithNextNonce(opts, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return ec.Claimer.SetMerkleRoot(opts, root)
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Info(fmt.Sprintf("claimer: new transaction: %v | root %v | type %v | nonce %v | gas %v | tip %v | limit %v",
+			tx.Hash().String(),
+			root.String(),
+			tx.Type(),
+			tx.Nonce(),
+			tx.GasPrice().String(),
+			tx.GasTipCap().String(),
+			tx.Gas(),
+		))
+
+		return &ethTransaction{Hash: tx.Hash().String(), Nonce: tx.Nonce()}, nil
 	}
 
 	return maybeRetryRPCRequest(ctx, fn)
 }
 
+// SpeedUpTransaction rebroadcasts the transaction with the same nonce, payload and gas limit, but with bumped fees.
+func (ec *ethClientImpl) SpeedUpTransaction(ctx context.Context, chanID *big.Int, gas gasGetter, hash string) (*ethTransaction, error) {
+	return ec.replaceTransaction(ctx, chanID, gas, hash, false)
+}
+
+// CancelTransaction replaces the transaction with a zero-value transfer to ourselves, using the same nonce and bumped fees.
+func (ec *ethClientImpl) CancelTransaction(ctx context.Context, chanID *big.Int, gas gasGetter, hash string) (*ethTransaction, error) {
+	return ec.replaceTransaction(ctx, chanID, gas, hash, true)
+}
+
+//nolint:funlen // .
+func (ec *ethClientImpl) replaceTransaction(ctx context.Context, chanID *big.Int, gas gasGetter, hash string, cancel bool) (*ethTransaction, error) {
+	original, err := maybeRetryRPCRequest(ctx, func() (*types.Transaction, error) {
+		tx, _, tErr := ec.RPC.TransactionByHash(ctx, common.HexToHash(hash))
+		if errors.Is(tErr, ethereum.NotFound) {
+			return nil, nil //nolint:nilnil // Nothing to retry, it was dropped from the mempool.
+		}
+
+		return tx, tErr //nolint:wrapcheck //.
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transaction %v", hash)
+	} else if original == nil {
+		return nil, errors.Wrapf(ethereum.NotFound, "transaction %v", hash)
+	}
+
+	fn := func() (*ethTransaction, error) {
+		gasOpts, gErr := gas.GetGasOptions(ctx)
+		if gErr != nil {
+			return nil, errors.Wrap(gErr, "failed to get gas options")
+		}
+
+		to, value, data, gasLimit := original.To(), original.Value(), original.Data(), original.Gas()
+		if cancel {
+			self := ec.Signer.Address()
+			to, value, data, gasLimit = &self, big.NewInt(0), nil, params.TxGas
+		}
+		fees := bumpGasOptions(original, gasOpts)
+		var txData types.TxData
+		if fees.GasPrice != nil {
+			txData = &types.LegacyTx{Nonce: original.Nonce(), GasPrice: fees.GasPrice, Gas: gasLimit, To: to, Value: value, Data: data}
+		} else {
+			txData = &types.DynamicFeeTx{
+				ChainID:   chanID,
+				Nonce:     original.Nonce(),
+				GasTipCap: fees.GasTipCap,
+				GasFeeCap: fees.GasFeeCap,
+				Gas:       gasLimit,
+				To:        to,
+				Value:     value,
+				Data:      data,
+			}
+		}
+		tx, sErr := ec.Signer.SignTx(ctx, types.NewTx(txData), chanID)
+		if sErr != nil {
+			return nil, multierror.Append(errClientUncoverable, sErr)
+		}
+
+		ec.Mutex.Lock()
+		defer ec.Mutex.Unlock()
+		if sErr = ec.RPC.SendTransaction(ctx, tx); sErr != nil {
+			return nil, sErr //nolint:wrapcheck //.
+		}
+		log.Info(fmt.Sprintf("airdropper: replacement transaction: %v | replaces %v | cancel %v | type %v | nonce %v | gas %v | tip %v | limit %v",
+			tx.Hash().String(),
+			hash,
+			cancel,
+			tx.Type(),
+			tx.Nonce(),
+			tx.GasPrice().String(),
+			tx.GasTipCap().String(),
+			tx.Gas(),
+		))
+
+		return &ethTransaction{Hash: tx.Hash().String(), Nonce: tx.Nonce()}, nil
+	}
+
+	return maybeRetryRPCRequest(ctx, fn)
+}
+
+// The replacement must outbid the original, so it pays whichever is higher: the current market fees or the original ones bumped.
+// The caps configured in the `global` table are not applied, otherwise the replacement would be rejected as underpriced.
+func bumpGasOptions(original *types.Transaction, fresh *gasOptions) *gasOptions {
+	bump := func(fee *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBumpPercent)) //nolint:gomnd // .
+		bumped.Div(bumped, big.NewInt(100))                                        //nolint:gomnd // .
+
+		return bumped.Add(bumped, big.NewInt(1))
+	}
+	maxOf := func(a, b *big.Int) *big.Int {
+		if a.Cmp(b) >= 0 {
+			return new(big.Int).Set(a)
+		}
+
+		return new(big.Int).Set(b)
+	}
+	// For legacy transactions, both GasFeeCap() and GasTipCap() are the gas price.
+	if fresh.GasPrice != nil {
+		return &gasOptions{GasPrice: maxOf(fresh.GasPrice, bump(original.GasFeeCap()))}
+	}
+	bumped := &gasOptions{
+		GasFeeCap: maxOf(fresh.GasFeeCap, bump(original.GasFeeCap())),
+		GasTipCap: maxOf(fresh.GasTipCap, bump(original.GasTipCap())),
+	}
+	if bumped.GasTipCap.Cmp(bumped.GasFeeCap) > 0 {
+		bumped.GasFeeCap.Set(bumped.GasTipCap)
+	}
+
+	return bumped
+}
+
 func (ec *ethClientImpl) TransactionStatus(ctx context.Context, hash string) (ethTxStatus, error) {
 	return maybeRetryRPCRequest(ctx, func() (ethTxStatus, error) {
 		receipt, err := ec.RPC.TransactionReceipt(ctx, common.HexToHash(hash))
@@ -205,7 +621,22 @@ func (ec *ethClientImpl) TransactionStatus(ctx context.Context, hash string) (et
 	})
 }
 
+func (ec *ethClientImpl) TransactionGasUsed(ctx context.Context, hash string) (uint64, error) {
+	return maybeRetryRPCRequest(ctx, func() (uint64, error) {
+		receipt, err := ec.RPC.TransactionReceipt(ctx, common.HexToHash(hash))
+		if err != nil {
+			return 0, err //nolint:wrapcheck //.
+		}
+
+		return receipt.GasUsed, nil
+	})
+}
+
 func (ec *ethClientImpl) TransactionsStatus(ctx context.Context, hashes []*string) (statuses map[ethTxStatus][]string, err error) { //nolint:funlen //.
+	raw, ok := ec.RPC.(rawRPCProvider)
+	if !ok {
+		return ec.transactionsStatusOneByOne(ctx, hashes)
+	}
 	elements := make([]rpc.BatchElem, len(hashes)) //nolint:makezero //.
 	results := make([]*types.Receipt, len(hashes)) //nolint:makezero //.
 	for elementIdx := range elements {
@@ -217,7 +648,7 @@ func (ec *ethClientImpl) TransactionsStatus(ctx context.Context, hashes []*strin
 	}
 
 	if _, batchErr := maybeRetryRPCRequest(ctx, func() (bool, error) {
-		return true, ec.RPC.Client().BatchCallContext(ctx, elements) //nolint:wrapcheck //.
+		return true, raw.Client().BatchCallContext(ctx, elements) //nolint:wrapcheck //.
 	}); batchErr != nil {
 		return nil, batchErr
 	}
@@ -244,8 +675,25 @@ func (ec *ethClientImpl) TransactionsStatus(ctx context.Context, hashes []*strin
 	return statuses, err //nolint:wrapcheck //.
 }
 
+func (ec *ethClientImpl) transactionsStatusOneByOne(ctx context.Context, hashes []*string) (map[ethTxStatus][]string, error) {
+	statuses := make(map[ethTxStatus][]string)
+	for _, hash := range hashes {
+		status, err := ec.TransactionStatus(ctx, *hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the status of transaction %v", *hash)
+		}
+		if status != ethTxStatusPending {
+			statuses[status] = append(statuses[status], *hash)
+		}
+	}
+
+	return statuses, nil
+}
+
 func (ec *ethClientImpl) Close() error {
-	ec.RPC.Close()
+	if closer, ok := ec.RPC.(rpcCloser); ok {
+		closer.Close()
+	}
 
-	return nil
+	return errors.Wrap(ec.Signer.Close(), "failed to close the signer")
 }
@@ -12,20 +12,32 @@ import (
 	"syscall"
 	"testing"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/require"
 
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution/internal"
 	"github.com/ice-blockchain/wintr/log"
 )
 
+// The simulated backend always uses this chain ID.
+const simulatedChainID = 1337
+
 type (
 	mockedDummyEthClient struct {
-		dropErr error
-		txErr   map[string]error
-		gas     int64
+		dropErr      error
+		revertErr    error
+		txErr        map[string]error
+		balance      *big.Int
+		gas          int64
+		recipientGas uint64
+		gasUsed      uint64
 	}
 	mockedAirDropper struct {
 		errBefore int
@@ -33,6 +45,12 @@ type (
 	mockedGasGetter struct {
 		val int64
 	}
+	mockedEthRPC struct {
+		EthRPC
+		sendErr      error
+		known        bool
+		pendingNonce uint64
+	}
 )
 
 func (m *mockedDummyEthClient) SuggestGasPrice(context.Context) (*big.Int, error) {
@@ -45,12 +63,60 @@ func (m *mockedDummyEthClient) SuggestGasPrice(context.Context) (*big.Int, error
 	return big.NewInt(m.gas), nil
 }
 
-func (m *mockedDummyEthClient) Airdrop(context.Context, *big.Int, gasGetter, []common.Address, []*big.Int) (string, error) {
+func (*mockedDummyEthClient) FeeHistory(_ context.Context, blockCount uint64, _ *big.Int, _ []float64) (*ethereum.FeeHistory, error) {
+	history := &ethereum.FeeHistory{OldestBlock: big.NewInt(1)}
+	for block := uint64(0); block <= blockCount; block++ {
+		history.BaseFee = append(history.BaseFee, big.NewInt(rand.Int63n(10_000)+1)) //nolint:gosec //.
+		if block < blockCount {
+			history.Reward = append(history.Reward, []*big.Int{big.NewInt(rand.Int63n(1_000) + 1)}) //nolint:gosec //.
+		}
+	}
+
+	return history, nil
+}
+
+func (m *mockedDummyEthClient) Airdrop(context.Context, *big.Int, gasGetter, []common.Address, []*big.Int) (*ethTransaction, error) {
 	if m.dropErr != nil {
-		return "", m.dropErr
+		return nil, m.dropErr
+	}
+
+	return &ethTransaction{Hash: fmt.Sprintf("%10d", rand.Int63n(10_000_000_000)), Nonce: uint64(rand.Int63n(1_000))}, nil //nolint:gosec //.
+}
+
+func (*mockedDummyEthClient) SpeedUpTransaction(context.Context, *big.Int, gasGetter, string) (*ethTransaction, error) {
+	return &ethTransaction{Hash: fmt.Sprintf("%10d", rand.Int63n(10_000_000_000))}, nil //nolint:gosec //.
+}
+
+func (*mockedDummyEthClient) CancelTransaction(context.Context, *big.Int, gasGetter, string) (*ethTransaction, error) {
+	return &ethTransaction{Hash: fmt.Sprintf("%10d", rand.Int63n(10_000_000_000))}, nil //nolint:gosec //.
+}
+
+func (*mockedDummyEthClient) PublishMerkleRoot(context.Context, *big.Int, gasGetter, common.Hash) (*ethTransaction, error) {
+	return &ethTransaction{Hash: fmt.Sprintf("%10d", rand.Int63n(10_000_000_000))}, nil //nolint:gosec //.
+}
+
+func (m *mockedDummyEthClient) EstimateAirdropGas(_ context.Context, recipients []common.Address, _ []*big.Int) (uint64, error) {
+	if m.revertErr != nil {
+		return 0, multierror.Append(errAirdropWouldRevert, m.revertErr)
 	}
 
-	return fmt.Sprintf("%10d", rand.Int63n(10_000_000_000)), nil //nolint:gosec //.
+	return uint64(len(recipients)) * m.recipientGas, nil
+}
+
+func (*mockedDummyEthClient) Transfers(context.Context, string, []common.Address) ([]*erc20Transfer, bool, error) {
+	return nil, false, nil
+}
+
+func (m *mockedDummyEthClient) TokenBalance(context.Context) (*big.Int, error) {
+	if m.balance == nil {
+		return new(big.Int).Lsh(big.NewInt(1), 128), nil
+	}
+
+	return m.balance, nil
+}
+
+func (m *mockedDummyEthClient) TransactionGasUsed(context.Context, string) (uint64, error) {
+	return m.gasUsed, nil
 }
 
 func (*mockedDummyEthClient) Close() error {
@@ -91,12 +157,28 @@ func (m *mockedAirDropper) AirdropToWallets(opts *bind.TransactOpts, _ []common.
 		nil
 }
 
-func (m *mockedGasGetter) GetGasOptions(context.Context) (*big.Int, uint64, error) {
+func (m *mockedEthRPC) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return m.pendingNonce, nil
+}
+
+func (m *mockedEthRPC) SendTransaction(context.Context, *types.Transaction) error {
+	return m.sendErr
+}
+
+func (m *mockedEthRPC) TransactionByHash(context.Context, common.Hash) (*types.Transaction, bool, error) {
+	if !m.known {
+		return nil, false, ethereum.NotFound
+	}
+
+	return new(types.Transaction), true, nil
+}
+
+func (m *mockedGasGetter) GetGasOptions(context.Context) (*gasOptions, error) {
 	m.val++
 
 	log.Info(fmt.Sprintf("gas getter: %v", m.val))
 
-	return big.NewInt(m.val), uint64(m.val), nil
+	return &gasOptions{GasPrice: big.NewInt(m.val), GasLimit: uint64(m.val)}, nil
 }
 
 func TestGasPriceUpdateDuringRetry(t *testing.T) {
@@ -111,8 +193,10 @@ func TestGasPriceUpdateDuringRetry(t *testing.T) {
 
 	impl := new(ethClientImpl)
 	impl.Mutex = new(sync.Mutex)
-	impl.Key = privateKey
+	impl.Signer = newLocalSigner(privateKey)
 	impl.AirDropper = dropper
+	impl.RPC = new(mockedEthRPC)
+	impl.Nonces = newNonceManager(impl.RPC, common.Address{})
 	gasGetter := new(mockedGasGetter)
 
 	_, err = impl.Airdrop(context.TODO(), big.NewInt(1), gasGetter, []common.Address{{1}}, []*big.Int{big.NewInt(1)})
@@ -121,3 +205,199 @@ func TestGasPriceUpdateDuringRetry(t *testing.T) {
 	require.Zero(t, dropper.errBefore)
 	require.Equal(t, errCount+1, int(gasGetter.val))
 }
+
+func TestBumpGasOptions(t *testing.T) {
+	t.Parallel()
+
+	to := common.HexToAddress("095e7baea6a6c7c4c2dfeb977efac326af552d87")
+	legacy := types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(1_000), Gas: 21_000, To: &to})
+	dynamic := types.NewTx(&types.DynamicFeeTx{GasFeeCap: big.NewInt(1_000), GasTipCap: big.NewInt(100), Gas: 21_000, To: &to})
+
+	bumped := bumpGasOptions(legacy, &gasOptions{GasPrice: big.NewInt(500)})
+	require.Equal(t, big.NewInt(1_151), bumped.GasPrice)
+	require.Nil(t, bumped.GasFeeCap)
+	bumped = bumpGasOptions(legacy, &gasOptions{GasPrice: big.NewInt(2_000)})
+	require.Equal(t, big.NewInt(2_000), bumped.GasPrice)
+
+	bumped = bumpGasOptions(dynamic, &gasOptions{GasFeeCap: big.NewInt(500), GasTipCap: big.NewInt(200)})
+	require.Nil(t, bumped.GasPrice)
+	require.Equal(t, big.NewInt(1_151), bumped.GasFeeCap)
+	require.Equal(t, big.NewInt(200), bumped.GasTipCap)
+
+	// A legacy transaction replaced by a dynamic one must outbid its gas price with the tip as well.
+	bumped = bumpGasOptions(legacy, &gasOptions{GasFeeCap: big.NewInt(500), GasTipCap: big.NewInt(200)})
+	require.Equal(t, big.NewInt(1_151), bumped.GasTipCap)
+	require.Equal(t, big.NewInt(1_151), bumped.GasFeeCap)
+}
+
+type (
+	staticGasGetter   struct{}
+	failingAirDropper struct {
+		airDropper
+		errBefore int
+	}
+)
+
+func (*staticGasGetter) GetGasOptions(context.Context) (*gasOptions, error) {
+	return &gasOptions{GasFeeCap: big.NewInt(params.GWei * 100), GasTipCap: big.NewInt(params.GWei), GasLimit: 1_000_000}, nil
+}
+
+func (f *failingAirDropper) AirdropToWallets(opts *bind.TransactOpts, recipients []common.Address, amounts []*big.Int) (*types.Transaction, error) {
+	if f.errBefore > 0 {
+		f.errBefore--
+
+		return nil, &net.OpError{Err: syscall.ECONNRESET}
+	}
+
+	return f.airDropper.AirdropToWallets(opts, recipients, amounts) //nolint:wrapcheck // .
+}
+
+func newSimulatedEthClient(t *testing.T) (*ethClientImpl, *simulated.Backend) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sim := simulated.NewBackend(types.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1_000))},
+	})
+	t.Cleanup(func() { require.NoError(t, sim.Close()) })
+	// It's not a rawRPCProvider, so the statuses of the transactions are fetched one by one.
+	rpcClient := sim.Client()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(simulatedChainID))
+	require.NoError(t, err)
+	// Skips the gas estimation, which runs without the Shanghai rules (PUSH0) on the simulated backend.
+	opts.GasLimit = 10_000_000
+	contract, _, distributor, err := coindistribution.DeployCoindistribution(opts, rpcClient)
+	require.NoError(t, err)
+	sim.Commit()
+	client := newEthClient(rpcClient, distributor, newLocalSigner(key))
+	client.Contract = contract
+
+	return client, sim
+}
+
+func TestEthClientConcurrentAirdropsSimulated(t *testing.T) {
+	t.Parallel()
+
+	const batches = 5
+
+	ec, sim := newSimulatedEthClient(t)
+	ctx := context.Background()
+
+	wg := new(sync.WaitGroup)
+	txs, errs := make([]*ethTransaction, batches), make([]error, batches)
+	for ix := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			txs[ix], errs[ix] = ec.Airdrop(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), []common.Address{{byte(ix + 1)}}, []*big.Int{big.NewInt(1)})
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	nonces := make(map[uint64]struct{}, batches)
+	hashes := make([]*string, 0, batches)
+	for _, tx := range txs {
+		nonces[tx.Nonce] = struct{}{}
+		hashes = append(hashes, &tx.Hash)
+	}
+	for nonce := uint64(1); nonce <= batches; nonce++ {
+		require.Contains(t, nonces, nonce)
+	}
+
+	sim.Commit()
+	statuses, err := ec.TransactionsStatus(ctx, hashes)
+	require.NoError(t, err)
+	require.Len(t, statuses[ethTxStatusSuccessful], batches)
+}
+
+func TestEthClientNonceResyncAfterFailureSimulated(t *testing.T) {
+	t.Parallel()
+
+	ec, sim := newSimulatedEthClient(t)
+	ctx := context.Background()
+
+	tx1, err := ec.Airdrop(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), []common.Address{{1}}, []*big.Int{big.NewInt(1)})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tx1.Nonce)
+
+	// The nonce is not burned by the failed attempt, the retry gets the very same one.
+	ec.AirDropper = &failingAirDropper{airDropper: ec.AirDropper, errBefore: 1}
+	ec.Nonces.next = new(uint64)
+	*ec.Nonces.next = 100
+	tx2, err := ec.Airdrop(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), []common.Address{{2}}, []*big.Int{big.NewInt(1)})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), tx2.Nonce)
+
+	sim.Commit()
+	status, err := ec.TransactionStatus(ctx, tx2.Hash)
+	require.NoError(t, err)
+	require.Equal(t, ethTxStatusSuccessful, status)
+}
+
+func TestEthClientSpeedUpAndCancelSimulated(t *testing.T) {
+	t.Parallel()
+
+	ec, sim := newSimulatedEthClient(t)
+	ctx := context.Background()
+
+	tx, err := ec.Airdrop(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), []common.Address{{1}}, []*big.Int{big.NewInt(1)})
+	require.NoError(t, err)
+	spedUp, err := ec.SpeedUpTransaction(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), tx.Hash)
+	require.NoError(t, err)
+	require.Equal(t, tx.Nonce, spedUp.Nonce)
+	require.NotEqual(t, tx.Hash, spedUp.Hash)
+
+	sim.Commit()
+	statuses, err := ec.TransactionsStatus(ctx, []*string{&tx.Hash, &spedUp.Hash})
+	require.NoError(t, err)
+	require.Equal(t, []string{spedUp.Hash}, statuses[ethTxStatusSuccessful])
+
+	tx, err = ec.Airdrop(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), []common.Address{{2}}, []*big.Int{big.NewInt(1)})
+	require.NoError(t, err)
+	cancel, err := ec.CancelTransaction(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), tx.Hash)
+	require.NoError(t, err)
+	require.Equal(t, tx.Nonce, cancel.Nonce)
+
+	sim.Commit()
+	statuses, err = ec.TransactionsStatus(ctx, []*string{&tx.Hash, &cancel.Hash})
+	require.NoError(t, err)
+	require.Equal(t, []string{cancel.Hash}, statuses[ethTxStatusSuccessful])
+}
+
+func TestEthClientSendTransactionErrors(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := types.NewTransaction(5, common.Address{1}, big.NewInt(0), 21_000, big.NewInt(1), nil)
+	sendErr := &net.OpError{Err: syscall.ECONNRESET}
+	for _, tc := range []struct {
+		rpc      *mockedEthRPC
+		expected error
+	}{
+		{rpc: &mockedEthRPC{sendErr: sendErr, pendingNonce: 5}, expected: errNotBroadcasted},
+		{rpc: &mockedEthRPC{sendErr: sendErr, pendingNonce: 6}, expected: errMaybeBroadcasted},
+		{rpc: &mockedEthRPC{sendErr: sendErr, known: true}},
+		{rpc: &mockedEthRPC{}},
+	} {
+		ec := newEthClient(tc.rpc, new(mockedAirDropper), newLocalSigner(key))
+		err = ec.sendTransaction(context.Background(), tx)
+		if tc.expected == nil {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, tc.expected)
+		}
+	}
+
+	// The packing/signing errors happen before broadcasting anything.
+	ec := newEthClient(new(mockedEthRPC), new(mockedAirDropper), newLocalSigner(key))
+	_, err = ec.transactWithNextNonce(&bind.TransactOpts{Context: context.Background()}, func(*bind.TransactOpts) (*types.Transaction, error) {
+		return nil, bind.ErrNotAuthorized
+	})
+	require.ErrorIs(t, err, errNotBroadcasted)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	stdlibtime "time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/constraints"
@@ -30,6 +31,25 @@ func (d *databaseConfig) MustDisable(reason string) {
 		"failed to sendCoinDistributionsProcessingStoppedDueToUnrecoverableFailureSlackMessage")
 }
 
+func retryDatabaseRequest(ctx context.Context, op func() error) error {
+	//nolint:wrapcheck // No need, its just a proxy.
+	return backoff.RetryNotify(
+		op,
+		//nolint:gomnd // Because those are static configs.
+		backoff.WithContext(&backoff.ExponentialBackOff{
+			InitialInterval:     100 * stdlibtime.Millisecond,
+			RandomizationFactor: 0.5,
+			Multiplier:          2.5,
+			MaxInterval:         5 * stdlibtime.Second,
+			MaxElapsedTime:      requestDeadline,
+			Stop:                backoff.Stop,
+			Clock:               backoff.SystemClock,
+		}, ctx),
+		func(e error, next stdlibtime.Duration) {
+			log.Error(errors.Wrapf(e, "database call failed. retrying in %v... ", next))
+		})
+}
+
 func databaseSetValue[T bool | constraints.Integer | *time.Time](ctx context.Context, db storage.Execer, key string, value T) error {
 	var textValue string
 
@@ -55,7 +75,7 @@ func databaseSetValue[T bool | constraints.Integer | *time.Time](ctx context.Con
 	return errors.Wrapf(err, "failed to set %v to %q", key, textValue)
 }
 
-func databaseGetValue[T bool | constraints.Integer | time.Time](ctx context.Context, db storage.Querier, key string, value *T) error {
+func databaseGetValue[T bool | constraints.Integer | constraints.Float | time.Time](ctx context.Context, db storage.Querier, key string, value *T) error {
 	var hint string
 
 	if value == nil {
@@ -72,6 +92,8 @@ func databaseGetValue[T bool | constraints.Integer | time.Time](ctx context.Cont
 		hint = "bigint"
 	case *uint, *uint8, *uint16, *uint32, *uint64:
 		hint = "bigint"
+	case *float32, *float64:
+		hint = "numeric"
 	case *time.Time:
 		hint = "timestamp with time zone"
 	default:
@@ -99,6 +121,67 @@ func (d *databaseConfig) GetGasPriceOverride(ctx context.Context) (val uint64, e
 	return val, err
 }
 
+func (d *databaseConfig) IsLegacyGasPriceEnabled(ctx context.Context) (val bool, err error) {
+	err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerLegacyGas, &val)
+
+	return val, err
+}
+
+func (d *databaseConfig) GetMaxFeePerGasCap(ctx context.Context) (val uint64, err error) {
+	err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerMaxFee, &val)
+
+	return val, err
+}
+
+func (d *databaseConfig) GetMaxPriorityFeePerGasCap(ctx context.Context) (val uint64, err error) {
+	err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerMaxTip, &val)
+
+	return val, err
+}
+
+func (d *databaseConfig) IsCancelPendingTransactionRequested(ctx context.Context) (val bool, err error) {
+	err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerCancelTX, &val)
+
+	return val, err
+}
+
+func (d *databaseConfig) ResetCancelPendingTransaction(ctx context.Context) error {
+	return databaseSetValue(ctx, d.DB, configKeyCoinDistributerCancelTX, false)
+}
+
+func (d *databaseConfig) IsMerkleClaimModeEnabled(ctx context.Context) (val bool, err error) {
+	err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerClaimMode, &val)
+
+	return val, err
+}
+
+func (d *databaseConfig) IsPreflightEnabled(ctx context.Context) (val bool, err error) {
+	err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerPreflight, &val)
+
+	return val, err
+}
+
+// The bounds that aren't set default to [1, batchSize].
+func (d *databaseConfig) GetBatchSizeBounds(ctx context.Context) (minSize, maxSize uint64, err error) {
+	if err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerBatchMin, &minSize); err != nil {
+		return 0, 0, err
+	}
+	if err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerBatchMax, &maxSize); err != nil {
+		return 0, 0, err
+	}
+	if maxSize == 0 {
+		maxSize = batchSize
+	}
+
+	return min(max(minSize, 1), maxSize), maxSize, nil
+}
+
+func (d *databaseConfig) GetBatchGasSafetyMarginPercent(ctx context.Context) (val uint64, err error) {
+	err = databaseGetValue(ctx, d.DB, configKeyCoinDistributerBatchMargin, &val)
+
+	return val, err
+}
+
 func (d *databaseConfig) IsEnabled(ctx context.Context) (val bool) {
 	log.Error(errors.Wrap(databaseGetValue(ctx, d.DB, configKeyCoinDistributerEnabled, &val), "failed to databaseGetValue"))
 
@@ -142,7 +225,7 @@ func init() {
 
 func MustStartCoinDistribution(ctx context.Context, _ context.CancelFunc) Client {
 	cfg.EnsureValid()
-	eth := mustNewEthClient(ctx, cfg.Ethereum.RPC, cfg.Ethereum.PrivateKey, cfg.Ethereum.ContractAddress)
+	eth := mustNewEthClient(ctx, cfg.Ethereum.RPC, mustNewEthSigner(ctx, &cfg), cfg.Ethereum.ContractAddress, cfg.Ethereum.ClaimContractAddress)
 
 	cd := mustCreateCoinDistributionFromConfig(ctx, &cfg, eth)
 	cd.MustStart(ctx, nil)
@@ -37,16 +37,16 @@ func TestFullCoinDistribution(t *testing.T) { //nolint:paralleltest,funlen //.
 		t.Skip("skip full coin distribution test")
 	}
 
-	cl := mustNewEthClient(context.TODO(), rpc, privateKey, contractAddr)
-	require.NotNil(t, cl)
-	defer cl.Close()
-
 	conf := new(config)
 	conf.Ethereum.ContractAddress = contractAddr
 	conf.Ethereum.ChainID = 97
 	conf.Ethereum.RPC = rpc
 	conf.Ethereum.PrivateKey = privateKey
 
+	cl := mustNewEthClient(context.TODO(), rpc, mustNewEthSigner(context.TODO(), conf), contractAddr, "")
+	require.NotNil(t, cl)
+	defer cl.Close()
+
 	t.Run("AddPendingEntry", func(t *testing.T) {
 		db := storage.MustConnect(context.TODO(), ddl, applicationYamlKey)
 		defer db.Close()
@@ -3,6 +3,7 @@
 package coindistribution
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 
@@ -16,11 +17,27 @@ func (cfg *config) EnsureValid() {
 	if cfg.Ethereum.RPC == "" {
 		log.Panic("ethereum.rpc must not be empty")
 	}
-	if cfg.Ethereum.PrivateKey == "" {
-		log.Panic("ethereum.privateKey must not be empty")
+	switch cfg.Ethereum.Signer.Type {
+	case "", privateKeyEthSignerType:
+		if cfg.Ethereum.PrivateKey == "" {
+			log.Panic("ethereum.privateKey must not be empty")
+		}
+		_, err := crypto.HexToECDSA(cfg.Ethereum.PrivateKey)
+		log.Panic(errors.Wrap(err, "ethereum.privateKey is invalid")) //nolint:revive,nolintlint //.
+	case keystoreEthSignerType:
+		if cfg.Ethereum.Signer.KeystoreFile == "" {
+			log.Panic("ethereum.signer.keystoreFile must not be empty")
+		}
+	case remoteEthSignerType:
+		if cfg.Ethereum.Signer.URL == "" {
+			log.Panic("ethereum.signer.url must not be empty")
+		}
+		if cfg.Ethereum.Signer.Account != "" && !common.IsHexAddress(cfg.Ethereum.Signer.Account) {
+			log.Panic("ethereum.signer.account is invalid")
+		}
+	default:
+		log.Panic("ethereum.signer.type must be one of `private-key`, `keystore` or `remote`")
 	}
-	_, err := crypto.HexToECDSA(cfg.Ethereum.PrivateKey)
-	log.Panic(errors.Wrap(err, "ethereum.privateKey is invalid")) //nolint:revive,nolintlint //.
 
 	if cfg.Ethereum.ContractAddress == "" {
 		log.Panic("ethereum.contractAddress must not be empty")
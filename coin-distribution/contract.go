@@ -12,17 +12,59 @@ import (
 	"sync"
 	stdlibtime "time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ice-blockchain/freezer/model"
 	"github.com/ice-blockchain/wintr/connectors/storage/v2"
 	"github.com/ice-blockchain/wintr/time"
 )
 
 // Public API.
 
+const (
+	PendingReviewExportSource = "pending-review"
+	ReviewedExportSource      = "reviewed"
+	PendingExportSource       = "pending"
+
+	CSVExportFormat    = "csv"
+	NDJSONExportFormat = "ndjson"
+)
+
+const (
+	// SkipEthereumAddressValidation can be used instead of the eth address, when it doesn't matter for the eligibility.
+	SkipEthereumAddressValidation = "skip"
+)
+
+const (
+	PushDistributionMode        = "push"
+	MerkleClaimDistributionMode = "merkle-claim"
+)
+
+// The anomaly flags of the coin distributions pending review, to help the reviewers spot the suspicious ones.
+const (
+	// The amount is above the user's average of the previous cycles, multiplied by the configured factor.
+	AmountAboveAverageAnomalyFlag = "amount-above-average"
+	// The eth address is used by at least the configured number of users.
+	SharedEthAddressAnomalyFlag = "shared-eth-address"
+	// The eth address is different from the one of the user's previous cycle.
+	EthAddressChangedAnomalyFlag = "eth-address-changed"
+	// A single referral earned more than the configured percent of the amount.
+	ReferralConcentrationAnomalyFlag = "referral-concentration"
+	// The user wasn't verified (KYC) in the previous cycle.
+	NewlyVerifiedAnomalyFlag = "newly-verified"
+)
+
+var (
+	ErrNoPendingTransaction   = errors.New("no pending transaction")
+	ErrReviewSnapshotMismatch = errors.New("review snapshot mismatch")
+	ErrTransactionFailed      = errors.New("transaction failed")
+)
+
 type (
 	Client interface {
 		io.Closer
@@ -33,12 +75,31 @@ type (
 		io.Closer
 		GetCoinDistributionsForReview(ctx context.Context, arg *GetCoinDistributionsForReviewArg) (*CoinDistributionsForReview, error)
 		CheckHealth(ctx context.Context) error
-		ReviewCoinDistributions(ctx context.Context, reviewerUserID string, decision string) error
+		ReviewCoinDistributions(ctx context.Context, reviewerUserID string, arg *ReviewCoinDistributionsArg) (*ReviewResult, error)
+		GetClaimProofs(ctx context.Context, userID string) ([]*ClaimProof, error)
+		GetCoinDistributionHistory(ctx context.Context, userID string, limit, offset uint64) ([]*CoinDistributionHistory, error)
+		ExportCoinDistributions(ctx context.Context, arg *ExportCoinDistributionsArg, w io.Writer) error
+		CancelPendingCoinDistributionTransaction(ctx context.Context, adminUserID string) error
+		GetHeldCoinDistributions(ctx context.Context, arg *GetHeldCoinDistributionsArg) (*HeldCoinDistributions, error)
+		ReleaseHeldCoinDistributions(ctx context.Context, adminUserID string, arg *ReleaseHeldCoinDistributionsArg) (*ReleasedCoinDistributions, error)
 		NotifyCoinDistributionCollectionCycleEnded(ctx context.Context) error
 		GetCollectorSettings(ctx context.Context) (*CollectorSettings, error)
 		CollectCoinDistributionsForReview(ctx context.Context, records []*ByEarnerForReview) error
 		StartPrepareCoinDistributionsForReviewMonitor(ctx context.Context)
 	}
+	// EthRPC is the part of the ethereum node API the eth client uses. It's implemented by *ethclient.Client and by the simulated backends.
+	EthRPC interface {
+		bind.ContractBackend
+		ethereum.TransactionReader
+		ethereum.FeeHistoryReader
+	}
+	// Transactor sends transactions to any contract, the same way the airdrops are sent:
+	// the nonces are managed locally, the fees are EIP-1559 ones (or legacy, if configured) and the recoverable RPC errors are retried.
+	Transactor struct {
+		client  *ethClientImpl
+		gas     gasGetter
+		chainID *big.Int
+	}
 	CollectorSettings struct {
 		DeniedCountries          map[string]struct{}
 		LatestDate               *time.Time
@@ -52,10 +113,13 @@ type (
 	}
 
 	CoinDistributionsForReview struct {
-		Distributions []*PendingReview `json:"distributions"`
-		Cursor        uint64           `json:"cursor" example:"5065"`
-		TotalRows     uint64           `json:"totalRows" example:"5065"`
-		TotalIce      float64          `json:"totalIce" example:"5065.3"`
+		// The number of the selected coin distributions that have each flag.
+		FlagCounts    map[string]uint64 `json:"flagCounts"`
+		Distributions []*PendingReview  `json:"distributions"`
+		Cursor        uint64            `json:"cursor" example:"5065"`
+		TotalRows     uint64            `json:"totalRows" example:"5065"`
+		TotalIce      float64           `json:"totalIce" example:"5065.3"`
+		Snapshot      string            `json:"snapshot" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
 	}
 
 	GetCoinDistributionsForReviewArg struct {
@@ -63,10 +127,53 @@ type (
 		IceOrderBy                string `form:"iceOrderBy" example:"asc"`
 		UsernameOrderBy           string `form:"usernameOrderBy" example:"asc"`
 		ReferredByUsernameOrderBy string `form:"referredByUsernameOrderBy" example:"asc"`
+		// Orders by the number of flags.
+		FlagsOrderBy              string `form:"flagsOrderBy" example:"desc"`
 		UsernameKeyword           string `form:"usernameKeyword" example:"jdoe"`
 		ReferredByUsernameKeyword string `form:"referredByUsernameKeyword" example:"jdoe"`
-		Cursor                    uint64 `form:"cursor" example:"5065"`
-		Limit                     uint64 `form:"limit" example:"5000"`
+		// Selects the ones that have all of these flags.
+		Flags  []string `form:"flags" example:"shared-eth-address"`
+		Cursor uint64   `form:"cursor" example:"5065"`
+		Limit  uint64   `form:"limit" example:"5000"`
+		MinIce float64  `form:"minIce" example:"10"`
+		MaxIce float64  `form:"maxIce" example:"1000"`
+	}
+
+	// ReviewCoinDistributionsArg applies the decision only to the coin distributions pending review that match the selection:
+	// the explicit user IDs or the same filters as GetCoinDistributionsForReviewArg. Without any selection, it applies to all of them.
+	ReviewCoinDistributionsArg struct {
+		Decision                  string   `form:"decision" required:"true" swaggerignore:"true" enums:"approve,approve-and-process-immediately,deny"`
+		Mode                      string   `form:"mode" swaggerignore:"true" enums:"push,merkle-claim"`
+		Reason                    string   `form:"reason" swaggerignore:"true" example:"suspicious referral chain"`
+		UsernameKeyword           string   `form:"usernameKeyword" swaggerignore:"true" example:"jdoe"`
+		ReferredByUsernameKeyword string   `form:"referredByUsernameKeyword" swaggerignore:"true" example:"jdoe"`
+		UserIDs                   []string `form:"userIds" swaggerignore:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		Flags                     []string `form:"flags" swaggerignore:"true" example:"shared-eth-address"`
+		MinIce                    float64  `form:"minIce" swaggerignore:"true" example:"10"`
+		MaxIce                    float64  `form:"maxIce" swaggerignore:"true" example:"1000"`
+		// Optional, if provided the review is rejected if the selected coin distributions are no longer the ones the snapshot was taken for.
+		Snapshot string `form:"snapshot" swaggerignore:"true" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	}
+
+	// ExportCoinDistributionsArg selects the coin distributions to export. The date range is inclusive and applies to `day`,
+	// or to `review_day` for the reviewed ones. The username filters are not supported for the pending ones.
+	ExportCoinDistributionsArg struct {
+		Source                    string  `form:"source" required:"true" swaggerignore:"true" enums:"pending-review,reviewed,pending"`
+		Format                    string  `form:"format" swaggerignore:"true" enums:"csv,ndjson"`
+		UsernameKeyword           string  `form:"usernameKeyword" swaggerignore:"true" example:"jdoe"`
+		ReferredByUsernameKeyword string  `form:"referredByUsernameKeyword" swaggerignore:"true" example:"jdoe"`
+		From                      string  `form:"from" swaggerignore:"true" example:"2024-01-01"`
+		To                        string  `form:"to" swaggerignore:"true" example:"2024-01-31"`
+		MinIce                    float64 `form:"minIce" swaggerignore:"true" example:"10"`
+		MaxIce                    float64 `form:"maxIce" swaggerignore:"true" example:"1000"`
+	}
+
+	// ReviewResult tells how far the approval of the snapshot is from the quorum. Denials are applied immediately.
+	ReviewResult struct {
+		Snapshot  string `json:"snapshot,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+		Approvals uint64 `json:"approvals" example:"1"`
+		Quorum    uint64 `json:"quorum" example:"2"`
+		Executed  bool   `json:"executed" example:"false"`
 	}
 
 	PendingReview struct {
@@ -76,11 +183,75 @@ type (
 		ReferredByUsername string     `json:"referredByUsername" swaggertype:"string" example:"myrefusername"`
 		UserID             string     `json:"userId" swaggertype:"string" example:"12746386-03de-44d7-91c7-856fa66b6ed6"`
 		EthAddress         string     `json:"ethAddress" swaggertype:"string" example:"0x43...."`
+		Flags              []string   `json:"flags" example:"shared-eth-address"`
 		Ice                float64    `json:"ice" db:"-" example:"1000"`
 		IceInternal        int64      `json:"-" db:"ice" swaggerignore:"true"`
 		Verified           bool       `json:"verified" db:"verified" swaggerignore:"true"`
 	}
 
+	GetHeldCoinDistributionsArg struct {
+		Cursor uint64 `form:"cursor" example:"5065"`
+		Limit  uint64 `form:"limit" example:"5000"`
+	}
+
+	HeldCoinDistributions struct {
+		Distributions []*HeldCoinDistribution `json:"distributions"`
+		Cursor        uint64                  `json:"cursor" example:"5065"`
+		TotalRows     uint64                  `json:"totalRows" example:"5065"`
+		TotalIce      float64                 `json:"totalIce" example:"5065.3"`
+	}
+
+	// HeldCoinDistribution is a coin distribution that breached one of the caps, it's out of the review until an admin releases it.
+	HeldCoinDistribution struct {
+		*PendingReview
+		HeldAt *time.Time `json:"heldAt" swaggertype:"string" example:"2022-01-03T16:20:52.156534Z"`
+		Day    string     `json:"day" example:"2022-01-03"`
+		Reason string     `json:"reason" example:"user total exceeds the per-user cap of 100000 ICE"`
+	}
+
+	// ReleaseHeldCoinDistributionsArg releases the held coin distributions of the user IDs, or all of them, if All is set.
+	ReleaseHeldCoinDistributionsArg struct {
+		Reason  string   `form:"reason" required:"true" swaggerignore:"true" example:"the referral campaign was expected"`
+		UserIDs []string `form:"userIds" swaggerignore:"true" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		All     bool     `form:"all" swaggerignore:"true" example:"false"`
+	}
+
+	// Conflicts are the held coin distributions that were not released, because there's one pending review for the same day and user.
+	ReleasedCoinDistributions struct {
+		Rows      uint64  `json:"rows" example:"5065"`
+		Ice       float64 `json:"ice" example:"5065.3"`
+		Conflicts uint64  `json:"conflicts" example:"2"`
+	}
+
+	// CoinDistributionHistory is a distribution cycle of the user, from the collection, through the review, up to the delivery on chain.
+	CoinDistributionHistory struct {
+		ReviewedAt *time.Time `json:"reviewedAt,omitempty" swaggertype:"string" example:"2022-01-03T16:20:52.156534Z"`
+		Day        string     `json:"day" example:"2022-01-03"`
+		Stage      string     `json:"stage" example:"reviewed" enums:"collecting,held,pending-review,reviewed"`
+		EthAddress string     `json:"ethAddress" example:"0x43...."`
+		// Only for the reviewed ones.
+		Decision string `json:"decision,omitempty" example:"approve" enums:"approve,approve-and-process-immediately,deny,deny due to incomplete data,deny due to invalid eth address"`
+		// Only for the approved ones. Once the transaction is confirmed, it stays ACCEPTED.
+		EthStatus     string                          `json:"ethStatus,omitempty" example:"ACCEPTED" enums:"NEW,PENDING,ACCEPTED,REJECTED"`
+		EthTX         string                          `json:"ethTx,omitempty" example:"0x43...."`
+		Contributions []*CoinDistributionContribution `json:"contributions"`
+		Ice           float64                         `json:"ice" example:"1000"`
+	}
+
+	// CoinDistributionContribution is the portion of a distribution cycle earned from a referral, or by the user itself.
+	CoinDistributionContribution struct {
+		EarnerUserID string  `json:"earnerUserId" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		Ice          float64 `json:"ice" example:"100"`
+	}
+
+	ClaimProof struct {
+		CreatedAt  *time.Time `json:"createdAt" swaggertype:"string" example:"2022-01-03T16:20:52.156534Z"`
+		MerkleRoot string     `json:"merkleRoot" example:"0x3f2b2a1e4bd6e5d0c7a8b7f7b0c3e4a8c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6"`
+		EthAddress string     `json:"ethAddress" example:"0x43...."`
+		Iceflakes  string     `json:"iceflakes" example:"100000000000000"`
+		Proof      []string   `json:"proof" example:"0x9e1c4b2d5a6f7e8d9c0b1a2f3e4d5c6b7a8f9e0d1c2b3a4f5e6d7c8b9a0f1e2d"`
+	}
+
 	ByEarnerForReview struct {
 		CreatedAt          *time.Time
 		Username           string
@@ -89,7 +260,7 @@ type (
 		EarnerUserID       string
 		EthAddress         string
 		InternalID         int64
-		Balance            float64
+		Balance            model.Amount
 		Verified           bool
 	}
 )
@@ -100,10 +271,50 @@ const (
 	applicationYamlKey = "coin-distribution"
 	requestDeadline    = 25 * stdlibtime.Second
 
-	batchSize = 700
+	// The max batch size, if none is configured. The sizes are learned from the gas used by the last batchSizeReceipts airdrops.
+	batchSize         = 700
+	batchSizeReceipts = 10
+	// The mainnet reward pool contribution is collected under this internal ID, see prepare_coin_distributions_for_review.
+	rewardPoolInternalID = 999999999
+
+	missingReconciliationDiscrepancy    = "missing"
+	extraReconciliationDiscrepancy      = "extra"
+	mismatchedReconciliationDiscrepancy = "mismatched"
+	unverifiedReconciliationDiscrepancy = "unverified"
+
+	reconciledReconciliationStatus  = "RECONCILED"
+	approximateReconciliationStatus = "APPROXIMATE"
+	failedReconciliationStatus      = "FAILED"
+
+	invalidEthAddressDecision                = "deny due to invalid eth address"
+	invalidFormatEthAddressRejectionReason   = "invalid format"
+	invalidChecksumEthAddressRejectionReason = "invalid EIP-55 checksum"
+	zeroEthAddressRejectionReason            = "zero address"
+	burnEthAddressRejectionReason            = "burn address"
+	contractEthAddressRejectionReason        = "contract address"
+	deniedEthAddressRejectionReason          = "deny-listed"
+
+	privateKeyEthSignerType = "private-key"
+	keystoreEthSignerType   = "keystore"
+	remoteEthSignerType     = "remote"
+	// All the approved distributions of a cycle are claimable under a single merkle root.
+	claimCycleMaxRecords = 1_000_000
+	claimProofsChunkSize = 10_000
+	// How many calls (eth_getCode, eth_call) are sent in a single batch request.
+	rpcBatchSize = 500
+	// The claim contract is expected to let the distributer publish the root the users claim against.
+	claimContractABI = `[{"inputs":[{"internalType":"bytes32","name":"merkleRoot","type":"bytes32"}],"name":"setMerkleRoot","outputs":[],"stateMutability":"nonpayable","type":"function"}]` //nolint:lll // .
 
 	gasPriceCacheTTL = stdlibtime.Minute
 
+	receiptPollInterval = 2 * stdlibtime.Second
+
+	feeHistoryBlocks               = 20
+	feeHistoryRewardPercentile     = 50
+	defaultMaxPriorityFeePerGasWei = 1_000_000_000
+	// Nodes reject replacements (same nonce) that don't raise both the fee cap and the tip by at least 10%.
+	replacementFeeBumpPercent = 15
+
 	workerActionRun      workerAction = 0
 	workerActionBlocked  workerAction = 1
 	workerActionDisabled workerAction = 2
@@ -117,16 +328,33 @@ const (
 	ethTxStatusSuccessful ethTxStatus = "SUCCESSFUL"
 	ethTxStatusFailed     ethTxStatus = "FAILED"
 	ethTxStatusPending    ethTxStatus = "PENDING"
+	ethTxStatusCancelled  ethTxStatus = "CANCELLED"
 
-	configKeyCoinDistributerEnabled     = "coin_distributer_enabled"
-	configKeyCoinDistributerOnDemand    = "coin_distributer_forced_execution"
-	configKeyCoinDistributerGasLimit    = "coin_distributer_gas_limit_units"
-	configKeyCoinDistributerGasPrice    = "coin_distributer_gas_price_override"
-	configKeyCoinDistributerMsgOnline   = "coin_distributer_msg_sent_online_date"
-	configKeyCoinDistributerMsgOffline  = "coin_distributer_msg_sent_offline_date"
-	configKeyCoinDistributerMsgFinished = "coin_distributer_msg_sent_finished_date"
+	configKeyCoinDistributerEnabled      = "coin_distributer_enabled"
+	configKeyCoinDistributerOnDemand     = "coin_distributer_forced_execution"
+	configKeyCoinDistributerGasLimit     = "coin_distributer_gas_limit_units"
+	configKeyCoinDistributerGasPrice     = "coin_distributer_gas_price_override"
+	configKeyCoinDistributerLegacyGas    = "coin_distributer_legacy_gas_price_enabled"
+	configKeyCoinDistributerMaxFee       = "coin_distributer_max_fee_per_gas_cap"
+	configKeyCoinDistributerMaxTip       = "coin_distributer_max_priority_fee_per_gas_cap"
+	configKeyCoinDistributerCancelTX     = "coin_distributer_cancel_pending_transaction"
+	configKeyCoinDistributerClaimMode    = "coin_distributer_merkle_claim_mode_enabled"
+	configKeyCoinDistributerDenyList     = "coin_distributer_eth_address_deny_list"
+	configKeyCoinDistributerPreflight    = "coin_distributer_preflight_enabled"
+	configKeyCoinDistributerBatchMin     = "coin_distributer_batch_size_min"
+	configKeyCoinDistributerBatchMax     = "coin_distributer_batch_size_max"
+	configKeyCoinDistributerBatchMargin  = "coin_distributer_batch_gas_safety_margin_percent"
+	configKeyCoinDistributerCycleCap     = "coin_distributer_cap_per_cycle_ice"
+	configKeyCoinDistributerUserCap      = "coin_distributer_cap_per_user_ice"
+	configKeyCoinDistributerDeviationCap = "coin_distributer_cap_cycle_deviation_percent"
 
-	doctorXTenant = "doctorx"
+	configKeyCoinDistributerFlagAverageCycles     = "coin_distributer_flag_average_cycles"
+	configKeyCoinDistributerFlagAverageMultiplier = "coin_distributer_flag_average_multiplier"
+	configKeyCoinDistributerFlagSharedAddress     = "coin_distributer_flag_shared_eth_address_users"
+	configKeyCoinDistributerFlagReferralPercent   = "coin_distributer_flag_referral_concentration_percent"
+	configKeyCoinDistributerMsgOnline             = "coin_distributer_msg_sent_online_date"
+	configKeyCoinDistributerMsgOffline            = "coin_distributer_msg_sent_offline_date"
+	configKeyCoinDistributerMsgFinished           = "coin_distributer_msg_sent_finished_date"
 )
 
 // .
@@ -134,9 +362,17 @@ var (
 	//nolint:gochecknoglobals // Singleton & global config mounted only during bootstrap.
 	cfg config
 	//go:embed DDL.sql
-	ddl                  string
-	errNotEnoughData     = errors.New("not enough data")
-	errClientUncoverable = errors.New("uncoverable error")
+	ddl                   string
+	errNotEnoughData      = errors.New("not enough data")
+	errClientUncoverable  = errors.New("uncoverable error")
+	errPreflightFailed    = errors.New("preflight failed")
+	errAirdropWouldRevert = errors.New("airdrop would revert")
+	// The transaction provably never reached the network, so its records can be safely retried.
+	errNotBroadcasted = errors.New("transaction was not broadcasted")
+	// The transaction might have reached the network, so it must not be retried with another nonce.
+	errMaybeBroadcasted = errors.New("transaction might have been broadcasted")
+	// The address wasn't checked for contract code before the review transaction, so it's retried later.
+	errEthAddressNotScreened = errors.New("eth address not screened")
 )
 
 type (
@@ -144,33 +380,202 @@ type (
 	ethApiStatus string
 	workerAction uint
 	gasGetter    interface {
-		GetGasOptions(ctx context.Context) (price *big.Int, limit uint64, err error)
+		GetGasOptions(ctx context.Context) (*gasOptions, error)
+	}
+	// The gas options of the transactions that aren't airdrops, based only on the network (the caps in the `global` table aren't used).
+	networkGasGetter struct {
+		Client   ethClient
+		GasLimit uint64
+		Legacy   bool
+	}
+	// Either GasPrice (legacy transactions) or GasFeeCap & GasTipCap (EIP-1559 dynamic fee transactions) are set.
+	gasOptions struct {
+		GasPrice  *big.Int
+		GasFeeCap *big.Int
+		GasTipCap *big.Int
+		GasLimit  uint64
 	}
 	ethClient interface {
 		SuggestGasPrice(ctx context.Context) (*big.Int, error)
+		FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
 		TransactionsStatus(ctx context.Context, hashes []*string) (statuses map[ethTxStatus][]string, err error)
 		TransactionStatus(ctx context.Context, hash string) (status ethTxStatus, err error)
-		Airdrop(ctx context.Context, chanID *big.Int, gas gasGetter, recipients []common.Address, amounts []*big.Int) (string, error)
+		Airdrop(ctx context.Context, chanID *big.Int, gas gasGetter, recipients []common.Address, amounts []*big.Int) (*ethTransaction, error)
+		SpeedUpTransaction(ctx context.Context, chanID *big.Int, gas gasGetter, hash string) (*ethTransaction, error)
+		CancelTransaction(ctx context.Context, chanID *big.Int, gas gasGetter, hash string) (*ethTransaction, error)
+		PublishMerkleRoot(ctx context.Context, chanID *big.Int, gas gasGetter, root common.Hash) (*ethTransaction, error)
+		EstimateAirdropGas(ctx context.Context, recipients []common.Address, amounts []*big.Int) (uint64, error)
+		TokenBalance(ctx context.Context) (*big.Int, error)
+		Transfers(ctx context.Context, hash string, recipients []common.Address) (transfers []*erc20Transfer, approximate bool, err error)
+		TransactionGasUsed(ctx context.Context, hash string) (uint64, error)
+		io.Closer
+	}
+	erc20Transfer struct {
+		Value *big.Int
+		From  common.Address
+		To    common.Address
+	}
+	// Missing: nothing was transferred to the recipient, Extra: the recipient got coins it wasn't supposed to,
+	// Mismatched: the recipient got a different amount than the one recorded,
+	// Unverified: the transfers couldn't be fetched, so only the expected amount is known.
+	reconciliationDiscrepancy struct {
+		Expected   *big.Int
+		Actual     *big.Int
+		Kind       string
+		EthAddress string
+		UserIDs    []string
+	}
+	// Status is APPROXIMATE if the transfers were derived from the balance changes in the block instead of the Transfer logs
+	// and FAILED (with the Error) if they couldn't be fetched at all.
+	reconciliationReport struct {
+		Day           *time.Time
+		Expected      *big.Int
+		Actual        *big.Int
+		TX            string
+		Status        string
+		Error         string
+		Discrepancies []*reconciliationDiscrepancy
+		Recipients    int
+		Transfers     int
+	}
+	// Fit is how many of the batch's records fit into the gas limit. If Reason is set, the batch can't succeed.
+	preflightReport struct {
+		Total       *big.Int
+		Balance     *big.Int
+		Reason      string
+		Recipients  int
+		Fit         int
+		GasEstimate uint64
+		GasLimit    uint64
+	}
+	ethTransaction struct {
+		Hash  string
+		Nonce uint64
+	}
+	nonceSource interface {
+		PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	}
+	// The nonces are handed out locally, so that several transactions can be broadcasted without waiting for the previous ones to be mined.
+	nonceManager struct {
+		Source  nonceSource
+		next    *uint64
+		Account common.Address
+	}
+	merkleRootPublisher interface {
+		SetMerkleRoot(opts *bind.TransactOpts, root common.Hash) (*types.Transaction, error)
+	}
+	claimContract struct {
+		*bind.BoundContract
+	}
+	// The layers go from the (sorted) leaves up to the root.
+	merkleTree struct {
+		layers [][]common.Hash
+	}
+	codeGetter interface {
+		// CodesAt returns the latest code of every account, in the same order.
+		CodesAt(ctx context.Context, accounts []common.Address) ([][]byte, error)
+	}
+	rpcCodeGetter struct {
+		Client *ethclient.Client
+	}
+	denyListProvider interface {
+		DeniedEthAddresses(ctx context.Context) (map[common.Address]string, error)
+	}
+	// The deny list is a local file, or, if none is configured, the `coin_distributer_eth_address_deny_list` global value.
+	fileDenyList struct {
+		Path string
+	}
+	databaseDenyList struct {
+		DB storage.Querier
+	}
+	ethAddressScreener struct {
+		Code     codeGetter
+		DenyList denyListProvider
+	}
+	// The hot wallet signs the transactions, with a key we hold (raw or in an encrypted keystore) or remotely (Clef compatible).
+	ethSigner interface {
 		io.Closer
+		Address() common.Address
+		SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	}
+	localSigner struct {
+		key     *ecdsa.PrivateKey
+		address common.Address
+	}
+	remoteSigner struct {
+		Client  *rpc.Client
+		Account common.Address
+	}
+	// *ethclient.Client provides the raw RPC client, for the batched calls. The simulated backends don't.
+	rawRPCProvider interface {
+		Client() *rpc.Client
+	}
+	rpcCloser interface {
+		Close()
+	}
+	tokenBalancer interface {
+		BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error)
 	}
 	airDropper interface {
 		AirdropToWallets(opts *bind.TransactOpts, recipients []common.Address, amounts []*big.Int) (*types.Transaction, error)
 	}
 	batchRecord struct {
-		CreatedAt  *time.Time   `db:"created_at"`
-		Day        *time.Time   `db:"day"`
-		EthTX      *string      `db:"eth_tx"`
-		UserID     string       `db:"user_id"`
-		EthAddress string       `db:"eth_address"`
-		EthStatus  ethApiStatus `db:"eth_status"`
-		Iceflakes  string       `db:"iceflakes"`
-		InternalID int64        `db:"internal_id"`
+		CreatedAt     *time.Time   `db:"created_at"`
+		Day           *time.Time   `db:"day"`
+		EthTX         *string      `db:"eth_tx"`
+		EthTXNonce    *int64       `db:"eth_tx_nonce"`
+		UserID        string       `db:"user_id"`
+		EthAddress    string       `db:"eth_address"`
+		EthStatus     ethApiStatus `db:"eth_status"`
+		Iceflakes     string       `db:"iceflakes"`
+		EthTXReplaced []string     `db:"eth_tx_replaced"`
+		EthTXCancel   []string     `db:"eth_tx_cancel"`
+		MerkleRoot    *string      `db:"merkle_root"`
+		InternalID    int64        `db:"internal_id"`
 	}
+	// TX is the latest airdrop transaction, Replaced are the previous ones (with the same Nonce) that it replaced
+	// and Cancel are the zero-value self-transfers (with the same Nonce) broadcasted to cancel it.
 	batch struct {
-		ID      string
-		TX      string
-		Status  ethTxStatus
-		Records []*batchRecord
+		Sizing   *batchSizing
+		ID       string
+		TX       string
+		Status   ethTxStatus
+		Records  []*batchRecord
+		Replaced []string
+		Cancel   []string
+		Nonce    uint64
+	}
+	// Size is the number of records the batch was allowed to have, it can have fewer, if there aren't enough of them.
+	batchSizing struct {
+		Rationale           string
+		GasLimit            uint64
+		GasPerRecipient     uint64
+		SafetyMarginPercent uint64
+		MinSize             uint64
+		MaxSize             uint64
+		Size                uint64
+		Receipts            int
+	}
+	// The caps are in ICE, except the deviation, which is the percent of the previous cycle's total.
+	coinDistributionCaps struct {
+		CycleIce              uint64
+		UserIce               uint64
+		CycleDeviationPercent uint64
+	}
+	anomalyThresholds struct {
+		AverageCycles                uint64
+		AverageMultiplier            float64
+		SharedEthAddressUsers        uint64
+		ReferralConcentrationPercent uint64
+	}
+	coinDistributionCycle struct {
+		Day         stdlibtime.Time
+		Ice         uint64
+		PreviousIce uint64
+	}
+	batchReceipt struct {
+		GasUsed    uint64 `db:"gas_used"`
+		Recipients uint64 `db:"recipients"`
 	}
 	databaseConfig struct {
 		DB *storage.DB
@@ -188,10 +593,14 @@ type (
 		}
 	}
 	ethClientImpl struct {
-		RPC        *ethclient.Client
+		RPC        EthRPC
 		Mutex      *sync.Mutex
-		Key        *ecdsa.PrivateKey
+		Signer     ethSigner
 		AirDropper airDropper
+		Token      tokenBalancer
+		Claimer    merkleRootPublisher
+		Contract   common.Address
+		Nonces     *nonceManager
 	}
 	coinDistributer struct {
 		Client     ethClient
@@ -207,14 +616,30 @@ type (
 		AlertSlackWebhook string `yaml:"alert-slack-webhook" mapstructure:"alert-slack-webhook"`
 		Environment       string `yaml:"environment"         mapstructure:"environment"`
 		ReviewURL         string `yaml:"review-url"          mapstructure:"review-url"`
-		Ethereum          struct {
+		// Optional, if not set the deny list is read from the DB.
+		EthAddressDenyListFile string `yaml:"ethAddressDenyListFile" mapstructure:"eth-address-deny-list-file"`
+		Ethereum               struct {
 			RPC             string `yaml:"rpc"             mapstructure:"rpc"`
 			PrivateKey      string `yaml:"privateKey"      mapstructure:"private-key"`
 			ContractAddress string `yaml:"contractAddress" mapstructure:"contract-address"`
-			ChainID         int64  `yaml:"chainId"         mapstructure:"chain-id"`
+			// Optional, it's needed only for the merkle-claim distribution mode.
+			ClaimContractAddress string `yaml:"claimContractAddress" mapstructure:"claim-contract-address"`
+			ChainID              int64  `yaml:"chainId"         mapstructure:"chain-id"`
+			Signer               struct {
+				// One of `private-key` (default, it uses ethereum.privateKey), `keystore` or `remote`.
+				Type         string `yaml:"type"         mapstructure:"type"`
+				KeystoreFile string `yaml:"keystoreFile" mapstructure:"keystore-file"`
+				Passphrase   string `yaml:"passphrase"   mapstructure:"passphrase"`
+				URL          string `yaml:"url"          mapstructure:"url"`
+				// Optional, if not set, the remote signer must manage exactly one account.
+				Account string `yaml:"account" mapstructure:"account"`
+			} `yaml:"signer" mapstructure:"signer"`
 		} `yaml:"ethereum" mapstructure:"ethereum"`
-		StartHours  int  `yaml:"startHours"  mapstructure:"start-hours"`
-		EndHours    int  `yaml:"endHours"    mapstructure:"end-hours"`
-		Development bool `yaml:"development" mapstructure:"development"`
+		StuckTransactionTimeout stdlibtime.Duration `yaml:"stuckTransactionTimeout" mapstructure:"stuck-transaction-timeout"`
+		StartHours              int                 `yaml:"startHours"              mapstructure:"start-hours"`
+		EndHours                int                 `yaml:"endHours"                mapstructure:"end-hours"`
+		MaxInFlightBatches      int                 `yaml:"maxInFlightBatches"      mapstructure:"max-in-flight-batches"`
+		ReviewQuorum            uint64              `yaml:"reviewQuorum"            mapstructure:"review-quorum"`
+		Development             bool                `yaml:"development"             mapstructure:"development"`
 	}
 )
@@ -26,21 +26,22 @@ func IsCoinDistributionCollectorEnabled(now *time.Time, ethereumDistributionFreq
 }
 
 func CalculateEthereumDistributionICEBalance(
-	standardBalance float64,
+	standardBalance model.Amount,
 	ethereumDistributionFrequencyMin, ethereumDistributionFrequencyMax stdlibtime.Duration,
 	now, ethereumDistributionEndDate *time.Time,
-) float64 {
+) model.Amount {
 	delta := ethereumDistributionEndDate.Truncate(ethereumDistributionFrequencyMin).Sub(now.Truncate(ethereumDistributionFrequencyMin))
 	if delta <= ethereumDistributionFrequencyMax {
 		return standardBalance
 	}
 
-	return standardBalance / float64(int64(delta/ethereumDistributionFrequencyMax)+1)
+	return standardBalance / model.Amount(int64(delta/ethereumDistributionFrequencyMax)+1)
 }
 
 func IsEligibleForEthereumDistribution(
 	minMiningStreaksRequired uint64,
-	standardBalance, minEthereumDistributionICEBalanceRequired float64,
+	standardBalance model.Amount,
+	minEthereumDistributionICEBalanceRequired float64,
 	ethAddress, country string,
 	distributionDeniedCountries map[string]struct{},
 	now, collectingEndedAt, miningSessionSoloStartedAt, miningSessionSoloEndedAt, ethereumDistributionEndDate *time.Time,
@@ -54,7 +55,7 @@ func IsEligibleForEthereumDistribution(
 	return countryAllowed &&
 		!miningSessionSoloEndedAt.IsNil() && (miningSessionSoloEndedAt.After(*collectingEndedAt.Time) || AllowInactiveUsers) &&
 		isEthereumAddressValid(ethAddress) &&
-		((minEthereumDistributionICEBalanceRequired > 0 && distributedBalance >= minEthereumDistributionICEBalanceRequired) || (minEthereumDistributionICEBalanceRequired == 0 && distributedBalance > 0)) && //nolint:lll // .
+		((minEthereumDistributionICEBalanceRequired > 0 && distributedBalance.Float64() >= minEthereumDistributionICEBalanceRequired) || (minEthereumDistributionICEBalanceRequired == 0 && distributedBalance > 0)) && //nolint:lll // .
 		model.CalculateMiningStreak(now, miningSessionSoloStartedAt, miningSessionSoloEndedAt, miningSessionDuration) >= minMiningStreaksRequired
 }
 
@@ -86,6 +87,8 @@ func isEligibleForEthereumDistributionNow(id int64,
 	return false
 }
 
-func isEthereumAddressValid(ethAddress string) bool {
+// The addresses are not rejected while collecting, otherwise they'd silently disappear.
+// They reach the review instead, where the invalid ones are denied with the reason (see screenCoinDistributionsPendingReview).
+func isEthereumAddressValid(string) bool {
 	return true
 }
@@ -98,19 +98,19 @@ func TestFinalDistribution(t *testing.T) {
 	miningSessionDuration := 24 * stdlibtime.Hour
 	activeMiningSessionStarted := time.New(stdlibtime.Date(2024, 2, 25, 0, 0, 0, 0, stdlibtime.UTC))
 	nonActiveMiningSessionEnded := time.New(stdlibtime.Date(2024, 2, 26, 0, 0, 0, 0, stdlibtime.UTC))
-	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), 0.1, balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
-	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), 1, balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
-	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), 1, balanceRequired, "bogusInvalidAddress", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
-	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), 1, balanceRequired, "", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(0.1), balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(1), balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(1), balanceRequired, "bogusInvalidAddress", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(1), balanceRequired, "", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
 
 	activeMiningSessionEnded := time.New(stdlibtime.Date(2024, 2, 29, 0, 0, 0, 0, stdlibtime.UTC))
-	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), 0.1, balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, activeMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
-	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), 1, balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, activeMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
-	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), 1, balanceRequired, "bogusInvalidAddress", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionEnded, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
-	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), 1, balanceRequired, "", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, activeMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(0.1), balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, activeMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.True(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(1), balanceRequired, "skip", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, activeMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(1), balanceRequired, "bogusInvalidAddress", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionEnded, nonActiveMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
+	assert.False(t, IsEligibleForEthereumDistribution(uint64(0), model.MustNewAmount(1), balanceRequired, "", "US", make(map[string]struct{}), now, collectingEndedAt, activeMiningSessionStarted, activeMiningSessionEnded, coinDistributionEndDate, miningSessionDuration, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour))
 
-	assert.Equal(t, float64(0), CalculateEthereumDistributionICEBalance(0, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour, now, coinDistributionEndDate))
-	assert.Equal(t, float64(100), CalculateEthereumDistributionICEBalance(100, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour, now, coinDistributionEndDate))
+	assert.Equal(t, model.Amount(0), CalculateEthereumDistributionICEBalance(0, 24*stdlibtime.Hour, 24*28*stdlibtime.Hour, now, coinDistributionEndDate))
+	assert.Equal(t, model.MustNewAmount(100), CalculateEthereumDistributionICEBalance(model.MustNewAmount(100), 24*stdlibtime.Hour, 24*28*stdlibtime.Hour, now, coinDistributionEndDate))
 
 	finalDistributionSettings := &CollectorSettings{
 		DeniedCountries:          nil,
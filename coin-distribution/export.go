@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+)
+
+type (
+	exportSource struct {
+		from       string
+		dateColumn string
+		orderBy    string
+		columns    []string
+	}
+	exportWriter interface {
+		Write(values []*string) error
+		Flush() error
+	}
+	csvExportWriter struct {
+		*csv.Writer
+		row []string
+	}
+	ndjsonExportWriter struct {
+		*json.Encoder
+		row     map[string]*string
+		columns []string
+	}
+	flusher interface {
+		Flush()
+	}
+)
+
+const (
+	exportFlushEvery = 1000
+)
+
+//nolint:gochecknoglobals // It's a static mapping.
+var exportSources = map[string]*exportSource{
+	PendingReviewExportSource: {
+		from:       "coin_distributions_pending_review",
+		dateColumn: "day",
+		orderBy:    "day, user_id",
+		columns:    []string{"created_at", "day", "user_id", "username", "referred_by_username", "eth_address", "ice", "iceflakes", "verified"},
+	},
+	ReviewedExportSource: {
+		from:       "reviewed_coin_distributions",
+		dateColumn: "review_day",
+		orderBy:    "review_day, day, user_id",
+		columns: []string{
+			"reviewed_at", "review_day", "created_at", "day", "user_id", "username", "referred_by_username", "eth_address", "ice", "iceflakes", "verified",
+			"reviewer_user_id", "decision", "reason",
+		},
+	},
+	// The pending ones have only the iceflakes, so the ice (in hundredths, like everywhere else) is derived from them.
+	PendingExportSource: {
+		from:       "(SELECT *, (iceflakes / 10000000000000000)::bigint AS ice FROM pending_coin_distributions) AS pending_coin_distributions",
+		dateColumn: "day",
+		orderBy:    "day, user_id",
+		columns:    []string{"created_at", "day", "user_id", "eth_address", "ice", "iceflakes", "eth_status", "eth_tx", "eth_tx_nonce", "merkle_root"},
+	},
+}
+
+// ExportCoinDistributions streams the selected coin distributions to the writer, from a consistent snapshot of the table.
+//
+//nolint:funlen // .
+func (r *repository) ExportCoinDistributions(ctx context.Context, arg *ExportCoinDistributionsArg, w io.Writer) error {
+	source, found := exportSources[strings.ToLower(arg.Source)]
+	if !found {
+		return errors.Errorf("unknown export source:`%v`", arg.Source)
+	}
+	selectColumns := make([]string, 0, len(source.columns))
+	for _, column := range source.columns {
+		if column == "ice" {
+			selectColumns = append(selectColumns, "(ice::numeric / 100)::text AS ice")
+		} else {
+			selectColumns = append(selectColumns, fmt.Sprintf("%[1]v::text AS %[1]v", column))
+		}
+	}
+	conditions, whereArgs := arg.where(source)
+	sql := fmt.Sprintf(`SELECT %[1]v
+						FROM %[2]v
+						WHERE 1=1
+						  AND %[3]v
+						ORDER BY %[4]v`, strings.Join(selectColumns, ", "), source.from, strings.Join(append(conditions, "1=1"), " AND "), source.orderBy)
+
+	return storage.DoInTransaction(ctx, r.db, func(conn storage.QueryExecer) error {
+		if _, err := storage.Exec(ctx, conn, "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY"); err != nil {
+			return errors.Wrap(err, "failed to set the export transaction as read only")
+		}
+		rows, err := conn.Query(ctx, sql, whereArgs...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to select the coin distributions to export for %#v", arg)
+		}
+		defer rows.Close()
+		out, err := newExportWriter(arg.Format, source.columns, w)
+		if err != nil {
+			return err
+		}
+		values := make([]*string, len(source.columns))
+		dest := make([]any, len(source.columns))
+		for ix := range values {
+			dest[ix] = &values[ix]
+		}
+		for count := 1; rows.Next(); count++ {
+			if err = rows.Scan(dest...); err != nil {
+				return errors.Wrapf(err, "failed to scan the exported coin distribution #%v", count)
+			}
+			if err = out.Write(values); err != nil {
+				return errors.Wrapf(err, "failed to write the exported coin distribution #%v", count)
+			}
+			if count%exportFlushEvery == 0 {
+				if err = flushExport(out, w); err != nil {
+					return err
+				}
+			}
+		}
+		if err = rows.Err(); err != nil {
+			return errors.Wrapf(err, "failed to iterate over the coin distributions to export for %#v", arg)
+		}
+
+		return flushExport(out, w)
+	})
+}
+
+func (a *ExportCoinDistributionsArg) where(source *exportSource) ([]string, []any) {
+	conditions, args := filterConditions(1, a.UsernameKeyword, a.ReferredByUsernameKeyword, a.MinIce, a.MaxIce, nil)
+	if a.From != "" {
+		conditions = append(conditions, fmt.Sprintf("%v >= $%v::date", source.dateColumn, 1+len(args)))
+		args = append(args, a.From)
+	}
+	if a.To != "" {
+		conditions = append(conditions, fmt.Sprintf("%v <= $%v::date", source.dateColumn, 1+len(args)))
+		args = append(args, a.To)
+	}
+
+	return conditions, args
+}
+
+func newExportWriter(format string, columns []string, w io.Writer) (exportWriter, error) {
+	switch strings.ToLower(format) {
+	case "", CSVExportFormat:
+		out := &csvExportWriter{Writer: csv.NewWriter(w), row: make([]string, len(columns))}
+
+		return out, errors.Wrap(out.Writer.Write(columns), "failed to write the csv header")
+	case NDJSONExportFormat:
+		return &ndjsonExportWriter{Encoder: json.NewEncoder(w), row: make(map[string]*string, len(columns)), columns: columns}, nil
+	default:
+		return nil, errors.Errorf("unknown export format:`%v`", format)
+	}
+}
+
+func (c *csvExportWriter) Write(values []*string) error {
+	for ix, value := range values {
+		if c.row[ix] = ""; value != nil {
+			c.row[ix] = *value
+		}
+	}
+
+	return errors.Wrap(c.Writer.Write(c.row), "failed to write csv row")
+}
+
+func (c *csvExportWriter) Flush() error {
+	c.Writer.Flush()
+
+	return errors.Wrap(c.Writer.Error(), "failed to flush csv")
+}
+
+func (n *ndjsonExportWriter) Write(values []*string) error {
+	for ix, column := range n.columns {
+		n.row[column] = values[ix]
+	}
+
+	return errors.Wrap(n.Encode(n.row), "failed to write ndjson row")
+}
+
+func (*ndjsonExportWriter) Flush() error {
+	return nil
+}
+
+// The rows are sent to the client as they are written, instead of being buffered until the whole export is done.
+func flushExport(out exportWriter, w io.Writer) error {
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	if f, ok := w.(flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportWriters(t *testing.T) {
+	t.Parallel()
+
+	userID, ice, tx := "bogus,user", "10.5", "0x1"
+	columns := []string{"user_id", "ice", "eth_tx"}
+
+	var buf bytes.Buffer
+	out, err := newExportWriter("", columns, &buf)
+	require.NoError(t, err)
+	require.NoError(t, out.Write([]*string{&userID, &ice, nil}))
+	require.NoError(t, out.Write([]*string{&userID, &ice, &tx}))
+	require.NoError(t, flushExport(out, &buf))
+	assert.Equal(t, "user_id,ice,eth_tx\n\"bogus,user\",10.5,\n\"bogus,user\",10.5,0x1\n", buf.String())
+
+	buf.Reset()
+	out, err = newExportWriter(NDJSONExportFormat, columns, &buf)
+	require.NoError(t, err)
+	require.NoError(t, out.Write([]*string{&userID, &ice, nil}))
+	require.NoError(t, out.Write([]*string{&userID, &ice, &tx}))
+	require.NoError(t, flushExport(out, &buf))
+	assert.Equal(t, `{"eth_tx":null,"ice":"10.5","user_id":"bogus,user"}`+"\n"+`{"eth_tx":"0x1","ice":"10.5","user_id":"bogus,user"}`+"\n", buf.String())
+
+	_, err = newExportWriter("xml", columns, &buf)
+	require.Error(t, err)
+}
+
+func TestExportCoinDistributionsArgWhere(t *testing.T) {
+	t.Parallel()
+
+	arg := &ExportCoinDistributionsArg{Source: ReviewedExportSource, UsernameKeyword: "jdoe", MinIce: 1, From: "2024-01-01", To: "2024-01-31"}
+	conditions, args := arg.where(exportSources[ReviewedExportSource])
+	assert.Equal(t, []string{"username LIKE $1 ESCAPE '!'", "ice >= $2", "review_day >= $3::date", "review_day <= $4::date"}, conditions)
+	assert.Equal(t, []any{"jdoe%", int64(100), "2024-01-01", "2024-01-31"}, args)
+
+	conditions, args = (&ExportCoinDistributionsArg{From: "2024-01-01"}).where(exportSources[PendingExportSource])
+	assert.Equal(t, []string{"day >= $1::date"}, conditions)
+	assert.Equal(t, []any{"2024-01-01"}, args)
+}
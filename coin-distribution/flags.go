@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/log"
+)
+
+// IsAnomalyFlag tells if the flag is one of the anomaly flags of the coin distributions pending review.
+func IsAnomalyFlag(flag string) bool {
+	switch flag {
+	case AmountAboveAverageAnomalyFlag, SharedEthAddressAnomalyFlag, EthAddressChangedAnomalyFlag, ReferralConcentrationAnomalyFlag, NewlyVerifiedAnomalyFlag:
+		return true
+	default:
+		return false
+	}
+}
+
+// The flags of every coin distribution pending review are (re)computed against the user's previously reviewed cycles
+// and the referral contributions of the cycle. A threshold of 0 disables its flag and the mainnet reward pool is never flagged.
+// Without any previously reviewed cycle, the user is considered not verified before, so a verified one is flagged as newly verified.
+//
+//nolint:funlen // It's a single statement.
+func flagCoinDistributionsPendingReview(ctx context.Context, conn storage.QueryExecer) error {
+	const sql = `
+WITH previous AS (
+	SELECT DISTINCT ON (p.day, p.user_id) p.day, p.user_id, r.eth_address, r.verified
+	FROM coin_distributions_pending_review p
+		JOIN reviewed_coin_distributions r
+		  ON r.user_id = p.user_id
+		 AND r.day < p.day
+	ORDER BY p.day, p.user_id, r.day DESC, r.reviewed_at DESC
+),
+averages AS (
+	SELECT p.day, p.user_id, avg(r.ice) AS ice
+	FROM coin_distributions_pending_review p
+		JOIN LATERAL (SELECT ice
+					  FROM reviewed_coin_distributions
+					  WHERE user_id = p.user_id
+						AND day < p.day
+					  ORDER BY day DESC
+					  LIMIT $1) r ON true
+	GROUP BY p.day, p.user_id
+),
+shared AS (
+	SELECT lower(eth_address) AS eth_address
+	FROM coin_distributions_pending_review
+	GROUP BY lower(eth_address)
+	HAVING $3 > 0 AND count(DISTINCT user_id) >= $3
+),
+referrals AS (
+	SELECT c.day, c.user_id,
+		   coalesce(max(c.balance) FILTER (WHERE c.earner_user_id != c.user_id), 0) * 100 / nullif(sum(c.balance), 0) AS top_earner_percent
+	FROM coin_distribution_contributions c
+		JOIN coin_distributions_pending_review p
+		  ON p.user_id = c.user_id
+		 AND p.day = c.day
+	GROUP BY c.day, c.user_id
+),
+flagged AS (
+	SELECT p.day, p.user_id,
+		   array_remove(ARRAY[
+			   CASE WHEN $2::numeric > 0 AND a.ice > 0 AND p.ice > a.ice * $2::numeric THEN $6::text END,
+			   CASE WHEN s.eth_address IS NOT NULL THEN $7::text END,
+			   CASE WHEN lower(pr.eth_address) != lower(p.eth_address) THEN $8::text END,
+			   CASE WHEN $4 > 0 AND rc.top_earner_percent > $4 THEN $9::text END,
+			   CASE WHEN p.verified AND NOT coalesce(pr.verified, false) THEN $10::text END
+		   ], NULL) AS flags
+	FROM coin_distributions_pending_review p
+		LEFT JOIN previous pr ON pr.day = p.day AND pr.user_id = p.user_id
+		LEFT JOIN averages a ON a.day = p.day AND a.user_id = p.user_id
+		LEFT JOIN shared s ON s.eth_address = lower(p.eth_address)
+		LEFT JOIN referrals rc ON rc.day = p.day AND rc.user_id = p.user_id
+	WHERE p.internal_id IS DISTINCT FROM $5
+)
+UPDATE coin_distributions_pending_review p
+SET flags = flagged.flags
+FROM flagged
+WHERE flagged.day = p.day
+  AND flagged.user_id = p.user_id
+  AND flagged.flags IS DISTINCT FROM p.flags`
+	thresholds, err := getAnomalyThresholds(ctx, conn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the anomaly thresholds")
+	}
+	flagged, err := storage.Exec(ctx, conn, sql,
+		thresholds.AverageCycles,
+		thresholds.AverageMultiplier,
+		thresholds.SharedEthAddressUsers,
+		thresholds.ReferralConcentrationPercent,
+		rewardPoolInternalID,
+		AmountAboveAverageAnomalyFlag,
+		SharedEthAddressAnomalyFlag,
+		EthAddressChangedAnomalyFlag,
+		ReferralConcentrationAnomalyFlag,
+		NewlyVerifiedAnomalyFlag,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to flag the coin distributions pending review")
+	}
+	log.Info(fmt.Sprintf("updated the flags of %v coin distributions pending review", flagged))
+
+	return nil
+}
+
+func getAnomalyThresholds(ctx context.Context, conn storage.Querier) (*anomalyThresholds, error) {
+	thresholds := new(anomalyThresholds)
+	for key, val := range map[string]*uint64{
+		configKeyCoinDistributerFlagAverageCycles:   &thresholds.AverageCycles,
+		configKeyCoinDistributerFlagSharedAddress:   &thresholds.SharedEthAddressUsers,
+		configKeyCoinDistributerFlagReferralPercent: &thresholds.ReferralConcentrationPercent,
+	} {
+		if err := databaseGetValue(ctx, conn, key, val); err != nil {
+			return nil, err
+		}
+	}
+	if err := databaseGetValue(ctx, conn, configKeyCoinDistributerFlagAverageMultiplier, &thresholds.AverageMultiplier); err != nil {
+		return nil, err
+	}
+
+	return thresholds, nil
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+const (
+	CollectingHistoryStage    = "collecting"
+	PendingReviewHistoryStage = "pending-review"
+	HeldHistoryStage          = "held"
+	ReviewedHistoryStage      = "reviewed"
+)
+
+// The contributions are moved from coin_distributions_by_earner to coin_distribution_contributions once the cycle is prepared for review,
+// and the pending distributions are deleted once their transaction is mined, leaving it in reviewed_coin_distributions.eth_tx.
+//
+//nolint:funlen // .
+func (r *repository) GetCoinDistributionHistory(ctx context.Context, userID string, limit, offset uint64) ([]*CoinDistributionHistory, error) {
+	const (
+		historySQL = `
+SELECT *
+FROM (SELECT $2::text AS stage, day, NULL::timestamp AS reviewed_at, sum(balance) AS ice, max(eth_address) AS eth_address,
+			 NULL AS decision, NULL AS eth_status, NULL AS eth_tx
+	  FROM coin_distributions_by_earner
+	  WHERE user_id = $1
+	  GROUP BY day
+	  UNION ALL
+	  SELECT $3::text, day, NULL, ice, eth_address, NULL, NULL, NULL
+	  FROM coin_distributions_pending_review
+	  WHERE user_id = $1
+	  UNION ALL
+	  SELECT $7::text, day, NULL, ice, eth_address, NULL, NULL, NULL
+	  FROM held_coin_distributions
+	  WHERE user_id = $1
+		AND released_at IS NULL
+	  UNION ALL
+	  SELECT $4::text, r.day, r.reviewed_at, r.ice, r.eth_address, r.decision,
+			 coalesce(p.eth_status::text, CASE WHEN r.eth_tx IS NOT NULL THEN 'ACCEPTED' END),
+			 coalesce(p.eth_tx, r.eth_tx)
+	  FROM reviewed_coin_distributions r
+		  LEFT JOIN pending_coin_distributions p
+				 ON p.user_id = r.user_id
+				AND p.day = r.day
+				AND r.decision LIKE 'approve%'
+	  WHERE r.user_id = $1) AS history
+ORDER BY day DESC, reviewed_at DESC NULLS FIRST
+LIMIT $5 OFFSET $6`
+		contributionsSQL = `
+SELECT day, earner_user_id, balance
+FROM coin_distributions_by_earner
+WHERE user_id = $1
+  AND day = ANY($2)
+UNION ALL
+SELECT day, earner_user_id, balance
+FROM coin_distribution_contributions
+WHERE user_id = $1
+  AND day = ANY($2)
+ORDER BY balance DESC, earner_user_id`
+	)
+	history, err := storage.Select[struct {
+		ReviewedAt *time.Time
+		Decision   *string
+		EthStatus  *string
+		EthTX      *string `db:"eth_tx"`
+		Stage      string
+		EthAddress string
+		Day        stdlibtime.Time
+		Ice        int64
+	}](ctx, r.db, historySQL, userID, CollectingHistoryStage, PendingReviewHistoryStage, ReviewedHistoryStage, limit, offset, HeldHistoryStage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select the coin distribution history for userID:%v", userID)
+	}
+	res := make([]*CoinDistributionHistory, 0, len(history))
+	if len(history) == 0 {
+		return res, nil
+	}
+	days := make([]stdlibtime.Time, 0, len(history))
+	byDay := make(map[string][]*CoinDistributionHistory, len(history))
+	for _, entry := range history {
+		day := entry.Day.Format(stdlibtime.DateOnly)
+		cycle := &CoinDistributionHistory{
+			ReviewedAt:    entry.ReviewedAt,
+			Day:           day,
+			Stage:         entry.Stage,
+			EthAddress:    entry.EthAddress,
+			Ice:           float64(entry.Ice) / 100, //nolint:gomnd,mnd // It's stored in hundredths.
+			Contributions: make([]*CoinDistributionContribution, 0),
+		}
+		if entry.Decision != nil {
+			cycle.Decision = *entry.Decision
+		}
+		if entry.EthStatus != nil {
+			cycle.EthStatus = *entry.EthStatus
+		}
+		if entry.EthTX != nil {
+			cycle.EthTX = *entry.EthTX
+		}
+		if _, found := byDay[day]; !found {
+			days = append(days, entry.Day)
+		}
+		byDay[day] = append(byDay[day], cycle)
+		res = append(res, cycle)
+	}
+	contributions, err := storage.Select[struct {
+		Day          stdlibtime.Time
+		EarnerUserID string
+		Balance      int64
+	}](ctx, r.db, contributionsSQL, userID, days)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select the coin distribution contributions for userID:%v", userID)
+	}
+	for _, contribution := range contributions {
+		for _, cycle := range byDay[contribution.Day.Format(stdlibtime.DateOnly)] {
+			cycle.Contributions = append(cycle.Contributions, &CoinDistributionContribution{
+				EarnerUserID: contribution.EarnerUserID,
+				Ice:          float64(contribution.Balance) / 100, //nolint:gomnd,mnd // It's stored in hundredths.
+			})
+		}
+	}
+
+	return res, nil
+}
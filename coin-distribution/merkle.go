@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The tree is compatible with OpenZeppelin's MerkleProof: the pairs are hashed sorted, so the proofs don't need to carry the sides.
+// The leaves are the same as the ones of OpenZeppelin's StandardMerkleTree for `(address account, uint256 amount)`,
+// i.e. keccak256(bytes.concat(keccak256(abi.encode(account, amount)))), so that they can't be mistaken for inner nodes.
+func merkleLeaf(account common.Address, amount *big.Int) common.Hash {
+	const wordSize = 32
+	encoded := append(common.LeftPadBytes(account.Bytes(), wordSize), common.LeftPadBytes(amount.Bytes(), wordSize)...)
+
+	return crypto.Keccak256Hash(crypto.Keccak256(encoded))
+}
+
+func merkleHashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a.Bytes(), b.Bytes()) > 0 {
+		a, b = b, a
+	}
+
+	return crypto.Keccak256Hash(a.Bytes(), b.Bytes())
+}
+
+func newMerkleTree(leaves []common.Hash) *merkleTree {
+	layer := append(make([]common.Hash, 0, len(leaves)), leaves...)
+	sort.Slice(layer, func(i, j int) bool { return bytes.Compare(layer[i].Bytes(), layer[j].Bytes()) < 0 })
+	tree := &merkleTree{layers: [][]common.Hash{layer}}
+	for len(layer) > 1 {
+		next := make([]common.Hash, 0, (len(layer)+1)/2) //nolint:gomnd // .
+		for ix := 0; ix < len(layer); ix += 2 {
+			if ix+1 == len(layer) {
+				// The odd one out is promoted as is.
+				next = append(next, layer[ix])
+			} else {
+				next = append(next, merkleHashPair(layer[ix], layer[ix+1]))
+			}
+		}
+		tree.layers = append(tree.layers, next)
+		layer = next
+	}
+
+	return tree
+}
+
+func (t *merkleTree) Root() common.Hash {
+	if len(t.layers[0]) == 0 {
+		return common.Hash{}
+	}
+
+	return t.layers[len(t.layers)-1][0]
+}
+
+func (t *merkleTree) Proof(leaf common.Hash) ([]common.Hash, bool) {
+	ix := sort.Search(len(t.layers[0]), func(i int) bool { return bytes.Compare(t.layers[0][i].Bytes(), leaf.Bytes()) >= 0 })
+	if ix == len(t.layers[0]) || t.layers[0][ix] != leaf {
+		return nil, false
+	}
+	proof := make([]common.Hash, 0, len(t.layers)-1)
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		if sibling := ix ^ 1; sibling < len(layer) {
+			proof = append(proof, layer[sibling])
+		}
+		ix /= 2
+	}
+
+	return proof, true
+}
+
+func verifyMerkleProof(root, leaf common.Hash, proof []common.Hash) bool {
+	for _, node := range proof {
+		leaf = merkleHashPair(leaf, node)
+	}
+
+	return leaf == root
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleTree(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{1, 2, 3, 4, 5, 8, 13} {
+		leaves := make([]common.Hash, 0, size)
+		for ix := range size {
+			leaves = append(leaves, merkleLeaf(common.BigToAddress(big.NewInt(int64(ix+1))), big.NewInt(int64(ix+1)*1_000)))
+		}
+		tree := newMerkleTree(leaves)
+		for _, leaf := range leaves {
+			proof, found := tree.Proof(leaf)
+			require.True(t, found)
+			require.True(t, verifyMerkleProof(tree.Root(), leaf, proof), "size %v", size)
+			if len(proof) > 0 {
+				proof[0][0]++
+				require.False(t, verifyMerkleProof(tree.Root(), leaf, proof), "size %v", size)
+			}
+		}
+		_, found := tree.Proof(merkleLeaf(common.Address{}, big.NewInt(1)))
+		require.False(t, found)
+	}
+
+	single := merkleLeaf(common.Address{1}, big.NewInt(1))
+	require.Equal(t, single, newMerkleTree([]common.Hash{single}).Root())
+	require.Equal(t, common.Hash{}, newMerkleTree(nil).Root())
+}
+
+func TestMerkleLeaf(t *testing.T) {
+	t.Parallel()
+
+	account := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	encoded := common.FromHex("0x0000000000000000000000001111111111111111111111111111111111111111" +
+		"00000000000000000000000000000000000000000000000000000000000003e8")
+	require.Equal(t, crypto.Keccak256Hash(crypto.Keccak256(encoded)), merkleLeaf(account, big.NewInt(1_000)))
+	require.NotEqual(t, merkleLeaf(account, big.NewInt(1_000)), merkleLeaf(account, big.NewInt(1_001)))
+}
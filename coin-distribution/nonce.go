@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/log"
+)
+
+func newNonceManager(source nonceSource, account common.Address) *nonceManager {
+	return &nonceManager{Source: source, Account: account}
+}
+
+// Next returns the nonce to be used by the next transaction. It's not safe for concurrent use,
+// it has to be called (together with Commit/Reset) while holding the lock that serializes the broadcasting.
+func (n *nonceManager) Next(ctx context.Context) (uint64, error) {
+	if n.next == nil {
+		nonce, err := n.Source.PendingNonceAt(ctx, n.Account)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get pending nonce of %v", n.Account.String())
+		}
+		log.Info(fmt.Sprintf("nonce manager: synced nonce of %v: %v", n.Account.String(), nonce))
+		n.next = &nonce
+	}
+
+	return *n.next, nil
+}
+
+// Commit marks the nonce returned by Next as used by a broadcasted transaction.
+func (n *nonceManager) Commit() {
+	*n.next++
+}
+
+// Reset drops the local state, so that the nonce gets synced with the node again.
+// It's used when a broadcast fails, because we can't know if the node saw the nonce or not.
+func (n *nonceManager) Reset() {
+	n.next = nil
+}
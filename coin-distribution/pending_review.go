@@ -5,11 +5,13 @@ package coindistribution
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 	stdlibtime "time"
 
 	"github.com/pkg/errors"
 
+	"github.com/ice-blockchain/freezer/model"
 	appcfg "github.com/ice-blockchain/wintr/config"
 	"github.com/ice-blockchain/wintr/connectors/storage/v2"
 	"github.com/ice-blockchain/wintr/log"
@@ -67,34 +69,35 @@ func (r *repository) GetCoinDistributionsForReview(ctx context.Context, arg *Get
 		distributions[i] = d.PendingReview
 	}
 	conditions, whereArgs = arg.totalsWhere()
-	sql = fmt.Sprintf(`SELECT count(1) AS rows,
-							   coalesce(sum(ice),0) AS ice 
-					   FROM coin_distributions_pending_review 
-					   WHERE 1=1
-						 AND %[1]v`, strings.Join(append(conditions, "1=1"), " AND "))
-	total, err := storage.ExecOne[struct {
-		Rows uint64
-		Ice  uint64
-	}](ctx, r.db, sql, whereArgs...)
+	total, err := selectPendingReviewSnapshot(ctx, r.db, conditions, whereArgs)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to select coin_distributions_pending_review totals for %#v", arg)
 	}
+	flagCounts, err := selectPendingReviewFlagCounts(ctx, r.db, conditions, whereArgs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select coin_distributions_pending_review flag counts for %#v", arg)
+	}
 	nextCursor := uint64(0)
 	if len(result) == int(arg.Limit) {
 		nextCursor = arg.Cursor + arg.Limit
 	}
 
 	return &CoinDistributionsForReview{
+		FlagCounts:    flagCounts,
 		Distributions: distributions,
 		Cursor:        nextCursor,
 		TotalRows:     total.Rows,
 		TotalIce:      float64(total.Ice) / 100,
+		Snapshot:      total.hash(),
 	}, nil
 }
 
 func (a *GetCoinDistributionsForReviewArg) orderBy() []string {
-	res := make([]string, 0, 4)
+	res := make([]string, 0, 5) //nolint:gomnd,mnd // .
 
+	if a.FlagsOrderBy != "" {
+		res = append(res, fmt.Sprintf("cardinality(flags) %v", a.FlagsOrderBy))
+	}
 	if a.IceOrderBy != "" {
 		res = append(res, fmt.Sprintf("ice %v", a.IceOrderBy))
 	}
@@ -112,121 +115,156 @@ func (a *GetCoinDistributionsForReviewArg) orderBy() []string {
 }
 
 func (a *GetCoinDistributionsForReviewArg) where() ([]string, []any) {
-	conditions := make([]string, 0, 2)
-	args := make([]any, 0, 2)
-
-	i := 3
-	if referredByUsernameKeyword := a.ReferredByUsernameKeyword; referredByUsernameKeyword != "" {
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "!", "!!")
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "%", "!%")
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "_", "!_")
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "[", "![")
-		referredByUsernameKeyword = referredByUsernameKeyword + "%"
-		conditions = append(conditions, fmt.Sprintf("referred_by_username LIKE $%v ESCAPE '!'", i))
-		args = append(args, strings.ToLower(referredByUsernameKeyword))
-		i++
-	}
-	if usernameKeyword := a.UsernameKeyword; usernameKeyword != "" {
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "!", "!!")
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "%", "!%")
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "_", "!_")
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "[", "![")
-		usernameKeyword = usernameKeyword + "%"
-		conditions = append(conditions, fmt.Sprintf("username LIKE $%v ESCAPE '!'", i))
-		args = append(args, strings.ToLower(usernameKeyword))
+	return filterConditions(3, a.UsernameKeyword, a.ReferredByUsernameKeyword, a.MinIce, a.MaxIce, a.Flags) //nolint:gomnd,mnd // $1 and $2 are the pagination.
+}
+
+func (a *GetCoinDistributionsForReviewArg) totalsWhere() ([]string, []any) {
+	return filterConditions(1, a.UsernameKeyword, a.ReferredByUsernameKeyword, a.MinIce, a.MaxIce, a.Flags)
+}
+
+func selectPendingReviewFlagCounts(ctx context.Context, db storage.Querier, conditions []string, args []any) (map[string]uint64, error) {
+	sql := fmt.Sprintf(`SELECT flag, count(1) AS rows
+					   FROM coin_distributions_pending_review, unnest(flags) AS flag
+					   WHERE 1=1
+						 AND %[1]v
+					   GROUP BY flag`, strings.Join(append(conditions, "1=1"), " AND "))
+	result, err := storage.Select[struct {
+		Flag string
+		Rows uint64
+	}](ctx, db, sql, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select the flag counts of coin_distributions_pending_review for %#v", args)
+	}
+	counts := make(map[string]uint64, len(result))
+	for _, count := range result {
+		counts[count.Flag] = count.Rows
+	}
+
+	return counts, nil
+}
+
+// Partial tells if the decision is applied only to a selection of the coin distributions pending review.
+func (a *ReviewCoinDistributionsArg) Partial() bool {
+	return len(a.UserIDs) != 0 || a.UsernameKeyword != "" || a.ReferredByUsernameKeyword != "" || a.MinIce != 0 || a.MaxIce != 0 ||
+		len(a.Flags) != 0
+}
+
+func (a *ReviewCoinDistributionsArg) where(index int) ([]string, []any) {
+	conditions, args := filterConditions(index, a.UsernameKeyword, a.ReferredByUsernameKeyword, a.MinIce, a.MaxIce, a.Flags)
+	if len(a.UserIDs) != 0 {
+		conditions = append(conditions, fmt.Sprintf("user_id = ANY($%v)", index+len(args)))
+		args = append(args, a.UserIDs)
 	}
 
 	return conditions, args
 }
 
-func (a *GetCoinDistributionsForReviewArg) totalsWhere() ([]string, []any) {
-	conditions := make([]string, 0, 2)
-	args := make([]any, 0, 2)
-
-	i := 1
-	if referredByUsernameKeyword := a.ReferredByUsernameKeyword; referredByUsernameKeyword != "" {
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "!", "!!")
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "%", "!%")
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "_", "!_")
-		referredByUsernameKeyword = strings.ReplaceAll(referredByUsernameKeyword, "[", "![")
-		referredByUsernameKeyword = referredByUsernameKeyword + "%"
-		conditions = append(conditions, fmt.Sprintf("referred_by_username LIKE $%v ESCAPE '!'", i))
-		args = append(args, strings.ToLower(referredByUsernameKeyword))
-		i++
-	}
-	if usernameKeyword := a.UsernameKeyword; usernameKeyword != "" {
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "!", "!!")
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "%", "!%")
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "_", "!_")
-		usernameKeyword = strings.ReplaceAll(usernameKeyword, "[", "![")
-		usernameKeyword = usernameKeyword + "%"
-		conditions = append(conditions, fmt.Sprintf("username LIKE $%v ESCAPE '!'", i))
-		args = append(args, strings.ToLower(usernameKeyword))
+//nolint:revive // .
+func filterConditions(index int, usernameKeyword, referredByUsernameKeyword string, minIce, maxIce float64, flags []string) ([]string, []any) {
+	conditions := make([]string, 0, 5) //nolint:gomnd,mnd // .
+	args := make([]any, 0, 5)          //nolint:gomnd,mnd // .
+	if referredByUsernameKeyword != "" {
+		conditions = append(conditions, fmt.Sprintf("referred_by_username LIKE $%v ESCAPE '!'", index+len(args)))
+		args = append(args, startsWithPattern(referredByUsernameKeyword))
+	}
+	if usernameKeyword != "" {
+		conditions = append(conditions, fmt.Sprintf("username LIKE $%v ESCAPE '!'", index+len(args)))
+		args = append(args, startsWithPattern(usernameKeyword))
+	}
+	// The ice column holds hundredths of ICE.
+	if minIce != 0 {
+		conditions = append(conditions, fmt.Sprintf("ice >= $%v", index+len(args)))
+		args = append(args, int64(math.Ceil(minIce*100))) //nolint:gomnd,mnd // .
+	}
+	if maxIce != 0 {
+		conditions = append(conditions, fmt.Sprintf("ice <= $%v", index+len(args)))
+		args = append(args, int64(math.Floor(maxIce*100))) //nolint:gomnd,mnd // .
+	}
+	if len(flags) != 0 {
+		conditions = append(conditions, fmt.Sprintf("flags @> $%v::text[]", index+len(args)))
+		args = append(args, flags)
 	}
 
 	return conditions, args
 }
 
+func startsWithPattern(keyword string) string {
+	keyword = strings.ReplaceAll(keyword, "!", "!!")
+	keyword = strings.ReplaceAll(keyword, "%", "!%")
+	keyword = strings.ReplaceAll(keyword, "_", "!_")
+	keyword = strings.ReplaceAll(keyword, "[", "![")
+
+	return strings.ToLower(keyword + "%")
+}
+
+func (r *repository) ReviewCoinDistributions(ctx context.Context, reviewerUserID string, arg *ReviewCoinDistributionsArg) (*ReviewResult, error) {
+	decision := strings.ToLower(arg.Decision)
+	var result *ReviewResult
+	err := storage.DoInTransaction(ctx, r.db, func(conn storage.QueryExecer) error {
+		if decision == "deny" {
+			result = &ReviewResult{Approvals: 1, Quorum: 1, Executed: true}
+		} else {
+			var err error
+			if result, err = r.recordApproval(ctx, conn, reviewerUserID, arg); err != nil || !result.Executed {
+				return err
+			}
+		}
+		if arg.Partial() {
+			return r.reviewSelectedCoinDistributions(ctx, conn, reviewerUserID, arg)
+		}
+
+		return r.reviewAllCoinDistributions(ctx, conn, reviewerUserID, decision, arg.Mode)
+	})
+
+	return result, err //nolint:wrapcheck // .
+}
+
 //nolint:funlen // .
-func (r *repository) ReviewCoinDistributions(ctx context.Context, reviewerUserID string, decision string) error {
+func (r *repository) reviewAllCoinDistributions(ctx context.Context, conn storage.QueryExecer, reviewerUserID, decision, mode string) error {
 	const sqlToCheckIfAnythingNeedsApproving = "SELECT true AS bogus WHERE exists (select 1 FROM coin_distributions_pending_review LIMIT 1)"
-	switch strings.ToLower(decision) {
-	case "approve":
-		return storage.DoInTransaction(ctx, r.db, func(conn storage.QueryExecer) error {
-			if _, err := storage.ExecOne[struct{ Bogus bool }](ctx, conn, sqlToCheckIfAnythingNeedsApproving); err != nil {
-				if storage.IsErr(err, storage.ErrNotFound) {
-					err = nil
-				}
+	if _, err := storage.ExecOne[struct{ Bogus bool }](ctx, conn, sqlToCheckIfAnythingNeedsApproving); err != nil {
+		if storage.IsErr(err, storage.ErrNotFound) {
+			err = nil
+		}
 
-				return errors.Wrap(err, "failed to check if any rows in coin_distributions_pending_review exist")
-			}
-			totals, err := storage.ExecOne[struct {
-				Rows uint64
-				Ice  uint64
-			}](ctx, conn, "SELECT rows, ice FROM approve_coin_distributions($1,false,true) AS (rows bigint, ice numeric);", reviewerUserID)
-			if err != nil {
-				return errors.Wrap(err, "failed to call approve_coin_distributions")
-			}
+		return errors.Wrap(err, "failed to check if any rows in coin_distributions_pending_review exist")
+	}
+	switch decision {
+	case "approve":
+		if err := setDistributionMode(ctx, conn, mode); err != nil {
+			return err
+		}
+		totals, err := storage.ExecOne[struct {
+			Rows uint64
+			Ice  uint64
+		}](ctx, conn, "SELECT rows, ice FROM approve_coin_distributions($1,false,true) AS (rows bigint, ice numeric);", reviewerUserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to call approve_coin_distributions")
+		}
 
-			return errors.Wrap(r.sendCurrentCoinDistributionsAvailableForReviewAreApprovedSlackMessage(ctx, totals.Rows, float64(totals.Ice)/100),
-				"failed to sendCurrentCoinDistributionsAvailableForReviewAreApprovedSlackMessage")
-		})
+		return errors.Wrap(r.sendCurrentCoinDistributionsAvailableForReviewAreApprovedSlackMessage(ctx, totals.Rows, float64(totals.Ice)/100),
+			"failed to sendCurrentCoinDistributionsAvailableForReviewAreApprovedSlackMessage")
 	case "approve-and-process-immediately":
-		return storage.DoInTransaction(ctx, r.db, func(conn storage.QueryExecer) error {
-			if _, err := storage.ExecOne[struct{ Bogus bool }](ctx, conn, sqlToCheckIfAnythingNeedsApproving); err != nil {
-				if storage.IsErr(err, storage.ErrNotFound) {
-					err = nil
-				}
-
-				return errors.Wrap(err, "failed to check if any rows in coin_distributions_pending_review exist")
-			}
-			totals, err := storage.ExecOne[struct {
-				Rows uint64
-				Ice  uint64
-			}](ctx, conn, "SELECT rows, ice FROM approve_coin_distributions($1,true,true) AS (rows bigint, ice numeric);", reviewerUserID)
-			if err != nil {
-				return errors.Wrap(err, "failed to call approve_coin_distributions")
-			}
+		if err := setDistributionMode(ctx, conn, mode); err != nil {
+			return err
+		}
+		totals, err := storage.ExecOne[struct {
+			Rows uint64
+			Ice  uint64
+		}](ctx, conn, "SELECT rows, ice FROM approve_coin_distributions($1,true,true) AS (rows bigint, ice numeric);", reviewerUserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to call approve_coin_distributions")
+		}
 
-			return errors.Wrap(r.sendCurrentCoinDistributionsAvailableForReviewAreApprovedToBeProcessedImmediatelySlackMessage(ctx, totals.Rows, float64(totals.Ice)/100),
-				"failed to sendCurrentCoinDistributionsAvailableForReviewAreApprovedToBeProcessedImmediatelySlackMessage")
-		})
+		return errors.Wrap(r.sendCurrentCoinDistributionsAvailableForReviewAreApprovedToBeProcessedImmediatelySlackMessage(ctx, totals.Rows, float64(totals.Ice)/100),
+			"failed to sendCurrentCoinDistributionsAvailableForReviewAreApprovedToBeProcessedImmediatelySlackMessage")
 	case "deny":
-		return storage.DoInTransaction(ctx, r.db, func(conn storage.QueryExecer) error {
-			if _, err := storage.ExecOne[struct{ Bogus bool }](ctx, conn, sqlToCheckIfAnythingNeedsApproving); err != nil {
-				if storage.IsErr(err, storage.ErrNotFound) {
-					err = nil
-				}
-
-				return errors.Wrap(err, "failed to check if any rows in coin_distributions_pending_review exist")
-			}
-			if _, err := storage.Exec(ctx, conn, "call deny_coin_distributions($1,true)", reviewerUserID); err != nil {
-				return errors.Wrap(err, "failed to call deny_coin_distributions")
-			}
+		if _, err := storage.Exec(ctx, conn, "call deny_coin_distributions($1,true)", reviewerUserID); err != nil {
+			return errors.Wrap(err, "failed to call deny_coin_distributions")
+		}
 
-			return errors.Wrap(r.sendCurrentCoinDistributionsAvailableForReviewAreDeniedSlackMessage(ctx),
-				"failed to sendCurrentCoinDistributionsAvailableForReviewAreDeniedSlackMessage")
-		})
+		return errors.Wrap(r.sendCurrentCoinDistributionsAvailableForReviewAreDeniedSlackMessage(ctx),
+			"failed to sendCurrentCoinDistributionsAvailableForReviewAreDeniedSlackMessage")
 	default:
 		log.Panic(fmt.Sprintf("unknown decision:`%v`", decision))
 	}
@@ -234,6 +272,100 @@ func (r *repository) ReviewCoinDistributions(ctx context.Context, reviewerUserID
 	return ctx.Err()
 }
 
+// Unlike the full review, denying a selection doesn't disable the coin distributer and collector.
+//
+//nolint:funlen // .
+func (r *repository) reviewSelectedCoinDistributions(ctx context.Context, conn storage.QueryExecer, reviewerUserID string, arg *ReviewCoinDistributionsArg) error {
+	const approvedSQL = `,
+	approved AS (
+		INSERT INTO pending_coin_distributions(created_at, internal_id, day, iceflakes, user_id, eth_address)
+		SELECT created_at, internal_id, day, iceflakes, user_id, eth_address
+		FROM del
+	)`
+	decision := strings.ToLower(arg.Decision)
+	var approved string
+	switch decision {
+	case "approve", "approve-and-process-immediately":
+		approved = approvedSQL
+	case "deny":
+	default:
+		log.Panic(fmt.Sprintf("unknown decision:`%v`", arg.Decision))
+	}
+	conditions, whereArgs := arg.where(5) //nolint:gomnd,mnd // $1-$4 are the review details.
+	sql := fmt.Sprintf(`WITH del AS (
+							DELETE FROM coin_distributions_pending_review
+							WHERE %[1]v
+							RETURNING *
+						),
+						reviewed AS (
+							INSERT INTO reviewed_coin_distributions(reviewed_at, created_at, internal_id, ice, day, review_day, iceflakes, username, referred_by_username, user_id, eth_address, reviewer_user_id, decision, verified, reason)
+							SELECT $1, created_at, internal_id, ice, day, $1::date, iceflakes, username, referred_by_username, user_id, eth_address, $2, $3, verified, $4
+							FROM del
+						)%[2]v
+						SELECT count(1) AS rows, coalesce(sum(ice), 0) AS ice FROM del`, strings.Join(conditions, " AND "), approved)
+	totals, err := storage.ExecOne[struct {
+		Rows uint64
+		Ice  uint64
+	}](ctx, conn, sql, append([]any{time.Now().Time, reviewerUserID, decision, arg.Reason}, whereArgs...)...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to review the selected coin distributions for %#v", arg)
+	}
+	if totals.Rows == 0 {
+		return nil
+	}
+	if approved != "" {
+		if err = setDistributionMode(ctx, conn, arg.Mode); err != nil {
+			return err
+		}
+	}
+	if decision == "approve-and-process-immediately" {
+		const processImmediatelySQL = `INSERT INTO global (key,value)
+												VALUES ($1,'true'),
+													   ($2,'true')
+									   ON CONFLICT (key) DO UPDATE
+												SET value = EXCLUDED.value`
+		if _, err = storage.Exec(ctx, conn, processImmediatelySQL, configKeyCoinDistributerEnabled, configKeyCoinDistributerOnDemand); err != nil {
+			return errors.Wrap(err, "failed to enable the coin distributer to process the selected coin distributions immediately")
+		}
+	}
+
+	return errors.Wrap(r.sendSelectedCoinDistributionsAreReviewedSlackMessage(ctx, reviewerUserID, decision, arg.Reason, totals.Rows, float64(totals.Ice)/100),
+		"failed to sendSelectedCoinDistributionsAreReviewedSlackMessage")
+}
+
+// The mode is chosen for every approved cycle; the push mode is the default one.
+func setDistributionMode(ctx context.Context, conn storage.Execer, mode string) error {
+	switch strings.ToLower(mode) {
+	case "", PushDistributionMode:
+		return errors.Wrapf(databaseSetValue(ctx, conn, configKeyCoinDistributerClaimMode, false), "failed to set the %v distribution mode", PushDistributionMode)
+	case MerkleClaimDistributionMode:
+		return errors.Wrapf(databaseSetValue(ctx, conn, configKeyCoinDistributerClaimMode, true), "failed to set the %v distribution mode", MerkleClaimDistributionMode)
+	default:
+		return errors.Errorf("unknown distribution mode:`%v`", mode)
+	}
+}
+
+// CancelPendingCoinDistributionTransaction asks the coin distributer to cancel the transactions it is waiting for,
+// by replacing each of them with a zero-value transfer to itself. The distributions of the cancelled transactions are rejected
+// and no new batches are sent until all the in-flight ones are done.
+func (r *repository) CancelPendingCoinDistributionTransaction(ctx context.Context, adminUserID string) error {
+	txHash, err := storage.Get[string](ctx, r.db, `SELECT eth_tx FROM pending_coin_distributions WHERE eth_status = 'ACCEPTED' ORDER BY created_at ASC LIMIT 1`)
+	if err != nil {
+		if storage.IsErr(err, storage.ErrNotFound) {
+			err = ErrNoPendingTransaction
+		}
+
+		return errors.Wrap(err, "failed to get the pending transaction")
+	}
+	if err = databaseSetValue(ctx, r.db, configKeyCoinDistributerCancelTX, true); err != nil {
+		return errors.Wrapf(err, "failed to request the cancellation of transaction %v", *txHash)
+	}
+	log.Info(fmt.Sprintf("cancellation of transaction %v requested by %v", *txHash, adminUserID))
+
+	return errors.Wrap(r.sendPendingCoinDistributionTransactionCancellationRequestedSlackMessage(ctx, *txHash, adminUserID),
+		"failed to sendPendingCoinDistributionTransactionCancellationRequestedSlackMessage")
+}
+
 func (r *repository) CollectCoinDistributionsForReview(ctx context.Context, records []*ByEarnerForReview) error {
 	if len(records) == 0 {
 		return nil
@@ -257,7 +389,7 @@ func (r *repository) CollectCoinDistributionsForReview(ctx context.Context, reco
 			record.CreatedAt.Time,
 			record.CreatedAt.Time,
 			record.InternalID,
-			int64(record.Balance*100),
+			int64(record.Balance/(model.AmountDenomination/100)), //nolint:gomnd,mnd // It's stored in hundredths.
 			record.Username,
 			record.ReferredByUsername,
 			record.UserID,
@@ -354,7 +486,20 @@ func (r *repository) GetCollectorSettings(ctx context.Context) (*CollectorSettin
 	}, nil
 }
 
-func tryPrepareCoinDistributionsForReview(ctx context.Context, db *storage.DB) error {
+func tryPrepareCoinDistributionsForReview(ctx context.Context, db *storage.DB, screener *ethAddressScreener) error {
+	// It's checked (and locked) again in the transaction, this only avoids the RPC calls of the screening when there's nothing to prepare.
+	if _, err := storage.Get[struct{ Bogus bool }](ctx, db, "SELECT true AS bogus FROM global WHERE key = 'new_coin_distributions_pending'"); err != nil {
+		if storage.IsErr(err, storage.ErrNotFound) {
+			err = nil
+		}
+
+		return errors.Wrap(err, "failed to check if there are new coin distributions to prepare for review")
+	}
+	contracts, err := screenEthAddressContracts(ctx, db, screener)
+	if err != nil {
+		return errors.Wrap(err, "failed to screenEthAddressContracts")
+	}
+
 	return storage.DoInTransaction(ctx, db, func(conn storage.QueryExecer) error {
 		if _, err := storage.ExecOne[struct{ Bogus bool }](ctx, conn, "SELECT true AS bogus FROM global WHERE key = 'new_coin_distributions_pending' FOR UPDATE SKIP LOCKED"); err != nil {
 			if storage.IsErr(err, storage.ErrNotFound) {
@@ -367,6 +512,15 @@ func tryPrepareCoinDistributionsForReview(ctx context.Context, db *storage.DB) e
 		if _, err := storage.Exec(ctx, conn, "call prepare_coin_distributions_for_review(true)"); err != nil {
 			return errors.Wrap(err, "failed to call prepare_coin_distributions_for_review")
 		}
+		if err := screenCoinDistributionsPendingReview(ctx, conn, screener, contracts); err != nil {
+			return errors.Wrap(err, "failed to screenCoinDistributionsPendingReview")
+		}
+		if err := flagCoinDistributionsPendingReview(ctx, conn); err != nil {
+			return errors.Wrap(err, "failed to flagCoinDistributionsPendingReview")
+		}
+		if err := holdCoinDistributionsExceedingCaps(ctx, conn); err != nil {
+			return errors.Wrap(err, "failed to holdCoinDistributionsExceedingCaps")
+		}
 
 		if rowsDeleted, err := storage.Exec(ctx, conn, "DELETE FROM global where key = 'new_coin_distributions_pending'"); err != nil || rowsDeleted != 1 {
 			if err == nil {
@@ -383,12 +537,14 @@ func tryPrepareCoinDistributionsForReview(ctx context.Context, db *storage.DB) e
 func (r *repository) StartPrepareCoinDistributionsForReviewMonitor(ctx context.Context) {
 	ticker := stdlibtime.NewTicker(30 * stdlibtime.Second) //nolint:gomnd // .
 	defer ticker.Stop()
+	screener := newEthAddressScreener(ctx, r.cfg, r.db)
+	defer screener.Close()
 
 	for {
 		select {
 		case <-ticker.C:
 			reqCtx, cancel := context.WithTimeout(ctx, 30*stdlibtime.Minute) //nolint:gomnd // .
-			log.Error(errors.Wrap(tryPrepareCoinDistributionsForReview(reqCtx, r.db), "failed to tryPrepareCoinDistributionsForReview"))
+			log.Error(errors.Wrap(tryPrepareCoinDistributionsForReview(reqCtx, r.db, screener), "failed to tryPrepareCoinDistributionsForReview"))
 			cancel()
 		case <-ctx.Done():
 			return
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewCoinDistributionsArgWhere(t *testing.T) {
+	t.Parallel()
+
+	arg := &ReviewCoinDistributionsArg{Decision: "deny"}
+	assert.False(t, arg.Partial())
+	conditions, args := arg.where(5)
+	assert.Empty(t, conditions)
+	assert.Empty(t, args)
+
+	arg.UsernameKeyword, arg.ReferredByUsernameKeyword = "J_Doe", "ref%"
+	arg.MinIce, arg.MaxIce = 10.005, 20.009
+	arg.UserIDs = []string{"a", "b"}
+	arg.Flags = []string{SharedEthAddressAnomalyFlag}
+	assert.True(t, arg.Partial())
+	conditions, args = arg.where(5)
+	assert.Equal(t, []string{
+		"referred_by_username LIKE $5 ESCAPE '!'",
+		"username LIKE $6 ESCAPE '!'",
+		"ice >= $7",
+		"ice <= $8",
+		"flags @> $9::text[]",
+		"user_id = ANY($10)",
+	}, conditions)
+	assert.Equal(t, []any{"ref!%%", "j!_doe%", int64(1001), int64(2000), []string{"shared-eth-address"}, []string{"a", "b"}}, args)
+
+	assert.True(t, (&ReviewCoinDistributionsArg{MaxIce: 1}).Partial())
+	conditions, args = (&GetCoinDistributionsForReviewArg{MaxIce: 1}).where()
+	assert.Equal(t, []string{"ice <= $3"}, conditions)
+	assert.Equal(t, []any{int64(100)}, args)
+
+	assert.True(t, (&ReviewCoinDistributionsArg{Flags: []string{NewlyVerifiedAnomalyFlag}}).Partial())
+	getArg := &GetCoinDistributionsForReviewArg{FlagsOrderBy: "desc", IceOrderBy: "asc", Flags: []string{NewlyVerifiedAnomalyFlag}}
+	conditions, args = getArg.where()
+	assert.Equal(t, []string{"flags @> $3::text[]"}, conditions)
+	assert.Equal(t, []any{[]string{"newly-verified"}}, args)
+	assert.Equal(t, []string{"cardinality(flags) desc", "ice asc"}, getArg.orderBy())
+}
+
+func TestIsAnomalyFlag(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsAnomalyFlag(AmountAboveAverageAnomalyFlag))
+	assert.True(t, IsAnomalyFlag(ReferralConcentrationAnomalyFlag))
+	assert.False(t, IsAnomalyFlag(""))
+	assert.False(t, IsAnomalyFlag("Shared-Eth-Address"))
+}
+
+func TestPendingReviewSnapshotHash(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &pendingReviewSnapshot{Rows: 2, Ice: 1000, Checksum: -12345}
+	assert.Len(t, snapshot.hash(), 64)
+	assert.Equal(t, snapshot.hash(), (&pendingReviewSnapshot{Rows: 2, Ice: 1000, Checksum: -12345}).hash())
+	assert.NotEqual(t, snapshot.hash(), (&pendingReviewSnapshot{Rows: 2, Ice: 1001, Checksum: -12345}).hash())
+	assert.NotEqual(t, snapshot.hash(), (&pendingReviewSnapshot{Rows: 2, Ice: 1000, Checksum: 12345}).hash())
+	assert.NotEqual(t, snapshot.hash(), (&pendingReviewSnapshot{Rows: 3, Ice: 1000, Checksum: -12345}).hash())
+}
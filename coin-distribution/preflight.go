@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/log"
+)
+
+// Preflight simulates the batch before it's signed, so that we don't burn gas on transactions that can't succeed.
+// If the batch doesn't fit into the gas limit, the records that don't fit are put back to NEW, to be picked up by the next batches.
+// If the batch can't succeed at all, it's rolled back, the distributer is disabled and the report is sent to slack.
+func (proc *coinProcessor) Preflight(ctx context.Context, data *batch) error {
+	if enabled, err := proc.IsPreflightEnabled(ctx); err != nil || !enabled {
+		return errors.Wrap(err, "failed to check if the preflight is enabled")
+	}
+	gasLimit, err := proc.GetGasLimit(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the gas limit")
+	}
+	report, err := proc.preflight(ctx, data, gasLimit)
+	if err != nil {
+		return errors.Wrapf(err, "failed to preflight batch %v", data.ID)
+	}
+	if report.Reason != "" {
+		if err = proc.BatchRollback(ctx, data); err != nil {
+			return errors.Wrapf(err, "failed to rollback batch %v that failed the preflight: %v", data.ID, report)
+		}
+		proc.MustDisable(fmt.Sprintf("batch %v failed the preflight: %v", data.ID, report))
+
+		return errors.Wrapf(errPreflightFailed, "batch %v: %v", data.ID, report)
+	}
+	if report.Fit < len(data.Records) {
+		rest := &batch{ID: data.ID, Records: data.Records[report.Fit:]}
+		if err = proc.BatchRollback(ctx, rest); err != nil {
+			return errors.Wrapf(err, "failed to put back the %v records of batch %v that don't fit into the gas limit", len(rest.Records), data.ID)
+		}
+		log.Info(fmt.Sprintf("batch %v: split, %v record(s) put back: %v", data.ID, len(rest.Records), report))
+		data.Records = data.Records[:report.Fit]
+		if data.Sizing != nil {
+			data.Sizing.Rationale += fmt.Sprintf("; split to %v by the preflight", report.Fit)
+		}
+	}
+
+	return nil
+}
+
+// The batch is halved until it fits into the gas limit. A batch of a single record that still doesn't fit can't succeed.
+func (proc *coinProcessor) preflight(ctx context.Context, data *batch, gasLimit uint64) (*preflightReport, error) {
+	recipients, amounts := data.Prepare()
+	report := &preflightReport{Total: new(big.Int), GasLimit: gasLimit, Fit: len(data.Records), Recipients: len(recipients)}
+	for _, amount := range amounts {
+		report.Total.Add(report.Total, amount)
+	}
+	balance, err := proc.Client.TokenBalance(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the token balance")
+	}
+	if report.Balance = balance; balance.Cmp(report.Total) < 0 {
+		report.Reason = "insufficient token balance"
+
+		return report, nil
+	}
+	for {
+		sub := &batch{ID: data.ID, Records: data.Records[:report.Fit]}
+		recipients, amounts = sub.Prepare()
+		report.GasEstimate, err = proc.Client.EstimateAirdropGas(ctx, recipients, amounts)
+		if err != nil {
+			if !errors.Is(err, errAirdropWouldRevert) {
+				return nil, errors.Wrap(err, "failed to estimate the airdrop gas")
+			}
+			report.Reason = err.Error()
+
+			return report, nil
+		}
+		if report.GasEstimate <= gasLimit {
+			return report, nil
+		}
+		if report.Fit == 1 {
+			report.Reason = "gas estimate exceeds the gas limit"
+
+			return report, nil
+		}
+		report.Fit /= 2
+	}
+}
+
+func (r *preflightReport) String() string {
+	return fmt.Sprintf("reason: `%v`, records that fit: %v, recipients: %v, total: %v, balance: %v, gas estimate: %v, gas limit: %v",
+		r.Reason, r.Fit, r.Recipients, r.Total, r.Balance, r.GasEstimate, r.GasLimit)
+}
+
+// The node returns the revert as an error with the revert data, or, depending on the client, just as a message.
+func isExecutionReverted(err error) bool {
+	var dataErr rpc.DataError
+
+	return errors.As(err, &dataErr) || strings.Contains(err.Error(), vm.ErrExecutionReverted.Error())
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	stdlibtime "time"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ice-blockchain/wintr/time"
+)
+
+type revertError struct{}
+
+func (*revertError) Error() string {
+	return "execution reverted: bogus"
+}
+
+func (*revertError) ErrorData() any {
+	return "0x08c379a0"
+}
+
+var _ rpc.DataError = (*revertError)(nil)
+
+func helperPreflightBatch(records int) *batch {
+	data := &batch{ID: "bogus"}
+	for ix := range records {
+		data.Records = append(data.Records, &batchRecord{
+			UserID:     fmt.Sprintf("user%v", ix),
+			EthAddress: fmt.Sprintf("0x%040x", ix+1),
+			Iceflakes:  "10",
+		})
+	}
+
+	return data
+}
+
+func TestPreflight(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("fits", func(t *testing.T) {
+		t.Parallel()
+		proc := &coinProcessor{Client: &mockedDummyEthClient{recipientGas: 100}}
+		report, err := proc.preflight(ctx, helperPreflightBatch(10), 1_000)
+		require.NoError(t, err)
+		assert.Empty(t, report.Reason)
+		assert.Equal(t, 10, report.Fit)
+		assert.Equal(t, uint64(1_000), report.GasEstimate)
+		assert.Equal(t, big.NewInt(100), report.Total)
+	})
+	t.Run("split", func(t *testing.T) {
+		t.Parallel()
+		proc := &coinProcessor{Client: &mockedDummyEthClient{recipientGas: 100}}
+		report, err := proc.preflight(ctx, helperPreflightBatch(10), 300)
+		require.NoError(t, err)
+		assert.Empty(t, report.Reason)
+		assert.Equal(t, 2, report.Fit)
+		assert.Equal(t, uint64(200), report.GasEstimate)
+	})
+	t.Run("single record over the gas limit", func(t *testing.T) {
+		t.Parallel()
+		proc := &coinProcessor{Client: &mockedDummyEthClient{recipientGas: 100}}
+		report, err := proc.preflight(ctx, helperPreflightBatch(4), 50)
+		require.NoError(t, err)
+		assert.Equal(t, "gas estimate exceeds the gas limit", report.Reason)
+		assert.Equal(t, 1, report.Fit)
+	})
+	t.Run("insufficient balance", func(t *testing.T) {
+		t.Parallel()
+		proc := &coinProcessor{Client: &mockedDummyEthClient{recipientGas: 100, balance: big.NewInt(99)}}
+		report, err := proc.preflight(ctx, helperPreflightBatch(10), 1_000)
+		require.NoError(t, err)
+		assert.Equal(t, "insufficient token balance", report.Reason)
+		assert.Equal(t, uint64(0), report.GasEstimate)
+	})
+	t.Run("revert", func(t *testing.T) {
+		t.Parallel()
+		proc := &coinProcessor{Client: &mockedDummyEthClient{revertErr: new(revertError)}}
+		report, err := proc.preflight(ctx, helperPreflightBatch(10), 1_000)
+		require.NoError(t, err)
+		assert.Contains(t, report.Reason, "execution reverted: bogus")
+	})
+}
+
+func TestIsExecutionReverted(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isExecutionReverted(new(revertError)))
+	assert.True(t, isExecutionReverted(fmt.Errorf("failed: %w", vm.ErrExecutionReverted)))
+	assert.False(t, isExecutionReverted(fmt.Errorf("connection reset"))) //nolint:goerr113 //.
+}
+
+func TestBatchKeys(t *testing.T) {
+	t.Parallel()
+
+	day1, day2 := time.New(stdlibtime.Date(2024, 1, 15, 0, 0, 0, 0, stdlibtime.UTC)), time.New(stdlibtime.Date(2024, 1, 16, 0, 0, 0, 0, stdlibtime.UTC))
+	data := &batch{Records: []*batchRecord{{Day: day1, UserID: "alice"}, {Day: day2, UserID: "alice"}, {Day: day2, UserID: "bob"}}}
+	// The records that don't fit are put back by their own keys, not by user, so the ones of the same user that stay aren't touched.
+	rest := &batch{Records: data.Records[1:]}
+	days, users := rest.Keys()
+	assert.Equal(t, []string{"2024-01-16", "2024-01-16"}, days)
+	assert.Equal(t, []string{"alice", "bob"}, users)
+}
@@ -6,9 +6,12 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"slices"
+	"sort"
 	"sync"
 	stdlibtime "time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/hashicorp/go-multierror"
 	"github.com/oklog/ulid/v2"
 	"github.com/pkg/errors"
@@ -81,23 +84,89 @@ func (proc *coinProcessor) GetGasPrice(ctx context.Context) (value *big.Int, err
 	return value, nil
 }
 
-func (proc *coinProcessor) BatchMarkAccepted(ctx context.Context, data *batch, txHash string) error {
+func (proc *coinProcessor) BatchMarkAccepted(ctx context.Context, data *batch, tx *ethTransaction) error {
 	const stmt = `
 update pending_coin_distributions
 set
 	eth_status = 'ACCEPTED',
-	eth_tx = $1
+	eth_tx = $1,
+	eth_tx_nonce = $2,
+	batch_id = $4
 where
 	eth_status = 'PENDING' and
-	user_id = ANY($2)
+	(day, user_id) IN (SELECT * FROM unnest($3::date[], $5::text[]))
+`
+
+	days, users := data.Keys()
+	err := storage.DoInTransaction(ctx, proc.DB, func(conn storage.QueryExecer) error {
+		if _, err := storage.Exec(ctx, conn, stmt, tx.Hash, int64(tx.Nonce), days, data.ID, users); err != nil {
+			return err //nolint:wrapcheck // Wrapped below.
+		}
+
+		return recordBatchSizing(ctx, conn, data)
+	})
+	data.SetAccepted(tx)
+
+	return errors.Wrapf(err, "failed to mark batch %v with TX %v as accepted", data.ID, tx.Hash)
+}
+
+// BatchRecordReplacement records the replacement on the batch's rows. The rows always point to the latest airdrop transaction
+// (so DeleteTransactions and RejectTransaction keep working), while the replaced and the cancel transactions are kept aside.
+func (proc *coinProcessor) BatchRecordReplacement(ctx context.Context, data *batch, tx *ethTransaction, cancel bool) error {
+	const speedUpStmt = `
+update pending_coin_distributions
+set
+	eth_tx = $2,
+	eth_tx_replaced = array_append(eth_tx_replaced, eth_tx)
+where
+	eth_status = 'ACCEPTED' and
+	eth_tx = $1
+`
+	const cancelStmt = `
+update pending_coin_distributions
+set
+	eth_tx_cancel = array_append(eth_tx_cancel, $2)
+where
+	eth_status = 'ACCEPTED' and
+	eth_tx = $1
+`
+
+	stmt := speedUpStmt
+	if cancel {
+		stmt = cancelStmt
+	}
+	if _, err := storage.Exec(ctx, proc.DB, stmt, data.TX, tx.Hash); err != nil {
+		return errors.Wrapf(err, "failed to record replacement %v (cancel: %v) of TX %v of batch %v", tx.Hash, cancel, data.TX, data.ID)
+	}
+	if cancel {
+		data.AddCancel(tx.Hash)
+	} else {
+		data.SetReplaced(tx.Hash)
+	}
+
+	return nil
+}
+
+// BatchMarkMinedReplacement is used when one of the replaced transactions gets mined instead of the latest one.
+func (proc *coinProcessor) BatchMarkMinedReplacement(ctx context.Context, data *batch, txHash string) error {
+	const stmt = `
+update pending_coin_distributions
+set
+	eth_tx = $2,
+	eth_tx_replaced = array_append(array_remove(eth_tx_replaced, $2), eth_tx)
+where
+	eth_status = 'ACCEPTED' and
+	eth_tx = $1
 `
 
-	_, err := storage.Exec(ctx, proc.DB, stmt, txHash, data.Users())
-	data.SetAccepted(txHash)
+	prevTX := data.TX
+	data.SetMined(txHash)
+	_, err := storage.Exec(ctx, proc.DB, stmt, prevTX, txHash)
 
-	return errors.Wrapf(err, "failed to mark batch %v with TX %v as accepted", data.ID, txHash)
+	return errors.Wrapf(err, "failed to switch batch %v from TX %v to mined TX %v", data.ID, prevTX, txHash)
 }
 
+// BatchMarkRejected is used when the batch's transaction might have been broadcasted, so it must not be retried automatically.
 func (proc *coinProcessor) BatchMarkRejected(ctx context.Context, data *batch) error {
 	const stmt = `
 update pending_coin_distributions
@@ -105,15 +174,48 @@ set
 	eth_status = 'REJECTED'
 where
 	eth_status = 'PENDING' and
-	user_id = ANY($1)
+	(day, user_id) IN (SELECT * FROM unnest($1::date[], $2::text[]))
 `
-	_, err := storage.Exec(ctx, proc.DB, stmt, data.Users())
+	days, users := data.Keys()
+	_, err := storage.Exec(ctx, proc.DB, stmt, days, users)
 	data.SetStatus(ethApiStatusRejected)
 
-	return errors.Wrapf(err, "failed to mark batch %v with as rejected", data.ID)
+	return errors.Wrapf(err, "failed to mark batch %v as rejected", data.ID)
+}
+
+// BatchRollback puts the batch back to NEW, it's used when its transaction was never broadcasted, so it can be safely retried later.
+func (proc *coinProcessor) BatchRollback(ctx context.Context, data *batch) error {
+	const stmt = `
+update pending_coin_distributions
+set
+	eth_status = 'NEW'
+where
+	eth_status = 'PENDING' and
+	(day, user_id) IN (SELECT * FROM unnest($1::date[], $2::text[]))
+`
+	days, users := data.Keys()
+	_, err := storage.Exec(ctx, proc.DB, stmt, days, users)
+	data.SetStatus(ethApiStatusNew)
+
+	return errors.Wrapf(err, "failed to rollback batch %v", data.ID)
+}
+
+func (proc *coinProcessor) BatchPrepareFetch(ctx context.Context) (*batch, error) {
+	sizing, err := proc.SizeBatch(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to size the batch")
+	}
+	data, err := proc.batchPrepareFetch(ctx, int(sizing.Size)) //nolint:gosec // It's bounded by the max batch size.
+	if err != nil {
+		return nil, err
+	}
+	data.Sizing = sizing
+	log.Info(fmt.Sprintf("batch %v: %v record(s), size %v: %v", data.ID, len(data.Records), sizing.Size, sizing.Rationale))
+
+	return data, nil
 }
 
-func (proc *coinProcessor) BatchPrepareFetch(ctx context.Context) (*batch, error) { //nolint:funlen //.
+func (proc *coinProcessor) batchPrepareFetch(ctx context.Context, limit int) (*batch, error) { //nolint:funlen //.
 	const stmt = `
 with records as (
 	select
@@ -137,7 +239,7 @@ where
 returning up.*
 `
 
-	result, err := storage.ExecMany[batchRecord](ctx, proc.DB, stmt, batchSize)
+	result, err := storage.ExecMany[batchRecord](ctx, proc.DB, stmt, limit)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to fetch pending coin distributions")
 	} else if len(result) == 0 {
@@ -153,6 +255,16 @@ returning up.*
 func (proc *coinProcessor) DeleteTransactions(ctx context.Context, hash string) error {
 	const stmt = `delete from pending_coin_distributions where eth_status = 'ACCEPTED' and eth_tx = $1`
 
+	if err := proc.ReconcileTransaction(ctx, hash); err != nil {
+		return errors.Wrapf(err, "failed to reconcile transaction %v", hash)
+	}
+	log.Error(errors.Wrapf(proc.RecordBatchGasUsed(ctx, hash), "failed to record the gas used by transaction %v", hash))
+	if err := proc.ConfirmClaimCycle(ctx, hash); err != nil {
+		return err
+	}
+	if err := proc.RecordDeliveredTransaction(ctx, hash); err != nil {
+		return err
+	}
 	r, err := storage.Exec(ctx, proc.DB, stmt, hash)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete transactions")
@@ -163,6 +275,26 @@ func (proc *coinProcessor) DeleteTransactions(ctx context.Context, hash string)
 	return nil
 }
 
+// The pending distributions are deleted once their transaction is mined, so the transaction is kept with their review, for the users' history.
+func (proc *coinProcessor) RecordDeliveredTransaction(ctx context.Context, hash string) error {
+	const stmt = `
+update reviewed_coin_distributions r
+set
+	eth_tx = p.eth_tx
+from pending_coin_distributions p
+where
+	p.eth_status = 'ACCEPTED' and
+	p.eth_tx = $1 and
+	r.user_id = p.user_id and
+	r.day = p.day and
+	r.decision LIKE 'approve%'
+`
+
+	_, err := storage.Exec(ctx, proc.DB, stmt, hash)
+
+	return errors.Wrapf(err, "failed to record the delivered transaction %v", hash)
+}
+
 func (proc *coinProcessor) RejectTransaction(ctx context.Context, hash string) error {
 	const stmt = `
 update pending_coin_distributions
@@ -183,30 +315,119 @@ where
 	return nil
 }
 
-func (proc *coinProcessor) GetGasOptions(ctx context.Context) (price *big.Int, limit uint64, err error) {
+// GetGasOptions prices the transactions with the legacy gas price, unless it's explicitly disabled in the `global` table.
+// An explicit `coin_distributer_gas_price_override` always wins, so the transactions are legacy whenever it's set.
+func (proc *coinProcessor) GetGasOptions(ctx context.Context) (*gasOptions, error) {
+	limit, err := proc.GetGasLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	gasOverride, err := proc.GetGasPriceOverride(ctx)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	if gasOverride == 0 {
-		price, err = proc.GetGasPrice(ctx)
-		if err != nil {
-			return nil, 0, err
+	legacy, err := proc.IsLegacyGasPriceEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if legacy || gasOverride != 0 {
+		price, lErr := proc.getLegacyGasPrice(ctx, gasOverride)
+		if lErr != nil {
+			return nil, lErr
 		}
-	} else {
-		price = big.NewInt(0).SetUint64(gasOverride)
+
+		return &gasOptions{GasPrice: price, GasLimit: limit}, nil
+	}
+
+	feeCap, tipCap, err := proc.GetDynamicFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gasOptions{GasFeeCap: feeCap, GasTipCap: tipCap, GasLimit: limit}, nil
+}
+
+func (proc *coinProcessor) getLegacyGasPrice(ctx context.Context, gasOverride uint64) (*big.Int, error) {
+	if gasOverride != 0 {
+		return big.NewInt(0).SetUint64(gasOverride), nil
 	}
 
-	limit, err = proc.GetGasLimit(ctx)
+	return proc.GetGasPrice(ctx)
+}
+
+// GetDynamicFees returns the `maxFeePerGas` and `maxPriorityFeePerGas` for an EIP-1559 transaction,
+// based on the recent blocks (`eth_feeHistory`) and capped by the caps configured in the `global` table.
+func (proc *coinProcessor) GetDynamicFees(ctx context.Context) (feeCap, tipCap *big.Int, err error) {
+	maxFeeCap, err := proc.GetMaxFeePerGasCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxTipCap, err := proc.GetMaxPriorityFeePerGasCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	history, err := proc.Client.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{feeHistoryRewardPercentile})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get fee history")
+	}
+
+	feeCap, tipCap, err = calculateDynamicFees(history, big.NewInt(0).SetUint64(maxFeeCap), big.NewInt(0).SetUint64(maxTipCap))
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
+	}
+
+	if nextBaseFee := history.BaseFee[len(history.BaseFee)-1]; feeCap.Cmp(nextBaseFee) < 0 {
+		log.Warn(fmt.Sprintf("max fee per gas %v is capped below the next base fee %v, the transaction will wait for the base fee to drop",
+			feeCap.String(), nextBaseFee.String()))
+	}
+
+	return feeCap, tipCap, nil
+}
+
+// The tip is the median of the recent blocks' median rewards; the fee cap allows the base fee to double before the transaction gets stuck.
+// Zero caps mean no cap.
+func calculateDynamicFees(history *ethereum.FeeHistory, maxFeeCap, maxTipCap *big.Int) (feeCap, tipCap *big.Int, err error) {
+	if history == nil || len(history.BaseFee) == 0 {
+		return nil, nil, errors.New("fee history has no base fees")
+	}
+
+	rewards := make([]*big.Int, 0, len(history.Reward))
+	for _, blockRewards := range history.Reward {
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			rewards = append(rewards, blockRewards[0])
+		}
+	}
+
+	if len(rewards) == 0 {
+		tipCap = big.NewInt(defaultMaxPriorityFeePerGasWei)
+	} else {
+		sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+		tipCap = new(big.Int).Set(rewards[len(rewards)/2])
+	}
+
+	if maxTipCap.Sign() > 0 && tipCap.Cmp(maxTipCap) > 0 {
+		tipCap.Set(maxTipCap)
+	}
+
+	feeCap = new(big.Int).Mul(history.BaseFee[len(history.BaseFee)-1], big.NewInt(2)) //nolint:gomnd // .
+	feeCap.Add(feeCap, tipCap)
+	if maxFeeCap.Sign() > 0 && feeCap.Cmp(maxFeeCap) > 0 {
+		feeCap.Set(maxFeeCap)
 	}
 
-	return price, limit, nil
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap.Set(feeCap)
+	}
+
+	return feeCap, tipCap, nil
 }
 
-func (proc *coinProcessor) Distribute(ctx context.Context, data *batch) (string, error) {
+func (proc *coinProcessor) Distribute(ctx context.Context, data *batch) (*ethTransaction, error) {
 	recipients, amounts := data.Prepare()
 	for recordNum := range data.Records {
 		log.Info(fmt.Sprintf("batch %v: distributing %v iceflakes to address %v for user %q",
@@ -217,16 +438,16 @@ func (proc *coinProcessor) Distribute(ctx context.Context, data *batch) (string,
 		))
 	}
 
-	txHash, err := proc.Client.Airdrop(ctx, big.NewInt(proc.Conf.Ethereum.ChainID), proc, recipients, amounts)
+	tx, err := proc.Client.Airdrop(ctx, big.NewInt(proc.Conf.Ethereum.ChainID), proc, recipients, amounts)
 	if err != nil {
 		log.Error(errors.Wrapf(err, "batch %v: failed to run contract", data.ID))
 
-		return "", errors.Wrapf(err, "failed to run contract on batch %v", data.ID)
+		return nil, errors.Wrapf(err, "failed to run contract on batch %v", data.ID)
 	}
 
-	log.Info(fmt.Sprintf("batch %v: transaction hash: %v", data.ID, txHash))
+	log.Info(fmt.Sprintf("batch %v: transaction hash: %v, nonce: %v", data.ID, tx.Hash, tx.Nonce))
 
-	return txHash, nil
+	return tx, nil
 }
 
 func (proc *coinProcessor) Do(ctx context.Context) (*batch, error) {
@@ -235,19 +456,33 @@ func (proc *coinProcessor) Do(ctx context.Context) (*batch, error) {
 		return nil, err
 	}
 
-	txHash, err := proc.Distribute(ctx, data)
+	if err = proc.Preflight(ctx, data); err != nil {
+		if !errors.Is(err, errPreflightFailed) {
+			if err2 := proc.BatchRollback(ctx, data); err2 != nil {
+				log.Error(errors.Wrapf(err2, "failed to rollback batch %v", data.ID))
+			}
+		}
+
+		return data, err
+	}
+
+	tx, err := proc.Distribute(ctx, data)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to distribute batch")
 		log.Error(err)
-		if err2 := proc.BatchMarkRejected(ctx, data); err2 != nil {
+		if errors.Is(err, errNotBroadcasted) {
+			if err2 := proc.BatchRollback(ctx, data); err2 != nil {
+				log.Error(errors.Wrapf(err2, "failed to rollback batch %v", data.ID))
+			}
+		} else if err2 := proc.BatchMarkRejected(ctx, data); err2 != nil {
 			log.Error(errors.Wrapf(err2, "failed to mark batch %v as rejected", data.ID))
 		}
 
 		return data, err
 	}
 
-	data.TX = txHash
-	if err = proc.BatchMarkAccepted(ctx, data, txHash); err != nil {
+	data.TX, data.Nonce = tx.Hash, tx.Nonce
+	if err = retryDatabaseRequest(ctx, func() error { return proc.BatchMarkAccepted(ctx, data, tx) }); err != nil {
 		log.Error(errors.Wrapf(err, "failed to mark batch %v as accepted", data.ID))
 
 		return data, err
@@ -416,65 +651,73 @@ func (proc *coinProcessor) Controller(ctx context.Context, notify chan<- *batch)
 	}
 }
 
-func (proc *coinProcessor) GetNextPendingTransaction(ctx context.Context) (string, error) {
+func (proc *coinProcessor) GetNextPendingTransaction(ctx context.Context) (*batch, error) {
 	const stmt = `
 select
-	eth_tx
+	eth_tx,
+	eth_tx_nonce,
+	eth_tx_replaced,
+	eth_tx_cancel
 from
 	pending_coin_distributions
 where
 	eth_status = 'ACCEPTED'
 order by
+	eth_tx_nonce ASC NULLS FIRST,
 	created_at ASC
 limit 1
 `
 
-	val, err := storage.Get[string](ctx, proc.DB, stmt)
+	val, err := storage.Get[batchRecord](ctx, proc.DB, stmt)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			err = nil
 		}
 
-		return "", errors.Wrap(err, "failed to get next pending transaction")
+		return nil, errors.Wrap(err, "failed to get next pending transaction")
+	}
+	data := &batch{TX: *val.EthTX, Replaced: val.EthTXReplaced, Cancel: val.EthTXCancel, Records: []*batchRecord{val}}
+	if val.EthTXNonce != nil {
+		data.Nonce = uint64(*val.EthTXNonce)
 	}
 
-	return *val, nil
+	return data, nil
 }
 
 func (proc *coinProcessor) WaitForAllAcceptedTransactions(ctx context.Context, notify chan<- *batch) error {
 	for ctx.Err() == nil {
-		txHash, err := proc.GetNextPendingTransaction(ctx)
+		data, err := proc.GetNextPendingTransaction(ctx)
 		if err != nil {
 			return err
 		}
 
-		if txHash == "" {
-			return nil
+		if data == nil {
+			return errors.Wrap(proc.maybeResetCancelPendingTransaction(ctx), "failed to maybeResetCancelPendingTransaction")
 		}
 
-		status, err := proc.WaitForTransaction(ctx, txHash)
+		status, err := proc.WaitForTransaction(ctx, data)
 		if err != nil {
 			return err
 		}
 
 		switch status {
 		case ethTxStatusSuccessful:
-			err = proc.DeleteTransactions(ctx, txHash)
+			err = proc.DeleteTransactions(ctx, data.TX)
 
 		case ethTxStatusFailed:
-			proc.MustDisable(fmt.Sprintf("accepted transaction %v failed", txHash))
-			err = proc.RejectTransaction(ctx, txHash)
+			proc.MustDisable(fmt.Sprintf("accepted transaction %v failed", data.TX))
+			err = proc.RejectTransaction(ctx, data.TX)
+
+		case ethTxStatusCancelled:
+			err = proc.RejectTransaction(ctx, data.TX)
 		}
 
 		if err != nil {
-			return errors.Wrapf(err, "failed to update transaction status: %v", txHash)
+			return errors.Wrapf(err, "failed to update transaction status: %v", data.TX)
 		}
 
-		sendNotify(notify, &batch{TX: txHash, Status: status, Records: []*batchRecord{
-			{
-				EthTX: &txHash,
-			},
-		}})
+		data.Status = status
+		sendNotify(notify, data)
 	}
 
 	return ctx.Err()
@@ -490,23 +733,36 @@ func sleepWithContext(ctx context.Context, d stdlibtime.Duration) {
 	}
 }
 
-func (proc *coinProcessor) WaitForTransaction(ctx context.Context, hash string) (ethTxStatus, error) {
-	start := time.Now()
+// WaitForTransaction waits until one of the transactions broadcasted for the batch's nonce is mined.
+// Meanwhile, the transaction is sped up every `stuck-transaction-timeout` and cancelled if an admin asked for it.
+func (proc *coinProcessor) WaitForTransaction(ctx context.Context, data *batch) (ethTxStatus, error) { //nolint:funlen // .
+	start, broadcastedAt := time.Now(), time.Now()
+	var stuckAlertSentAt *time.Time
 
 	for ctx.Err() == nil {
-		status, err := proc.Client.TransactionStatus(ctx, hash)
+		status, err := proc.batchTransactionStatus(ctx, data)
 		if err != nil {
-			return "", errors.Wrapf(err, "failed to get transaction status: %v", hash)
+			return "", errors.Wrapf(err, "failed to get transaction status: %v", data.TX)
 		}
 
 		if status == ethTxStatusPending {
+			attempted, rErr := proc.maybeReplaceTransaction(ctx, data, broadcastedAt)
+			if rErr != nil {
+				return "", rErr
+			}
+			if attempted {
+				broadcastedAt = time.Now()
+			}
 			sleepDuration := stdlibtime.Second * 3
 
 			if d := stdlibtime.Since(*start.Time); d > stdlibtime.Hour {
-				sleepDuration = stdlibtime.Hour
-				log.Warn(fmt.Sprintf("transaction %v is in PENDING state since %v (%v)", hash, start, d))
-				if d > stdlibtime.Hour*24 {
-					sendCoinDistributerTransactionStuck(ctx, hash, start)
+				// Not too long, an admin might be waiting for the cancellation to be broadcasted.
+				sleepDuration = stdlibtime.Minute
+				log.Warn(fmt.Sprintf("transaction %v is in PENDING state since %v (%v)", data.LatestTX(), start, d))
+				if d > stdlibtime.Hour*24 && (stuckAlertSentAt == nil || stdlibtime.Since(*stuckAlertSentAt.Time) > stdlibtime.Hour) {
+					log.Error(errors.Wrap(sendCoinDistributerTransactionStuck(ctx, data.LatestTX(), start),
+						"failed to sendCoinDistributerTransactionStuck"))
+					stuckAlertSentAt = time.Now()
 				}
 			}
 
@@ -515,7 +771,7 @@ func (proc *coinProcessor) WaitForTransaction(ctx context.Context, hash string)
 			continue
 		}
 
-		log.Info(fmt.Sprintf("transaction %v: status: %v, duration: %v", hash, status, stdlibtime.Since(*start.Time)))
+		log.Info(fmt.Sprintf("transaction %v: status: %v, duration: %v", data.TX, status, stdlibtime.Since(*start.Time)))
 
 		return status, nil
 	}
@@ -523,55 +779,199 @@ func (proc *coinProcessor) WaitForTransaction(ctx context.Context, hash string)
 	return "", ctx.Err()
 }
 
-func (proc *coinProcessor) RunDistribution(ctx context.Context, ondemand bool, notify chan<- *batch) error {
-	for it := 1; ctx.Err() == nil; it++ {
-		if !proc.IsEnabled(ctx) {
-			log.Info(fmt.Sprintf("distribution: iteration %v: disabled", it))
+func (proc *coinProcessor) batchTransactionStatus(ctx context.Context, data *batch) (ethTxStatus, error) {
+	if len(data.Replaced) == 0 && !data.IsCancelled() {
+		return proc.Client.TransactionStatus(ctx, data.TX) //nolint:wrapcheck // .
+	}
+
+	statuses, err := proc.Client.TransactionsStatus(ctx, data.Hashes())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get statuses of transactions %v", data.Hashes())
+	}
+	for _, status := range []ethTxStatus{ethTxStatusSuccessful, ethTxStatusFailed} {
+		for _, hash := range statuses[status] {
+			switch {
+			case slices.Contains(data.Cancel, hash):
+				log.Info(fmt.Sprintf("transaction %v: cancelled by %v", data.TX, hash))
+				log.Error(errors.Wrap(sendCoinDistributerTransactionCancelledSlackMessage(ctx, data.TX, hash),
+					"failed to sendCoinDistributerTransactionCancelledSlackMessage"))
+
+				return ethTxStatusCancelled, nil
+			case hash != data.TX:
+				log.Info(fmt.Sprintf("transaction %v: replaced one %v was mined instead", data.TX, hash))
+				if err = proc.BatchMarkMinedReplacement(ctx, data, hash); err != nil {
+					return "", err
+				}
+			}
+
+			return status, nil
+		}
+	}
+
+	return ethTxStatusPending, nil
+}
+
+// The replacement is attempted (even if it fails) at most once per `stuck-transaction-timeout`, unless an admin asked for the cancellation.
+// Once broadcasted, the replacement must be recorded, otherwise the rows would keep waiting for a transaction that will never be mined.
+func (proc *coinProcessor) maybeReplaceTransaction(ctx context.Context, data *batch, broadcastedAt *time.Time) (attempted bool, err error) {
+	cancelRequested, err := proc.IsCancelPendingTransactionRequested(ctx)
+	if err != nil {
+		log.Error(errors.Wrap(err, "failed to check if the cancellation of the pending transaction was requested"))
+	}
+	// The request stays active until all the in-flight transactions are done, but every transaction is cancelled only once.
+	cancelRequested = cancelRequested && !data.IsCancelled()
+	timeout := proc.Conf.StuckTransactionTimeout
+	if !cancelRequested && (timeout <= 0 || stdlibtime.Since(*broadcastedAt.Time) < timeout) {
+		return false, nil
+	}
+
+	var tx *ethTransaction
+	cancel := cancelRequested || data.IsCancelled()
+	if cancel {
+		log.Info(fmt.Sprintf("batch %v: cancelling transaction %v (requested: %v)", data.ID, data.LatestTX(), cancelRequested))
+		tx, err = proc.Client.CancelTransaction(ctx, big.NewInt(proc.Conf.Ethereum.ChainID), proc, data.LatestTX())
+	} else {
+		log.Info(fmt.Sprintf("batch %v: speeding up transaction %v, pending for more than %v", data.ID, data.TX, timeout))
+		tx, err = proc.Client.SpeedUpTransaction(ctx, big.NewInt(proc.Conf.Ethereum.ChainID), proc, data.TX)
+	}
+	if err != nil {
+		log.Error(errors.Wrapf(err, "batch %v: failed to replace transaction %v", data.ID, data.LatestTX()))
 
-			return nil
-		} else if proc.isBlocked() && !ondemand {
-			log.Info(fmt.Sprintf("distribution: iteration %v: blocked", it))
+		return true, nil
+	}
+	if err = retryDatabaseRequest(ctx, func() error { return proc.BatchRecordReplacement(ctx, data, tx, cancel) }); err != nil {
+		return true, errors.Wrapf(err, "failed to BatchRecordReplacement of broadcasted TX %v", tx.Hash)
+	}
+
+	return true, nil
+}
+
+// RunDistribution keeps up to `max-in-flight-batches` transactions pending at the same time.
+// If a batch fails to be broadcasted, no new batches are sent, but the in-flight ones are still waited for.
+func (proc *coinProcessor) RunDistribution(ctx context.Context, ondemand bool, notify chan<- *batch) error { //nolint:funlen // .
+	if claimMode, err := proc.IsMerkleClaimModeEnabled(ctx); err != nil {
+		return errors.Wrap(err, "failed to check the distribution mode")
+	} else if claimMode {
+		return proc.RunClaimCycle(ctx, notify)
+	}
+	var (
+		wg    = new(sync.WaitGroup)
+		mx    = new(sync.Mutex)
+		errs  = new(multierror.Error)
+		slots = make(chan struct{}, proc.maxInFlightBatches())
+	)
+	failed := func() bool {
+		mx.Lock()
+		defer mx.Unlock()
+
+		return errs.ErrorOrNil() != nil
+	}
+	for it := 1; ctx.Err() == nil; it++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			continue
+		}
+		if stop := proc.shouldStopDistribution(ctx, it, ondemand); stop || failed() {
+			<-slots
 
-			return nil
+			break
 		}
 
 		log.Info(fmt.Sprintf("distribution: iteration %v", it))
 		b, err := proc.Do(context.WithoutCancel(ctx))
 		if err != nil {
-			if errors.Is(err, errNotEnoughData) {
-				err = nil
+			<-slots
+			if !errors.Is(err, errNotEnoughData) {
+				mx.Lock()
+				errs = multierror.Append(errs, err)
+				mx.Unlock()
 			}
 
-			return err
+			break
 		}
 
-		status, err := proc.WaitForTransaction(ctx, b.TX)
-		if err != nil {
-			return err
-		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if fErr := proc.FinishBatch(ctx, b, notify); fErr != nil {
+				mx.Lock()
+				errs = multierror.Append(errs, fErr)
+				mx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() == nil {
+		log.Error(errors.Wrap(proc.maybeResetCancelPendingTransaction(ctx), "failed to maybeResetCancelPendingTransaction"))
+	}
 
-		b.Status = status
-		sendNotify(notify, b)
+	return multierror.Append(errs, ctx.Err()).ErrorOrNil() //nolint:wrapcheck // .
+}
 
-		switch status {
-		case ethTxStatusSuccessful:
-			err = proc.DeleteTransactions(ctx, b.TX)
+func (proc *coinProcessor) maxInFlightBatches() int {
+	if proc.Conf.MaxInFlightBatches < 1 {
+		return 1
+	}
 
-		case ethTxStatusFailed:
-			proc.MustDisable(fmt.Sprintf("transaction %v failed", b.TX))
+	return proc.Conf.MaxInFlightBatches
+}
 
-			return errors.Wrapf(proc.RejectTransaction(ctx, b.TX), "failed to reject transaction %v", b.TX)
+func (proc *coinProcessor) shouldStopDistribution(ctx context.Context, it int, ondemand bool) bool {
+	if !proc.IsEnabled(ctx) {
+		log.Info(fmt.Sprintf("distribution: iteration %v: disabled", it))
 
-		default:
-			log.Panic(fmt.Sprintf("unexpected transaction status: %v (%v)", status, b.TX))
-		}
+		return true
+	} else if proc.isBlocked() && !ondemand {
+		log.Info(fmt.Sprintf("distribution: iteration %v: blocked", it))
 
-		if err != nil {
-			return errors.Wrapf(err, "failed to update transaction status: %v", b.TX)
-		}
+		return true
+	} else if cancelRequested, err := proc.IsCancelPendingTransactionRequested(ctx); err != nil || cancelRequested {
+		log.Info(fmt.Sprintf("distribution: iteration %v: cancellation of the pending transactions requested (%v)", it, err))
+
+		return true
 	}
 
-	return ctx.Err()
+	return false
+}
+
+// FinishBatch waits for the batch's transaction and then updates its records accordingly.
+func (proc *coinProcessor) FinishBatch(ctx context.Context, b *batch, notify chan<- *batch) error {
+	status, err := proc.WaitForTransaction(ctx, b)
+	if err != nil {
+		return err
+	}
+
+	b.Status = status
+	sendNotify(notify, b)
+
+	switch status {
+	case ethTxStatusSuccessful:
+		err = proc.DeleteTransactions(ctx, b.TX)
+
+	case ethTxStatusFailed:
+		proc.MustDisable(fmt.Sprintf("transaction %v failed", b.TX))
+
+		return errors.Wrapf(proc.RejectTransaction(ctx, b.TX), "failed to reject transaction %v", b.TX)
+
+	case ethTxStatusCancelled:
+		err = proc.RejectTransaction(ctx, b.TX)
+
+	default:
+		log.Panic(fmt.Sprintf("unexpected transaction status: %v (%v)", status, b.TX))
+	}
+
+	return errors.Wrapf(err, "failed to update transaction status: %v", b.TX)
+}
+
+// The cancellation request is over only when there are no more transactions to cancel.
+func (proc *coinProcessor) maybeResetCancelPendingTransaction(ctx context.Context) error {
+	if cancelRequested, err := proc.IsCancelPendingTransactionRequested(ctx); err != nil || !cancelRequested {
+		return err
+	}
+
+	return proc.ResetCancelPendingTransaction(ctx)
 }
 
 // isInTimeWindow checks if current hour is in time window [startHour, endHour].
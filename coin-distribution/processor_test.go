@@ -5,11 +5,13 @@ package coindistribution
 import (
 	"context"
 	"errors"
+	"math/big"
 	"math/rand"
 	"os"
 	"testing"
 	stdlibtime "time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ice-blockchain/wintr/connectors/storage/v2"
@@ -121,6 +123,49 @@ func TestGetGasPrice(t *testing.T) { //nolint:tparallel //.
 	require.NotEqual(t, gas, gasNew)
 }
 
+func TestCalculateDynamicFees(t *testing.T) {
+	t.Parallel()
+
+	gwei := func(val int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(val), big.NewInt(1_000_000_000))
+	}
+	history := &ethereum.FeeHistory{
+		BaseFee: []*big.Int{gwei(10), gwei(12), gwei(11), gwei(20)},
+		Reward:  [][]*big.Int{{gwei(3)}, {gwei(1)}, {gwei(2)}},
+	}
+
+	feeCap, tipCap, err := calculateDynamicFees(history, big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, gwei(2), tipCap)
+	require.Equal(t, gwei(42), feeCap)
+
+	t.Run("Capped", func(t *testing.T) {
+		t.Parallel()
+
+		feeCap, tipCap, err := calculateDynamicFees(history, gwei(30), big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), tipCap)
+		require.Equal(t, gwei(30), feeCap)
+
+		feeCap, tipCap, err = calculateDynamicFees(history, gwei(1), gwei(5))
+		require.NoError(t, err)
+		require.Equal(t, gwei(1), tipCap)
+		require.Equal(t, gwei(1), feeCap)
+	})
+
+	t.Run("EmptyBlocks", func(t *testing.T) {
+		t.Parallel()
+
+		feeCap, tipCap, err := calculateDynamicFees(&ethereum.FeeHistory{BaseFee: []*big.Int{gwei(10)}}, big.NewInt(0), big.NewInt(0))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(defaultMaxPriorityFeePerGasWei), tipCap)
+		require.Equal(t, gwei(21), feeCap)
+
+		_, _, err = calculateDynamicFees(&ethereum.FeeHistory{}, big.NewInt(0), big.NewInt(0))
+		require.Error(t, err)
+	})
+}
+
 func TestProcessorDistributeAccepted(t *testing.T) { //nolint:paralleltest //.
 	maybeSkipTest(t)
 	ctx := context.TODO()
@@ -142,7 +187,7 @@ func TestProcessorDistributeAccepted(t *testing.T) { //nolint:paralleltest //.
 	}
 }
 
-func TestProcessorDistributeRejected(t *testing.T) { //nolint:paralleltest //.
+func TestProcessorDistributeRolledBack(t *testing.T) { //nolint:paralleltest //.
 	maybeSkipTest(t)
 	ctx := context.TODO()
 	proc := newCoinProcessor(&mockedDummyEthClient{dropErr: errors.New("drop error")}, //nolint:goerr113 //.
@@ -161,7 +206,7 @@ func TestProcessorDistributeRejected(t *testing.T) { //nolint:paralleltest //.
 	data := <-ch
 	t.Logf("batch: %v: processed with %v record(s)", data.ID, len(data.Records))
 	for _, r := range data.Records {
-		require.Equal(t, ethApiStatusRejected, r.EthStatus)
+		require.Equal(t, ethApiStatusNew, r.EthStatus)
 	}
 
 	select {
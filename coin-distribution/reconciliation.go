@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+// ReconcileTransaction checks, before the records of the mined transaction are deleted, that every recipient got exactly what we recorded,
+// according to the Transfer logs (or, approximately, the balance changes) of the transaction.
+// The report is kept in coin_distribution_reconciliations and any discrepancy is alerted.
+// If the transfers can't be fetched, a FAILED report is kept instead, with every recipient's expected amount, so it can be reconciled later.
+// It fails only if the report couldn't be stored, so the records are not deleted without one.
+// The merkle-claim cycles don't transfer anything, so there's nothing to reconcile for them.
+func (proc *coinProcessor) ReconcileTransaction(ctx context.Context, hash string) error {
+	const stmt = `select * from pending_coin_distributions where eth_status = 'ACCEPTED' and eth_tx = $1 and merkle_root is null`
+
+	records, err := storage.Select[batchRecord](ctx, proc.DB, stmt, hash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to select the records of transaction %v", hash)
+	} else if len(records) == 0 {
+		return nil
+	}
+	recipients, _ := (&batch{Records: records}).Prepare()
+	var report *reconciliationReport
+	if transfers, approximate, tErr := proc.Client.Transfers(ctx, hash, recipients); tErr != nil {
+		log.Error(errors.Wrapf(tErr, "failed to get the transfers of transaction %v", hash))
+		report = unverifiedTransfers(hash, records, tErr)
+	} else {
+		report = reconcileTransfers(hash, records, transfers)
+		if approximate {
+			report.Status = approximateReconciliationStatus
+		}
+	}
+	if err = retryDatabaseRequest(ctx, func() error { return proc.storeReconciliationReport(ctx, report) }); err != nil {
+		return err
+	}
+	if len(report.Discrepancies) == 0 {
+		log.Info(fmt.Sprintf("transaction: %v: reconciled: %v recipient(s)", hash, report.Recipients))
+
+		return nil
+	}
+	log.Error(errors.Wrap(sendCoinDistributionReconciliationDiscrepanciesSlackMessage(ctx, report),
+		"failed to sendCoinDistributionReconciliationDiscrepanciesSlackMessage"))
+
+	return nil
+}
+
+// Every recipient is reported as unverified, with nothing actually known to be transferred.
+func unverifiedTransfers(hash string, records []*batchRecord, cause error) *reconciliationReport {
+	report := reconcileTransfers(hash, records, nil)
+	report.Status, report.Error, report.Actual, report.Transfers = failedReconciliationStatus, cause.Error(), nil, 0
+	for _, discrepancy := range report.Discrepancies {
+		discrepancy.Kind, discrepancy.Actual = unverifiedReconciliationDiscrepancy, nil
+	}
+
+	return report
+}
+
+// The records are matched with the transfers by recipient, because the amounts of the same recipient are summed up into a single transfer.
+func reconcileTransfers(hash string, records []*batchRecord, transfers []*erc20Transfer) *reconciliationReport {
+	report := &reconciliationReport{
+		TX:        hash,
+		Status:    reconciledReconciliationStatus,
+		Expected:  new(big.Int),
+		Actual:    new(big.Int),
+		Transfers: len(transfers),
+	}
+	expected := make(map[common.Address]*reconciliationDiscrepancy, len(records))
+	for _, record := range records {
+		if report.Day == nil || record.Day.Before(*report.Day.Time) {
+			report.Day = record.Day
+		}
+		amount := record.Amount()
+		report.Expected.Add(report.Expected, amount)
+		recipient, found := expected[record.Address()]
+		if !found {
+			recipient = &reconciliationDiscrepancy{EthAddress: record.Address().Hex(), Expected: new(big.Int), Actual: new(big.Int)}
+			expected[record.Address()] = recipient
+		}
+		recipient.Expected.Add(recipient.Expected, amount)
+		recipient.UserIDs = append(recipient.UserIDs, record.UserID)
+	}
+	report.Recipients = len(expected)
+	extra := make(map[common.Address]*reconciliationDiscrepancy)
+	for _, transfer := range transfers {
+		report.Actual.Add(report.Actual, transfer.Value)
+		recipient, found := expected[transfer.To]
+		if !found {
+			if recipient, found = extra[transfer.To]; !found {
+				recipient = &reconciliationDiscrepancy{
+					Kind:       extraReconciliationDiscrepancy,
+					EthAddress: transfer.To.Hex(),
+					UserIDs:    []string{},
+					Expected:   new(big.Int),
+					Actual:     new(big.Int),
+				}
+				extra[transfer.To] = recipient
+			}
+		}
+		recipient.Actual.Add(recipient.Actual, transfer.Value)
+	}
+	for _, recipient := range expected {
+		switch {
+		case recipient.Actual.Sign() == 0:
+			recipient.Kind = missingReconciliationDiscrepancy
+		case recipient.Actual.Cmp(recipient.Expected) != 0:
+			recipient.Kind = mismatchedReconciliationDiscrepancy
+		default:
+			continue
+		}
+		report.Discrepancies = append(report.Discrepancies, recipient)
+	}
+	for _, recipient := range extra {
+		report.Discrepancies = append(report.Discrepancies, recipient)
+	}
+	slices.SortFunc(report.Discrepancies, func(a, b *reconciliationDiscrepancy) int {
+		return strings.Compare(a.EthAddress, b.EthAddress)
+	})
+
+	return report
+}
+
+func (proc *coinProcessor) storeReconciliationReport(ctx context.Context, report *reconciliationReport) error {
+	const (
+		reportStmt = `
+insert into coin_distribution_reconciliations
+	(created_at, day, expected_iceflakes, actual_iceflakes, recipients, transfers, discrepancies, status, error, eth_tx)
+values
+	($1, $2, $3, $4, $5, $6, $7, $8, nullif($9, ''), $10)
+on conflict (eth_tx) do update
+set
+	created_at = excluded.created_at,
+	day = excluded.day,
+	expected_iceflakes = excluded.expected_iceflakes,
+	actual_iceflakes = excluded.actual_iceflakes,
+	recipients = excluded.recipients,
+	transfers = excluded.transfers,
+	discrepancies = excluded.discrepancies,
+	status = excluded.status,
+	error = excluded.error
+`
+		columns = 7
+	)
+
+	return storage.DoInTransaction(ctx, proc.DB, func(conn storage.QueryExecer) error {
+		now := time.Now()
+		if _, err := storage.Exec(ctx, conn, reportStmt,
+			now.Time, report.Day.Time, report.Expected.String(), nullableIceflakes(report.Actual), report.Recipients, report.Transfers, len(report.Discrepancies),
+			report.Status, report.Error, report.TX,
+		); err != nil {
+			return errors.Wrapf(err, "failed to insert the reconciliation report of transaction %v", report.TX)
+		}
+		if _, err := storage.Exec(ctx, conn, `delete from coin_distribution_reconciliation_discrepancies where eth_tx = $1`, report.TX); err != nil {
+			return errors.Wrapf(err, "failed to delete the previous reconciliation discrepancies of transaction %v", report.TX)
+		}
+		if len(report.Discrepancies) == 0 {
+			return nil
+		}
+		values := make([]string, 0, len(report.Discrepancies))
+		args := make([]any, 0, len(report.Discrepancies)*columns)
+		for ix, discrepancy := range report.Discrepancies {
+			values = append(values, generateValuesSQLParams(ix, columns))
+			args = append(args,
+				now.Time, discrepancy.Expected.String(), nullableIceflakes(discrepancy.Actual), report.TX, discrepancy.EthAddress, discrepancy.Kind, discrepancy.UserIDs)
+		}
+		sql := fmt.Sprintf(`insert into coin_distribution_reconciliation_discrepancies(created_at, expected_iceflakes, actual_iceflakes, eth_tx, eth_address, kind, user_ids)
+							values %v`, strings.Join(values, ",\n"))
+		_, err := storage.Exec(ctx, conn, sql, args...)
+
+		return errors.Wrapf(err, "failed to insert the %v reconciliation discrepancies of transaction %v", len(report.Discrepancies), report.TX)
+	})
+}
+
+func nullableIceflakes(amount *big.Int) *string {
+	if amount == nil {
+		return nil
+	}
+	val := amount.String()
+
+	return &val
+}
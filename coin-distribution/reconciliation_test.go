@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	stdlibtime "time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution/internal"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+func TestReconcileAirdropSimulated(t *testing.T) {
+	t.Parallel()
+
+	ec, sim := newSimulatedEthClient(t)
+	ctx := context.Background()
+	alice, bob, carol := common.Address{1}, common.Address{2}, common.Address{3}
+
+	tx, err := ec.Airdrop(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), []common.Address{alice, bob}, []*big.Int{big.NewInt(30), big.NewInt(5)})
+	require.NoError(t, err)
+	sim.Commit()
+
+	// The airdrops don't emit any Transfer event, so the balance changes are used.
+	transfers, approximate, err := ec.Transfers(ctx, tx.Hash, []common.Address{alice, bob, carol})
+	require.NoError(t, err)
+	assert.True(t, approximate)
+	require.Equal(t, []*erc20Transfer{{To: alice, Value: big.NewInt(30)}, {To: bob, Value: big.NewInt(5)}}, transfers)
+
+	day := time.New(stdlibtime.Date(2024, 1, 16, 0, 0, 0, 0, stdlibtime.UTC))
+	records := []*batchRecord{
+		{Day: day, UserID: "alice1", EthAddress: alice.Hex(), Iceflakes: "10"},
+		{Day: day, UserID: "alice2", EthAddress: alice.Hex(), Iceflakes: "20"},
+		{Day: day, UserID: "bob", EthAddress: bob.Hex(), Iceflakes: "5"},
+	}
+	report := reconcileTransfers(tx.Hash, records, transfers)
+	assert.Empty(t, report.Discrepancies)
+	assert.Equal(t, 2, report.Recipients)
+	assert.Equal(t, big.NewInt(35), report.Expected)
+	assert.Equal(t, big.NewInt(35), report.Actual)
+	assert.Equal(t, day, report.Day)
+	assert.Equal(t, reconciledReconciliationStatus, report.Status)
+
+	records = []*batchRecord{
+		{Day: day, UserID: "alice", EthAddress: alice.Hex(), Iceflakes: "25"},
+		{Day: day, UserID: "bob", EthAddress: bob.Hex(), Iceflakes: "5"},
+		{Day: day, UserID: "carol", EthAddress: carol.Hex(), Iceflakes: "7"},
+	}
+	report = reconcileTransfers(tx.Hash, records, transfers)
+	require.Len(t, report.Discrepancies, 2)
+	assert.Equal(t, &reconciliationDiscrepancy{
+		Kind: mismatchedReconciliationDiscrepancy, EthAddress: alice.Hex(), UserIDs: []string{"alice"}, Expected: big.NewInt(25), Actual: big.NewInt(30),
+	}, report.Discrepancies[0])
+	assert.Equal(t, &reconciliationDiscrepancy{
+		Kind: missingReconciliationDiscrepancy, EthAddress: carol.Hex(), UserIDs: []string{"carol"}, Expected: big.NewInt(7), Actual: big.NewInt(0),
+	}, report.Discrepancies[1])
+}
+
+func TestReconcileTransferLogsSimulated(t *testing.T) {
+	t.Parallel()
+
+	ec, sim := newSimulatedEthClient(t)
+	ctx := context.Background()
+	me, alice, bob := ec.Signer.Address(), common.Address{1}, common.Address{2}
+
+	_, err := ec.Airdrop(ctx, big.NewInt(simulatedChainID), new(staticGasGetter), []common.Address{me}, []*big.Int{big.NewInt(100)})
+	require.NoError(t, err)
+	sim.Commit()
+	token, err := coindistribution.NewCoindistribution(ec.Contract, ec.RPC)
+	require.NoError(t, err)
+	opts := ec.CreateTransactionOpts(ctx, &gasOptions{GasFeeCap: big.NewInt(1e11), GasTipCap: big.NewInt(1e9), GasLimit: 1_000_000}, big.NewInt(simulatedChainID))
+	tx, err := ec.transactWithNextNonce(opts, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return token.Transfer(opts, bob, big.NewInt(3)) //nolint:wrapcheck // .
+	})
+	require.NoError(t, err)
+	sim.Commit()
+
+	transfers, approximate, err := ec.Transfers(ctx, tx.Hash().Hex(), []common.Address{alice})
+	require.NoError(t, err)
+	assert.False(t, approximate)
+	require.Equal(t, []*erc20Transfer{{From: me, To: bob, Value: big.NewInt(3)}}, transfers)
+
+	day := time.New(stdlibtime.Date(2024, 1, 16, 0, 0, 0, 0, stdlibtime.UTC))
+	report := reconcileTransfers(tx.Hash().Hex(), []*batchRecord{{Day: day, UserID: "alice", EthAddress: alice.Hex(), Iceflakes: "3"}}, transfers)
+	require.Len(t, report.Discrepancies, 2)
+	assert.Equal(t, missingReconciliationDiscrepancy, report.Discrepancies[0].Kind)
+	assert.Equal(t, alice.Hex(), report.Discrepancies[0].EthAddress)
+	assert.Equal(t, &reconciliationDiscrepancy{
+		Kind: extraReconciliationDiscrepancy, EthAddress: bob.Hex(), UserIDs: []string{}, Expected: big.NewInt(0), Actual: big.NewInt(3),
+	}, report.Discrepancies[1])
+}
+
+func TestUnverifiedTransfers(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := common.Address{1}, common.Address{2}
+	day := time.New(stdlibtime.Date(2024, 1, 16, 0, 0, 0, 0, stdlibtime.UTC))
+	records := []*batchRecord{
+		{Day: day, UserID: "alice1", EthAddress: alice.Hex(), Iceflakes: "10"},
+		{Day: day, UserID: "alice2", EthAddress: alice.Hex(), Iceflakes: "20"},
+		{Day: day, UserID: "bob", EthAddress: bob.Hex(), Iceflakes: "5"},
+	}
+	report := unverifiedTransfers("0x1", records, errors.New("receipt not found"))
+	assert.Equal(t, failedReconciliationStatus, report.Status)
+	assert.Equal(t, "receipt not found", report.Error)
+	assert.Equal(t, big.NewInt(35), report.Expected)
+	assert.Nil(t, report.Actual)
+	assert.Equal(t, 2, report.Recipients)
+	assert.Equal(t, []*reconciliationDiscrepancy{
+		{Kind: unverifiedReconciliationDiscrepancy, EthAddress: alice.Hex(), UserIDs: []string{"alice1", "alice2"}, Expected: big.NewInt(30)},
+		{Kind: unverifiedReconciliationDiscrepancy, EthAddress: bob.Hex(), UserIDs: []string{"bob"}, Expected: big.NewInt(5)},
+	}, report.Discrepancies)
+}
+
+type (
+	batchedEthRPC struct {
+		EthRPC
+		client *rpc.Client
+	}
+	mockedBalances map[string]map[common.Address]*big.Int
+)
+
+func (b *batchedEthRPC) Client() *rpc.Client {
+	return b.client
+}
+
+// Call serves eth_call of balanceOf(address) at the given block.
+func (m mockedBalances) Call(args map[string]any, block string) (hexutil.Bytes, error) {
+	data, err := hexutil.Decode(args["data"].(string)) //nolint:forcetypeassert // .
+	if err != nil {
+		return nil, err //nolint:wrapcheck // .
+	}
+	balance, found := m[block][common.BytesToAddress(data[len(data)-common.AddressLength:])]
+	if !found {
+		balance = new(big.Int)
+	}
+
+	return common.LeftPadBytes(balance.Bytes(), 32), nil //nolint:gomnd // uint256.
+}
+
+func TestEthClientBalanceDeltasBatched(t *testing.T) {
+	t.Parallel()
+
+	alice, bob, carol := common.Address{1}, common.Address{2}, common.Address{3}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", mockedBalances{
+		"0x9": {alice: big.NewInt(10), bob: big.NewInt(7)},
+		"0xa": {alice: big.NewInt(40), bob: big.NewInt(7), carol: big.NewInt(5)},
+	}))
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	ec := &ethClientImpl{RPC: &batchedEthRPC{client: client}, Contract: common.Address{9}}
+
+	deltas, err := ec.balanceDeltas(context.Background(), []common.Address{alice, bob, carol}, big.NewInt(9), big.NewInt(10))
+	require.NoError(t, err)
+	require.Len(t, deltas, 3)
+	assert.Equal(t, "30", deltas[0].String())
+	assert.Equal(t, "0", deltas[1].String())
+	assert.Equal(t, "5", deltas[2].String())
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/connectors/storage/v2"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+type (
+	pendingReviewSnapshot struct {
+		Rows     uint64
+		Ice      uint64
+		Checksum int64
+	}
+)
+
+// The snapshot identifies the set of coin distributions pending review, so that any change of it invalidates the approvals given so far.
+func selectPendingReviewSnapshot(ctx context.Context, db storage.Querier, conditions []string, args []any) (*pendingReviewSnapshot, error) {
+	sql := fmt.Sprintf(`SELECT count(1) AS rows,
+							   coalesce(sum(ice),0) AS ice,
+							   coalesce(sum(hashtext(user_id || '~' || day || '~' || ice || '~' || eth_address)),0) AS checksum
+					   FROM coin_distributions_pending_review
+					   WHERE 1=1
+						 AND %[1]v`, strings.Join(append(conditions, "1=1"), " AND "))
+	snapshot, err := storage.ExecOne[pendingReviewSnapshot](ctx, db, sql, args...)
+
+	return snapshot, errors.Wrapf(err, "failed to select the snapshot of coin_distributions_pending_review for %#v", args)
+}
+
+func (s *pendingReviewSnapshot) hash() string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%v:%v:%v", s.Rows, s.Ice, s.Checksum)))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Every approval is recorded for the snapshot of the selected coin distributions and the review is executed only once
+// the configured quorum of admins approved that exact snapshot, with the same decision and distribution mode.
+//
+//nolint:funlen // .
+func (r *repository) recordApproval(
+	ctx context.Context, conn storage.QueryExecer, reviewerUserID string, arg *ReviewCoinDistributionsArg,
+) (*ReviewResult, error) {
+	const (
+		lockSQL   = `SELECT pg_advisory_xact_lock(hashtext('coin_distribution_review_approvals'))`
+		insertSQL = `INSERT INTO coin_distribution_review_approvals(created_at, snapshot, reviewer_user_id, decision, mode)
+													  VALUES ($1, $2, $3, $4, $5)
+					 ON CONFLICT (snapshot, reviewer_user_id, decision, mode) DO NOTHING`
+		countSQL  = `SELECT count(1) AS approvals FROM coin_distribution_review_approvals WHERE snapshot = $1 AND decision = $2 AND mode = $3`
+		deleteSQL = `DELETE FROM coin_distribution_review_approvals WHERE snapshot = $1`
+	)
+	if _, err := storage.Exec(ctx, conn, lockSQL); err != nil {
+		return nil, errors.Wrap(err, "failed to lock coin_distribution_review_approvals")
+	}
+	conditions, whereArgs := arg.where(1)
+	snapshot, err := selectPendingReviewSnapshot(ctx, conn, conditions, whereArgs)
+	if err != nil {
+		return nil, err
+	}
+	result := &ReviewResult{Snapshot: snapshot.hash(), Quorum: max(r.cfg.ReviewQuorum, 1)}
+	if arg.Snapshot != "" && !strings.EqualFold(arg.Snapshot, result.Snapshot) {
+		return nil, errors.Wrapf(ErrReviewSnapshotMismatch, "expected snapshot %v, actual %v", arg.Snapshot, result.Snapshot)
+	}
+	if snapshot.Rows == 0 {
+		return result, nil
+	}
+	if result.Quorum == 1 {
+		result.Approvals, result.Executed = 1, true
+
+		return result, nil
+	}
+	decision, mode := strings.ToLower(arg.Decision), strings.ToLower(arg.Mode)
+	if mode == "" {
+		mode = PushDistributionMode
+	}
+	if _, err = storage.Exec(ctx, conn, insertSQL, time.Now().Time, result.Snapshot, reviewerUserID, decision, mode); err != nil {
+		return nil, errors.Wrapf(err, "failed to record the approval of %v for snapshot %v", reviewerUserID, result.Snapshot)
+	}
+	approvals, err := storage.ExecOne[struct{ Approvals uint64 }](ctx, conn, countSQL, result.Snapshot, decision, mode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to count the approvals for snapshot %v", result.Snapshot)
+	}
+	if result.Approvals = approvals.Approvals; result.Approvals < result.Quorum {
+		return result, errors.Wrap(r.sendCoinDistributionsApprovalRecordedSlackMessage(ctx, reviewerUserID, decision, result),
+			"failed to sendCoinDistributionsApprovalRecordedSlackMessage")
+	}
+	if _, err = storage.Exec(ctx, conn, deleteSQL, result.Snapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete the approvals for snapshot %v", result.Snapshot)
+	}
+	result.Executed = true
+
+	return result, nil
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/log"
+)
+
+// The signer is picked by `ethereum.signer.type`, so switching to a new key (or to a new signer) is only a matter of configuration.
+func mustNewEthSigner(ctx context.Context, conf *config) ethSigner {
+	switch conf.Ethereum.Signer.Type {
+	case "", privateKeyEthSignerType:
+		key, err := crypto.HexToECDSA(conf.Ethereum.PrivateKey)
+		log.Panic(errors.Wrap(err, "failed to parse private key")) //nolint:revive,nolintlint //.
+
+		return newLocalSigner(key)
+	case keystoreEthSignerType:
+		content, err := os.ReadFile(conf.Ethereum.Signer.KeystoreFile)
+		log.Panic(errors.Wrapf(err, "failed to read keystore file %v", conf.Ethereum.Signer.KeystoreFile)) //nolint:revive,nolintlint //.
+		key, err := keystore.DecryptKey(content, conf.Ethereum.Signer.Passphrase)
+		log.Panic(errors.Wrapf(err, "failed to decrypt keystore file %v", conf.Ethereum.Signer.KeystoreFile)) //nolint:revive,nolintlint //.
+
+		return newLocalSigner(key.PrivateKey)
+	case remoteEthSignerType:
+		signer, err := newRemoteSigner(ctx, conf.Ethereum.Signer.URL, conf.Ethereum.Signer.Account)
+		log.Panic(errors.Wrapf(err, "failed to connect to remote signer %v", conf.Ethereum.Signer.URL)) //nolint:revive,nolintlint //.
+
+		return signer
+	default:
+		log.Panic(fmt.Sprintf("unknown ethereum.signer.type `%v`", conf.Ethereum.Signer.Type))
+
+		return nil
+	}
+}
+
+func newLocalSigner(key *ecdsa.PrivateKey) *localSigner {
+	return &localSigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *localSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *localSigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key) //nolint:wrapcheck //.
+}
+
+func (*localSigner) Close() error {
+	return nil
+}
+
+// If no account is configured, the signer must manage exactly one, which is then used.
+func newRemoteSigner(ctx context.Context, url, account string) (*remoteSigner, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dial")
+	}
+	var accounts []common.Address
+	if err = client.CallContext(ctx, &accounts, "account_list"); err != nil {
+		client.Close()
+
+		return nil, errors.Wrap(err, "failed to list the accounts")
+	}
+	signer := &remoteSigner{Client: client}
+	switch {
+	case account != "":
+		signer.Account = common.HexToAddress(account)
+		for _, available := range accounts {
+			if available == signer.Account {
+				return signer, nil
+			}
+		}
+		err = errors.Errorf("account %v is not managed by the signer", account)
+	case len(accounts) == 1:
+		signer.Account = accounts[0]
+
+		return signer, nil
+	default:
+		err = errors.Errorf("the signer manages %v accounts, one of them must be configured", len(accounts))
+	}
+	client.Close()
+
+	return nil, err
+}
+
+func (s *remoteSigner) Address() common.Address {
+	return s.Account
+}
+
+// SignTx asks the signer to sign the transaction (`account_signTransaction`) and checks that it signed exactly what we asked for.
+func (s *remoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := &apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.Account),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Input:   &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas, args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasFeeCap()), (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, errors.Errorf("unsupported transaction type %v", tx.Type())
+	}
+	var res struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := s.Client.CallContext(ctx, &res, "account_signTransaction", args); err != nil {
+		return nil, errors.Wrap(err, "failed to call account_signTransaction")
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(res.Raw); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the signed transaction")
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recover the sender of the signed transaction")
+	}
+	if sender != s.Account || !isSameTransaction(tx, signed) {
+		return nil, errors.Errorf("the signer returned a different transaction %v, sender %v", signed.Hash(), sender)
+	}
+
+	return signed, nil
+}
+
+func (s *remoteSigner) Close() error {
+	s.Client.Close()
+
+	return nil
+}
+
+func isSameTransaction(expected, actual *types.Transaction) bool {
+	sameTo := (expected.To() == nil && actual.To() == nil) || (expected.To() != nil && actual.To() != nil && *expected.To() == *actual.To())
+
+	return sameTo &&
+		expected.Type() == actual.Type() &&
+		expected.Nonce() == actual.Nonce() &&
+		expected.Gas() == actual.Gas() &&
+		expected.Value().Cmp(actual.Value()) == 0 &&
+		expected.GasPrice().Cmp(actual.GasPrice()) == 0 &&
+		expected.GasFeeCap().Cmp(actual.GasFeeCap()) == 0 &&
+		expected.GasTipCap().Cmp(actual.GasTipCap()) == 0 &&
+		bytes.Equal(expected.Data(), actual.Data())
+}
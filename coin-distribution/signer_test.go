@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+// A Clef compatible signer (`account_list` and `account_signTransaction`) that signs everything, with an optional tampering.
+type (
+	mockedRemoteSignerAPI struct {
+		key    *ecdsa.PrivateKey
+		tamper func(tx *types.Transaction) *types.Transaction
+	}
+	mockedSignTransactionResult struct {
+		Raw hexutil.Bytes      `json:"raw"`
+		Tx  *types.Transaction `json:"tx"`
+	}
+)
+
+func (m *mockedRemoteSignerAPI) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(m.key.PublicKey)}
+}
+
+func (m *mockedRemoteSignerAPI) SignTransaction(args apitypes.SendTxArgs, _ *string) (*mockedSignTransactionResult, error) {
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if m.tamper != nil {
+		tx = m.tamper(tx)
+	}
+	if tx, err = types.SignTx(tx, types.LatestSignerForChainID((*big.Int)(args.ChainID)), m.key); err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+
+	return &mockedSignTransactionResult{Raw: raw, Tx: tx}, err
+}
+
+func newMockedRemoteSigner(t *testing.T, api *mockedRemoteSignerAPI) string {
+	t.Helper()
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", api))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	return httpServer.URL
+}
+
+func newSignerTestTransactions() []*types.Transaction {
+	to := common.Address{1}
+
+	return []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1_000), Gas: 21_000, To: &to, Value: big.NewInt(1), Data: []byte{1, 2, 3}}),
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID: big.NewInt(simulatedChainID), Nonce: 2, GasFeeCap: big.NewInt(1_000), GasTipCap: big.NewInt(100), Gas: 50_000, To: &to, Data: []byte{4, 5},
+		}),
+	}
+}
+
+func TestLocalSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := newLocalSigner(key)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	for _, tx := range newSignerTestTransactions() {
+		signed, sErr := signer.SignTx(context.Background(), tx, big.NewInt(simulatedChainID))
+		require.NoError(t, sErr)
+		sender, sErr := types.Sender(types.LatestSignerForChainID(big.NewInt(simulatedChainID)), signed)
+		require.NoError(t, sErr)
+		require.Equal(t, signer.Address(), sender)
+		require.True(t, isSameTransaction(tx, signed))
+	}
+	require.NoError(t, signer.Close())
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	account, err := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "secret")
+	require.NoError(t, err)
+
+	conf := new(config)
+	conf.Ethereum.Signer.Type = keystoreEthSignerType
+	conf.Ethereum.Signer.KeystoreFile = account.URL.Path
+	conf.Ethereum.Signer.Passphrase = "secret"
+	signer := mustNewEthSigner(context.Background(), conf)
+	require.Equal(t, account.Address, signer.Address())
+
+	conf.Ethereum.Signer.Passphrase = "wrong"
+	require.Panics(t, func() { mustNewEthSigner(context.Background(), conf) })
+}
+
+func TestRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	api := &mockedRemoteSignerAPI{key: key}
+	url := newMockedRemoteSigner(t, api)
+	ctx := context.Background()
+
+	t.Run("the only account is used", func(t *testing.T) {
+		t.Parallel()
+
+		signer, sErr := newRemoteSigner(ctx, url, "")
+		require.NoError(t, sErr)
+		defer signer.Close()
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+		for _, tx := range newSignerTestTransactions() {
+			signed, tErr := signer.SignTx(ctx, tx, big.NewInt(simulatedChainID))
+			require.NoError(t, tErr)
+			require.True(t, isSameTransaction(tx, signed))
+			sender, tErr := types.Sender(types.LatestSignerForChainID(big.NewInt(simulatedChainID)), signed)
+			require.NoError(t, tErr)
+			require.Equal(t, signer.Address(), sender)
+		}
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		t.Parallel()
+
+		_, sErr := newRemoteSigner(ctx, url, common.Address{1}.Hex())
+		require.ErrorContains(t, sErr, "is not managed by the signer")
+	})
+
+	t.Run("tampered transaction", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := &mockedRemoteSignerAPI{key: key, tamper: func(tx *types.Transaction) *types.Transaction {
+			return types.NewTx(&types.LegacyTx{Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), Gas: tx.Gas(), To: &common.Address{2}, Data: tx.Data()})
+		}}
+		signer, sErr := newRemoteSigner(ctx, newMockedRemoteSigner(t, tampered), crypto.PubkeyToAddress(key.PublicKey).Hex())
+		require.NoError(t, sErr)
+		defer signer.Close()
+
+		_, sErr = signer.SignTx(ctx, newSignerTestTransactions()[0], big.NewInt(simulatedChainID))
+		require.ErrorContains(t, sErr, "the signer returned a different transaction")
+	})
+}
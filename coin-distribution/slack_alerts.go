@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	stdlibtime "time"
 
 	"github.com/goccy/go-json"
@@ -34,6 +35,37 @@ func (r *repository) sendCurrentCoinDistributionsAvailableForReviewAreDeniedSlac
 	return errors.Wrap(sendSlackMessage(ctx, text, r.cfg.AlertSlackWebhook), "failed to sendSlackMessage")
 }
 
+func (r *repository) sendSelectedCoinDistributionsAreReviewedSlackMessage(
+	ctx context.Context, reviewerUserID, decision, reason string, recipients uint64, iceCoins float64,
+) error {
+	text := fmt.Sprintf(":mag:`%v` a selection of the current pending coin distributions was reviewed by `%v`, the rest are still pending review :mag:\n`decision`: `%v`\n`reason`: `%v`\n`users`: `%v`\n`coins`: `%v`", r.cfg.Environment, reviewerUserID, decision, reason, recipients, fmt.Sprintf("%.2f", iceCoins)) //nolint:lll // .
+
+	return errors.Wrap(sendSlackMessage(ctx, text, r.cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
+func (r *repository) sendCoinDistributionsApprovalRecordedSlackMessage(ctx context.Context, reviewerUserID, decision string, result *ReviewResult) error {
+	text := fmt.Sprintf(":ballot_box_with_check:`%v` `%v` approved the pending coin distributions with snapshot `%v`, waiting for the quorum to execute it :ballot_box_with_check:\n`decision`: `%v`\n`approvals`: `%v/%v`", r.cfg.Environment, reviewerUserID, result.Snapshot, decision, result.Approvals, result.Quorum) //nolint:lll // .
+
+	return errors.Wrap(sendSlackMessage(ctx, text, r.cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
+func (r *repository) sendPendingCoinDistributionTransactionCancellationRequestedSlackMessage(ctx context.Context, txHash, adminUserID string) error {
+	text := fmt.Sprintf(":x:`%v` cancellation of the pending coin distribution transaction `%v` was requested by `%v` :x:", r.cfg.Environment, txHash, adminUserID) //nolint:lll // .
+
+	return errors.Wrap(sendSlackMessage(ctx, text, r.cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
+func (r *repository) sendHeldCoinDistributionsReleasedSlackMessage(
+	ctx context.Context, adminUserID, reason, userIDs string, released *ReleasedCoinDistributions,
+) error {
+	if userIDs == "" {
+		userIDs = "all"
+	}
+	text := fmt.Sprintf(":unlock:`%v` held coin distributions were released back to review by `%v` :unlock:\n`reason`: `%v`\n`userIds`: `%v`\n`users`: `%v`\n`coins`: `%v`\n`kept held due to conflicts`: `%v`", r.cfg.Environment, adminUserID, reason, userIDs, released.Rows, fmt.Sprintf("%.2f", released.Ice), released.Conflicts) //nolint:lll // .
+
+	return errors.Wrap(sendSlackMessage(ctx, text, r.cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
 func sendNewCoinDistributionsAvailableForReviewSlackMessage(ctx context.Context) error {
 	text := fmt.Sprintf(":eyes:`%v` <%v|new coin distributions are available for review> :eyes:", cfg.Environment, cfg.ReviewURL)
 
@@ -80,6 +112,12 @@ func sendCoinDistributerTransactionStuck(ctx context.Context, hash string, start
 	return errors.Wrap(sendSlackMessage(ctx, text, cfg.AlertSlackWebhook), "failed to sendSlackMessage")
 }
 
+func sendCoinDistributerTransactionCancelledSlackMessage(ctx context.Context, hash, cancelHash string) error {
+	text := fmt.Sprintf(":x:`%v` transaction `%v` was cancelled by `%v`, its coin distributions are rejected :x:", cfg.Environment, hash, cancelHash)
+
+	return errors.Wrap(sendSlackMessage(ctx, text, cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
 func sendAllCurrentCoinDistributionsWereCommittedInEthereumSlackMessage(ctx context.Context) error {
 	text := fmt.Sprintf(":tada:`%v` all coin distributions have been committed successfully in ethereum :tada:", cfg.Environment)
 
@@ -98,12 +136,38 @@ func sendEthereumGasLimitTooLowSlackMessage(ctx context.Context, errMsg string)
 	return errors.Wrap(sendSlackMessage(ctx, text, cfg.AlertSlackWebhook), "failed to sendSlackMessage")
 }
 
+func sendBatchSizeBelowMinSizeSlackMessage(ctx context.Context, sizing *batchSizing) error {
+	text := fmt.Sprintf(":warning:`%v` only `%v` coin distributions fit into the gas limit `%v`, below the min batch size `%v`. The gas limit or the min batch size should be changed :warning:\n`rationale`: `%v`", cfg.Environment, sizing.Size, sizing.GasLimit, sizing.MinSize, sizing.Rationale) //nolint:lll // .
+
+	return errors.Wrap(sendSlackMessage(ctx, text, cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
 func sendCoinDistributionsProcessingStoppedDueToUnrecoverableFailureSlackMessage(ctx context.Context, reason string) error {
 	text := fmt.Sprintf(":bangbang:`%v` coin distribution processing stopped due to failure :bangbang:\n:rotating_light: reason: `%v` :rotating_light:", cfg.Environment, reason) //nolint:lll // .
 
 	return errors.Wrap(sendSlackMessage(ctx, text, cfg.AlertSlackWebhook), "failed to sendSlackMessage")
 }
 
+func sendCoinDistributionReconciliationDiscrepanciesSlackMessage(ctx context.Context, report *reconciliationReport) error {
+	const maxListed = 10
+	discrepancies := make([]string, 0, maxListed)
+	for _, discrepancy := range report.Discrepancies[:min(maxListed, len(report.Discrepancies))] {
+		discrepancies = append(discrepancies, fmt.Sprintf("`%v` `%v`: expected `%v`, actual `%v`", discrepancy.Kind, discrepancy.EthAddress, discrepancy.Expected, discrepancy.Actual)) //nolint:lll // .
+	}
+	text := fmt.Sprintf(":mag_right:`%v` transaction `%v` doesn't match the coin distributions it was sent for :mag_right:\n`status`: `%v`\n`recipients`: `%v`\n`transfers`: `%v`\n`discrepancies`: `%v`\n`expected`: `%v`\n`actual`: `%v`\n%v", cfg.Environment, report.TX, report.Status, report.Recipients, report.Transfers, len(report.Discrepancies), report.Expected, report.Actual, strings.Join(discrepancies, "\n")) //nolint:lll // .
+	if report.Error != "" {
+		text = fmt.Sprintf("%v\n`error`: `%v`", text, report.Error)
+	}
+
+	return errors.Wrap(sendSlackMessage(ctx, text, cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
+func sendCoinDistributionsHeldSlackMessage(ctx context.Context, reason string, recipients uint64, iceCoins float64) error {
+	text := fmt.Sprintf(":octagonal_sign:`%v` coin distributions breached a cap and are held until an admin releases them :octagonal_sign:\n`reason`: `%v`\n`users`: `%v`\n`coins`: `%v`", cfg.Environment, reason, recipients, fmt.Sprintf("%.2f", iceCoins)) //nolint:lll // .
+
+	return errors.Wrap(sendSlackMessage(ctx, text, cfg.AlertSlackWebhook), "failed to sendSlackMessage")
+}
+
 func sendSlackMessage(ctx context.Context, text, alertSlackWebhook string) error {
 	message := struct {
 		Text string `json:"text,omitempty"`
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package coindistribution
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+	stdlibtime "time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/log"
+)
+
+// NewTransactor signs the transactions with the key. If gasLimit is 0, it's estimated for each transaction.
+func NewTransactor(rpcClient EthRPC, key *ecdsa.PrivateKey, chainID *big.Int, gasLimit uint64, legacyGasPrice bool) *Transactor {
+	signer := newLocalSigner(key)
+	client := &ethClientImpl{
+		RPC:    rpcClient,
+		Signer: signer,
+		Mutex:  new(sync.Mutex),
+		Nonces: newNonceManager(rpcClient, signer.Address()),
+	}
+
+	return &Transactor{
+		client:  client,
+		gas:     &networkGasGetter{Client: client, GasLimit: gasLimit, Legacy: legacyGasPrice},
+		chainID: chainID,
+	}
+}
+
+// Transact calls the method of the contract and returns the hash of the broadcasted transaction.
+func (t *Transactor) Transact(ctx context.Context, contract *bind.BoundContract, method string, params ...any) (string, error) {
+	return maybeRetryRPCRequest(ctx, func() (string, error) {
+		gasOpts, err := t.gas.GetGasOptions(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get gas options")
+		}
+
+		opts := t.client.CreateTransactionOpts(ctx, gasOpts, t.chainID)
+		tx, err := t.client.transactWithNextNonce(opts, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.Transact(opts, method, params...) //nolint:wrapcheck // .
+		})
+		if err != nil {
+			return "", err
+		}
+		log.Info(fmt.Sprintf("transactor: new transaction: %v | method %v | type %v | nonce %v | gas %v | tip %v | limit %v",
+			tx.Hash().String(),
+			method,
+			tx.Type(),
+			tx.Nonce(),
+			tx.GasPrice().String(),
+			tx.GasTipCap().String(),
+			tx.Gas(),
+		))
+
+		return tx.Hash().String(), nil
+	})
+}
+
+// WaitMined waits for the receipt of the transaction. If the transaction reverted, it returns ErrTransactionFailed.
+func (t *Transactor) WaitMined(ctx context.Context, hash string) error {
+	ticker := stdlibtime.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := t.client.TransactionStatus(ctx, hash)
+		switch {
+		case err != nil:
+			return errors.Wrapf(err, "failed to get the status of transaction %v", hash)
+		case status == ethTxStatusSuccessful:
+			return nil
+		case status == ethTxStatusFailed:
+			return errors.Wrapf(ErrTransactionFailed, "transaction %v", hash)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "transaction %v is still pending", hash)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Transactor) Close() error {
+	return errors.Wrap(t.client.Close(), "failed to close the eth client")
+}
+
+func (g *networkGasGetter) GetGasOptions(ctx context.Context) (*gasOptions, error) {
+	if g.Legacy {
+		price, err := g.Client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get gas price")
+		}
+
+		return &gasOptions{GasPrice: price, GasLimit: g.GasLimit}, nil
+	}
+
+	history, err := g.Client.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{feeHistoryRewardPercentile})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get fee history")
+	}
+	feeCap, tipCap, err := calculateDynamicFees(history, big.NewInt(0), big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gasOptions{GasFeeCap: feeCap, GasTipCap: tipCap, GasLimit: g.GasLimit}, nil
+}
@@ -3,16 +3,24 @@
 package extrabonusnotifier
 
 import (
+	"context"
+	"io"
+	"sync"
 	stdlibtime "time"
 
 	"github.com/ice-blockchain/freezer/model"
 	messagebroker "github.com/ice-blockchain/wintr/connectors/message_broker"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
 	"github.com/ice-blockchain/wintr/time"
 )
 
 // Public API.
 
 type (
+	Client interface {
+		io.Closer
+		CheckHealth(context.Context) error
+	}
 	User struct {
 		model.ExtraBonusStartedAtField
 		model.UserIDField
@@ -54,6 +62,7 @@ var (
 	cfg struct {
 		ExtraBonusConfig      `mapstructure:",squash"` //nolint:tagliatelle // Nope.
 		messagebrokerConfig   `mapstructure:",squash"` //nolint:tagliatelle // Nope.
+		tenantConfig          `mapstructure:",squash"` //nolint:tagliatelle // Nope.
 		MiningSessionDuration stdlibtime.Duration      `yaml:"miningSessionDuration"`
 		Workers               int64                    `yaml:"workers"`
 		BatchSize             int64                    `yaml:"batchSize"`
@@ -63,8 +72,16 @@ var (
 
 type (
 	messagebrokerConfig = messagebroker.Config
-	extraBonusNotifier  struct {
+	// The same as tokenomics.Config.Tenant and tokenomics.Config.RedisKeyPrefix.
+	tenantConfig struct {
+		Tenant         string `yaml:"tenant"`
+		RedisKeyPrefix string `yaml:"redisKeyPrefix" mapstructure:"redisKeyPrefix"`
+	}
+	extraBonusNotifier struct {
 		mb                            messagebroker.Client
+		db                            storage.DB
+		cancel                        context.CancelFunc
+		wg                            *sync.WaitGroup
 		extraBonusStartDate           *time.Time
 		extraBonusIndicesDistribution map[uint16]map[uint16]uint16
 	}
@@ -27,30 +27,33 @@ import (
 func init() {
 	appCfg.MustLoadFromKey(parentApplicationYamlKey, &cfg.messagebrokerConfig)
 	appCfg.MustLoadFromKey(parentApplicationYamlKey, &cfg.ExtraBonusConfig)
+	appCfg.MustLoadFromKey(parentApplicationYamlKey, &cfg.tenantConfig)
+	if tenant := strings.ToLower(cfg.Tenant); tenant != "" {
+		appCfg.MustLoadFromKey(parentApplicationYamlKey+".tenants."+tenant, &cfg.ExtraBonusConfig)
+		appCfg.MustLoadFromKey(parentApplicationYamlKey+".tenants."+tenant, &cfg.tenantConfig)
+	}
 	appCfg.MustLoadFromKey(applicationYamlKey, &cfg)
 }
 
-func MustStartNotifyingExtraBonusAvailability(ctx context.Context) {
-	ebs := &extraBonusNotifier{
-		mb: messagebroker.MustConnect(context.Background(), parentApplicationYamlKey),
+func MustStartNotifyingExtraBonusAvailability(ctx context.Context, cancel context.CancelFunc) Client {
+	ebn := &extraBonusNotifier{
+		mb:     messagebroker.MustConnect(context.Background(), parentApplicationYamlKey),
+		db:     model.MustConnectStorage(context.Background(), parentApplicationYamlKey, cfg.RedisKeyPrefix, 1),
+		cancel: cancel,
+		wg:     new(sync.WaitGroup),
 	}
-	tmpDb := storage.MustConnect(context.Background(), parentApplicationYamlKey, 1)
-	ebs.extraBonusStartDate = MustGetExtraBonusStartDate(ctx, tmpDb)
-	ebs.extraBonusIndicesDistribution = MustGetExtraBonusIndicesDistribution(ctx, tmpDb)
-	log.Panic(tmpDb.Close())
-
-	defer func() { log.Panic(errors.Wrap(ebs.Close(), "failed to stop extraBonusNotifier")) }()
-
-	wg := new(sync.WaitGroup)
-	wg.Add(int(cfg.Workers))
-	defer wg.Wait()
+	ebn.extraBonusStartDate = MustGetExtraBonusStartDate(ctx, ebn.db)
+	ebn.extraBonusIndicesDistribution = MustGetExtraBonusIndicesDistribution(ctx, ebn.db)
+	ebn.wg.Add(int(cfg.Workers))
 
 	for workerNumber := int64(0); workerNumber < cfg.Workers; workerNumber++ {
 		go func(wn int64) {
-			defer wg.Done()
-			ebs.notifyingExtraBonusAvailability(ctx, wn)
+			defer ebn.wg.Done()
+			ebn.notifyingExtraBonusAvailability(ctx, wn)
 		}(workerNumber)
 	}
+
+	return ebn
 }
 
 func MustGetExtraBonusStartDate(ctx context.Context, db storage.DB) (extraBonusStartDate *time.Time) {
@@ -137,13 +140,55 @@ func MustGetExtraBonusIndicesDistribution(ctx context.Context, db storage.DB) ma
 }
 
 func (ebn *extraBonusNotifier) Close() error {
+	ebn.cancel()
+	ebn.wg.Wait()
+
 	return multierror.Append(
 		errors.Wrap(ebn.mb.Close(), "failed to close mb"),
+		errors.Wrap(ebn.db.Close(), "failed to close db"),
 	).ErrorOrNil()
 }
 
+func (ebn *extraBonusNotifier) CheckHealth(ctx context.Context) error {
+	if err := ebn.checkDBHealth(ctx); err != nil {
+		return err
+	}
+	type ts struct {
+		TS *time.Time `json:"ts"`
+	}
+	now := ts{TS: time.Now()}
+	bytes, err := json.MarshalContext(ctx, now)
+	if err != nil {
+		return errors.Wrapf(err, "[health-check] failed to marshal %#v", now)
+	}
+	responder := make(chan error, 1)
+	ebn.mb.SendMessage(ctx, &messagebroker.Message{
+		Headers: map[string]string{"producer": "freezer"},
+		Key:     cfg.MessageBroker.Topics[0].Name,
+		Topic:   cfg.MessageBroker.Topics[0].Name,
+		Value:   bytes,
+	}, responder)
+
+	return errors.Wrapf(<-responder, "[health-check] failed to send health check message to broker")
+}
+
+func (ebn *extraBonusNotifier) checkDBHealth(ctx context.Context) error {
+	if resp := ebn.db.Ping(ctx); resp.Err() != nil || resp.Val() != "PONG" {
+		if resp.Err() == nil {
+			resp.SetErr(errors.Errorf("response `%v` is not `PONG`", resp.Val()))
+		}
+
+		return errors.Wrap(resp.Err(), "[health-check] failed to ping DB")
+	}
+	if !ebn.db.IsRW(ctx) {
+		return errors.New("db is not writeable")
+	}
+
+	return nil
+}
+
 func (ebn *extraBonusNotifier) notifyingExtraBonusAvailability(ctx context.Context, workerNumber int64) {
-	db := storage.MustConnect(context.Background(), parentApplicationYamlKey, 1)
+	db := model.MustConnectStorage(context.Background(), parentApplicationYamlKey, cfg.RedisKeyPrefix, 1)
 	defer func() {
 		if err := recover(); err != nil {
 			log.Error(db.Close())
@@ -35,6 +35,81 @@ type (
 		RemainingFreeMiningSessions uint64     `json:"remainingFreeMiningSessions,omitempty"`
 		MiningStreak                uint64     `json:"miningStreak,omitempty"`
 	}
+	SimulationScenario struct {
+		StartedAt             stdlibtime.Time               `yaml:"startedAt"`
+		Development           *bool                         `yaml:"development"`
+		MiningSessionDuration *stdlibtime.Duration          `yaml:"miningSessionDuration"`
+		CoinDistribution      *SimulationCoinDistribution   `yaml:"coinDistribution"`
+		Users                 []*SimulationUser             `yaml:"users"`
+		MiningBoostLevels     []*SimulationMiningBoostLevel `yaml:"miningBoostLevels"`
+		Duration              stdlibtime.Duration           `yaml:"duration"`
+		Tick                  stdlibtime.Duration           `yaml:"tick"`
+	}
+	SimulationMiningBoostLevel struct {
+		MaxT1Referrals      uint64              `yaml:"maxT1Referrals"`
+		MiningSessionLength stdlibtime.Duration `yaml:"miningSessionLength"`
+		SlashingDisabled    bool                `yaml:"slashingDisabled"`
+	}
+	SimulationCoinDistribution struct {
+		DeniedCountries          []string            `yaml:"deniedCountries"`
+		StartedAfter             stdlibtime.Duration `yaml:"startedAfter"`
+		EndedAfter               stdlibtime.Duration `yaml:"endedAfter"`
+		MinBalanceRequired       float64             `yaml:"minBalanceRequired"`
+		StartHour                int                 `yaml:"startHour"`
+		MinMiningStreaksRequired uint64              `yaml:"minMiningStreaksRequired"`
+		Enabled                  bool                `yaml:"enabled"`
+		ForcedExecution          bool                `yaml:"forcedExecution"`
+	}
+	SimulationUser struct {
+		MiningBoostLevelIndex          *uint64                    `yaml:"miningBoostLevelIndex"`
+		ID                             string                     `yaml:"id"`
+		ReferredBy                     string                     `yaml:"referredBy"`
+		Country                        string                     `yaml:"country"`
+		Email                          string                     `yaml:"email"`
+		MiningBlockchainAccountAddress string                     `yaml:"miningBlockchainAccountAddress"`
+		MiningSessions                 []*SimulationMiningSession `yaml:"miningSessions"`
+		JoinedAfter                    stdlibtime.Duration        `yaml:"joinedAfter"`
+		PreStakingAllocation           float64                    `yaml:"preStakingAllocation"`
+		PreStakingBonus                float64                    `yaml:"preStakingBonus"`
+		Verified                       bool                       `yaml:"verified"`
+	}
+	SimulationMiningSession struct {
+		StoppedAfter *stdlibtime.Duration `yaml:"stoppedAfter"`
+		StartedAfter stdlibtime.Duration  `yaml:"startedAfter"`
+		Resurrect    bool                 `yaml:"resurrect"`
+	}
+	SimulationTick struct {
+		Now                     *time.Time `json:"now"`
+		UserID                  string     `json:"userId"`
+		BaseMiningRate          float64    `json:"baseMiningRate"`
+		BalanceTotalStandard    float64    `json:"balanceTotalStandard"`
+		BalanceTotalPreStaking  float64    `json:"balanceTotalPreStaking"`
+		BalanceTotalMinted      float64    `json:"balanceTotalMinted"`
+		BalanceTotalSlashed     float64    `json:"balanceTotalSlashed"`
+		BalanceSolo             float64    `json:"balanceSolo"`
+		BalanceT0               float64    `json:"balanceT0"`
+		BalanceT1               float64    `json:"balanceT1"`
+		BalanceT2               float64    `json:"balanceT2"`
+		BalanceForT0            float64    `json:"balanceForT0"`
+		BalanceForTMinus1       float64    `json:"balanceForTMinus1"`
+		BalanceSoloPending      float64    `json:"balanceSoloPending"`
+		BalanceT1Pending        float64    `json:"balanceT1Pending"`
+		BalanceT2Pending        float64    `json:"balanceT2Pending"`
+		BalanceSoloEthereum     float64    `json:"balanceSoloEthereum"`
+		BalanceT0Ethereum       float64    `json:"balanceT0Ethereum"`
+		BalanceT1Ethereum       float64    `json:"balanceT1Ethereum"`
+		BalanceForT0Ethereum    float64    `json:"balanceForT0Ethereum"`
+		SlashingRateSolo        float64    `json:"slashingRateSolo"`
+		SlashingRateT0          float64    `json:"slashingRateT0"`
+		SlashingRateForT0       float64    `json:"slashingRateForT0"`
+		SlashingRateForTMinus1  float64    `json:"slashingRateForTMinus1"`
+		PendingAmountForT0      float64    `json:"pendingAmountForT0"`
+		PendingAmountForTMinus1 float64    `json:"pendingAmountForTMinus1"`
+		EthereumDistributed     float64    `json:"ethereumDistributed"`
+		ActiveT1Referrals       int32      `json:"activeT1Referrals"`
+		ActiveT2Referrals       int32      `json:"activeT2Referrals"`
+		Mining                  bool       `json:"mining"`
+	}
 )
 
 // Private API.
@@ -43,7 +118,8 @@ const (
 	applicationYamlKey       = "miner"
 	parentApplicationYamlKey = "tokenomics"
 	requestDeadline          = 30 * stdlibtime.Second
-	doctorXTenant            = "doctorx"
+	// The part of the welcome bonus that isn't given to the user itself.
+	welcomeBonusV2Deduction = 10 * model.AmountDenomination
 )
 
 // .
@@ -105,6 +181,8 @@ type (
 		model.BalanceTotalPreStakingField
 		model.BalanceTotalMintedField
 		model.BalanceTotalSlashedField
+		model.BalanceMintedByRateField
+		model.BalanceSlashedByRateField
 		model.BalanceSoloPendingAppliedField
 		model.BalanceT1WelcomeBonusPendingAppliedField
 		model.BalanceT1PendingAppliedField
@@ -217,22 +295,18 @@ type (
 		extraBonusIndicesDistribution               map[uint16]map[uint16]uint16
 	}
 	config struct {
-		miningBoostLevels                       *atomic.Pointer[[]*tokenomics.MiningBoostLevel]
-		disableAdvancedTeam                     *atomic.Pointer[[]string]
-		coinDistributionCollectorStartedAt      *atomic.Pointer[time.Time]
-		coinDistributionCollectorSettings       *atomic.Pointer[coindistribution.CollectorSettings]
-		MainnetRewardPoolContributionEthAddress string                   `yaml:"mainnetRewardPoolContributionEthAddress" mapstructure:"mainnetRewardPoolContributionEthAddress"`
-		tokenomics.Config                       `mapstructure:",squash"` //nolint:tagliatelle // Nope.
-		EthereumDistributionFrequency           struct {
-			Min stdlibtime.Duration `yaml:"min"`
-			Max stdlibtime.Duration `yaml:"max"`
-		} `yaml:"ethereumDistributionFrequency" mapstructure:"ethereumDistributionFrequency"`
-		MainnetRewardPoolContributionPercentage      float64 `yaml:"mainnetRewardPoolContributionPercentage" mapstructure:"mainnetRewardPoolContributionPercentage"`
-		Workers                                      int64   `yaml:"workers"`
-		BatchSize                                    int64   `yaml:"batchSize"`
-		SlashingDaysCount                            int64   `yaml:"slashingDaysCount"`
-		Development                                  bool    `yaml:"development"`
-		T1ReferralsAllowedWithoutAnyMiningBoostLevel bool    `yaml:"t1ReferralsAllowedWithoutAnyMiningBoostLevel" mapstructure:"t1ReferralsAllowedWithoutAnyMiningBoostLevel"`
-		DryRunDistribution                           bool    `yaml:"dryRunDistribution" mapstructure:"dryRunDistribution"`
+		miningBoostLevels                            *atomic.Pointer[[]*tokenomics.MiningBoostLevel]
+		disableAdvancedTeam                          *atomic.Pointer[[]string]
+		coinDistributionCollectorStartedAt           *atomic.Pointer[time.Time]
+		coinDistributionCollectorSettings            *atomic.Pointer[coindistribution.CollectorSettings]
+		MainnetRewardPoolContributionEthAddress      string                   `yaml:"mainnetRewardPoolContributionEthAddress" mapstructure:"mainnetRewardPoolContributionEthAddress"`
+		tokenomics.Config                            `mapstructure:",squash"` //nolint:tagliatelle // Nope.
+		MainnetRewardPoolContributionPercentage      float64                  `yaml:"mainnetRewardPoolContributionPercentage" mapstructure:"mainnetRewardPoolContributionPercentage"`
+		Workers                                      int64                    `yaml:"workers"`
+		BatchSize                                    int64                    `yaml:"batchSize"`
+		SlashingDaysCount                            int64                    `yaml:"slashingDaysCount"`
+		Development                                  bool                     `yaml:"development"`
+		T1ReferralsAllowedWithoutAnyMiningBoostLevel bool                     `yaml:"t1ReferralsAllowedWithoutAnyMiningBoostLevel" mapstructure:"t1ReferralsAllowedWithoutAnyMiningBoostLevel"`
+		DryRunDistribution                           bool                     `yaml:"dryRunDistribution" mapstructure:"dryRunDistribution"`
 	}
 )
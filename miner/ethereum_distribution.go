@@ -37,7 +37,7 @@ func (ref *referral) isEligibleForSelfForEthereumDistribution(now, lastEthereumC
 			return false
 		}
 	} else {
-		miningBlockchainAccountAddress = "skip"
+		miningBlockchainAccountAddress = coindistribution.SkipEthereumAddressValidation
 	}
 
 	return ref.ID != 0 &&
@@ -78,9 +78,9 @@ func (ref *referral) isEligibleForReferralForEthereumDistribution(now *time.Time
 		ref.isMandatoryFieldsSetForDistributionValid() &&
 		coindistribution.IsEligibleForEthereumDistribution(
 			0,
-			0.1,
+			model.MustNewAmount(0.1),
 			0,
-			"skip",
+			coindistribution.SkipEthereumAddressValidation,
 			ref.Country,
 			coinDistributionCollectorSettings.DeniedCountries,
 			now,
@@ -100,7 +100,7 @@ func (u *user) isEligibleForSelfForEthereumDistribution(now *time.Time, selfVeri
 	coinDistributionCollectorSettings := cfg.coinDistributionCollectorSettings.Load()
 	miningBlockchainAccountAddress := u.MiningBlockchainAccountAddress
 	if !selfVerified {
-		miningBlockchainAccountAddress = "skip"
+		miningBlockchainAccountAddress = coindistribution.SkipEthereumAddressValidation
 	}
 
 	return u.ID != 0 &&
@@ -164,9 +164,9 @@ func (u *user) isEligibleForReferralForEthereumDistribution(now *time.Time) bool
 	coinDistributionCollectorSettings := cfg.coinDistributionCollectorSettings.Load()
 	return coindistribution.IsEligibleForEthereumDistribution(
 		0,
-		0.1,
+		model.MustNewAmount(0.1),
 		0,
-		"skip",
+		coindistribution.SkipEthereumAddressValidation,
 		u.Country,
 		coinDistributionCollectorSettings.DeniedCountries,
 		now,
@@ -190,27 +190,27 @@ func (ref *referral) couldHaveBeenEligibleForEthereumDistributionRecently(now *t
 //nolint:funlen // .
 func (u *user) processEthereumCoinDistribution(
 	enabled bool, now *time.Time, t0, tMinus1 *referral,
-) (records []*coindistribution.ByEarnerForReview, balanceDistributedForT0, balanceDistributedForTMinus1 float64) {
+) (records []*coindistribution.ByEarnerForReview, balanceDistributedForT0, balanceDistributedForTMinus1 model.Amount) {
 	if !enabled {
 		if u.BalanceSoloEthereumPending != nil {
-			u.BalanceSoloEthereum += float64(*u.BalanceSoloEthereumPending)
-			u.BalanceSoloEthereumMainnetRewardPoolContribution += float64(*u.BalanceSoloEthereumPending) * cfg.MainnetRewardPoolContributionPercentage
-			u.BalanceSoloEthereumPending = new(model.FlexibleFloat64)
+			u.BalanceSoloEthereum += *u.BalanceSoloEthereumPending
+			u.BalanceSoloEthereumMainnetRewardPoolContribution += u.BalanceSoloEthereumPending.Fraction(cfg.MainnetRewardPoolContributionPercentage)
+			u.BalanceSoloEthereumPending = new(model.Amount)
 		}
 		if u.BalanceT0EthereumPending != nil {
-			u.BalanceT0Ethereum += float64(*u.BalanceT0EthereumPending)
-			u.BalanceT0EthereumMainnetRewardPoolContribution += float64(*u.BalanceT0EthereumPending) * cfg.MainnetRewardPoolContributionPercentage
-			u.BalanceT0EthereumPending = new(model.FlexibleFloat64)
+			u.BalanceT0Ethereum += *u.BalanceT0EthereumPending
+			u.BalanceT0EthereumMainnetRewardPoolContribution += u.BalanceT0EthereumPending.Fraction(cfg.MainnetRewardPoolContributionPercentage)
+			u.BalanceT0EthereumPending = new(model.Amount)
 		}
 		if u.BalanceT1EthereumPending != nil {
-			u.BalanceT1Ethereum += float64(*u.BalanceT1EthereumPending)
-			u.BalanceT1EthereumMainnetRewardPoolContribution += float64(*u.BalanceT1EthereumPending) * cfg.MainnetRewardPoolContributionPercentage
-			u.BalanceT1EthereumPending = new(model.FlexibleFloat64)
+			u.BalanceT1Ethereum += *u.BalanceT1EthereumPending
+			u.BalanceT1EthereumMainnetRewardPoolContribution += u.BalanceT1EthereumPending.Fraction(cfg.MainnetRewardPoolContributionPercentage)
+			u.BalanceT1EthereumPending = new(model.Amount)
 		}
 		if u.BalanceT2EthereumPending != nil {
-			u.BalanceT2Ethereum += float64(*u.BalanceT2EthereumPending)
-			u.BalanceT2EthereumMainnetRewardPoolContribution += float64(*u.BalanceT2EthereumPending) * cfg.MainnetRewardPoolContributionPercentage
-			u.BalanceT2EthereumPending = new(model.FlexibleFloat64)
+			u.BalanceT2Ethereum += *u.BalanceT2EthereumPending
+			u.BalanceT2EthereumMainnetRewardPoolContribution += u.BalanceT2EthereumPending.Fraction(cfg.MainnetRewardPoolContributionPercentage)
+			u.BalanceT2EthereumPending = new(model.Amount)
 		}
 		u.SoloLastEthereumCoinDistributionProcessedAt = nil
 		u.ForT0LastEthereumCoinDistributionProcessedAt = nil
@@ -321,7 +321,7 @@ func (u *user) processEthereumCoinDistribution(
 		// Amount I've earned for myself.
 		soloCD.Balance = u.processEthereumCoinDistributionForSolo(now)
 		if cfg.MainnetRewardPoolContributionPercentage > 0 {
-			soloMainnetRewardPoolContributionCD.Balance = soloCD.Balance * cfg.MainnetRewardPoolContributionPercentage
+			soloMainnetRewardPoolContributionCD.Balance = soloCD.Balance.Fraction(cfg.MainnetRewardPoolContributionPercentage)
 			soloCD.Balance -= soloMainnetRewardPoolContributionCD.Balance
 		}
 		totalForSelf := soloCD.Balance
@@ -330,7 +330,7 @@ func (u *user) processEthereumCoinDistribution(
 			// Amount my T0 earned for me.
 			t0CD.Balance = u.processEthereumCoinDistributionForT0(now)
 			if cfg.MainnetRewardPoolContributionPercentage > 0 {
-				t0MainnetRewardPoolContributionCD.Balance = t0CD.Balance * cfg.MainnetRewardPoolContributionPercentage
+				t0MainnetRewardPoolContributionCD.Balance = t0CD.Balance.Fraction(cfg.MainnetRewardPoolContributionPercentage)
 				t0CD.Balance -= t0MainnetRewardPoolContributionCD.Balance
 			}
 			totalForSelf += t0CD.Balance
@@ -350,7 +350,7 @@ func (u *user) processEthereumCoinDistribution(
 		balanceDistributedForT0 = u.processEthereumCoinDistributionForForT0(t0, now)
 		forT0CD.Balance = balanceDistributedForT0
 		if cfg.MainnetRewardPoolContributionPercentage > 0 {
-			forT0MainnetRewardPoolContributionCD.Balance = forT0CD.Balance * cfg.MainnetRewardPoolContributionPercentage
+			forT0MainnetRewardPoolContributionCD.Balance = forT0CD.Balance.Fraction(cfg.MainnetRewardPoolContributionPercentage)
 			forT0CD.Balance -= forT0MainnetRewardPoolContributionCD.Balance
 		}
 
@@ -369,7 +369,7 @@ func (u *user) processEthereumCoinDistribution(
 		balanceDistributedForTMinus1 = u.processEthereumCoinDistributionForForTMinus1(tMinus1, now)
 		forTMinus1CD.Balance = balanceDistributedForTMinus1
 		if cfg.MainnetRewardPoolContributionPercentage > 0 {
-			forTMinus1MainnetRewardPoolContributionCD.Balance = forTMinus1CD.Balance * cfg.MainnetRewardPoolContributionPercentage
+			forTMinus1MainnetRewardPoolContributionCD.Balance = forTMinus1CD.Balance.Fraction(cfg.MainnetRewardPoolContributionPercentage)
 			forTMinus1CD.Balance -= forTMinus1MainnetRewardPoolContributionCD.Balance
 		}
 
@@ -391,14 +391,14 @@ func wasNotProcessedToday(now, lastEthereumCoinDistributionProcessedAt *time.Tim
 		!lastEthereumCoinDistributionProcessedAt.Truncate(cfg.EthereumDistributionFrequency.Min).Equal(now.Truncate(cfg.EthereumDistributionFrequency.Min))
 }
 
-func (u *user) processEthereumCoinDistributionForSolo(now *time.Time) float64 {
+func (u *user) processEthereumCoinDistributionForSolo(now *time.Time) model.Amount {
 	standard, preStaking := tokenomics.ApplyPreStaking(u.BalanceSolo, u.PreStakingAllocation, u.PreStakingBonus)
 	ethIce := coindistribution.CalculateEthereumDistributionICEBalance(standard+preStaking-u.BalanceSoloEthereum, cfg.EthereumDistributionFrequency.Min, cfg.EthereumDistributionFrequency.Max, now, cfg.coinDistributionCollectorSettings.Load().EndDate) //nolint:lll // .
 	if ethIce <= 0 {
 		return 0
 	}
 
-	val := model.FlexibleFloat64(ethIce)
+	val := ethIce
 	if !cfg.DryRunDistribution {
 		u.BalanceSoloEthereumPending = &val
 	}
@@ -406,14 +406,14 @@ func (u *user) processEthereumCoinDistributionForSolo(now *time.Time) float64 {
 	return ethIce
 }
 
-func (u *user) processEthereumCoinDistributionForT0(now *time.Time) float64 {
+func (u *user) processEthereumCoinDistributionForT0(now *time.Time) model.Amount {
 	standard, preStaking := tokenomics.ApplyPreStaking(u.BalanceT0, u.PreStakingAllocation, u.PreStakingBonus)
 	ethIce := coindistribution.CalculateEthereumDistributionICEBalance(standard+preStaking-u.BalanceT0Ethereum, cfg.EthereumDistributionFrequency.Min, cfg.EthereumDistributionFrequency.Max, now, cfg.coinDistributionCollectorSettings.Load().EndDate) //nolint:lll // .
 	if ethIce <= 0 {
 		return 0
 	}
 
-	val := model.FlexibleFloat64(ethIce)
+	val := ethIce
 	if !cfg.DryRunDistribution {
 		u.BalanceT0EthereumPending = &val
 	}
@@ -422,8 +422,8 @@ func (u *user) processEthereumCoinDistributionForT0(now *time.Time) float64 {
 }
 
 // The double `For` is intended, cuz it's ForXX, where XX can be Solo/T0/ForT1/ForTMinus1.
-func (u *user) processEthereumCoinDistributionForForT0(t0 *referral, now *time.Time) float64 {
-	standard, preStaking := tokenomics.ApplyPreStaking(u.BalanceForT0+cfg.WelcomeBonusV2Amount, t0.PreStakingAllocation, t0.PreStakingBonus)
+func (u *user) processEthereumCoinDistributionForForT0(t0 *referral, now *time.Time) model.Amount {
+	standard, preStaking := tokenomics.ApplyPreStaking(u.BalanceForT0+cfg.WelcomeBonusV2(), t0.PreStakingAllocation, t0.PreStakingBonus)
 	ethIce := coindistribution.CalculateEthereumDistributionICEBalance(standard+preStaking-u.BalanceForT0Ethereum, cfg.EthereumDistributionFrequency.Min, cfg.EthereumDistributionFrequency.Max, now, cfg.coinDistributionCollectorSettings.Load().EndDate) //nolint:lll // .
 	if ethIce <= 0 {
 		return 0
@@ -437,7 +437,7 @@ func (u *user) processEthereumCoinDistributionForForT0(t0 *referral, now *time.T
 }
 
 // The double `For` is intended, cuz it's ForXX, where XX can be Solo/T0/ForT1/ForTMinus1.
-func (u *user) processEthereumCoinDistributionForForTMinus1(tMinus1 *referral, now *time.Time) float64 {
+func (u *user) processEthereumCoinDistributionForForTMinus1(tMinus1 *referral, now *time.Time) model.Amount {
 	standard, preStaking := tokenomics.ApplyPreStaking(u.BalanceForTMinus1, tMinus1.PreStakingAllocation, tMinus1.PreStakingBonus)
 	ethIce := coindistribution.CalculateEthereumDistributionICEBalance(standard+preStaking-u.BalanceForTMinus1Ethereum, cfg.EthereumDistributionFrequency.Min, cfg.EthereumDistributionFrequency.Max, now, cfg.coinDistributionCollectorSettings.Load().EndDate) //nolint:lll // .
 	if ethIce <= 0 {
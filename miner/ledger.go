@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package miner
+
+import (
+	stdlibtime "time"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+func newLedgerEntry(now *time.Time, id int64, userID string, reason dwh.LedgerReason, source string, amount model.Amount) *dwh.LedgerEntry {
+	if id < 0 {
+		id *= -1
+	}
+
+	return &dwh.LedgerEntry{
+		CreatedAt: now,
+		Reason:    reason,
+		Source:    source,
+		UserID:    userID,
+		ID:        id,
+		Amount:    amount,
+	}
+}
+
+// Mining and slashing are recorded once per day, with what the rates alone minted/slashed that day.
+// The pending amounts, the welcome bonuses and the resurrection have their own entries, when they're credited, so they're not counted here again.
+func appendMiningLedgerEntries(entries []*dwh.LedgerEntry, histories []*model.User) []*dwh.LedgerEntry {
+	for _, history := range histories {
+		if history.BalanceLastUpdatedAt.IsNil() {
+			continue
+		}
+		day := history.BalanceLastUpdatedAt.Format(stdlibtime.DateOnly)
+		if history.BalanceMintedByRate != 0 {
+			entries = append(entries, newLedgerEntry(history.BalanceLastUpdatedAt, history.ID, history.UserID, dwh.MiningLedgerReason, day, history.BalanceMintedByRate))
+		}
+		if history.BalanceSlashedByRate != 0 {
+			entries = append(entries, newLedgerEntry(history.BalanceLastUpdatedAt, history.ID, history.UserID, dwh.SlashingLedgerReason, day, -history.BalanceSlashedByRate)) //nolint:lll // .
+		}
+	}
+
+	return entries
+}
+
+func (u *user) appendEthereumDistributionLedgerEntries(
+	entries []*dwh.LedgerEntry, now *time.Time, t0Ref, tMinus1Ref *referral, balanceDistributedForT0, balanceDistributedForTMinus1 model.Amount,
+) []*dwh.LedgerEntry {
+	if u.BalanceSoloEthereumPending != nil && *u.BalanceSoloEthereumPending > 0 {
+		entries = append(entries, newLedgerEntry(now, u.ID, u.UserID, dwh.EthereumDistributionLedgerReason, u.UserID, *u.BalanceSoloEthereumPending))
+	}
+	if u.BalanceT0EthereumPending != nil && *u.BalanceT0EthereumPending > 0 && t0Ref != nil {
+		entries = append(entries, newLedgerEntry(now, u.ID, u.UserID, dwh.EthereumDistributionLedgerReason, t0Ref.UserID, *u.BalanceT0EthereumPending))
+	}
+	if balanceDistributedForT0 > 0 {
+		entries = append(entries, newLedgerEntry(now, t0Ref.ID, t0Ref.UserID, dwh.EthereumDistributionLedgerReason, u.UserID, balanceDistributedForT0))
+	}
+	if balanceDistributedForTMinus1 > 0 {
+		entries = append(entries, newLedgerEntry(now, tMinus1Ref.ID, tMinus1Ref.UserID, dwh.EthereumDistributionLedgerReason, u.UserID, balanceDistributedForTMinus1)) //nolint:lll // .
+	}
+
+	return entries
+}
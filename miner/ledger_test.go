@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package miner
+
+import (
+	"fmt"
+	"testing"
+	stdlibtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
+)
+
+func TestAppendMiningLedgerEntries(t *testing.T) {
+	t.Parallel()
+
+	history := func(id int64, lastUpdatedAt stdlibtime.Duration, minted, slashed model.Amount) *model.User {
+		usr := new(model.User)
+		usr.ID = id
+		usr.UserID = fmt.Sprintf("user%v", id)
+		if lastUpdatedAt != 0 {
+			usr.BalanceLastUpdatedAt = timeDelta(lastUpdatedAt)
+		}
+		usr.BalanceMintedByRate, usr.BalanceTotalMinted = minted, 2*minted
+		usr.BalanceSlashedByRate, usr.BalanceTotalSlashed = slashed, 2*slashed
+
+		return usr
+	}
+	entries := appendMiningLedgerEntries(nil, []*model.User{
+		history(1, -stdlibtime.Hour, 10, 2),
+		history(2, -stdlibtime.Hour, 5, 0),
+		history(3, -stdlibtime.Hour, 0, 0),
+		history(4, 0, 10, 2),
+	})
+	require.Len(t, entries, 3)
+	assert.Equal(t, &dwh.LedgerEntry{CreatedAt: timeDelta(-stdlibtime.Hour), Reason: dwh.MiningLedgerReason, Source: "2023-01-02", UserID: "user1", ID: 1, Amount: 10}, entries[0])   //nolint:lll // .
+	assert.Equal(t, &dwh.LedgerEntry{CreatedAt: timeDelta(-stdlibtime.Hour), Reason: dwh.SlashingLedgerReason, Source: "2023-01-02", UserID: "user1", ID: 1, Amount: -2}, entries[1]) //nolint:lll // .
+	assert.Equal(t, &dwh.LedgerEntry{CreatedAt: timeDelta(-stdlibtime.Hour), Reason: dwh.MiningLedgerReason, Source: "2023-01-02", UserID: "user2", ID: 2, Amount: 5}, entries[2])    //nolint:lll // .
+}
+
+func TestSoloResurrectionAmountMatchesResurrect(t *testing.T) {
+	t.Parallel()
+
+	usr := newUser()
+	usr.MiningSessionSoloPreviouslyEndedAt = timeDelta(-49 * stdlibtime.Hour)
+	usr.ResurrectSoloUsedAt = timeDelta(stdlibtime.Hour)
+	usr.SlashingRateSolo, usr.SlashingRateT0 = ice(2), ice(1)
+	usr.PreStakingAllocation, usr.PreStakingBonus = 50, 100
+
+	amount := soloResurrectionAmount(testTime, usr, new(amountCalculator))
+	resurrected := *usr
+	resurrect(testTime, &resurrected, nil, nil, new(amountCalculator))
+	assert.Equal(t, resurrected.BalanceTotalMinted, amount)
+	assert.Equal(t, ice(48*(2+1)*(0.5+0.5*2)), amount)
+
+	usr.ResurrectSoloUsedAt = timeDelta(-stdlibtime.Hour)
+	assert.Zero(t, soloResurrectionAmount(testTime, usr, new(amountCalculator)))
+}
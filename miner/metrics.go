@@ -19,7 +19,8 @@ func init() {
 
 type telemetry struct {
 	registry        metrics.Registry
-	steps           [10]string
+	invalidAmounts  metrics.Counter
+	steps           [11]string
 	currentStepName string
 	cfg             config
 }
@@ -31,13 +32,16 @@ func (t *telemetry) mustInit(cfg config) *telemetry {
 	)
 	t.cfg = cfg
 	t.registry = metrics.NewRegistry()
-	t.steps = [10]string{"mine[full iteration]", "mine", "get_users", "get_referrals", "send_messages", "get_history", "sync_quiz_status", "insert_history", "collect_coin_distributions", "update_users"} //nolint:lll // .
+	t.steps = [11]string{"mine[full iteration]", "mine", "get_users", "get_referrals", "send_messages", "get_history", "sync_quiz_status", "insert_history", "collect_coin_distributions", "update_users", "insert_ledger"} //nolint:lll // .
 	for ix := range &t.steps {
 		if ix > 1 {
 			t.steps[ix] = fmt.Sprintf("[%v]mine.%v", ix-1, t.steps[ix])
 		}
 		log.Panic(t.registry.Register(t.steps[ix], metrics.NewCustomTimer(metrics.NewHistogram(metrics.NewExpDecaySample(reservoirSize, decayAlpha)), metrics.NewMeter()))) //nolint:lll // .
 	}
+	// The users that couldn't be mined, because of amounts that can't be represented. They're retried every iteration.
+	t.invalidAmounts = metrics.NewCounter()
+	log.Panic(t.registry.Register("mine.invalid_amounts", t.invalidAmounts))
 
 	go metrics.LogScaled(t.registry, 15*stdlibtime.Minute, stdlibtime.Millisecond, t) //nolint:gomnd // .
 
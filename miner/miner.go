@@ -5,7 +5,6 @@ package miner
 import (
 	"context"
 	"fmt"
-	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,7 +33,7 @@ import (
 )
 
 func init() {
-	appCfg.MustLoadFromKey(parentApplicationYamlKey, &cfg.Config)
+	tokenomics.MustLoadConfig(parentApplicationYamlKey, &cfg.Config)
 	appCfg.MustLoadFromKey(applicationYamlKey, &cfg)
 	if cfg.SlashingDaysCount == 0 {
 		log.Panic(errors.Errorf("slashingDaysCount is zero"))
@@ -64,18 +63,19 @@ func MustStartMining(ctx context.Context, cancel context.CancelFunc) Client {
 	mi := &miner{
 		coinDistributionRepository: coindistribution.NewRepository(context.Background(), func() {}),
 		mb:                         messagebroker.MustConnect(context.Background(), parentApplicationYamlKey),
-		db:                         storage.MustConnect(context.Background(), parentApplicationYamlKey, int(cfg.Workers)),
-		dwhClient:                  dwh.MustConnect(context.Background(), applicationYamlKey),
+		db:                         tokenomics.MustConnectStorage(context.Background(), &cfg.Config, parentApplicationYamlKey, int(cfg.Workers)),
+		dwhClient:                  dwh.MustConnect(context.Background(), applicationYamlKey, cfg.DWHTenant()),
 		wg:                         new(sync.WaitGroup),
 		telemetry:                  new(telemetry).mustInit(cfg),
 		//quizRepository:             quiz.NewReadRepository(context.Background()),
 	}
+	model.MustMigrateAmounts(ctx, mi.db)
 	go mi.startDisableAdvancedTeamCfgSyncer(ctx)
 	mi.wg.Add(int(cfg.Workers))
 	mi.cancel = cancel
 	mi.extraBonusStartDate = extrabonusnotifier.MustGetExtraBonusStartDate(ctx, mi.db)
 	mi.mustInitCoinDistributionCollector(ctx)
-	if isTenantInDistributionMode() {
+	if cfg.Capabilities.DistributionMode {
 		mi.usersRepository = users.New(context.Background(), nil)
 		go mi.coinDistributionRepository.StartPrepareCoinDistributionsForReviewMonitor(ctx)
 	}
@@ -102,7 +102,7 @@ func (m *miner) Close() error {
 		errors.Wrap(m.coinDistributionRepository.Close(), "failed to close coinDistributionRepository"),
 		//errors.Wrap(m.quizRepository.Close(), "failed to close quizClient"),
 	)
-	if isTenantInDistributionMode() {
+	if cfg.Capabilities.DistributionMode {
 		errs = multierror.Append(errs, errors.Wrap(m.usersRepository.Close(), "failed to close usersRepository"))
 	}
 
@@ -157,7 +157,7 @@ func (m *miner) checkDBHealth(ctx context.Context) error {
 }
 
 func (m *miner) mine(ctx context.Context, workerNumber int64) {
-	dwhClient := dwh.MustConnect(context.Background(), applicationYamlKey)
+	dwhClient := dwh.MustConnect(context.Background(), applicationYamlKey, cfg.DWHTenant())
 	defer func() {
 		if err := recover(); err != nil {
 			log.Error(dwhClient.Close())
@@ -177,11 +177,12 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 		t0Referrals, tMinus1Referrals                                                                       = make(map[int64]*referral, batchSize), make(map[int64]*referral, batchSize)
 		t1ReferralsToIncrementActiveValue, t2ReferralsToIncrementActiveValue                                = make(map[int64]int32, batchSize), make(map[int64]int32, batchSize)
 		t1ReferralsThatStoppedMining, t2ReferralsThatStoppedMining                                          = make(map[int64]uint32, batchSize), make(map[int64]uint32, batchSize)
-		balanceT1EthereumIncr, balanceT2EthereumIncr                                                        = make(map[int64]float64, batchSize), make(map[int64]float64, batchSize)
-		balanceT1WelcomeBonusIncr                                                                           = make(map[int64]float64, batchSize)
-		pendingBalancesForTMinus1, pendingBalancesForT0                                                     = make(map[int64]float64, batchSize), make(map[int64]float64, batchSize)
+		balanceT1EthereumIncr, balanceT2EthereumIncr                                                        = make(map[int64]model.Amount, batchSize), make(map[int64]model.Amount, batchSize)
+		balanceT1WelcomeBonusIncr                                                                           = make(map[int64]model.Amount, batchSize)
+		pendingBalancesForTMinus1, pendingBalancesForT0                                                     = make(map[int64]model.Amount, batchSize), make(map[int64]model.Amount, batchSize)
 		referralsThatStoppedMining                                                                          = make([]*referralThatStoppedMining, 0, batchSize)
 		coinDistributions                                                                                   = make([]*coindistribution.ByEarnerForReview, 0, 4*batchSize)
+		ledgerEntries                                                                                       = make([]*dwh.LedgerEntry, 0, 4*batchSize)
 		msgResponder                                                                                        = make(chan error, 3*batchSize)
 		msgs                                                                                                = make([]*messagebroker.Message, 0, 3*batchSize)
 		errs                                                                                                = make([]error, 0, 3*batchSize)
@@ -241,6 +242,7 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 		userGlobalRanks = userGlobalRanks[:0]
 		referralsThatStoppedMining = referralsThatStoppedMining[:0]
 		coinDistributions = coinDistributions[:0]
+		ledgerEntries = ledgerEntries[:0]
 		usersThatStoppedMiningForDistribution = usersThatStoppedMiningForDistribution[:0]
 
 		for k := range t0Referrals {
@@ -384,17 +386,24 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 				usr.ActiveT2Referrals = 0
 			}
 			beforeWelcomeBonusV2NotApplied := usr.WelcomeBonusV2Applied == nil || !*usr.WelcomeBonusV2Applied
-			updatedUser, shouldGenerateHistory, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(now, usr, t0Ref, tMinus1Ref)
+			resurrectedAmount := soloResurrectionAmount(now, usr, new(amountCalculator))
+			updatedUser, shouldGenerateHistory, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, mErr := mine(now, usr, t0Ref, tMinus1Ref)
+			if mErr != nil {
+				m.telemetry.invalidAmounts.Inc(1)
+				log.Error(errors.Wrapf(mErr, "[miner] failed to mine userID:%v, bmr[%#v], user[%+v]", usr.UserID, usr.baseMiningRate(now), usr))
+
+				continue
+			}
 			if shouldGenerateHistory {
 				syncQuizUserIDs = append(syncQuizUserIDs, usr.UserID)
 				userHistoryKeys = append(userHistoryKeys, usr.Key())
 			}
-			if isTenantInDistributionMode() && !usr.isMandatoryFieldsSetForDistributionValid() && !usr.MiningSessionSoloStartedAt.IsNil() {
+			if cfg.Capabilities.DistributionMode && !usr.isMandatoryFieldsSetForDistributionValid() && !usr.MiningSessionSoloStartedAt.IsNil() {
 				syncMandatoryUserFieldsForDistributionIDs = append(syncMandatoryUserFieldsForDistributionIDs, usr.UserID)
 			}
 
 			if updatedUser != nil {
-				if !isTenantInDistributionMode() {
+				if !cfg.Capabilities.DistributionMode {
 					if userStoppedMining := didUserStoppedMining(now, usr); userStoppedMining != nil {
 						referralsCountGuardOnlyUpdatedUsers = append(referralsCountGuardOnlyUpdatedUsers, userStoppedMining)
 					}
@@ -432,30 +441,41 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 				}
 				userCoinDistributions, balanceDistributedForT0, balanceDistributedForTMinus1 := updatedUser.processEthereumCoinDistribution(startedCoinDistributionCollecting, now, t0Ref, tMinus1Ref)
 				coinDistributions = append(coinDistributions, userCoinDistributions...)
+				ledgerEntries = updatedUser.appendEthereumDistributionLedgerEntries(ledgerEntries, now, t0Ref, tMinus1Ref, balanceDistributedForT0, balanceDistributedForTMinus1)
 				if balanceDistributedForT0 > 0 {
 					balanceT1EthereumIncr[t0Ref.ID] += balanceDistributedForT0
 				}
 				if balanceDistributedForTMinus1 > 0 {
 					balanceT2EthereumIncr[tMinus1Ref.ID] += balanceDistributedForTMinus1
 				}
-				if !isTenantInDistributionMode() {
+				if !cfg.Capabilities.DistributionMode {
 					if tMinus1Ref != nil && tMinus1Ref.ID != 0 && pendingAmountForTMinus1 != 0 {
 						pendingBalancesForTMinus1[tMinus1Ref.ID] += pendingAmountForTMinus1
+						ledgerEntries = append(ledgerEntries, newLedgerEntry(now, tMinus1Ref.ID, tMinus1Ref.UserID, dwh.T2PendingLedgerReason, usr.UserID, pendingAmountForTMinus1))
 					}
 					if t0Ref != nil && t0Ref.ID != 0 && pendingAmountForT0 != 0 {
 						pendingBalancesForT0[t0Ref.ID] += pendingAmountForT0
+						ledgerEntries = append(ledgerEntries, newLedgerEntry(now, t0Ref.ID, t0Ref.UserID, dwh.T1PendingLedgerReason, usr.UserID, pendingAmountForT0))
 					}
-					if afterWelcomeBonusV2Applied := updatedUser.WelcomeBonusV2Applied != nil && *updatedUser.WelcomeBonusV2Applied; t0Ref != nil && t0Ref.ID != 0 && beforeWelcomeBonusV2NotApplied && afterWelcomeBonusV2Applied {
+					afterWelcomeBonusV2Applied := updatedUser.WelcomeBonusV2Applied != nil && *updatedUser.WelcomeBonusV2Applied
+					if beforeWelcomeBonusV2NotApplied && afterWelcomeBonusV2Applied {
+						ledgerEntries = append(ledgerEntries, newLedgerEntry(now, usr.ID, usr.UserID, dwh.WelcomeBonusLedgerReason, usr.UserID, cfg.WelcomeBonusV2()-welcomeBonusV2Deduction))
+					}
+					if t0Ref != nil && t0Ref.ID != 0 && beforeWelcomeBonusV2NotApplied && afterWelcomeBonusV2Applied {
 						idT0 := t0Ref.ID
 						if idT0 < 0 {
 							idT0 *= -1
 						}
-						balanceT1WelcomeBonusIncr[idT0] += cfg.WelcomeBonusV2Amount
+						balanceT1WelcomeBonusIncr[idT0] += cfg.WelcomeBonusV2()
+						ledgerEntries = append(ledgerEntries, newLedgerEntry(now, idT0, t0Ref.UserID, dwh.WelcomeBonusLedgerReason, usr.UserID, cfg.WelcomeBonusV2()))
+					}
+					if resurrectedAmount != 0 && updatedUser.ResurrectSoloUsedAt != nil {
+						ledgerEntries = append(ledgerEntries, newLedgerEntry(now, usr.ID, usr.UserID, dwh.ResurrectionLedgerReason, usr.UserID, resurrectedAmount))
 					}
 				}
 				updatedUsers = append(updatedUsers, &updatedUser.UpdatedUser)
 			} else {
-				if !isTenantInDistributionMode() {
+				if !cfg.Capabilities.DistributionMode {
 					if updUsr := updateT0AndTMinus1ReferralsForUserHasNeverMined(usr); updUsr != nil {
 						referralsUpdated = append(referralsUpdated, updUsr)
 						if t0Ref != nil && t0Ref.ID != 0 && usr.ActiveT1Referrals > 0 {
@@ -464,7 +484,7 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 					}
 				}
 			}
-			if !isTenantInDistributionMode() {
+			if !cfg.Capabilities.DistributionMode {
 				totalStandardBalance, totalPreStakingBalance := usr.BalanceTotalStandard, usr.BalanceTotalPreStaking
 				if updatedUser != nil {
 					totalStandardBalance, totalPreStakingBalance = updatedUser.BalanceTotalStandard, updatedUser.BalanceTotalPreStaking
@@ -472,9 +492,6 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 				totalBalance := totalStandardBalance + totalPreStakingBalance
 				if shouldSynchronizeBalance {
 					userGlobalRanks = append(userGlobalRanks, balancesynchronizer.GlobalRank(usr.ID, totalBalance))
-					if math.IsNaN(totalStandardBalance) || math.IsNaN(totalPreStakingBalance) {
-						log.Info(fmt.Sprintf("bmr[%#v],before[%+v], after[%+v]", updatedUser.baseMiningRate(now), usr, updatedUser))
-					}
 					msgs = append(msgs, balancesynchronizer.BalanceUpdatedMessage(reqCtx, usr.UserID, totalStandardBalance, totalPreStakingBalance))
 				}
 			}
@@ -518,6 +535,7 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 			continue
 		}
 		reqCancel()
+		ledgerEntries = appendMiningLedgerEntries(ledgerEntries, histories)
 
 		if len(userHistoryKeys) > 0 {
 			go m.telemetry.collectElapsed(5, *before.Time)
@@ -689,7 +707,7 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 				}
 			}
 			for idT0, amount := range balanceT1WelcomeBonusIncr {
-				if err := pipeliner.HIncrByFloat(reqCtx, model.SerializedUsersKey(idT0), "balance_t1_welcome_bonus_pending", amount).Err(); err != nil {
+				if err := pipeliner.HIncrBy(reqCtx, model.SerializedUsersKey(idT0), "balance_t1_welcome_bonus_pending", int64(amount)).Err(); err != nil {
 					return err
 				}
 			}
@@ -697,7 +715,7 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 				if amount == 0 {
 					continue
 				}
-				if err := pipeliner.HIncrByFloat(reqCtx, model.SerializedUsersKey(idT0), "balance_t1_ethereum_pending", amount).Err(); err != nil {
+				if err := pipeliner.HIncrBy(reqCtx, model.SerializedUsersKey(idT0), "balance_t1_ethereum_pending", int64(amount)).Err(); err != nil {
 					return err
 				}
 			}
@@ -705,21 +723,21 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 				if amount == 0 {
 					continue
 				}
-				if err := pipeliner.HIncrByFloat(reqCtx, model.SerializedUsersKey(idTMinus1), "balance_t2_ethereum_pending", amount).Err(); err != nil {
+				if err := pipeliner.HIncrBy(reqCtx, model.SerializedUsersKey(idTMinus1), "balance_t2_ethereum_pending", int64(amount)).Err(); err != nil {
 					return err
 				}
 			}
 			for idT0, amount := range pendingBalancesForT0 {
-				if err := pipeliner.HIncrByFloat(reqCtx, model.SerializedUsersKey(idT0), "balance_t1_pending", amount).Err(); err != nil {
+				if err := pipeliner.HIncrBy(reqCtx, model.SerializedUsersKey(idT0), "balance_t1_pending", int64(amount)).Err(); err != nil {
 					return err
 				}
 			}
 			for idTMinus1, amount := range pendingBalancesForTMinus1 {
-				if err := pipeliner.HIncrByFloat(reqCtx, model.SerializedUsersKey(idTMinus1), "balance_t2_pending", amount).Err(); err != nil {
+				if err := pipeliner.HIncrBy(reqCtx, model.SerializedUsersKey(idTMinus1), "balance_t2_pending", int64(amount)).Err(); err != nil {
 					return err
 				}
 			}
-			if isTenantInDistributionMode() {
+			if cfg.Capabilities.DistributionMode {
 				for _, value := range usersThatStoppedMiningForDistribution {
 					if err := pipeliner.HSet(reqCtx, value.Key(), storage.SerializeValue(value)...).Err(); err != nil {
 						return err
@@ -755,6 +773,28 @@ func (m *miner) mine(ctx context.Context, workerNumber int64) {
 			go m.telemetry.collectElapsed(9, *before.Time)
 		}
 
+		/******************************************************************************************************************************************************
+			10. Inserting the ledger entries for the balance mutations that we just persisted.
+		******************************************************************************************************************************************************/
+
+		reqCancel()
+		before = time.Now()
+		reqCtx, reqCancel = context.WithTimeout(context.Background(), requestDeadline)
+		if err := dwhClient.InsertLedger(reqCtx, ledgerEntries); err != nil {
+			// The mining progress is already persisted, so we can't retry the batch; the entries are inserted later on, instead.
+			log.Error(errors.Wrapf(err, "[miner] failed to insert %v ledger entries for batchNumber:%v,workerNumber:%v", len(ledgerEntries), batchNumber, workerNumber))
+			log.Error(errors.Wrapf(dwh.PostponeLedger(reqCtx, m.db, ledgerEntries),
+				"[miner] failed to postpone %v ledger entries for batchNumber:%v,workerNumber:%v", len(ledgerEntries), batchNumber, workerNumber))
+		} else {
+			if workerNumber == 0 {
+				log.Error(errors.Wrapf(dwh.FlushPostponedLedger(reqCtx, m.db, dwhClient, int(batchSize)),
+					"[miner] failed to flush postponed ledger entries for batchNumber:%v,workerNumber:%v", batchNumber, workerNumber))
+			}
+			if len(ledgerEntries) > 0 {
+				go m.telemetry.collectElapsed(10, *before.Time)
+			}
+		}
+
 		batchNumber++
 		reqCancel()
 		resetVars(true)
@@ -840,7 +880,3 @@ func didUserStoppedMining(now *time.Time, before *user) *referralCountGuardUpdat
 
 	return nil
 }
-
-func isTenantInDistributionMode() bool {
-	return cfg.Tenant == doctorXTenant
-}
@@ -6,22 +6,32 @@ import (
 	"math"
 	stdlibtime "time"
 
+	"github.com/pkg/errors"
+
 	"github.com/ice-blockchain/freezer/model"
 	"github.com/ice-blockchain/freezer/tokenomics"
 	"github.com/ice-blockchain/wintr/time"
 )
 
-func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *user, shouldGenerateHistory, IDT0Changed bool, pendingAmountForTMinus1, pendingAmountForT0 float64) {
+// The amounts are fixed-point, so the only ones that can be invalid are the products of rates and (elapsed) time,
+// which are all checked. If any of them is invalid, the user is not updated and the error is returned.
+//
+//nolint:funlen,gocognit,gocyclo,revive,cyclop // .
+func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *user, shouldGenerateHistory, IDT0Changed bool, pendingAmountForTMinus1, pendingAmountForT0 model.Amount, err error) {
 	if usr == nil || usr.MiningSessionSoloStartedAt.IsNil() || usr.MiningSessionSoloEndedAt.IsNil() {
-		return nil, false, false, 0, 0
+		return nil, false, false, 0, 0, nil
 	}
 	clonedUser1 := *usr
 	updatedUser = &clonedUser1
-	if isTenantInDistributionMode() {
-		return updatedUser, false, false, 0, 0
+	if cfg.Capabilities.DistributionMode {
+		return updatedUser, false, false, 0, 0, nil
 	}
 
-	pendingResurrectionForTMinus1, pendingResurrectionForT0 := resurrect(now, updatedUser, t0Ref, tMinus1Ref)
+	calc := new(amountCalculator)
+	pendingResurrectionForTMinus1, pendingResurrectionForT0 := resurrect(now, updatedUser, t0Ref, tMinus1Ref, calc)
+	if calc.err != nil {
+		return nil, false, false, 0, 0, errors.Wrap(calc.err, "invalid resurrection amounts")
+	}
 	IDT0Changed, _ = changeT0AndTMinus1Referrals(updatedUser)
 	if updatedUser.MiningSessionSoloEndedAt.Before(*now.Time) && updatedUser.isAbsoluteZero() {
 		if updatedUser.BalanceT1Pending-updatedUser.BalanceT1PendingApplied != 0 ||
@@ -30,7 +40,7 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 			updatedUser.BalanceT2PendingApplied = updatedUser.BalanceT2Pending
 			updatedUser.BalanceLastUpdatedAt = now
 
-			return updatedUser, false, IDT0Changed, 0, 0
+			return updatedUser, false, IDT0Changed, 0, 0, nil
 		}
 		if updatedUser.BalanceT1 > 0 || updatedUser.BalanceT2 > 0 {
 			updatedUser.BalanceTotalStandard, updatedUser.BalanceTotalPreStaking = 0, 0
@@ -38,10 +48,10 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 			updatedUser.BalanceT2 = 0
 			updatedUser.BalanceLastUpdatedAt = now
 
-			return updatedUser, false, IDT0Changed, 0, 0
+			return updatedUser, false, IDT0Changed, 0, 0, nil
 		}
 
-		return nil, false, IDT0Changed, 0, 0
+		return nil, false, IDT0Changed, 0, 0, nil
 	}
 
 	var miningSessionRatio float64
@@ -53,6 +63,7 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 		miningPeriod = 1 * stdlibtime.Hour
 		miningSessionRatio = 24.
 	}
+	slashingPeriods := model.Amount(cfg.SlashingDaysCount * int64(miningSessionRatio))
 	if updatedUser.MiningSessionSoloEndedAt.Before(*now.Time) && (updatedUser.reachedSlashingFloor() || updatedUser.slashingDisabled()) {
 		fullSlashingDuration := stdlibtime.Duration(cfg.SlashingDaysCount * int64(miningSessionRatio) * int64(miningPeriod))
 		shouldGenerateHistory = (updatedUser.BalanceLastUpdatedAt.Year() != now.Year() ||
@@ -67,6 +78,8 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 		if shouldGenerateHistory {
 			updatedUser.BalanceTotalSlashed = 0
 			updatedUser.BalanceTotalMinted = 0
+			updatedUser.BalanceSlashedByRate = 0
+			updatedUser.BalanceMintedByRate = 0
 		}
 
 		if shouldGenerateHistory ||
@@ -75,10 +88,10 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 				updatedUser.ReferralsCountChangeGuardUpdatedAt.Equal(*updatedUser.MiningSessionSoloStartedAt.Time)) {
 			updatedUser.BalanceLastUpdatedAt = now
 			// We need to update ReferralsCountChangeGuardUpdatedAt last time to avoid ErrDuplicate on next sessions
-			return updatedUser, shouldGenerateHistory, IDT0Changed, 0, 0
+			return updatedUser, shouldGenerateHistory, IDT0Changed, 0, 0, nil
 		}
 
-		return nil, shouldGenerateHistory, IDT0Changed, 0, 0
+		return nil, shouldGenerateHistory, IDT0Changed, 0, 0, nil
 	}
 
 	if updatedUser.BalanceLastUpdatedAt.IsNil() {
@@ -90,6 +103,8 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 			shouldGenerateHistory = true
 			updatedUser.BalanceTotalSlashed = 0
 			updatedUser.BalanceTotalMinted = 0
+			updatedUser.BalanceSlashedByRate = 0
+			updatedUser.BalanceMintedByRate = 0
 		}
 		userWasInSlashingAndReachedFloor := (updatedUser.reachedSlashingFloor() && usr.SlashingRateSolo > 0)
 		if updatedUser.MiningSessionSoloEndedAt.After(*now.Time) && (updatedUser.isAbsoluteZero() || userWasInSlashingAndReachedFloor) {
@@ -98,7 +113,7 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 	}
 
 	var (
-		mintedAmount        float64
+		mintedAmount        model.Amount
 		elapsedTimeFraction float64
 	)
 	if timeSpent := now.Sub(*updatedUser.BalanceLastUpdatedAt.Time); cfg.Development {
@@ -130,16 +145,16 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 	maxT1Referrals := (*cfg.miningBoostLevels.Load())[len(*cfg.miningBoostLevels.Load())-1].MaxT1Referrals
 	if updatedUser.MiningSessionSoloEndedAt.After(*now.Time) {
 		if !updatedUser.ExtraBonusStartedAt.IsNil() && now.Before(updatedUser.ExtraBonusStartedAt.Add(cfg.ExtraBonuses.Duration)) {
-			rate := (100 + float64(updatedUser.ExtraBonus)) * baseMiningRate * elapsedTimeFraction / 100.
+			rate := calc.ice((100 + float64(updatedUser.ExtraBonus)) * baseMiningRate * elapsedTimeFraction / 100.)
 			updatedUser.BalanceSolo += rate
 			mintedAmount += rate
 		} else {
-			rate := baseMiningRate * elapsedTimeFraction
+			rate := calc.ice(baseMiningRate * elapsedTimeFraction)
 			updatedUser.BalanceSolo += rate
 			mintedAmount += rate
 		}
 		if t0Ref != nil && !t0Ref.MiningSessionSoloEndedAt.IsNil() && t0Ref.MiningSessionSoloEndedAt.After(*now.Time) {
-			rate := 25 * baseMiningRate * elapsedTimeFraction / 100
+			rate := calc.ice(25 * baseMiningRate * elapsedTimeFraction / 100)
 			updatedUser.BalanceForT0 += rate
 			updatedUser.BalanceT0 += rate
 			mintedAmount += rate
@@ -149,7 +164,7 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 			}
 		}
 		if tMinus1Ref != nil && !tMinus1Ref.MiningSessionSoloEndedAt.IsNil() && tMinus1Ref.MiningSessionSoloEndedAt.After(*now.Time) {
-			updatedUser.BalanceForTMinus1 += 5 * baseMiningRate * elapsedTimeFraction / 100
+			updatedUser.BalanceForTMinus1 += calc.ice(5 * baseMiningRate * elapsedTimeFraction / 100)
 
 			if updatedUser.SlashingRateForTMinus1 != 0 {
 				updatedUser.SlashingRateForTMinus1 = 0
@@ -173,8 +188,8 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 				activeT1Referrals = updatedUser.ActiveT1Referrals
 			}
 		}
-		t1Rate := (25 * float64(activeT1Referrals)) * baseMiningRate * elapsedTimeFraction / 100
-		t2Rate := (5 * float64(updatedUser.ActiveT2Referrals)) * baseMiningRate * elapsedTimeFraction / 100
+		t1Rate := calc.ice((25 * float64(activeT1Referrals)) * baseMiningRate * elapsedTimeFraction / 100)
+		t2Rate := calc.ice((5 * float64(updatedUser.ActiveT2Referrals)) * baseMiningRate * elapsedTimeFraction / 100)
 		updatedUser.BalanceT1 += t1Rate
 		updatedUser.BalanceT2 += t2Rate
 		mintedAmount += t1Rate + t2Rate
@@ -182,10 +197,10 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 	} else {
 		if !updatedUser.slashingDisabled() {
 			if updatedUser.SlashingRateSolo == 0 {
-				updatedUser.SlashingRateSolo = updatedUser.BalanceSolo / float64(cfg.SlashingDaysCount) / miningSessionRatio
+				updatedUser.SlashingRateSolo = updatedUser.BalanceSolo / slashingPeriods
 			}
 			if unAppliedSoloPending != 0 {
-				updatedUser.SlashingRateSolo += unAppliedSoloPending / float64(cfg.SlashingDaysCount) / miningSessionRatio
+				updatedUser.SlashingRateSolo += unAppliedSoloPending / slashingPeriods
 			}
 			if updatedUser.SlashingRateSolo < 0 {
 				updatedUser.SlashingRateSolo = 0
@@ -195,39 +210,41 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 
 	if t0Ref != nil {
 		if updatedUser.SlashingRateForT0 == 0 && !t0Ref.MiningSessionSoloEndedAt.IsNil() && t0Ref.MiningSessionSoloEndedAt.Before(*now.Time) && !t0Ref.slashingDisabled() && !t0Ref.reachedSlashingFloor() {
-			updatedUser.SlashingRateForT0 = updatedUser.BalanceForT0 / float64(cfg.SlashingDaysCount) / miningSessionRatio
+			updatedUser.SlashingRateForT0 = updatedUser.BalanceForT0 / slashingPeriods
 		}
 		if updatedUser.SlashingRateT0 == 0 && !updatedUser.MiningSessionSoloEndedAt.IsNil() && updatedUser.MiningSessionSoloEndedAt.Before(*now.Time) && !updatedUser.slashingDisabled() && !updatedUser.reachedSlashingFloor() {
-			updatedUser.SlashingRateT0 = updatedUser.BalanceT0 / float64(cfg.SlashingDaysCount) / miningSessionRatio
+			updatedUser.SlashingRateT0 = updatedUser.BalanceT0 / slashingPeriods
 		}
 	}
 	if tMinus1Ref != nil {
 		if updatedUser.SlashingRateForTMinus1 == 0 && !tMinus1Ref.MiningSessionSoloEndedAt.IsNil() && tMinus1Ref.MiningSessionSoloEndedAt.Before(*now.Time) && !tMinus1Ref.slashingDisabled() && !tMinus1Ref.reachedSlashingFloor() {
-			updatedUser.SlashingRateForTMinus1 = updatedUser.BalanceForTMinus1 / float64(cfg.SlashingDaysCount) / miningSessionRatio
+			updatedUser.SlashingRateForTMinus1 = updatedUser.BalanceForTMinus1 / slashingPeriods
 		}
 	}
-	if updatedUser.BalanceT1WelcomeBonusPendingApplied < float64(min(maxT1Referrals, uint64(usr.TotalT1Referrals)))*cfg.WelcomeBonusV2Amount {
+	if maxT1WelcomeBonus := model.Amount(min(maxT1Referrals, uint64(usr.TotalT1Referrals))) * cfg.WelcomeBonusV2(); updatedUser.BalanceT1WelcomeBonusPendingApplied < maxT1WelcomeBonus {
 		if unAppliedT1WelcomeBonusPending := updatedUser.BalanceT1WelcomeBonusPending - updatedUser.BalanceT1WelcomeBonusPendingApplied; unAppliedT1WelcomeBonusPending == 0 {
 			updatedUser.BalanceT1WelcomeBonusPending = 0
 			updatedUser.BalanceT1WelcomeBonusPendingApplied = 0
 		} else {
-			unAppliedT1Pending += min(unAppliedT1WelcomeBonusPending, float64(min(maxT1Referrals, uint64(usr.TotalT1Referrals)))*cfg.WelcomeBonusV2Amount-updatedUser.BalanceT1WelcomeBonusPendingApplied)
-			updatedUser.BalanceT1WelcomeBonusPendingApplied = min(updatedUser.BalanceT1WelcomeBonusPending, float64(min(maxT1Referrals, uint64(usr.TotalT1Referrals)))*cfg.WelcomeBonusV2Amount)
+			unAppliedT1Pending += min(unAppliedT1WelcomeBonusPending, maxT1WelcomeBonus-updatedUser.BalanceT1WelcomeBonusPendingApplied)
+			updatedUser.BalanceT1WelcomeBonusPendingApplied = min(updatedUser.BalanceT1WelcomeBonusPending, maxT1WelcomeBonus)
 		}
 	} else {
 		updatedUser.BalanceT1WelcomeBonusPending = 0
 		updatedUser.BalanceT1WelcomeBonusPendingApplied = 0
 	}
 
-	slashedAmount := (updatedUser.SlashingRateSolo + updatedUser.SlashingRateT0) * elapsedTimeFraction
-	updatedUser.BalanceSolo -= updatedUser.SlashingRateSolo * elapsedTimeFraction
+	slashedSolo, slashedT0 := calc.mul(updatedUser.SlashingRateSolo, elapsedTimeFraction), calc.mul(updatedUser.SlashingRateT0, elapsedTimeFraction)
+	slashedAmount := slashedSolo + slashedT0
+	mintedByRate, slashedByRate := mintedAmount, slashedAmount
+	updatedUser.BalanceSolo -= slashedSolo
 
-	pendingAmountForTMinus1 -= updatedUser.SlashingRateForTMinus1 * elapsedTimeFraction
-	pendingAmountForT0 -= updatedUser.SlashingRateForT0 * elapsedTimeFraction
+	pendingAmountForTMinus1 -= calc.mul(updatedUser.SlashingRateForTMinus1, elapsedTimeFraction)
+	pendingAmountForT0 -= calc.mul(updatedUser.SlashingRateForT0, elapsedTimeFraction)
 
 	updatedUser.BalanceForTMinus1 += pendingAmountForTMinus1
 	updatedUser.BalanceForT0 += pendingAmountForT0
-	updatedUser.BalanceT0 -= updatedUser.SlashingRateT0 * elapsedTimeFraction
+	updatedUser.BalanceT0 -= slashedT0
 	updatedUser.BalanceSolo += unAppliedSoloPending
 	updatedUser.BalanceT1 += unAppliedT1Pending
 	updatedUser.BalanceT2 += unAppliedT2Pending
@@ -272,10 +289,10 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 	}
 
 	if usr.BalanceTotalPreStaking+usr.BalanceTotalStandard == 0 {
-		slashedAmount = 0
+		slashedAmount, slashedByRate = 0, 0
 	}
 	if updatedUser.WelcomeBonusV2Applied == nil || !*updatedUser.WelcomeBonusV2Applied {
-		updatedUser.BalanceSolo += cfg.WelcomeBonusV2Amount - 10
+		updatedUser.BalanceSolo += cfg.WelcomeBonusV2() - welcomeBonusV2Deduction
 		trueVal := model.FlexibleBool(true)
 		updatedUser.WelcomeBonusV2Applied = &trueVal
 	} else {
@@ -288,9 +305,40 @@ func mine(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (updatedUser *
 	slashedStandard, slashedPreStaking := tokenomics.ApplyPreStaking(slashedAmount, updatedUser.PreStakingAllocation, updatedUser.PreStakingBonus)
 	updatedUser.BalanceTotalMinted += mintedStandard + mintedPreStaking
 	updatedUser.BalanceTotalSlashed += slashedStandard + slashedPreStaking
+	mintedByRateStandard, mintedByRatePreStaking := tokenomics.ApplyPreStaking(mintedByRate, updatedUser.PreStakingAllocation, updatedUser.PreStakingBonus)
+	slashedByRateStandard, slashedByRatePreStaking := tokenomics.ApplyPreStaking(slashedByRate, updatedUser.PreStakingAllocation, updatedUser.PreStakingBonus)
+	updatedUser.BalanceMintedByRate += mintedByRateStandard + mintedByRatePreStaking
+	updatedUser.BalanceSlashedByRate += slashedByRateStandard + slashedByRatePreStaking
 	updatedUser.BalanceLastUpdatedAt = now
+	if calc.err != nil {
+		return nil, false, false, 0, 0, errors.Wrapf(calc.err, "invalid amounts, bmr:%v, elapsed:%v", baseMiningRate, elapsedTimeFraction)
+	}
+
+	return updatedUser, shouldGenerateHistory, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, nil
+}
+
+// Converts the (float) rate × time products to amounts, keeping the first one that can't be converted,
+// so that the mining arithmetic stays readable.
+type amountCalculator struct {
+	err error
+}
+
+func (c *amountCalculator) ice(val float64) model.Amount {
+	amount, err := model.NewAmount(val)
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+
+	return amount
+}
+
+func (c *amountCalculator) mul(amount model.Amount, factor float64) model.Amount {
+	product, err := amount.MulFloat64(factor)
+	if err != nil && c.err == nil {
+		c.err = errors.Wrapf(err, "%v x %v", amount, factor)
+	}
 
-	return updatedUser, shouldGenerateHistory, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0
+	return product
 }
 
 func updateT0AndTMinus1ReferralsForUserHasNeverMined(usr *user) (updatedUser *referralUpdated) {
@@ -317,11 +365,11 @@ func (u *user) isAbsoluteZero() bool {
 }
 
 func (u *user) reachedSlashingFloor() bool {
-	return (u.BalanceSolo + u.BalanceT0 + u.BalanceT1 + u.BalanceT2) <= cfg.SlashingFloor
+	return (u.BalanceSolo + u.BalanceT0 + u.BalanceT1 + u.BalanceT2) <= cfg.SlashingFloorAmount()
 }
 
 func (ref *referral) reachedSlashingFloor() bool {
-	return (ref.BalanceSolo + ref.BalanceT0 + ref.BalanceT1 + ref.BalanceT2) <= cfg.SlashingFloor
+	return (ref.BalanceSolo + ref.BalanceT0 + ref.BalanceT1 + ref.BalanceT2) <= cfg.SlashingFloorAmount()
 }
 
 func (u *user) slashingDisabled() bool {
@@ -46,13 +46,19 @@ func timeDelta(d stdlibtime.Duration) *time.Time {
 	return time.New(testTime.Add(d))
 }
 
+func ice(amount float64) model.Amount {
+	return model.MustNewAmount(amount)
+}
+
 func testSoloMiningNoExtraBonus(t *testing.T) {
 	t.Run("No referrals", func(t *testing.T) {
 		m := newUser()
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, testMiningBase, m.BalanceSolo)
+		require.EqualValues(t, ice(testMiningBase), m.BalanceSolo)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -63,11 +69,13 @@ func testSoloMiningNoExtraBonus(t *testing.T) {
 		m := newUser()
 		ref := newRef()
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -79,11 +87,13 @@ func testSoloMiningNoExtraBonus(t *testing.T) {
 		m := newUser()
 		ref := newRef()
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -98,10 +108,12 @@ func testSoloMiningNoExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -116,29 +128,33 @@ func testSoloMiningNoExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, tMinus1Ref)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, tMinus1Ref)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 
 	t.Run("With T2", func(t *testing.T) {
 		m := newUser()
 		m.ActiveT2Referrals = 20
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 16, m.BalanceT2)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(16), m.BalanceT2)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -156,21 +172,23 @@ func testSoloMiningNoExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, tMinus1Ref)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, tMinus1Ref)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
-		require.EqualValues(t, 16, m.BalanceT2)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceT2)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 }
 
@@ -180,9 +198,11 @@ func testSoloMiningWithExtraBonus(t *testing.T) {
 		m.ExtraBonusStartedAt = timeDelta(stdlibtime.Hour)
 		m.ExtraBonus = 100
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -198,17 +218,19 @@ func testSoloMiningWithExtraBonus(t *testing.T) {
 		m.ExtraBonus = 100
 		ref := newRef()
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.EqualValues(t, 0, m.BalanceForTMinus1)
 	})
 
@@ -218,17 +240,19 @@ func testSoloMiningWithExtraBonus(t *testing.T) {
 		m.ExtraBonus = 100
 		ref := newRef()
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
 		require.EqualValues(t, 0, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 
 	t.Run("With T1", func(t *testing.T) {
@@ -239,10 +263,12 @@ func testSoloMiningWithExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -260,18 +286,20 @@ func testSoloMiningWithExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.EqualValues(t, 0, m.BalanceForTMinus1)
 	})
 	t.Run("With T2", func(t *testing.T) {
@@ -280,10 +308,12 @@ func testSoloMiningWithExtraBonus(t *testing.T) {
 		m.ExtraBonusStartedAt = timeDelta(stdlibtime.Hour)
 		m.ExtraBonus = 100
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 16, m.BalanceT2)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(16), m.BalanceT2)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -303,21 +333,23 @@ func testSoloMiningWithExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, refMinus)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, refMinus)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
-		require.EqualValues(t, 16, m.BalanceT2)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceT2)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 }
 
@@ -327,9 +359,11 @@ func testSoloMiningWithPreStaking(t *testing.T) {
 		m.PreStakingBonus = 200
 		m.PreStakingAllocation = 50
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -344,17 +378,19 @@ func testSoloMiningWithPreStaking(t *testing.T) {
 		m.PreStakingAllocation = 50
 		ref := newRef()
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.EqualValues(t, 0, m.BalanceForTMinus1)
 	})
 	t.Run("For tMinus1", func(t *testing.T) {
@@ -365,17 +401,19 @@ func testSoloMiningWithPreStaking(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
 		require.EqualValues(t, 0, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 	t.Run("With T1", func(t *testing.T) {
 		m := newUser()
@@ -385,10 +423,12 @@ func testSoloMiningWithPreStaking(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -406,18 +446,20 @@ func testSoloMiningWithPreStaking(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.EqualValues(t, 0, m.BalanceForTMinus1)
 	})
 	t.Run("With T2", func(t *testing.T) {
@@ -428,10 +470,12 @@ func testSoloMiningWithPreStaking(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 16, m.BalanceT2)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(16), m.BalanceT2)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -451,21 +495,23 @@ func testSoloMiningWithPreStaking(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, refMinus)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, refMinus)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 16, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
-		require.EqualValues(t, 16, m.BalanceT2)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(16), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceT2)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 }
 
@@ -477,9 +523,11 @@ func testSoloMiningWithPreStakingAndExtraBonus(t *testing.T) {
 		m.ExtraBonus = 100
 		m.ExtraBonusStartedAt = timeDelta(stdlibtime.Hour)
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -496,17 +544,19 @@ func testSoloMiningWithPreStakingAndExtraBonus(t *testing.T) {
 		m.ExtraBonusStartedAt = timeDelta(stdlibtime.Hour)
 		ref := newRef()
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.EqualValues(t, 0, m.BalanceForTMinus1)
 	})
 	t.Run("For tMinus1", func(t *testing.T) {
@@ -519,17 +569,19 @@ func testSoloMiningWithPreStakingAndExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
 		require.EqualValues(t, 0, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 	t.Run("With T1", func(t *testing.T) {
 		m := newUser()
@@ -541,10 +593,12 @@ func testSoloMiningWithPreStakingAndExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -564,18 +618,20 @@ func testSoloMiningWithPreStakingAndExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
 		require.EqualValues(t, 0, m.BalanceForTMinus1)
 	})
 	t.Run("With T2", func(t *testing.T) {
@@ -588,10 +644,12 @@ func testSoloMiningWithPreStakingAndExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 16, m.BalanceT2)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(16), m.BalanceT2)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -613,21 +671,23 @@ func testSoloMiningWithPreStakingAndExtraBonus(t *testing.T) {
 		slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 		m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, refMinus)
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, refMinus)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 32, m.BalanceSolo)
-		require.EqualValues(t, 4, m.BalanceT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 8, m.BalanceT1)
-		require.EqualValues(t, 16, m.BalanceT2)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(32), m.BalanceSolo)
+		require.EqualValues(t, ice(4), m.BalanceT0)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(8), m.BalanceT1)
+		require.EqualValues(t, ice(16), m.BalanceT2)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
 		require.EqualValues(t, 0, pendingAmountForT0)
-		require.EqualValues(t, 4, m.BalanceForT0)
-		require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+		require.EqualValues(t, ice(4), m.BalanceForT0)
+		require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 	})
 }
 
@@ -645,17 +705,19 @@ func testNegativeMiningSoloSlashing(t *testing.T) {
 	m.BalanceLastUpdatedAt = timeDelta(-stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(25 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
-	m.BalanceSolo = 1440
-	m.BalanceT0 = 1440
-	m.BalanceForT0 = 1440
-	m.BalanceT1 = 1440
-	m.BalanceT2 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT0 = ice(1440)
+	m.BalanceForT0 = ice(1440)
+	m.BalanceT1 = ice(1440)
+	m.BalanceT2 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 	m.IDT0 = testIDT0
 	slashingDisabledMiningBoostIx := model.FlexibleUint64(1)
 	m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-	m1, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+	m1, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+	require.NoError(t, err)
 	require.Nil(t, m1)
 
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
@@ -667,10 +729,12 @@ func testNegativeMiningSoloSlashing(t *testing.T) {
 	m.MiningSessionSoloEndedAt = timeDelta(-168 * stdlibtime.Hour)
 	m.MiningBoostLevelIndex = nil
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(testTime, m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(testTime, m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 
-	require.EqualValues(t, 6, m.SlashingRateSolo)
+	require.EqualValues(t, ice(6), m.SlashingRateSolo)
 	require.EqualValues(t, 0, m.SlashingRateT0)
 	require.EqualValues(t, 0, m.SlashingRateT1)
 	require.EqualValues(t, 0, m.SlashingRateT2)
@@ -679,11 +743,11 @@ func testNegativeMiningSoloSlashing(t *testing.T) {
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
 
-	require.EqualValues(t, 432, m.BalanceSolo)
-	require.EqualValues(t, 1440, m.BalanceT0)
-	require.EqualValues(t, 1440, m.BalanceT1)
-	require.EqualValues(t, 1440, m.BalanceT2)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(432), m.BalanceSolo)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT1)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, testIDT0, m.IDT0)
@@ -695,15 +759,17 @@ func testNegativeMiningT0Slashing(t *testing.T) {
 	m.BalanceLastUpdatedAt = timeDelta(-stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(23 * stdlibtime.Hour)
-	m.BalanceT0 = 1440
-	m.BalanceForT0 = 1440
+	m.BalanceT0 = ice(1440)
+	m.BalanceForT0 = ice(1440)
 	m.IDT0 = testIDT0
 
 	ref := newRef()
 	ref.MiningSessionSoloStartedAt = timeDelta(-25 * stdlibtime.Hour)
 	ref.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 
 	require.EqualValues(t, 0, m.SlashingRateSolo)
@@ -712,7 +778,7 @@ func testNegativeMiningT0Slashing(t *testing.T) {
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
 
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, testIDT0, m.IDT0)
@@ -725,15 +791,17 @@ func testNegativeMiningT0Slashing(t *testing.T) {
 	ref.MiningSessionSoloStartedAt = timeDelta(-192 * stdlibtime.Hour)
 	ref.MiningSessionSoloEndedAt = timeDelta(-168 * stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(testTime, m, ref, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(testTime, m, ref, nil)
 
-	require.EqualValues(t, 0, m.SlashingRateSolo)
-	require.EqualValues(t, 6, m.SlashingRateT0)
+	require.NoError(t, err)
+
+	require.EqualValues(t, ice(0.066666), m.SlashingRateSolo)
+	require.EqualValues(t, ice(6), m.SlashingRateT0)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
 
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, testIDT0, m.IDT0)
@@ -745,11 +813,11 @@ func testNegativeMiningT0SlashingSoloSlashing(t *testing.T) {
 	m.BalanceLastUpdatedAt = timeDelta(-stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-25 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
-	m.BalanceSolo = 1440
-	m.BalanceT0 = 1440
-	m.BalanceForT0 = 1440
-	m.BalanceT1 = 1440
-	m.BalanceT2 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT0 = ice(1440)
+	m.BalanceForT0 = ice(1440)
+	m.BalanceT1 = ice(1440)
+	m.BalanceT2 = ice(1440)
 	m.IDT0 = testIDT0
 	slashingDisabledMiningBoostIx := model.FlexibleUint64(1)
 	m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
@@ -758,7 +826,9 @@ func testNegativeMiningT0SlashingSoloSlashing(t *testing.T) {
 	ref.MiningSessionSoloStartedAt = timeDelta(-25 * stdlibtime.Hour)
 	ref.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
 
-	m1, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, ref, nil)
+	m1, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, ref, nil)
+
+	require.NoError(t, err)
 	require.Nil(t, m1)
 
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
@@ -774,22 +844,24 @@ func testNegativeMiningT0SlashingSoloSlashing(t *testing.T) {
 	ref.MiningSessionSoloEndedAt = timeDelta(-168 * stdlibtime.Hour)
 	m.MiningBoostLevelIndex = nil
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(testTime, m, ref, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(testTime, m, ref, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 
-	require.EqualValues(t, 6, m.SlashingRateSolo)
-	require.EqualValues(t, 6, m.SlashingRateT0)
+	require.EqualValues(t, ice(6), m.SlashingRateSolo)
+	require.EqualValues(t, ice(6), m.SlashingRateT0)
 	require.EqualValues(t, 0, m.SlashingRateT1)
 	require.EqualValues(t, 0, m.SlashingRateT2)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
 
-	require.EqualValues(t, 432, m.BalanceSolo)
-	require.EqualValues(t, 432, m.BalanceT0)
-	require.EqualValues(t, 1440, m.BalanceT1)
-	require.EqualValues(t, 1440, m.BalanceT2)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(432), m.BalanceSolo)
+	require.EqualValues(t, ice(432), m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT1)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, testIDT0, m.IDT0)
@@ -801,8 +873,8 @@ func testNegativeMiningT1minusSlashingSoloMining(t *testing.T) {
 	m.BalanceLastUpdatedAt = timeDelta(-stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(23 * stdlibtime.Hour)
-	m.BalanceForTMinus1 = 1440
-	m.BalanceSolo = 1440
+	m.BalanceForTMinus1 = ice(1440)
+	m.BalanceSolo = ice(1440)
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = testIDTMinus1
 
@@ -810,7 +882,9 @@ func testNegativeMiningT1minusSlashingSoloMining(t *testing.T) {
 	ref.MiningSessionSoloStartedAt = timeDelta(-25 * stdlibtime.Hour)
 	ref.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 
 	require.EqualValues(t, 0, m.SlashingRateSolo)
@@ -818,7 +892,7 @@ func testNegativeMiningT1minusSlashingSoloMining(t *testing.T) {
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
 	require.EqualValues(t, 0, m.BalanceForT0)
-	require.EqualValues(t, 1440, m.BalanceForTMinus1)
+	require.EqualValues(t, ice(1440), m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
@@ -832,15 +906,17 @@ func testNegativeMiningT1minusSlashingSoloMining(t *testing.T) {
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = testIDTMinus1
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(testTime, m, nil, ref)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(testTime, m, nil, ref)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 
-	require.EqualValues(t, 6.066666666666666, m.SlashingRateSolo)
+	require.EqualValues(t, ice(6.066666), m.SlashingRateSolo)
 	require.EqualValues(t, 0, m.SlashingRateForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
 	require.EqualValues(t, 0, m.BalanceForT0)
-	require.EqualValues(t, 1440, m.BalanceForTMinus1)
+	require.EqualValues(t, ice(1440), m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
@@ -857,36 +933,38 @@ func testNegativeMining(t *testing.T) {
 
 func testMiningResurrectT0(t *testing.T) {
 	m := newUser()
-	m.SlashingRateForT0 = 10
+	m.SlashingRateForT0 = ice(10)
 	m.IDT0 = testIDT0
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 
 	t0Ref := new(referral)
 	t0Ref.MiningSessionSoloStartedAt = timeDelta(0)
 	t0Ref.MiningSessionSoloPreviouslyEndedAt = timeDelta(-24 * 10 * stdlibtime.Hour)
 	t0Ref.ResurrectSoloUsedAt = timeDelta(stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, t0Ref, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, t0Ref, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 
-	require.EqualValues(t, 3840, m.BalanceForT0)
+	require.EqualValues(t, ice(3840), m.BalanceForT0)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, testIDT0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
-	require.EqualValues(t, 2400, pendingAmountForT0)
-	require.EqualValues(t, 3840, m.BalanceForT0)
+	require.EqualValues(t, ice(2400), pendingAmountForT0)
+	require.EqualValues(t, ice(3840), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 }
 
 func testMiningResurrectT0ResetSlashing(t *testing.T) {
 	m := newUser()
-	m.SlashingRateForT0 = 10
+	m.SlashingRateForT0 = ice(10)
 	m.IDT0 = testIDT0
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 
 	t0Ref := new(referral)
 	t0Ref.MiningSessionSoloStartedAt = timeDelta(0)
@@ -894,7 +972,9 @@ func testMiningResurrectT0ResetSlashing(t *testing.T) {
 	t0Ref.MiningSessionSoloPreviouslyEndedAt = timeDelta(-24 * 10 * stdlibtime.Hour)
 	m.ResurrectT0UsedAt = timeDelta(stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, t0Ref, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, t0Ref, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.False(t, IDT0Changed)
@@ -902,16 +982,16 @@ func testMiningResurrectT0ResetSlashing(t *testing.T) {
 	require.EqualValues(t, 0, m.IDTMinus1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1444, m.BalanceForT0)
+	require.EqualValues(t, ice(1444), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 }
 
 func testMiningResurrectTMinus1ResetSlashing(t *testing.T) {
 	m := newUser()
-	m.SlashingRateForTMinus1 = 10
+	m.SlashingRateForTMinus1 = ice(10)
 	m.IDTMinus1 = testIDTMinus1
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 
 	ref := new(referral)
 	ref.MiningSessionSoloStartedAt = timeDelta(0)
@@ -919,7 +999,9 @@ func testMiningResurrectTMinus1ResetSlashing(t *testing.T) {
 	ref.MiningSessionSoloPreviouslyEndedAt = timeDelta(-24 * 10 * stdlibtime.Hour)
 	m.ResurrectTMinus1UsedAt = timeDelta(stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 	require.EqualValues(t, 0, m.SlashingRateForTMinus1)
 	require.False(t, IDT0Changed)
@@ -928,21 +1010,23 @@ func testMiningResurrectTMinus1ResetSlashing(t *testing.T) {
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
 	require.EqualValues(t, 0, m.BalanceForT0)
-	require.EqualValues(t, 0.8, m.BalanceForTMinus1)
+	require.EqualValues(t, ice(0.8), m.BalanceForTMinus1)
 }
 
 func testMiningResurrectSolo(t *testing.T) {
 	m := newUser()
-	m.SlashingRateSolo = 10
+	m.SlashingRateSolo = ice(10)
 	m.MiningSessionSoloStartedAt = timeDelta(0)
 	m.MiningSessionSoloPreviouslyEndedAt = timeDelta(-24 * 10 * stdlibtime.Hour)
 	m.ResurrectSoloUsedAt = timeDelta(stdlibtime.Hour)
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
+
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 2400, m.BalanceSolo)
+	require.EqualValues(t, ice(2400), m.BalanceSolo)
 	require.EqualValues(t, 0, m.SlashingRateSolo)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
@@ -955,28 +1039,30 @@ func testMiningResurrectSolo(t *testing.T) {
 
 func testMiningResurrectT1(t *testing.T) {
 	m := newUser()
-	m.SlashingRateForTMinus1 = 10
+	m.SlashingRateForTMinus1 = ice(10)
 	m.IDTMinus1 = testIDTMinus1
 	m.IDT0 = testIDT0
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 
 	ref := new(referral)
 	ref.MiningSessionSoloStartedAt = timeDelta(0)
 	ref.MiningSessionSoloPreviouslyEndedAt = timeDelta(-24 * 10 * stdlibtime.Hour)
 	ref.ResurrectSoloUsedAt = timeDelta(stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, ref)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, ref)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 3840, m.BalanceForTMinus1)
+	require.EqualValues(t, ice(3840), m.BalanceForTMinus1)
 	require.EqualValues(t, 0, m.SlashingRateForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
-	require.EqualValues(t, 2400, pendingAmountForTMinus1)
+	require.EqualValues(t, ice(2400), pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
-	require.EqualValues(t, 3840, m.BalanceForTMinus1)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
+	require.EqualValues(t, ice(3840), m.BalanceForTMinus1)
 }
 
 func testMiningResurrect(t *testing.T) {
@@ -1001,7 +1087,9 @@ func Test_BalancePositive(t *testing.T) {
 func Test_MinerNil(t *testing.T) {
 	t.Parallel()
 
-	m, h, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, nil, nil, nil)
+	m, h, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, nil, nil, nil)
+
+	require.NoError(t, err)
 	require.Nil(t, m)
 	require.False(t, h)
 	require.False(t, IDT0Changed)
@@ -1015,25 +1103,29 @@ func Test_MinerPending(t *testing.T) {
 	t.Run("Apply", func(t *testing.T) {
 		m := newUser()
 		m.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
-		m.BalanceT1Pending = 2
-		m.BalanceT2Pending = 2
-		m.BalanceT1PendingApplied = 1
-		m.BalanceT2PendingApplied = 1
-		m.BalanceT1 = 1440
-		m.BalanceT2 = 1440
-		m.BalanceForT0 = 1440
-		m.BalanceForTMinus1 = 1440
-		m.BalanceSolo = 1440
-
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m.BalanceT1Pending = ice(2)
+		m.BalanceT2Pending = ice(2)
+		m.BalanceT1PendingApplied = ice(1)
+		m.BalanceT2PendingApplied = ice(1)
+		m.BalanceT1 = ice(1440)
+		m.BalanceT2 = ice(1440)
+		m.BalanceForT0 = ice(1440)
+		m.BalanceForTMinus1 = ice(1440)
+		m.BalanceSolo = ice(1440)
+
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, 2.0, m.BalanceT1PendingApplied)
-		require.EqualValues(t, 2.0, m.BalanceT2PendingApplied)
-		require.EqualValues(t, 2.0, m.BalanceT1Pending)
-		require.EqualValues(t, 2.0, m.BalanceT2Pending)
-		require.EqualValues(t, 1441, m.BalanceT1)
-		require.EqualValues(t, 1441, m.BalanceT2)
-		require.EqualValues(t, 2.0, m.BalanceT2Pending)
+		require.EqualValues(t, ice(2.0), m.BalanceT1PendingApplied)
+		require.EqualValues(t, ice(2.0), m.BalanceT2PendingApplied)
+		require.EqualValues(t, ice(2.0), m.BalanceT1Pending)
+		require.EqualValues(t, ice(2.0), m.BalanceT2Pending)
+		require.EqualValues(t, ice(1441), m.BalanceT1)
+		require.EqualValues(t, ice(1441), m.BalanceT2)
+		require.EqualValues(t, ice(2.0), m.BalanceT2Pending)
+		require.EqualValues(t, ice(2.0), m.BalanceTotalMinted)
+		require.Zero(t, m.BalanceMintedByRate)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -1046,24 +1138,26 @@ func Test_MinerPending(t *testing.T) {
 	t.Run("Apply slashing", func(t *testing.T) {
 		m := newUser()
 		m.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
-		m.BalanceT1Pending = -4
-		m.BalanceT2Pending = -4
-		m.BalanceT1PendingApplied = 0
-		m.BalanceT2PendingApplied = 0
-		m.BalanceT1 = 1440
-		m.BalanceT2 = 1440
-		m.BalanceForT0 = 1440
-		m.BalanceForTMinus1 = 1440
-		m.BalanceSolo = 1440
-
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m.BalanceT1Pending = ice(-4)
+		m.BalanceT2Pending = ice(-4)
+		m.BalanceT1PendingApplied = ice(0)
+		m.BalanceT2PendingApplied = ice(0)
+		m.BalanceT1 = ice(1440)
+		m.BalanceT2 = ice(1440)
+		m.BalanceForT0 = ice(1440)
+		m.BalanceForTMinus1 = ice(1440)
+		m.BalanceSolo = ice(1440)
+
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, -4, m.BalanceT1PendingApplied)
-		require.EqualValues(t, -4, m.BalanceT2PendingApplied)
-		require.EqualValues(t, -4, m.BalanceT1Pending)
-		require.EqualValues(t, -4, m.BalanceT2Pending)
-		require.EqualValues(t, 1436, m.BalanceT1)
-		require.EqualValues(t, 1436, m.BalanceT2)
+		require.EqualValues(t, ice(-4), m.BalanceT1PendingApplied)
+		require.EqualValues(t, ice(-4), m.BalanceT2PendingApplied)
+		require.EqualValues(t, ice(-4), m.BalanceT1Pending)
+		require.EqualValues(t, ice(-4), m.BalanceT2Pending)
+		require.EqualValues(t, ice(1436), m.BalanceT1)
+		require.EqualValues(t, ice(1436), m.BalanceT2)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -1076,23 +1170,25 @@ func Test_MinerPending(t *testing.T) {
 	t.Run("Pending/applied with empty balanceSolo", func(t *testing.T) {
 		m := newUser()
 		m.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
-		m.BalanceT1Pending = -4
-		m.BalanceT2Pending = -4
-		m.BalanceT1PendingApplied = 0
-		m.BalanceT2PendingApplied = 0
-		m.BalanceT1 = 1440
-		m.BalanceT2 = 1440
-		m.BalanceForT0 = 1440
-		m.BalanceForTMinus1 = 1440
-
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m.BalanceT1Pending = ice(-4)
+		m.BalanceT2Pending = ice(-4)
+		m.BalanceT1PendingApplied = ice(0)
+		m.BalanceT2PendingApplied = ice(0)
+		m.BalanceT1 = ice(1440)
+		m.BalanceT2 = ice(1440)
+		m.BalanceForT0 = ice(1440)
+		m.BalanceForTMinus1 = ice(1440)
+
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.NotNil(t, m)
-		require.EqualValues(t, -4, m.BalanceT1PendingApplied)
-		require.EqualValues(t, -4, m.BalanceT2PendingApplied)
-		require.EqualValues(t, -4, m.BalanceT1Pending)
-		require.EqualValues(t, -4, m.BalanceT2Pending)
-		require.EqualValues(t, 1440, m.BalanceT1)
-		require.EqualValues(t, 1440, m.BalanceT2)
+		require.EqualValues(t, ice(-4), m.BalanceT1PendingApplied)
+		require.EqualValues(t, ice(-4), m.BalanceT2PendingApplied)
+		require.EqualValues(t, ice(-4), m.BalanceT1Pending)
+		require.EqualValues(t, ice(-4), m.BalanceT2Pending)
+		require.EqualValues(t, ice(1440), m.BalanceT1)
+		require.EqualValues(t, ice(1440), m.BalanceT2)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, m.IDT0)
 		require.EqualValues(t, 0, m.IDTMinus1)
@@ -1105,14 +1201,16 @@ func Test_MinerPending(t *testing.T) {
 	t.Run("Skip", func(t *testing.T) {
 		m := newUser()
 		m.MiningSessionSoloEndedAt = timeDelta(-stdlibtime.Hour)
-		m.BalanceT1Pending = 1
-		m.BalanceT2Pending = 1
-		m.BalanceT1PendingApplied = 1
-		m.BalanceT2PendingApplied = 1
-		m.BalanceForT0 = 1440
-		m.BalanceForTMinus1 = 1440
-
-		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+		m.BalanceT1Pending = ice(1)
+		m.BalanceT2Pending = ice(1)
+		m.BalanceT1PendingApplied = ice(1)
+		m.BalanceT2PendingApplied = ice(1)
+		m.BalanceForT0 = ice(1440)
+		m.BalanceForTMinus1 = ice(1440)
+
+		m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+		require.NoError(t, err)
 		require.Nil(t, m)
 		require.False(t, IDT0Changed)
 		require.EqualValues(t, 0, pendingAmountForTMinus1)
@@ -1125,14 +1223,16 @@ func Test_MinerWithHistory(t *testing.T) {
 	// 24h not passed yet -> no history.
 	m := newUser()
 	m.BalanceLastUpdatedAt = time.New(testTime.Add(-stdlibtime.Hour * 2))
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 
-	m, h, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+	m, h, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 	require.False(t, h)
 
-	require.EqualValues(t, float64(testMiningBase), m.BalanceSolo)
+	require.EqualValues(t, ice(float64(testMiningBase)), m.BalanceSolo)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
@@ -1144,14 +1244,16 @@ func Test_MinerWithHistory(t *testing.T) {
 	t.Logf("new:     %p", m)
 	// 24h passed -> generate history.
 	m.BalanceLastUpdatedAt = time.New(testTime.Add(-stdlibtime.Hour * 24))
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
+
+	m, h, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(testTime, m, nil, nil)
 
-	m, h, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(testTime, m, nil, nil)
+	require.NoError(t, err)
 	require.NotNil(t, m)
 	require.True(t, h)
 
-	require.EqualValues(t, 890, m.BalanceSolo)
+	require.EqualValues(t, ice(890), m.BalanceSolo)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
@@ -1165,26 +1267,29 @@ func Test_MinerWithHistory(t *testing.T) {
 	// User with disabled slashing, but session is not fully processed yet -> no history.
 	m.BalanceLastUpdatedAt = timeDelta(23*stdlibtime.Hour - 1*stdlibtime.Second)
 	var m_nil *user
-	m_nil, h, _, _, _ = mine(timeDelta(48*stdlibtime.Hour), m, nil, nil)
+	m_nil, h, _, _, _, err = mine(timeDelta(48*stdlibtime.Hour), m, nil, nil)
+	require.NoError(t, err)
 	require.False(t, h)
 	require.Nil(t, m_nil)
 	// User with disabled slashing, and session is completed (balanceLastUpdatedAt after session end) -> history.
 	m.BalanceLastUpdatedAt = timeDelta(23*stdlibtime.Hour + 1*stdlibtime.Second)
 	m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
-	m, h, _, _, _ = mine(timeDelta(48*stdlibtime.Hour), m, nil, nil)
+	m, h, _, _, _, err = mine(timeDelta(48*stdlibtime.Hour), m, nil, nil)
+	require.NoError(t, err)
 	require.True(t, h)
 	require.NotNil(t, m)
 	require.Equal(t, timeDelta(48*stdlibtime.Hour), m.BalanceLastUpdatedAt)
 	// User is on slashing floor and last day of slashing -> history.
-	m.BalanceSolo = 0.99
-	m.BalanceT0 = 0
-	m.BalanceT1 = 0
-	m.BalanceT2 = 0
+	m.BalanceSolo = ice(0.99)
+	m.BalanceT0 = ice(0)
+	m.BalanceT1 = ice(0)
+	m.BalanceT2 = ice(0)
 	require.True(t, m.reachedSlashingFloor())
 	m.MiningSessionSoloLastStartedAt = timeDelta(-11 * 24 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(-10 * 24 * stdlibtime.Hour)
 	m.MiningBoostLevelIndex = nil
-	m, h, _, _, _ = mine(timeDelta(1*stdlibtime.Minute), m, nil, nil)
+	m, h, _, _, _, err = mine(timeDelta(1*stdlibtime.Minute), m, nil, nil)
+	require.NoError(t, err)
 	require.True(t, h)
 	require.NotNil(t, m)
 	require.Equal(t, timeDelta(1*stdlibtime.Minute), m.BalanceLastUpdatedAt)
@@ -1197,16 +1302,18 @@ func Test_MinerNegativeBalance(t *testing.T) {
 	m.MiningSessionSoloStartedAt = m.MiningSessionSoloEndedAt
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = testIDTMinus1
-	m.BalanceSolo = -1
-	m.BalanceT0 = -2
-	m.BalanceT1 = -3
-	m.BalanceT2 = -4
-	m.BalanceForT0 = -5
-	m.BalanceForTMinus1 = -6
+	m.BalanceSolo = ice(-1)
+	m.BalanceT0 = ice(-2)
+	m.BalanceT1 = ice(-3)
+	m.BalanceT2 = ice(-4)
+	m.BalanceForT0 = ice(-5)
+	m.BalanceForTMinus1 = ice(-6)
 	m.ActiveT1Referrals = -7
 	m.ActiveT2Referrals = -8
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
 
 	require.Zero(t, m.BalanceSolo)
@@ -1233,21 +1340,23 @@ func testMinerPendingSlashingSolo(t *testing.T) {
 	m.MiningSessionSoloStartedAt = m.MiningSessionSoloEndedAt
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = testIDTMinus1
-	m.BalanceSoloPending = 1
-	m.BalanceSoloPendingApplied = 3
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceSoloPending = ice(1)
+	m.BalanceSoloPendingApplied = ice(3)
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
+
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 1., m.BalanceSoloPendingApplied)
+	require.EqualValues(t, ice(1.), m.BalanceSoloPendingApplied)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
-	require.EqualValues(t, 1440, m.BalanceForTMinus1)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForTMinus1)
 }
 
 func testMinerPendingSlashingT1(t *testing.T) {
@@ -1257,12 +1366,14 @@ func testMinerPendingSlashingT1(t *testing.T) {
 	m.MiningSessionSoloStartedAt = m.MiningSessionSoloEndedAt
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = testIDTMinus1
-	m.BalanceT1Pending = 1
-	m.BalanceT1PendingApplied = 3
+	m.BalanceT1Pending = ice(1)
+	m.BalanceT1PendingApplied = ice(3)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 1., m.BalanceT1PendingApplied)
+	require.EqualValues(t, ice(1.), m.BalanceT1PendingApplied)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
@@ -1277,12 +1388,14 @@ func testMinerPendingSlashingT2(t *testing.T) {
 	m.MiningSessionSoloStartedAt = m.MiningSessionSoloEndedAt
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = testIDTMinus1
-	m.BalanceT2Pending = 1
-	m.BalanceT2PendingApplied = 3
+	m.BalanceT2Pending = ice(1)
+	m.BalanceT2PendingApplied = ice(3)
+
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(testTime, m, nil, nil)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(testTime, m, nil, nil)
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 1., m.BalanceT2PendingApplied)
+	require.EqualValues(t, ice(1.), m.BalanceT2PendingApplied)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
@@ -1303,31 +1416,33 @@ func testMinerPendingSlashingSolo_BonusPrizeCase(t *testing.T) {
 	m.BalanceLastUpdatedAt = timeDelta(-23 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-192 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(-192 * stdlibtime.Hour)
-	m.BalanceSolo = 1440
-	m.BalanceT0 = 1440
-	m.BalanceForT0 = 1440
-	m.BalanceT1 = 1440
-	m.BalanceT2 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT0 = ice(1440)
+	m.BalanceForT0 = ice(1440)
+	m.BalanceT1 = ice(1440)
+	m.BalanceT2 = ice(1440)
 	m.IDT0 = testIDT0
 
 	ref := newRef()
 	ref.MiningSessionSoloStartedAt = timeDelta(-192 * stdlibtime.Hour)
 	ref.MiningSessionSoloEndedAt = timeDelta(-168 * stdlibtime.Hour)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 6, m.SlashingRateSolo)
+	require.EqualValues(t, ice(6), m.SlashingRateSolo)
 	require.EqualValues(t, 0, m.SlashingRateT0)
 	require.EqualValues(t, 0, m.SlashingRateT1)
 	require.EqualValues(t, 0, m.SlashingRateT2)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1314, m.BalanceSolo)
-	require.EqualValues(t, 1440, m.BalanceT0)
-	require.EqualValues(t, 1440, m.BalanceT1)
-	require.EqualValues(t, 1440, m.BalanceT2)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1314), m.BalanceSolo)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT1)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.EqualValues(t, 0., m.BalanceSoloPending)
 	require.EqualValues(t, 0., m.BalanceSoloPendingApplied)
@@ -1335,45 +1450,48 @@ func testMinerPendingSlashingSolo_BonusPrizeCase(t *testing.T) {
 	require.EqualValues(t, testIDT0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
 
-	m.BalanceSoloPending += 5000
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m.BalanceSoloPending += ice(5000)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 6804, m.BalanceSolo)
-	require.EqualValues(t, 26.833333333333332, m.SlashingRateSolo)
-	require.EqualValues(t, 1440, m.BalanceT0)
+	require.EqualValues(t, ice(6804), m.BalanceSolo)
+	require.EqualValues(t, ice(26.833333333333332), m.SlashingRateSolo)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
 	require.EqualValues(t, 0, m.SlashingRateT0)
 	require.EqualValues(t, 0, m.SlashingRateT1)
 	require.EqualValues(t, 0, m.SlashingRateT2)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceT0)
-	require.EqualValues(t, 1440, m.BalanceT1)
-	require.EqualValues(t, 1440, m.BalanceT2)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT1)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
-	require.EqualValues(t, 5000., m.BalanceSoloPending)
-	require.EqualValues(t, 5000., m.BalanceSoloPendingApplied)
+	require.EqualValues(t, ice(5000.), m.BalanceSoloPending)
+	require.EqualValues(t, ice(5000.), m.BalanceSoloPendingApplied)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, testIDT0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(time.New(testTime.Add(-1*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(time.New(testTime.Add(-1*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 6777.166666666667, m.BalanceSolo)
-	require.EqualValues(t, 26.833333333333332, m.SlashingRateSolo)
+	require.EqualValues(t, ice(6777.166666666667), m.BalanceSolo)
+	require.EqualValues(t, ice(26.833333333333332), m.SlashingRateSolo)
 
-	require.EqualValues(t, 1440, m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
 	require.EqualValues(t, 0, m.SlashingRateT0)
 	require.EqualValues(t, 0, m.SlashingRateT1)
 	require.EqualValues(t, 0, m.SlashingRateT2)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceT0)
-	require.EqualValues(t, 1440, m.BalanceT1)
-	require.EqualValues(t, 1440, m.BalanceT2)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT1)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.EqualValues(t, 0., m.BalanceSoloPending)
 	require.EqualValues(t, 0., m.BalanceSoloPendingApplied)
@@ -1381,24 +1499,25 @@ func testMinerPendingSlashingSolo_BonusPrizeCase(t *testing.T) {
 	require.EqualValues(t, testIDT0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
 
-	m.BalanceSoloPending = 5000.
-	m.BalanceSoloPendingApplied = 5000.
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 = mine(testTime, m, nil, nil)
+	m.BalanceSoloPending = ice(5000.)
+	m.BalanceSoloPendingApplied = ice(5000.)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err = mine(testTime, m, nil, nil)
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 7240.333333333334, m.BalanceSolo)
-	require.EqualValues(t, 26.833333333333332, m.SlashingRateSolo)
+	require.EqualValues(t, ice(7240.333334), m.BalanceSolo)
+	require.EqualValues(t, ice(26.833333333333332), m.SlashingRateSolo)
 
-	require.EqualValues(t, 1440, m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
 	require.EqualValues(t, 0, m.SlashingRateT0)
 	require.EqualValues(t, 0, m.SlashingRateT1)
 	require.EqualValues(t, 0, m.SlashingRateT2)
 	require.EqualValues(t, 0, m.SlashingRateForT0)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceT0)
-	require.EqualValues(t, 1440, m.BalanceT1)
-	require.EqualValues(t, 1440, m.BalanceT2)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceT0)
+	require.EqualValues(t, ice(1440), m.BalanceT1)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.EqualValues(t, 0., m.BalanceSoloPending)
 	require.EqualValues(t, 0., m.BalanceSoloPendingApplied)
@@ -1417,27 +1536,29 @@ func testT0NotChanged(t *testing.T) {
 	t.Parallel()
 
 	m := newUser()
-	m.BalanceSolo = 1440
-	m.BalanceT2 = 1440
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT2 = ice(1440)
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 	m.BalanceLastUpdatedAt = timeDelta(-189 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-189 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(-189 * stdlibtime.Hour)
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = testIDTMinus1
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 318., m.BalanceSolo)
-	require.EqualValues(t, 1440, m.BalanceT2)
+	require.EqualValues(t, ice(318.), m.BalanceSolo)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
 	require.EqualValues(t, 0., m.BalanceT2Pending)
 	require.EqualValues(t, 0., m.BalanceT2PendingApplied)
 	require.EqualValues(t, 0, m.SlashingRateT2)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
-	require.EqualValues(t, 1440, m.BalanceForTMinus1)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
 	require.EqualValues(t, 0, m.IDTMinus1)
@@ -1447,20 +1568,22 @@ func testT0Changed(t *testing.T) {
 	t.Parallel()
 
 	m := newUser()
-	m.BalanceSolo = 1440
-	m.BalanceT2 = 1440
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT2 = ice(1440)
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 	m.BalanceLastUpdatedAt = timeDelta(-189 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-192 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(-189 * stdlibtime.Hour)
 	m.IDT0 = -testIDT0
 	m.IDTMinus1 = -testIDTMinus1
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 318., m.BalanceSolo)
-	require.EqualValues(t, 1440, m.BalanceT2)
+	require.EqualValues(t, ice(318.), m.BalanceSolo)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
 	require.EqualValues(t, 0., m.BalanceT2Pending)
 	require.EqualValues(t, 0., m.BalanceT2PendingApplied)
 	require.EqualValues(t, 0, m.SlashingRateT2)
@@ -1477,26 +1600,28 @@ func testOnlyTMinus1Changed(t *testing.T) {
 	t.Parallel()
 
 	m := newUser()
-	m.BalanceSolo = 1440
-	m.BalanceT2 = 1440
-	m.BalanceForT0 = 1440
-	m.BalanceForTMinus1 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT2 = ice(1440)
+	m.BalanceForT0 = ice(1440)
+	m.BalanceForTMinus1 = ice(1440)
 	m.BalanceLastUpdatedAt = timeDelta(-189 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-192 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(-189 * stdlibtime.Hour)
 	m.IDT0 = testIDT0
 	m.IDTMinus1 = -testIDTMinus1
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 318., m.BalanceSolo)
-	require.EqualValues(t, 1440, m.BalanceT2)
+	require.EqualValues(t, ice(318.), m.BalanceSolo)
+	require.EqualValues(t, ice(1440), m.BalanceT2)
 	require.EqualValues(t, 0., m.BalanceT2Pending)
 	require.EqualValues(t, 0., m.BalanceT2PendingApplied)
 	require.EqualValues(t, 0, m.SlashingRateT2)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, m.IDT0, m.IDT0)
@@ -1616,9 +1741,9 @@ func testMinerTier1Limit_0(t *testing.T) {
 	t.Parallel()
 
 	m := newUser()
-	m.BalanceSolo = 1440
-	m.BalanceT1 = 1440
-	m.BalanceForT0 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT1 = ice(1440)
+	m.BalanceForT0 = ice(1440)
 	m.BalanceLastUpdatedAt = timeDelta(-3 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-21 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(3 * stdlibtime.Hour)
@@ -1628,16 +1753,18 @@ func testMinerTier1Limit_0(t *testing.T) {
 	slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 	m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 1456., m.BalanceSolo)
-	require.EqualValues(t, 1440., m.BalanceT1)
+	require.EqualValues(t, ice(1456.), m.BalanceSolo)
+	require.EqualValues(t, ice(1440.), m.BalanceT1)
 	require.EqualValues(t, 0., m.BalanceT1Pending)
 	require.EqualValues(t, 0., m.BalanceT1PendingApplied)
 	require.EqualValues(t, 0., m.SlashingRateT1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
@@ -1648,9 +1775,9 @@ func testMinerTier1Limit_1(t *testing.T) {
 	t.Parallel()
 
 	m := newUser()
-	m.BalanceSolo = 1440
-	m.BalanceT1 = 1440
-	m.BalanceForT0 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT1 = ice(1440)
+	m.BalanceForT0 = ice(1440)
 	m.BalanceLastUpdatedAt = timeDelta(-3 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-21 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(3 * stdlibtime.Hour)
@@ -1660,16 +1787,18 @@ func testMinerTier1Limit_1(t *testing.T) {
 	slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 	m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 1456., m.BalanceSolo)
-	require.EqualValues(t, 1444., m.BalanceT1)
+	require.EqualValues(t, ice(1456.), m.BalanceSolo)
+	require.EqualValues(t, ice(1444.), m.BalanceT1)
 	require.EqualValues(t, 0., m.BalanceT1Pending)
 	require.EqualValues(t, 0., m.BalanceT1PendingApplied)
 	require.EqualValues(t, 0., m.SlashingRateT1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
@@ -1680,9 +1809,9 @@ func testMinerTier1Limit_2(t *testing.T) {
 	t.Parallel()
 
 	m := newUser()
-	m.BalanceSolo = 1440
-	m.BalanceT1 = 1440
-	m.BalanceForT0 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT1 = ice(1440)
+	m.BalanceForT0 = ice(1440)
 	m.BalanceLastUpdatedAt = timeDelta(-3 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-21 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(3 * stdlibtime.Hour)
@@ -1692,16 +1821,18 @@ func testMinerTier1Limit_2(t *testing.T) {
 	slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 	m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 1456., m.BalanceSolo)
-	require.EqualValues(t, 1448., m.BalanceT1)
+	require.EqualValues(t, ice(1456.), m.BalanceSolo)
+	require.EqualValues(t, ice(1448.), m.BalanceT1)
 	require.EqualValues(t, 0., m.BalanceT1Pending)
 	require.EqualValues(t, 0., m.BalanceT1PendingApplied)
 	require.EqualValues(t, 0., m.SlashingRateT1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
@@ -1712,9 +1843,9 @@ func testMinerTier1Limit_1000(t *testing.T) {
 	t.Parallel()
 
 	m := newUser()
-	m.BalanceSolo = 1440
-	m.BalanceT1 = 1440
-	m.BalanceForT0 = 1440
+	m.BalanceSolo = ice(1440)
+	m.BalanceT1 = ice(1440)
+	m.BalanceForT0 = ice(1440)
 	m.BalanceLastUpdatedAt = timeDelta(-3 * stdlibtime.Hour)
 	m.MiningSessionSoloStartedAt = timeDelta(-21 * stdlibtime.Hour)
 	m.MiningSessionSoloEndedAt = timeDelta(3 * stdlibtime.Hour)
@@ -1724,16 +1855,18 @@ func testMinerTier1Limit_1000(t *testing.T) {
 	slashingDisabledMiningBoostIx := model.FlexibleUint64(2)
 	m.MiningBoostLevelIndex = &slashingDisabledMiningBoostIx
 
-	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0 := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+	m, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(time.New(testTime.Add(-2*stdlibtime.Hour)), m, nil, nil)
+
+	require.NoError(t, err)
 	require.NotNil(t, m)
-	require.EqualValues(t, 1456., m.BalanceSolo)
-	require.EqualValues(t, 1448., m.BalanceT1)
+	require.EqualValues(t, ice(1456.), m.BalanceSolo)
+	require.EqualValues(t, ice(1448.), m.BalanceT1)
 	require.EqualValues(t, 0., m.BalanceT1Pending)
 	require.EqualValues(t, 0., m.BalanceT1PendingApplied)
 	require.EqualValues(t, 0., m.SlashingRateT1)
 	require.EqualValues(t, 0, pendingAmountForTMinus1)
 	require.EqualValues(t, 0, pendingAmountForT0)
-	require.EqualValues(t, 1440, m.BalanceForT0)
+	require.EqualValues(t, ice(1440), m.BalanceForT0)
 	require.EqualValues(t, 0, m.BalanceForTMinus1)
 	require.False(t, IDT0Changed)
 	require.EqualValues(t, 0, m.IDT0)
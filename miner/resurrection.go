@@ -3,24 +3,19 @@
 package miner
 
 import (
+	"github.com/ice-blockchain/freezer/model"
 	"github.com/ice-blockchain/freezer/tokenomics"
 	"github.com/ice-blockchain/wintr/time"
 )
 
-func resurrect(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (pendingResurrectionForTMinus1, pendingResurrectionForT0 float64) {
+func resurrect(
+	now *time.Time, usr *user, t0Ref, tMinus1Ref *referral, calc *amountCalculator,
+) (pendingResurrectionForTMinus1, pendingResurrectionForT0 model.Amount) {
 	if !usr.ResurrectSoloUsedAt.IsNil() && usr.ResurrectSoloUsedAt.After(*now.Time) {
-		var resurrectDelta float64
-		if timeSpent := usr.MiningSessionSoloStartedAt.Sub(*usr.MiningSessionSoloPreviouslyEndedAt.Time); cfg.Development {
-			resurrectDelta = timeSpent.Minutes()
-		} else {
-			resurrectDelta = timeSpent.Hours()
-		}
-
-		usr.BalanceSolo += usr.SlashingRateSolo * resurrectDelta
-		usr.BalanceT0 += usr.SlashingRateT0 * resurrectDelta
-		mintedAmount := (usr.SlashingRateSolo + usr.SlashingRateT0) * resurrectDelta
-		mintedStandard, mintedPreStaking := tokenomics.ApplyPreStaking(mintedAmount, usr.PreStakingAllocation, usr.PreStakingBonus)
-		usr.BalanceTotalMinted += mintedStandard + mintedPreStaking
+		resurrectDelta := resurrectionDelta(usr.MiningSessionSoloStartedAt, usr.MiningSessionSoloPreviouslyEndedAt)
+		usr.BalanceTotalMinted += soloResurrectionAmount(now, usr, calc)
+		usr.BalanceSolo += calc.mul(usr.SlashingRateSolo, resurrectDelta)
+		usr.BalanceT0 += calc.mul(usr.SlashingRateT0, resurrectDelta)
 
 		usr.SlashingRateSolo, usr.SlashingRateT0 = 0, 0
 		usr.ResurrectSoloUsedAt = now
@@ -29,14 +24,8 @@ func resurrect(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (pendingR
 	}
 
 	if t0Ref != nil && !t0Ref.ResurrectSoloUsedAt.IsNil() && usr.ResurrectT0UsedAt.IsNil() {
-		var resurrectDelta float64
-		if timeSpent := t0Ref.MiningSessionSoloStartedAt.Sub(*t0Ref.MiningSessionSoloPreviouslyEndedAt.Time); cfg.Development {
-			resurrectDelta = timeSpent.Minutes()
-		} else {
-			resurrectDelta = timeSpent.Hours()
-		}
-
-		amount := usr.SlashingRateForT0 * resurrectDelta
+		resurrectDelta := resurrectionDelta(t0Ref.MiningSessionSoloStartedAt, t0Ref.MiningSessionSoloPreviouslyEndedAt)
+		amount := calc.mul(usr.SlashingRateForT0, resurrectDelta)
 		usr.BalanceForT0 += amount
 		pendingResurrectionForT0 += amount
 
@@ -47,14 +36,8 @@ func resurrect(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (pendingR
 	}
 
 	if tMinus1Ref != nil && !tMinus1Ref.ResurrectSoloUsedAt.IsNil() && usr.ResurrectTMinus1UsedAt.IsNil() {
-		var resurrectDelta float64
-		if timeSpent := tMinus1Ref.MiningSessionSoloStartedAt.Sub(*tMinus1Ref.MiningSessionSoloPreviouslyEndedAt.Time); cfg.Development {
-			resurrectDelta = timeSpent.Minutes()
-		} else {
-			resurrectDelta = timeSpent.Hours()
-		}
-
-		amount := usr.SlashingRateForTMinus1 * resurrectDelta
+		resurrectDelta := resurrectionDelta(tMinus1Ref.MiningSessionSoloStartedAt, tMinus1Ref.MiningSessionSoloPreviouslyEndedAt)
+		amount := calc.mul(usr.SlashingRateForTMinus1, resurrectDelta)
 		usr.BalanceForTMinus1 += amount
 		pendingResurrectionForTMinus1 += amount
 
@@ -77,3 +60,24 @@ func resurrect(now *time.Time, usr *user, t0Ref, tMinus1Ref *referral) (pendingR
 
 	return pendingResurrectionForTMinus1, pendingResurrectionForT0
 }
+
+// The amount resurrect is going to give back to the user for its own (solo + t0) slashed balance, if any.
+func soloResurrectionAmount(now *time.Time, usr *user, calc *amountCalculator) model.Amount {
+	if usr.ResurrectSoloUsedAt.IsNil() || !usr.ResurrectSoloUsedAt.After(*now.Time) {
+		return 0
+	}
+	resurrectDelta := resurrectionDelta(usr.MiningSessionSoloStartedAt, usr.MiningSessionSoloPreviouslyEndedAt)
+	mintedAmount := calc.mul(usr.SlashingRateSolo, resurrectDelta) + calc.mul(usr.SlashingRateT0, resurrectDelta)
+	mintedStandard, mintedPreStaking := tokenomics.ApplyPreStaking(mintedAmount, usr.PreStakingAllocation, usr.PreStakingBonus)
+
+	return mintedStandard + mintedPreStaking
+}
+
+func resurrectionDelta(miningSessionSoloStartedAt, miningSessionSoloPreviouslyEndedAt *time.Time) float64 {
+	timeSpent := miningSessionSoloStartedAt.Sub(*miningSessionSoloPreviouslyEndedAt.Time)
+	if cfg.Development {
+		return timeSpent.Minutes()
+	}
+
+	return timeSpent.Hours()
+}
@@ -0,0 +1,489 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package miner
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ice-blockchain/eskimo/users"
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/freezer/tokenomics"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+// Simulate replays the scenario against the same pure functions the mining workers use (mine, resurrect, processEthereumCoinDistribution),
+// with a virtual clock and an in-memory replacement for the users' redis hashes. It temporarily overrides the package config,
+// so it must never run alongside MustStartMining.
+func Simulate(scenario *SimulationScenario) ([]*SimulationTick, error) {
+	if err := scenario.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid scenario")
+	}
+	defer scenario.applyConfigOverrides()()
+	var (
+		sim = &simulation{
+			hashes:     make(map[int64]simulatedHash, len(scenario.Users)),
+			internalID: make(map[string]int64, len(scenario.Users)),
+			scenario:   scenario,
+		}
+		startedAt = time.New(scenario.StartedAt.UTC())
+		endedAt   = time.New(startedAt.Add(scenario.Duration))
+		ticks     = make([]*SimulationTick, 0, int(scenario.Duration/scenario.Tick+1)*len(scenario.Users))
+	)
+	for ix, usr := range scenario.Users {
+		sim.internalID[usr.ID] = int64(ix + 1)
+	}
+	for previous, now := (*time.Time)(nil), startedAt; !now.After(*endedAt.Time); previous, now = now, time.New(now.Add(scenario.Tick)) {
+		sim.join(previous, now)
+		sim.startOrStopMiningSessions(previous, now)
+		// Mining runs right after the tick's events, otherwise a session ending exactly on a tick would be seen as "just stopped" twice.
+		pendingAmounts := sim.mine(time.New(now.Add(stdlibtime.Nanosecond)))
+		ticks = append(ticks, sim.snapshot(now, pendingAmounts)...)
+	}
+
+	return ticks, nil
+}
+
+func (s *SimulationScenario) validate() error {
+	if s.Tick <= 0 || s.Duration <= 0 {
+		return errors.Errorf("tick `%v` and duration `%v` must be positive", s.Tick, s.Duration)
+	}
+	ids := make(map[string]int, len(s.Users))
+	for ix, usr := range s.Users {
+		if usr.ID == "" {
+			return errors.New("every user must have an id")
+		}
+		if _, duplicate := ids[usr.ID]; duplicate {
+			return errors.Errorf("duplicate user id `%v`", usr.ID)
+		}
+		ids[usr.ID] = ix
+	}
+	for ix, usr := range s.Users {
+		referrerIx, found := ids[usr.ReferredBy]
+		if usr.ReferredBy != "" && !found {
+			return errors.Errorf("user `%v` is referred by unknown user `%v`", usr.ID, usr.ReferredBy)
+		}
+		// Users join in order within the same tick, and a referrer has to exist before its referees can be attributed to it.
+		if referrer := s.Users[referrerIx]; found && (referrer.JoinedAfter > usr.JoinedAfter || (referrer.JoinedAfter == usr.JoinedAfter && referrerIx > ix)) {
+			return errors.Errorf("user `%v` joins before its referrer `%v`", usr.ID, usr.ReferredBy)
+		}
+		if usr.MiningBoostLevelIndex != nil && int(*usr.MiningBoostLevelIndex) >= len(*cfg.miningBoostLevels.Load()) && int(*usr.MiningBoostLevelIndex) >= len(s.MiningBoostLevels) { //nolint:lll // .
+			return errors.Errorf("user `%v` has unknown miningBoostLevelIndex `%v`", usr.ID, *usr.MiningBoostLevelIndex)
+		}
+	}
+
+	return nil
+}
+
+func (s *SimulationScenario) applyConfigOverrides() (restore func()) {
+	development, maxMiningSessionDuration := cfg.Development, cfg.MiningSessionDuration.Max
+	miningBoostLevels, collectorSettings, collectorStartedAt := cfg.miningBoostLevels.Load(), cfg.coinDistributionCollectorSettings.Load(), cfg.coinDistributionCollectorStartedAt.Load() //nolint:lll // .
+	restore = func() {
+		cfg.Development, cfg.MiningSessionDuration.Max = development, maxMiningSessionDuration
+		cfg.miningBoostLevels.Store(miningBoostLevels)
+		cfg.coinDistributionCollectorSettings.Store(collectorSettings)
+		cfg.coinDistributionCollectorStartedAt.Store(collectorStartedAt)
+	}
+	if s.Development != nil {
+		cfg.Development = *s.Development
+	}
+	if s.MiningSessionDuration != nil {
+		cfg.MiningSessionDuration.Max = *s.MiningSessionDuration
+	}
+	if len(s.MiningBoostLevels) != 0 {
+		levels := make([]*tokenomics.MiningBoostLevel, 0, len(s.MiningBoostLevels))
+		for _, level := range s.MiningBoostLevels {
+			levels = append(levels, &tokenomics.MiningBoostLevel{
+				MaxT1Referrals:             level.MaxT1Referrals,
+				MiningSessionLengthSeconds: uint32(level.MiningSessionLength / stdlibtime.Second),
+				SlashingDisabled:           level.SlashingDisabled,
+			})
+		}
+		cfg.miningBoostLevels.Store(&levels)
+	}
+	settings := new(coindistribution.CollectorSettings)
+	if cd := s.CoinDistribution; cd != nil {
+		settings.Enabled = cd.Enabled
+		settings.ForcedExecution = cd.ForcedExecution
+		settings.StartHour = cd.StartHour
+		settings.MinBalanceRequired = cd.MinBalanceRequired
+		settings.MinMiningStreaksRequired = cd.MinMiningStreaksRequired
+		settings.StartDate = time.New(s.StartedAt.UTC().Add(cd.StartedAfter))
+		settings.EndDate = time.New(s.StartedAt.UTC().Add(cd.EndedAfter))
+		settings.DeniedCountries = make(map[string]struct{}, len(cd.DeniedCountries))
+		for _, country := range cd.DeniedCountries {
+			settings.DeniedCountries[strings.ToLower(country)] = struct{}{}
+		}
+	}
+	cfg.coinDistributionCollectorSettings.Store(settings)
+
+	return restore
+}
+
+type (
+	simulation struct {
+		hashes     map[int64]simulatedHash
+		internalID map[string]int64
+		scenario   *SimulationScenario
+	}
+	simulatedHash map[string]string
+)
+
+func (s *simulation) join(previous, now *time.Time) {
+	for _, usr := range s.scenario.Users {
+		joinedAt := time.New(s.scenario.StartedAt.UTC().Add(usr.JoinedAfter))
+		if !isWithinTick(previous, now, joinedAt) {
+			continue
+		}
+		id := s.internalID[usr.ID]
+		hash := make(simulatedHash)
+		s.hashes[id] = hash
+		welcomeBonus := strconv.FormatInt(int64(cfg.WelcomeBonusV2()), 10)
+		hash["balance_total_standard"], hash["balance_total_minted"], hash["balance_solo"] = welcomeBonus, welcomeBonus, welcomeBonus
+		hash["welcome_bonus_v2_applied"] = "true"
+		state := &struct {
+			model.CreatedAtField
+			model.KYCState
+			model.MiningBoostLevelIndexField
+			model.PreStakingAllocationField
+			model.PreStakingBonusField
+			model.UserIDField
+			model.CountryField
+			model.EmailField
+			model.MiningBlockchainAccountAddressField
+			model.IDT0Field
+			model.IDTMinus1Field
+		}{
+			CreatedAtField:                      model.CreatedAtField{CreatedAt: joinedAt},
+			UserIDField:                         model.UserIDField{UserID: usr.ID},
+			CountryField:                        model.CountryField{Country: usr.Country},
+			EmailField:                          model.EmailField{Email: usr.Email},
+			MiningBlockchainAccountAddressField: model.MiningBlockchainAccountAddressField{MiningBlockchainAccountAddress: usr.MiningBlockchainAccountAddress},
+			PreStakingAllocationField:           model.PreStakingAllocationField{PreStakingAllocation: usr.PreStakingAllocation},
+			PreStakingBonusField:                model.PreStakingBonusField{PreStakingBonus: usr.PreStakingBonus},
+		}
+		if usr.MiningBoostLevelIndex != nil {
+			state.MiningBoostLevelIndex = (*model.FlexibleUint64)(usr.MiningBoostLevelIndex)
+		}
+		if usr.Verified {
+			kycStepsAttemptedAt := model.TimeSlice{joinedAt, joinedAt}
+			state.KYCStepPassed = users.LivenessDetectionKYCStep
+			state.KYCStepsCreatedAt, state.KYCStepsLastUpdatedAt = &kycStepsAttemptedAt, &kycStepsAttemptedAt
+		}
+		if idT0 := s.internalID[usr.ReferredBy]; idT0 != 0 {
+			state.IDT0 = -idT0
+			if t0, found := s.hashes[idT0]; found {
+				if idTMinus1, err := strconv.ParseInt(t0["id_t0"], 10, 64); err == nil && idTMinus1 != 0 {
+					state.IDTMinus1 = -abs(idTMinus1)
+				}
+				t0.incrBy("balance_t1_welcome_bonus_pending", int64(cfg.WelcomeBonusV2()))
+				t0.incrBy("total_t1_referrals", 1)
+			}
+		}
+		hash.set(state)
+	}
+}
+
+func (s *simulation) startOrStopMiningSessions(previous, now *time.Time) {
+	for _, usr := range s.scenario.Users {
+		hash, joined := s.hashes[s.internalID[usr.ID]]
+		if !joined {
+			continue
+		}
+		for _, session := range usr.MiningSessions {
+			if startedAt := time.New(s.scenario.StartedAt.UTC().Add(session.StartedAfter)); isWithinTick(previous, now, startedAt) {
+				s.startOrExtendMiningSession(hash, startedAt, session.Resurrect)
+			}
+			if session.StoppedAfter == nil {
+				continue
+			}
+			if stoppedAt := time.New(s.scenario.StartedAt.UTC().Add(*session.StoppedAfter)); isWithinTick(previous, now, stoppedAt) {
+				old := mustGetSimulated[user](hash)
+				if !old.MiningSessionSoloEndedAt.IsNil() && old.MiningSessionSoloEndedAt.After(*stoppedAt.Time) {
+					hash.set(&model.MiningSessionSoloEndedAtField{MiningSessionSoloEndedAt: stoppedAt})
+				}
+			}
+		}
+	}
+}
+
+// Simplified version of tokenomics.StartNewMiningSession (no free sessions, no KYC or rollback validation).
+func (s *simulation) startOrExtendMiningSession(hash simulatedHash, now *time.Time, resurrect bool) {
+	old := mustGetSimulated[user](hash)
+	newMS := new(tokenomics.StartOrExtendMiningSession)
+	newMS.MiningSessionSoloLastStartedAt = now
+	newMS.MiningSessionSoloEndedAt = time.New(now.Add(old.maxMiningSessionDuration()))
+	if old.MiningSessionSoloEndedAt.IsNil() || old.MiningSessionSoloEndedAt.Before(*now.Time) {
+		newMS.MiningSessionSoloStartedAt = now
+		newMS.MiningSessionSoloPreviouslyEndedAt = old.MiningSessionSoloEndedAt
+		newMS.MiningSessionSoloDayOffLastAwardedAt = new(time.Time)
+		newMS.ReferralsCountChangeGuardUpdatedAt = now
+		if idT0 := abs(old.IDT0); idT0 != 0 {
+			s.hashes[idT0].incrBy("active_t1_referrals", 1)
+		}
+		if idTMinus1 := abs(old.IDTMinus1); idTMinus1 != 0 {
+			s.hashes[idTMinus1].incrBy("active_t2_referrals", 1)
+		}
+	}
+	if resurrect && old.ResurrectSoloUsedAt.IsNil() {
+		newMS.ResurrectSoloUsedAt = time.New(stdlibtime.Date(3000, 0, 0, 0, 0, 0, 0, stdlibtime.UTC)) //nolint:gomnd // Same as in tokenomics.
+	}
+	hash.set(newMS)
+}
+
+//nolint:funlen,gocognit,revive // Mirrors the 3rd and 9th steps of the mining loop.
+func (s *simulation) mine(now *time.Time) map[int64][2]model.Amount {
+	var (
+		ids                                                                  = s.ids()
+		userResults                                                          = make([]*user, 0, len(ids))
+		referrals                                                            = make(map[int64]*referral, len(ids))
+		pendingAmounts                                                       = make(map[int64][2]model.Amount, len(ids))
+		updatedUsers                                                         = make([]*UpdatedUser, 0, len(ids))
+		referralsUpdated                                                     = make([]*referralUpdated, 0, len(ids))
+		referralsCountGuardOnlyUpdatedUsers                                  = make([]*referralCountGuardUpdatedUser, 0, len(ids))
+		referralsThatStoppedMining                                           = make([]*referralThatStoppedMining, 0, len(ids))
+		t1ReferralsToIncrementActiveValue, t2ReferralsToIncrementActiveValue = make(map[int64]int32), make(map[int64]int32)
+		balanceT1EthereumIncr, balanceT2EthereumIncr                         = make(map[int64]model.Amount), make(map[int64]model.Amount)
+		balanceT1WelcomeBonusIncr                                            = make(map[int64]model.Amount)
+		pendingBalancesForTMinus1, pendingBalancesForT0                      = make(map[int64]model.Amount), make(map[int64]model.Amount)
+		coinDistributionCollectorEnabled                                     = isCoinDistributionCollectorEnabled(now)
+	)
+	cfg.coinDistributionCollectorStartedAt.Store(now)
+	for _, id := range ids {
+		usr := mustGetSimulated[user](s.hashes[id])
+		usr.ID = id
+		userResults = append(userResults, usr)
+		ref := mustGetSimulated[referral](s.hashes[id])
+		ref.ID = id
+		referrals[id] = ref
+	}
+	for _, usr := range userResults {
+		var t0Ref, tMinus1Ref *referral
+		if usr.IDT0 != 0 {
+			t0Ref = referrals[abs(usr.IDT0)]
+		}
+		if usr.IDTMinus1 != 0 {
+			tMinus1Ref = tMinus1Referral(referrals, usr.IDTMinus1)
+		}
+		if isAdvancedTeamDisabled(usr.LatestDevice) {
+			usr.ActiveT2Referrals = 0
+		}
+		beforeWelcomeBonusV2NotApplied := usr.WelcomeBonusV2Applied == nil || !*usr.WelcomeBonusV2Applied
+		updatedUser, _, IDT0Changed, pendingAmountForTMinus1, pendingAmountForT0, err := mine(now, usr, t0Ref, tMinus1Ref)
+		if err != nil {
+			panic(errors.Wrapf(err, "failed to mine simulated user %v", usr.UserID))
+		}
+		if updatedUser == nil {
+			if updUsr := updateT0AndTMinus1ReferralsForUserHasNeverMined(usr); updUsr != nil {
+				referralsUpdated = append(referralsUpdated, updUsr)
+				if t0Ref != nil && t0Ref.ID != 0 && usr.ActiveT1Referrals > 0 {
+					t2ReferralsToIncrementActiveValue[t0Ref.ID] += usr.ActiveT1Referrals
+				}
+			}
+
+			continue
+		}
+		if userStoppedMining := didUserStoppedMining(now, usr); userStoppedMining != nil {
+			referralsCountGuardOnlyUpdatedUsers = append(referralsCountGuardOnlyUpdatedUsers, userStoppedMining)
+		}
+		if userStoppedMining := didReferralJustStopMining(now, usr, t0Ref, tMinus1Ref); userStoppedMining != nil {
+			referralsThatStoppedMining = append(referralsThatStoppedMining, userStoppedMining)
+		}
+		if t0Ref != nil {
+			if IDT0Changed {
+				if !usr.BalanceLastUpdatedAt.IsNil() {
+					t1ReferralsToIncrementActiveValue[t0Ref.ID]++
+					if t0Ref.IDT0 != 0 {
+						t2ReferralsToIncrementActiveValue[abs(t0Ref.IDT0)]++
+					}
+				}
+				if usr.ActiveT1Referrals > 0 && t0Ref.ID != 0 {
+					t2ReferralsToIncrementActiveValue[t0Ref.ID] += usr.ActiveT1Referrals
+				}
+			}
+			if usr.IDTMinus1 != t0Ref.IDT0 {
+				updatedUser.IDTMinus1 = t0Ref.IDT0
+				tMinus1Ref = tMinus1Referral(referrals, updatedUser.IDTMinus1)
+			}
+		}
+		_, balanceDistributedForT0, balanceDistributedForTMinus1 := updatedUser.processEthereumCoinDistribution(coinDistributionCollectorEnabled, now, t0Ref, tMinus1Ref) //nolint:lll // .
+		if balanceDistributedForT0 > 0 {
+			balanceT1EthereumIncr[t0Ref.ID] += balanceDistributedForT0
+		}
+		if balanceDistributedForTMinus1 > 0 {
+			balanceT2EthereumIncr[tMinus1Ref.ID] += balanceDistributedForTMinus1
+		}
+		if tMinus1Ref != nil && tMinus1Ref.ID != 0 && pendingAmountForTMinus1 != 0 {
+			pendingBalancesForTMinus1[tMinus1Ref.ID] += pendingAmountForTMinus1
+		}
+		if t0Ref != nil && t0Ref.ID != 0 && pendingAmountForT0 != 0 {
+			pendingBalancesForT0[t0Ref.ID] += pendingAmountForT0
+		}
+		if afterWelcomeBonusV2Applied := updatedUser.WelcomeBonusV2Applied != nil && *updatedUser.WelcomeBonusV2Applied; t0Ref != nil && t0Ref.ID != 0 && beforeWelcomeBonusV2NotApplied && afterWelcomeBonusV2Applied { //nolint:lll // .
+			balanceT1WelcomeBonusIncr[t0Ref.ID] += cfg.WelcomeBonusV2()
+		}
+		pendingAmounts[usr.ID] = [2]model.Amount{pendingAmountForT0, pendingAmountForTMinus1}
+		updatedUsers = append(updatedUsers, &updatedUser.UpdatedUser)
+	}
+	if coinDistributionCollectorEnabled {
+		settings := *cfg.coinDistributionCollectorSettings.Load()
+		settings.LatestDate = now
+		cfg.coinDistributionCollectorSettings.Store(&settings)
+	}
+
+	for _, usr := range referralsThatStoppedMining {
+		if usr.IDT0 > 0 {
+			t1ReferralsToIncrementActiveValue[usr.IDT0]--
+		}
+		if usr.IDTMinus1 > 0 {
+			t2ReferralsToIncrementActiveValue[usr.IDTMinus1]--
+		}
+	}
+	for id, value := range t1ReferralsToIncrementActiveValue {
+		s.hashes[id].incrBy("active_t1_referrals", int64(value))
+	}
+	for id, value := range t2ReferralsToIncrementActiveValue {
+		s.hashes[id].incrBy("active_t2_referrals", int64(value))
+	}
+	for _, value := range referralsCountGuardOnlyUpdatedUsers {
+		s.hashes[value.ID].set(value)
+	}
+	for _, value := range updatedUsers {
+		s.hashes[value.ID].set(value)
+	}
+	for _, value := range referralsUpdated {
+		s.hashes[value.ID].set(value)
+	}
+	for id, amount := range balanceT1WelcomeBonusIncr {
+		s.hashes[id].incrBy("balance_t1_welcome_bonus_pending", int64(amount))
+	}
+	for id, amount := range balanceT1EthereumIncr {
+		s.hashes[id].incrBy("balance_t1_ethereum_pending", int64(amount))
+	}
+	for id, amount := range balanceT2EthereumIncr {
+		s.hashes[id].incrBy("balance_t2_ethereum_pending", int64(amount))
+	}
+	for id, amount := range pendingBalancesForT0 {
+		s.hashes[id].incrBy("balance_t1_pending", int64(amount))
+	}
+	for id, amount := range pendingBalancesForTMinus1 {
+		s.hashes[id].incrBy("balance_t2_pending", int64(amount))
+	}
+
+	return pendingAmounts
+}
+
+func (s *simulation) snapshot(now *time.Time, pendingAmounts map[int64][2]model.Amount) []*SimulationTick {
+	ids := s.ids()
+	ticks := make([]*SimulationTick, 0, len(ids))
+	for _, id := range ids {
+		usr := mustGetSimulated[user](s.hashes[id])
+		ticks = append(ticks, &SimulationTick{
+			Now:                     now,
+			UserID:                  usr.UserID,
+			BaseMiningRate:          usr.baseMiningRate(now),
+			BalanceTotalStandard:    usr.BalanceTotalStandard.Float64(),
+			BalanceTotalPreStaking:  usr.BalanceTotalPreStaking.Float64(),
+			BalanceTotalMinted:      usr.BalanceTotalMinted.Float64(),
+			BalanceTotalSlashed:     usr.BalanceTotalSlashed.Float64(),
+			BalanceSolo:             usr.BalanceSolo.Float64(),
+			BalanceT0:               usr.BalanceT0.Float64(),
+			BalanceT1:               usr.BalanceT1.Float64(),
+			BalanceT2:               usr.BalanceT2.Float64(),
+			BalanceForT0:            usr.BalanceForT0.Float64(),
+			BalanceForTMinus1:       usr.BalanceForTMinus1.Float64(),
+			BalanceSoloPending:      (usr.BalanceSoloPending - usr.BalanceSoloPendingApplied).Float64(),
+			BalanceT1Pending:        (usr.BalanceT1Pending - usr.BalanceT1PendingApplied).Float64(),
+			BalanceT2Pending:        (usr.BalanceT2Pending - usr.BalanceT2PendingApplied).Float64(),
+			BalanceSoloEthereum:     usr.BalanceSoloEthereum.Float64(),
+			BalanceT0Ethereum:       usr.BalanceT0Ethereum.Float64(),
+			BalanceT1Ethereum:       usr.BalanceT1Ethereum.Float64(),
+			BalanceForT0Ethereum:    usr.BalanceForT0Ethereum.Float64(),
+			SlashingRateSolo:        usr.SlashingRateSolo.Float64(),
+			SlashingRateT0:          usr.SlashingRateT0.Float64(),
+			SlashingRateForT0:       usr.SlashingRateForT0.Float64(),
+			SlashingRateForTMinus1:  usr.SlashingRateForTMinus1.Float64(),
+			PendingAmountForT0:      pendingAmounts[id][0].Float64(),
+			PendingAmountForTMinus1: pendingAmounts[id][1].Float64(),
+			EthereumDistributed:     (usr.BalanceSoloEthereum + usr.BalanceT0Ethereum + usr.BalanceT1Ethereum + usr.BalanceT2Ethereum).Float64(),
+			ActiveT1Referrals:       usr.ActiveT1Referrals,
+			ActiveT2Referrals:       usr.ActiveT2Referrals,
+			Mining:                  !usr.MiningSessionSoloEndedAt.IsNil() && usr.MiningSessionSoloEndedAt.After(*now.Time),
+		})
+	}
+
+	return ticks
+}
+
+func (s *simulation) ids() []int64 {
+	ids := make([]int64, 0, len(s.hashes))
+	for id := range s.hashes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(ii, jj int) bool { return ids[ii] < ids[jj] })
+
+	return ids
+}
+
+func (h simulatedHash) set(value any) {
+	fields := storage.SerializeValue(value)
+	for ix := 0; ix+1 < len(fields); ix += 2 {
+		h[fields[ix].(string)] = fields[ix+1].(string) //nolint:forcetypeassert // SerializeValue always yields strings.
+	}
+}
+
+// Same as HINCRBY.
+func (h simulatedHash) incrBy(field string, amount int64) {
+	current, err := strconv.ParseInt(h[field], 10, 64)
+	if err != nil {
+		current = 0
+	}
+	h[field] = strconv.FormatInt(current+amount, 10)
+}
+
+// Same as what storage.Bind does with the HMGET response, minus redis.
+func mustGetSimulated[T any](h simulatedHash) *T {
+	args := make([]any, 0, 2+len(h))
+	vals := make([]any, 0, len(h))
+	args = append(args, "hmget", "simulated")
+	for field, value := range h {
+		args = append(args, field)
+		vals = append(vals, value)
+	}
+	cmd := redis.NewSliceCmd(context.Background(), args...)
+	cmd.SetVal(vals)
+	result := new(T)
+	if err := storage.DeserializeValue(result, cmd.Scan); err != nil {
+		panic(errors.Wrapf(err, "failed to deserialize simulated state %#v", h))
+	}
+
+	return result
+}
+
+func tMinus1Referral(referrals map[int64]*referral, idTMinus1 int64) *referral {
+	if ref := referrals[abs(idTMinus1)]; ref != nil && !isAdvancedTeamDisabled(ref.LatestDevice) {
+		return ref
+	}
+
+	return nil
+}
+
+func isWithinTick(previous, now, date *time.Time) bool {
+	return !date.After(*now.Time) && (previous.IsNil() || date.After(*previous.Time))
+}
+
+func abs(id int64) int64 {
+	if id < 0 {
+		return -id
+	}
+
+	return id
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package miner
+
+import (
+	"testing"
+	stdlibtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) { //nolint:paralleltest // Simulate overrides the package config.
+	sessionDuration := 24 * stdlibtime.Hour
+	scenario := &SimulationScenario{
+		StartedAt:             stdlibtime.Date(2024, 1, 1, 0, 0, 0, 0, stdlibtime.UTC),
+		MiningSessionDuration: &sessionDuration,
+		Duration:              12 * stdlibtime.Hour,
+		Tick:                  stdlibtime.Hour,
+		Users: []*SimulationUser{
+			{ID: "t0", MiningSessions: []*SimulationMiningSession{{}}},
+			{ID: "t1", ReferredBy: "t0", JoinedAfter: stdlibtime.Hour, MiningSessions: []*SimulationMiningSession{{StartedAfter: stdlibtime.Hour}}},
+		},
+	}
+	maxMiningSessionDuration := cfg.MiningSessionDuration.Max
+
+	ticks, err := Simulate(scenario)
+	require.NoError(t, err)
+	assert.Equal(t, maxMiningSessionDuration, cfg.MiningSessionDuration.Max)
+	require.Len(t, ticks, 13+12)
+
+	first, last := ticks[0], ticks[len(ticks)-1]
+	assert.Equal(t, "t0", first.UserID)
+	assert.True(t, first.Mining)
+	assert.InDelta(t, cfg.WelcomeBonusV2Amount, first.BalanceTotalStandard, 1e-6)
+	assert.Equal(t, "t1", last.UserID)
+	assert.True(t, last.Mining)
+	assert.Greater(t, last.BalanceSolo, cfg.WelcomeBonusV2Amount)
+	assert.Greater(t, last.BalanceForT0, 0.)
+	t0 := ticks[len(ticks)-2]
+	assert.Equal(t, "t0", t0.UserID)
+	assert.EqualValues(t, 1, t0.ActiveT1Referrals)
+	assert.Greater(t, t0.BalanceT1, 0.)
+
+	again, err := Simulate(scenario)
+	require.NoError(t, err)
+	assert.Equal(t, ticks, again)
+
+	scenario.Users[1].ReferredBy = "bogus"
+	_, err = Simulate(scenario)
+	require.Error(t, err)
+
+	// The referee joins before its referrer.
+	scenario.Users[1].ReferredBy = "t0"
+	scenario.Users[0].ReferredBy, scenario.Users[1].ReferredBy = "t1", ""
+	_, err = Simulate(scenario)
+	require.EqualError(t, err, "invalid scenario: user `t0` joins before its referrer `t1`")
+
+	// Both join in the same tick, but the referee is listed first.
+	scenario.Users[0].ReferredBy, scenario.Users[1].ReferredBy = "", ""
+	scenario.Users = append(scenario.Users, &SimulationUser{ID: "t2", ReferredBy: "t3"}, &SimulationUser{ID: "t3"})
+	_, err = Simulate(scenario)
+	require.EqualError(t, err, "invalid scenario: user `t2` joins before its referrer `t3`")
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package model
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/wintr/coin"
+)
+
+const (
+	AmountDecimals     = 6
+	AmountDenomination = 1_000_000
+	// MaxAmountICE is the biggest ICE value an Amount can hold (~9.2 trillion ICE, way above the total supply).
+	MaxAmountICE = math.MaxInt64 / AmountDenomination
+
+	iceFlakesPerAmountUnit = coin.Denomination / AmountDenomination
+)
+
+var (
+	ErrInvalidAmount = errors.New("invalid amount")
+)
+
+type (
+	// Amount is a fixed-point ICE amount, expressed as a signed number of micro ICE (1 ICE = AmountDenomination micro ICE).
+	// It's stored in redis as the plain integer number of micro ICE, so it can be atomically changed via HINCRBY,
+	// and in clickhouse as Decimal64(AmountDecimals), whose raw value is, again, the number of micro ICE.
+	// Its text (and JSON) form is the decimal ICE value, i.e. `12.5`.
+	Amount int64
+)
+
+// NewAmount converts a float64 ICE value to the nearest Amount. It fails for NaN, Inf and for values out of range.
+func NewAmount(ice float64) (Amount, error) {
+	return newAmount(ice * AmountDenomination)
+}
+
+// MustNewAmount is NewAmount for values that are known to be valid, like the ones from the configuration.
+func MustNewAmount(ice float64) Amount {
+	val, err := NewAmount(ice)
+	if err != nil {
+		panic(err)
+	}
+
+	return val
+}
+
+func newAmount(microICE float64) (Amount, error) {
+	if math.IsNaN(microICE) || math.Abs(microICE) >= math.MaxInt64 {
+		return 0, errors.Wrapf(ErrInvalidAmount, "unsupported value %v micro ICE", microICE)
+	}
+
+	return Amount(math.Round(microICE)), nil
+}
+
+// ParseAmount parses a decimal ICE value. Digits past the micro ICE precision are rounded half away from zero.
+// Exponent notation is accepted as well, for values that were written as floats.
+func ParseAmount(text string) (Amount, error) { //nolint:funlen,revive // .
+	val := strings.TrimSpace(text)
+	if strings.ContainsAny(val, "eEnNiI") {
+		ice, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return 0, errors.Wrapf(ErrInvalidAmount, "failed to ParseFloat `%v`: %v", text, err)
+		}
+
+		return NewAmount(ice)
+	}
+	negative := false
+	if val != "" && (val[0] == '-' || val[0] == '+') {
+		negative = val[0] == '-'
+		val = val[1:]
+	}
+	whole, fraction, _ := strings.Cut(val, ".")
+	if whole == "" && fraction == "" {
+		return 0, errors.Wrapf(ErrInvalidAmount, "empty value `%v`", text)
+	}
+	roundUp := false
+	if len(fraction) > AmountDecimals {
+		if strings.Trim(fraction[AmountDecimals:], "0123456789") != "" {
+			return 0, errors.Wrapf(ErrInvalidAmount, "invalid fraction `%v`", text)
+		}
+		roundUp = fraction[AmountDecimals] >= '5'
+		fraction = fraction[:AmountDecimals]
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	units, err := strconv.ParseUint(whole+fraction+strings.Repeat("0", AmountDecimals-len(fraction)), 10, 63) //nolint:gomnd // Max int64.
+	if err != nil {
+		return 0, errors.Wrapf(ErrInvalidAmount, "failed to ParseUint `%v`: %v", text, err)
+	}
+	if roundUp {
+		if units == math.MaxInt64 {
+			return 0, errors.Wrapf(ErrInvalidAmount, "value out of range `%v`", text)
+		}
+		units++
+	}
+	if negative {
+		return -Amount(units), nil
+	}
+
+	return Amount(units), nil
+}
+
+// Float64 returns the amount in ICE. It's meant for ratios and for reporting, never for the balance arithmetic.
+func (a Amount) Float64() float64 {
+	return float64(a) / AmountDenomination
+}
+
+// MulFloat64 multiplies the amount by a (time/percentage) factor, rounding the result to the nearest micro ICE.
+func (a Amount) MulFloat64(factor float64) (Amount, error) {
+	return newAmount(float64(a) * factor)
+}
+
+// Fraction returns the given fraction (i.e. a percentage, as a factor in [0, 1]) of the amount, rounded to the nearest micro ICE.
+func (a Amount) Fraction(factor float64) Amount {
+	return Amount(math.Round(float64(a) * factor))
+}
+
+// ICEFlake returns the amount as coin.ICEFlake. Negative amounts are returned as zero, because ICEFlake is unsigned.
+func (a Amount) ICEFlake() *coin.ICEFlake {
+	if a <= 0 {
+		return coin.ZeroICEFlakes()
+	}
+
+	return coin.NewAmountUint64(uint64(a)).MultiplyUint64(iceFlakesPerAmountUnit)
+}
+
+func (a Amount) String() string {
+	sign, units := "", uint64(a)
+	if a < 0 {
+		sign, units = "-", uint64(-(a+1))+1
+	}
+	whole, fraction := units/AmountDenomination, units%AmountDenomination
+	if fraction == 0 {
+		return sign + strconv.FormatUint(whole, 10)
+	}
+	fractionDigits := strconv.FormatUint(fraction, 10)
+
+	return sign + strconv.FormatUint(whole, 10) + "." +
+		strings.TrimRight(strings.Repeat("0", AmountDecimals-len(fractionDigits))+fractionDigits, "0")
+}
+
+// ScanRedis reads the integer number of micro ICE stored in redis.
+func (a *Amount) ScanRedis(val string) error {
+	units, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidAmount, "failed to ParseInt `%v`: %v", val, err)
+	}
+	*a = Amount(units)
+
+	return nil
+}
+
+func (a *Amount) UnmarshalBinary(data []byte) error {
+	return a.ScanRedis(string(data))
+}
+
+func (a Amount) MarshalBinary() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(a), 10)), nil
+}
+
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *Amount) UnmarshalText(text []byte) error {
+	val, err := ParseAmount(string(text))
+	if err != nil {
+		return errors.Wrapf(err, "failed to ParseAmount `%v`", string(text))
+	}
+	*a = val
+
+	return nil
+}
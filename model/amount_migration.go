@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package model
+
+import (
+	"context"
+	stdlibtime "time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	storage "github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/log"
+)
+
+const (
+	// AmountsMigratedKey is set once every `users:<id>` hash holds its amounts as integer micro ICE.
+	AmountsMigratedKey = "amounts_migrated_to_micro_ice"
+
+	amountsMigratedField     = "amounts_in_micro_ice"
+	amountsMigrationBatch    = 1_000
+	amountsMigrationDeadline = 30 * stdlibtime.Second
+)
+
+var (
+	// AmountFields are the redis fields, of the user hashes, that hold an Amount.
+	AmountFields = []string{ //nolint:gochecknoglobals // .
+		"balance_total_standard", "balance_total_pre_staking", "balance_total_minted", "balance_total_slashed",
+		"balance_minted_by_rate", "balance_slashed_by_rate",
+		"balance_solo_pending", "balance_t1_pending", "balance_t2_pending", "balance_t1_welcome_bonus_pending",
+		"balance_solo_pending_applied", "balance_t1_pending_applied", "balance_t2_pending_applied", "balance_t1_welcome_bonus_pending_applied",
+		"balance_solo", "balance_t0", "balance_t1", "balance_t2", "balance_for_t0", "balance_for_tminus1",
+		"balance_solo_ethereum", "balance_t0_ethereum", "balance_t1_ethereum", "balance_t2_ethereum",
+		"balance_for_t0_ethereum", "balance_for_tminus1_ethereum",
+		"balance_solo_ethereum_pending", "balance_t0_ethereum_pending", "balance_t1_ethereum_pending", "balance_t2_ethereum_pending",
+		"balance_solo_ethereum_mainnet_reward_pool_contribution", "balance_t0_ethereum_mainnet_reward_pool_contribution",
+		"balance_t1_ethereum_mainnet_reward_pool_contribution", "balance_t2_ethereum_mainnet_reward_pool_contribution",
+		"balance_for_t0_ethereum_mainnet_reward_pool_contribution", "balance_for_tminus1_ethereum_mainnet_reward_pool_contribution",
+		"slashing_rate_solo", "slashing_rate_t0", "slashing_rate_t1", "slashing_rate_t2", "slashing_rate_for_t0", "slashing_rate_for_tminus1",
+	}
+
+	// Rewrites the float ICE amounts of one user hash as integer micro ICE.
+	// It's a no-op once the whole migration is done (KEYS[2]), so it can never touch a value that's already in micro ICE,
+	// and for the hashes it already rewrote (the migration can be resumed, if interrupted).
+	migrateAmountsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[2]) == 1 or redis.call('TYPE', KEYS[1])['ok'] ~= 'hash' or redis.call('HEXISTS', KEYS[1], ARGV[1]) == 1 then
+	return 0
+end
+local migrated = 0
+for i = 3, #ARGV do
+	local val = redis.call('HGET', KEYS[1], ARGV[i])
+	if val then
+		local ice = tonumber(val)
+		if ice == nil or ice ~= ice then
+			return redis.error_reply('invalid amount ' .. ARGV[i] .. '=' .. val .. ' for ' .. KEYS[1])
+		end
+		local microICE = ice * tonumber(ARGV[2])
+		if math.abs(microICE) >= 9.2e18 then
+			return redis.error_reply('amount out of range ' .. ARGV[i] .. '=' .. val .. ' for ' .. KEYS[1])
+		end
+		redis.call('HSET', KEYS[1], ARGV[i], string.format('%.0f', microICE))
+		migrated = migrated + 1
+	end
+end
+redis.call('HSET', KEYS[1], ARGV[1], '1')
+return migrated
+`)
+)
+
+// MustMigrateAmounts rewrites the amounts of all the users, stored as float ICE, as integer micro ICE. See Amount.
+// It's idempotent and it can run concurrently, from every process that reads the user hashes, but it expects that
+// nothing writes float amounts anymore, so the processes that still use float amounts must be stopped before it runs.
+func MustMigrateAmounts(ctx context.Context, db storage.DB) {
+	log.Panic(errors.Wrap(MigrateAmounts(ctx, db), "failed to MigrateAmounts"))
+}
+
+func MigrateAmounts(ctx context.Context, db storage.DB) error { //nolint:funlen,gocognit,revive // .
+	if done, err := db.Exists(ctx, AmountsMigratedKey).Result(); err != nil || done == 1 {
+		return errors.Wrapf(err, "failed to check if %v exists", AmountsMigratedKey)
+	}
+	log.Info("migrating the user amounts to micro ICE")
+	args := append(make([]any, 0, 2+len(AmountFields)), amountsMigratedField, AmountDenomination)
+	for _, field := range AmountFields {
+		args = append(args, field)
+	}
+	var (
+		cursor            uint64
+		keys              []string
+		err               error
+		scanned, migrated uint64
+		invalid           *multierror.Error
+		cmds              = make([]*redis.Cmd, 0, amountsMigrationBatch)
+	)
+	for {
+		reqCtx, reqCancel := context.WithTimeout(ctx, amountsMigrationDeadline)
+		if keys, cursor, err = db.Scan(reqCtx, cursor, "users:*", amountsMigrationBatch).Result(); err != nil {
+			reqCancel()
+
+			return errors.Wrapf(err, "failed to scan users, cursor:%v", cursor)
+		}
+		cmds = cmds[:0]
+		_, err = db.Pipelined(reqCtx, func(pipeliner redis.Pipeliner) error {
+			for _, key := range keys {
+				cmds = append(cmds, migrateAmountsScript.Eval(reqCtx, pipeliner, []string{key, AmountsMigratedKey}, args...))
+			}
+
+			return nil
+		})
+		reqCancel()
+		for _, cmd := range cmds {
+			val, cErr := cmd.Int64()
+			var redisErr redis.Error
+			switch {
+			case cErr == nil && val > 0:
+				migrated++
+			case cErr != nil && errors.As(cErr, &redisErr):
+				// The rest of the users are still migrated, the invalid ones need to be fixed before the migration can finish.
+				invalid = multierror.Append(invalid, cErr)
+			case cErr != nil:
+				return errors.Wrapf(multierror.Append(err, cErr), "failed to migrate the amounts of %#v", keys)
+			}
+		}
+		if scanned += uint64(len(keys)); cursor == 0 {
+			break
+		}
+	}
+	if err = invalid.ErrorOrNil(); err != nil {
+		return errors.Wrapf(err, "some user amounts can't be migrated")
+	}
+	log.Info("migrated the user amounts to micro ICE", "scanned", scanned, "migrated", migrated)
+
+	return errors.Wrapf(db.Set(ctx, AmountsMigratedKey, stdlibtime.Now().UTC().Format(stdlibtime.RFC3339Nano), 0).Err(),
+		"failed to set %v", AmountsMigratedKey)
+}
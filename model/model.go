@@ -44,6 +44,8 @@ type (
 		BalanceTotalPreStakingField
 		BalanceTotalMintedField
 		BalanceTotalSlashedField
+		BalanceMintedByRateField
+		BalanceSlashedByRateField
 		BalanceSoloPendingField
 		BalanceT1PendingField
 		BalanceT2PendingField
@@ -161,124 +163,132 @@ type (
 		Country string `redis:"country" json:"country"`
 	}
 	BalanceTotalStandardField struct {
-		BalanceTotalStandard float64 `redis:"balance_total_standard"`
+		BalanceTotalStandard Amount `redis:"balance_total_standard"`
 	}
 	BalanceTotalPreStakingField struct {
-		BalanceTotalPreStaking float64 `redis:"balance_total_pre_staking"`
+		BalanceTotalPreStaking Amount `redis:"balance_total_pre_staking"`
 	}
 	BalanceTotalMintedField struct {
-		BalanceTotalMinted float64 `redis:"balance_total_minted"`
+		BalanceTotalMinted Amount `redis:"balance_total_minted"`
 	}
 	BalanceTotalSlashedField struct {
-		BalanceTotalSlashed float64 `redis:"balance_total_slashed"`
+		BalanceTotalSlashed Amount `redis:"balance_total_slashed"`
+	}
+	// BalanceMintedByRateField is the part of BalanceTotalMinted that comes from the mining rates only (without the pending amounts).
+	BalanceMintedByRateField struct {
+		BalanceMintedByRate Amount `redis:"balance_minted_by_rate"`
+	}
+	// BalanceSlashedByRateField is the part of BalanceTotalSlashed that comes from the slashing rates only (without the pending amounts).
+	BalanceSlashedByRateField struct {
+		BalanceSlashedByRate Amount `redis:"balance_slashed_by_rate"`
 	}
 	BalanceSoloPendingField struct {
-		BalanceSoloPending float64 `redis:"balance_solo_pending,omitempty"`
+		BalanceSoloPending Amount `redis:"balance_solo_pending,omitempty"`
 	}
 	BalanceT1PendingField struct {
-		BalanceT1Pending float64 `redis:"balance_t1_pending,omitempty"`
+		BalanceT1Pending Amount `redis:"balance_t1_pending,omitempty"`
 	}
 	BalanceT1WelcomeBonusPendingField struct {
-		BalanceT1WelcomeBonusPending float64 `redis:"balance_t1_welcome_bonus_pending,omitempty"`
+		BalanceT1WelcomeBonusPending Amount `redis:"balance_t1_welcome_bonus_pending,omitempty"`
 	}
 	BalanceT2PendingField struct {
-		BalanceT2Pending float64 `redis:"balance_t2_pending,omitempty"`
+		BalanceT2Pending Amount `redis:"balance_t2_pending,omitempty"`
 	}
 	BalanceSoloPendingAppliedField struct {
-		BalanceSoloPendingApplied float64 `redis:"balance_solo_pending_applied,omitempty"`
+		BalanceSoloPendingApplied Amount `redis:"balance_solo_pending_applied,omitempty"`
 	}
 	BalanceT1PendingAppliedField struct {
-		BalanceT1PendingApplied float64 `redis:"balance_t1_pending_applied,omitempty"`
+		BalanceT1PendingApplied Amount `redis:"balance_t1_pending_applied,omitempty"`
 	}
 	BalanceT1WelcomeBonusPendingAppliedField struct {
-		BalanceT1WelcomeBonusPendingApplied float64 `redis:"balance_t1_welcome_bonus_pending_applied,omitempty"`
+		BalanceT1WelcomeBonusPendingApplied Amount `redis:"balance_t1_welcome_bonus_pending_applied,omitempty"`
 	}
 	BalanceT2PendingAppliedField struct {
-		BalanceT2PendingApplied float64 `redis:"balance_t2_pending_applied,omitempty"`
+		BalanceT2PendingApplied Amount `redis:"balance_t2_pending_applied,omitempty"`
 	}
 	BalanceSoloField struct {
-		BalanceSolo float64 `redis:"balance_solo"`
+		BalanceSolo Amount `redis:"balance_solo"`
 	}
 	BalanceSoloEthereumField struct {
-		BalanceSoloEthereum float64 `redis:"balance_solo_ethereum"`
+		BalanceSoloEthereum Amount `redis:"balance_solo_ethereum"`
 	}
 	BalanceSoloEthereumMainnetRewardPoolContributionField struct {
-		BalanceSoloEthereumMainnetRewardPoolContribution float64 `redis:"balance_solo_ethereum_mainnet_reward_pool_contribution"`
+		BalanceSoloEthereumMainnetRewardPoolContribution Amount `redis:"balance_solo_ethereum_mainnet_reward_pool_contribution"`
 	}
 	BalanceSoloEthereumPendingField struct {
-		BalanceSoloEthereumPending *FlexibleFloat64 `redis:"balance_solo_ethereum_pending,omitempty"`
+		BalanceSoloEthereumPending *Amount `redis:"balance_solo_ethereum_pending,omitempty"`
 	}
 	BalanceT0Field struct {
-		BalanceT0 float64 `redis:"balance_t0"`
+		BalanceT0 Amount `redis:"balance_t0"`
 	}
 	BalanceT0EthereumField struct {
-		BalanceT0Ethereum float64 `redis:"balance_t0_ethereum"`
+		BalanceT0Ethereum Amount `redis:"balance_t0_ethereum"`
 	}
 	BalanceT0EthereumMainnetRewardPoolContributionField struct {
-		BalanceT0EthereumMainnetRewardPoolContribution float64 `redis:"balance_t0_ethereum_mainnet_reward_pool_contribution"`
+		BalanceT0EthereumMainnetRewardPoolContribution Amount `redis:"balance_t0_ethereum_mainnet_reward_pool_contribution"`
 	}
 	BalanceT0EthereumPendingField struct {
-		BalanceT0EthereumPending *FlexibleFloat64 `redis:"balance_t0_ethereum_pending,omitempty"`
+		BalanceT0EthereumPending *Amount `redis:"balance_t0_ethereum_pending,omitempty"`
 	}
 	BalanceT1Field struct {
-		BalanceT1 float64 `redis:"balance_t1"`
+		BalanceT1 Amount `redis:"balance_t1"`
 	}
 	BalanceT1EthereumField struct {
-		BalanceT1Ethereum float64 `redis:"balance_t1_ethereum"`
+		BalanceT1Ethereum Amount `redis:"balance_t1_ethereum"`
 	}
 	BalanceT1EthereumMainnetRewardPoolContributionField struct {
-		BalanceT1EthereumMainnetRewardPoolContribution float64 `redis:"balance_t1_ethereum_mainnet_reward_pool_contribution"`
+		BalanceT1EthereumMainnetRewardPoolContribution Amount `redis:"balance_t1_ethereum_mainnet_reward_pool_contribution"`
 	}
 	BalanceT1EthereumPendingField struct {
-		BalanceT1EthereumPending *FlexibleFloat64 `redis:"balance_t1_ethereum_pending,omitempty"`
+		BalanceT1EthereumPending *Amount `redis:"balance_t1_ethereum_pending,omitempty"`
 	}
 	BalanceT2Field struct {
-		BalanceT2 float64 `redis:"balance_t2"`
+		BalanceT2 Amount `redis:"balance_t2"`
 	}
 	BalanceT2EthereumField struct {
-		BalanceT2Ethereum float64 `redis:"balance_t2_ethereum"`
+		BalanceT2Ethereum Amount `redis:"balance_t2_ethereum"`
 	}
 	BalanceT2EthereumMainnetRewardPoolContributionField struct {
-		BalanceT2EthereumMainnetRewardPoolContribution float64 `redis:"balance_t2_ethereum_mainnet_reward_pool_contribution"`
+		BalanceT2EthereumMainnetRewardPoolContribution Amount `redis:"balance_t2_ethereum_mainnet_reward_pool_contribution"`
 	}
 	BalanceT2EthereumPendingField struct {
-		BalanceT2EthereumPending *FlexibleFloat64 `redis:"balance_t2_ethereum_pending,omitempty"`
+		BalanceT2EthereumPending *Amount `redis:"balance_t2_ethereum_pending,omitempty"`
 	}
 	BalanceForT0Field struct {
-		BalanceForT0 float64 `redis:"balance_for_t0"`
+		BalanceForT0 Amount `redis:"balance_for_t0"`
 	}
 	BalanceForT0EthereumField struct {
-		BalanceForT0Ethereum float64 `redis:"balance_for_t0_ethereum"`
+		BalanceForT0Ethereum Amount `redis:"balance_for_t0_ethereum"`
 	}
 	BalanceForT0EthereumMainnetRewardPoolContributionField struct {
-		BalanceForT0EthereumMainnetRewardPoolContribution float64 `redis:"balance_for_t0_ethereum_mainnet_reward_pool_contribution"`
+		BalanceForT0EthereumMainnetRewardPoolContribution Amount `redis:"balance_for_t0_ethereum_mainnet_reward_pool_contribution"`
 	}
 	BalanceForTMinus1Field struct {
-		BalanceForTMinus1 float64 `redis:"balance_for_tminus1"`
+		BalanceForTMinus1 Amount `redis:"balance_for_tminus1"`
 	}
 	BalanceForTMinus1EthereumField struct {
-		BalanceForTMinus1Ethereum float64 `redis:"balance_for_tminus1_ethereum"`
+		BalanceForTMinus1Ethereum Amount `redis:"balance_for_tminus1_ethereum"`
 	}
 	BalanceForTMinus1EthereumMainnetRewardPoolContributionField struct {
-		BalanceForTMinus1EthereumMainnetRewardPoolContribution float64 `redis:"balance_for_tminus1_ethereum_mainnet_reward_pool_contribution"`
+		BalanceForTMinus1EthereumMainnetRewardPoolContribution Amount `redis:"balance_for_tminus1_ethereum_mainnet_reward_pool_contribution"`
 	}
 	SlashingRateSoloField struct {
-		SlashingRateSolo float64 `redis:"slashing_rate_solo"`
+		SlashingRateSolo Amount `redis:"slashing_rate_solo"`
 	}
 	SlashingRateT0Field struct {
-		SlashingRateT0 float64 `redis:"slashing_rate_t0"`
+		SlashingRateT0 Amount `redis:"slashing_rate_t0"`
 	}
 	SlashingRateT1Field struct {
-		SlashingRateT1 float64 `redis:"slashing_rate_t1"`
+		SlashingRateT1 Amount `redis:"slashing_rate_t1"`
 	}
 	SlashingRateT2Field struct {
-		SlashingRateT2 float64 `redis:"slashing_rate_t2"`
+		SlashingRateT2 Amount `redis:"slashing_rate_t2"`
 	}
 	SlashingRateForT0Field struct {
-		SlashingRateForT0 float64 `redis:"slashing_rate_for_t0"`
+		SlashingRateForT0 Amount `redis:"slashing_rate_for_t0"`
 	}
 	SlashingRateForTMinus1Field struct {
-		SlashingRateForTMinus1 float64 `redis:"slashing_rate_for_tminus1"`
+		SlashingRateForTMinus1 Amount `redis:"slashing_rate_for_tminus1"`
 	}
 	PreStakingBonusField struct {
 		PreStakingBonus float64 `redis:"pre_staking_bonus,omitempty"`
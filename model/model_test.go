@@ -4,6 +4,10 @@ package model
 
 import (
 	"context"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	stdlibtime "time"
 
@@ -440,3 +444,142 @@ func TestKYCStepPassedCorrectly(t *testing.T) {
 		assert.True(t, kycState.KYCStepPassedCorrectly(users.Social2KYCStep))
 	})
 }
+
+func TestAmount(t *testing.T) {
+	t.Parallel()
+	for text, expected := range map[string]Amount{
+		"0":                      0,
+		"1":                      1_000_000,
+		"-1":                     -1_000_000,
+		"+1.5":                   1_500_000,
+		".5":                     500_000,
+		"12.000001":              12_000_001,
+		"0.1234564":              123_456,
+		"0.1234565":              123_457,
+		"-0.1234565":             -123_457,
+		"1e-05":                  10,
+		"9223372036854.775807":   9_223_372_036_854_775_807,
+		"123456.78901234567890":  123_456_789_012,
+		"1234567.00000000000001": 1_234_567_000_000,
+	} {
+		actual, err := ParseAmount(text)
+		require.NoError(t, err, text)
+		assert.EqualValues(t, expected, actual, text)
+	}
+	for _, text := range []string{"", ".", "-", "abc", "1.2.3", "1.0000001x", "NaN", "Inf", "9223372036854.775808", "1-2"} {
+		_, err := ParseAmount(text)
+		require.ErrorIs(t, err, ErrInvalidAmount, text)
+	}
+	for amount, expected := range map[Amount]string{
+		0:                     "0",
+		1:                     "0.000001",
+		1_500_000:             "1.5",
+		-1_500_000:            "-1.5",
+		-1:                    "-0.000001",
+		Amount(math.MinInt64): "-9223372036854.775808",
+	} {
+		assert.Equal(t, expected, amount.String())
+	}
+	val, err := NewAmount(0.1 + 0.2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 300_000, val)
+	assert.InDelta(t, 0.3, val.Float64(), 0)
+	assert.Equal(t, "300000000", val.ICEFlake().String())
+	assert.Equal(t, "0", Amount(-1).ICEFlake().String())
+	assert.EqualValues(t, 33_333, Amount(100_000).Fraction(1./3))
+	val, err = Amount(1_000_000).MulFloat64(2.5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2_500_000, val)
+	_, err = Amount(math.MaxInt64).MulFloat64(2)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = NewAmount(math.NaN())
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = NewAmount(math.Inf(-1))
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = NewAmount(MaxAmountICE + 1)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestAmountRedisSerialization(t *testing.T) {
+	t.Parallel()
+	for _, expected := range []Amount{0, 12_500_000, -3_000_001, math.MaxInt64, math.MinInt64} {
+		resp := storage.SerializeValue(&BalanceSoloField{BalanceSolo: expected})
+		assert.Equal(t, strconv.FormatInt(int64(expected), 10), resp[1])
+		cmd := redis.NewSliceCmd(context.Background(), "hmget", "boguskey", resp[0])
+		cmd.SetVal([]any{resp[1]})
+		var res BalanceSoloField
+		require.NoError(t, storage.DeserializeValue(&res, cmd.Scan))
+		assert.Equal(t, expected, res.BalanceSolo)
+	}
+	// The float ICE values must be migrated first, see MigrateAmounts.
+	cmd := redis.NewSliceCmd(context.Background(), "hmget", "boguskey", "balance_solo")
+	cmd.SetVal([]any{"12.5"})
+	var res BalanceSoloField
+	require.ErrorIs(t, storage.DeserializeValue(&res, cmd.Scan), ErrInvalidAmount)
+}
+
+func TestAmountFields(t *testing.T) {
+	t.Parallel()
+	amountType := reflect.TypeOf(Amount(0))
+	expected := make([]string, 0, len(AmountFields))
+	var collect func(reflect.Type)
+	collect = func(typ reflect.Type) {
+		for ix := range typ.NumField() {
+			field := typ.Field(ix)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				collect(field.Type)
+
+				continue
+			}
+			if field.Type == amountType || field.Type == reflect.PointerTo(amountType) {
+				name, _, _ := strings.Cut(field.Tag.Get("redis"), ",")
+				expected = append(expected, name)
+			}
+		}
+	}
+	// The ones that are not part of User.
+	collect(reflect.TypeOf(struct {
+		BalanceT1WelcomeBonusPendingField
+		BalanceT1WelcomeBonusPendingAppliedField
+		BalanceSoloEthereumPendingField
+		BalanceT0EthereumPendingField
+		BalanceT1EthereumPendingField
+		BalanceT2EthereumPendingField
+	}{}))
+	collect(reflect.TypeOf(User{}))
+	assert.ElementsMatch(t, expected, AmountFields)
+}
+
+func TestKeyPrefixHook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	hook := &keyPrefixHook{prefix: "doctorx:"}
+	for _, tc := range []struct {
+		args     []any
+		expected []any
+	}{
+		{args: []any{"hgetall", "users:1"}, expected: []any{"hgetall", "doctorx:users:1"}},
+		{args: []any{"hincrby", "users:1", "balance_solo", 1}, expected: []any{"hincrby", "doctorx:users:1", "balance_solo", 1}},
+		{args: []any{"sadd", "lookup:bo", "users:1"}, expected: []any{"sadd", "doctorx:lookup:bo", "users:1"}},
+		{args: []any{"del", "users:1", "users:2"}, expected: []any{"del", "doctorx:users:1", "doctorx:users:2"}},
+		{args: []any{"mset", "a", 1, "b", 2}, expected: []any{"mset", "doctorx:a", 1, "doctorx:b", 2}},
+		{args: []any{"evalsha", "sha", 2, "users_serial", "users:bo", "arg"}, expected: []any{"evalsha", "sha", 2, "doctorx:users_serial", "doctorx:users:bo", "arg"}},
+		{args: []any{"scan", 0, "match", "users:*", "count", 10}, expected: []any{"scan", 0, "match", "doctorx:users:*", "count", 10}},
+		{args: []any{"multi"}, expected: []any{"multi"}},
+	} {
+		cmd := redis.NewCmd(ctx, tc.args...)
+		require.NoError(t, hook.prefixKeys(cmd))
+		assert.Equal(t, tc.expected, cmd.Args())
+	}
+	require.ErrorIs(t, hook.prefixKeys(redis.NewCmd(ctx, "scan", 0)), errUnsupportedPrefixedCommand)
+	require.ErrorIs(t, hook.prefixKeys(redis.NewCmd(ctx, "flushall")), errUnsupportedPrefixedCommand)
+	require.ErrorIs(t, hook.prefixKeys(redis.NewCmd(ctx, "evalsha", "sha", 3, "a")), errUnsupportedPrefixedCommand)
+
+	scan := redis.NewScanCmd(ctx, nil, "scan", 0, "match", "doctorx:users:*")
+	scan.SetVal([]string{"doctorx:users:1", "doctorx:users:2"}, 5)
+	hook.trimKeys(scan)
+	keys, cursor := scan.Val()
+	assert.Equal(t, []string{"users:1", "users:2"}, keys)
+	assert.EqualValues(t, 5, cursor)
+}
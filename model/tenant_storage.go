@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	appCfg "github.com/ice-blockchain/wintr/config"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+type (
+	prefixedDB struct {
+		*redis.Client
+	}
+	keyPrefixHook struct {
+		prefix string
+	}
+	storageConfig struct {
+		WintrStorage struct {
+			Credentials struct {
+				User     string `yaml:"user"`
+				Password string `yaml:"password"`
+			} `yaml:"credentials" mapstructure:"credentials"`
+			URL                string   `yaml:"url" mapstructure:"url"`
+			URLs               []string `yaml:"urls" mapstructure:"urls"` //nolint:tagliatelle // .
+			ConnectionsPerCore int      `yaml:"connectionsPerCore" mapstructure:"connectionsPerCore"`
+		} `yaml:"wintr/connectors/storage/v3" mapstructure:"wintr/connectors/storage/v3"` //nolint:tagliatelle // Nope.
+	}
+)
+
+const (
+	redisConnectionsPerCore = 10
+	redisMaxRetries         = 25
+)
+
+var (
+	errUnsupportedPrefixedCommand = errors.New("command not supported with a redis key prefix")
+
+	//nolint:gochecknoglobals // Static lookup tables.
+	noKeyCommands = map[string]bool{
+		"ping": true, "info": true, "hello": true, "auth": true, "select": true, "client": true, "readonly": true,
+		"multi": true, "exec": true, "discard": true, "script": true, "command": true, "role": true, "time": true,
+	}
+	//nolint:gochecknoglobals // Static lookup tables.
+	singleKeyCommands = map[string]bool{
+		"get": true, "set": true, "setnx": true, "setex": true, "psetex": true, "getset": true, "getdel": true, "getex": true,
+		"incr": true, "incrby": true, "incrbyfloat": true, "decr": true, "decrby": true, "append": true, "strlen": true,
+		"expire": true, "expireat": true, "pexpire": true, "pexpireat": true, "persist": true, "ttl": true, "pttl": true, "type": true,
+		"hget": true, "hset": true, "hsetnx": true, "hmset": true, "hmget": true, "hgetall": true, "hdel": true, "hlen": true,
+		"hexists": true, "hincrby": true, "hincrbyfloat": true, "hkeys": true, "hvals": true, "hscan": true,
+		"sadd": true, "srem": true, "smembers": true, "sismember": true, "smismember": true, "scard": true, "sscan": true,
+		"spop": true, "srandmember": true,
+		"zadd": true, "zrem": true, "zscore": true, "zincrby": true, "zcard": true, "zcount": true, "zrank": true, "zrevrank": true,
+		"zrange": true, "zrangebyscore": true, "zrevrange": true, "zrevrangebyscore": true, "zremrangebyscore": true,
+		"zremrangebyrank": true, "zscan": true,
+		"lpush": true, "rpush": true, "lpop": true, "rpop": true, "lrange": true, "ltrim": true, "llen": true, "lindex": true,
+		"lrem": true, "lset": true,
+	}
+	//nolint:gochecknoglobals // Static lookup tables.
+	allKeysCommands = map[string]bool{
+		"del": true, "exists": true, "unlink": true, "mget": true, "touch": true, "watch": true,
+		"sinter": true, "sunion": true, "sdiff": true,
+	}
+	//nolint:gochecknoglobals // Static lookup tables.
+	twoKeysCommands = map[string]bool{
+		"rename": true, "renamenx": true, "smove": true, "rpoplpush": true, "lmove": true, "copy": true,
+	}
+	//nolint:gochecknoglobals // Static lookup tables.
+	scriptCommands = map[string]bool{
+		"eval": true, "evalsha": true, "eval_ro": true, "evalsha_ro": true,
+	}
+)
+
+// MustConnectStorage connects to the redis of a tenant. If the tenant has a redis key prefix,
+// it's prepended to the keys of all the commands (and trimmed from the scanned keys), so the callers never deal with it.
+func MustConnectStorage(ctx context.Context, applicationYamlKey, redisKeyPrefix string, overriddenPoolSize ...int) storage.DB {
+	if redisKeyPrefix == "" {
+		return storage.MustConnect(ctx, applicationYamlKey, overriddenPoolSize...)
+	}
+
+	return mustConnectPrefixedStorage(ctx, applicationYamlKey, redisKeyPrefix, overriddenPoolSize...)
+}
+
+// Same options as storage.MustConnect, but with only the first url, because the hook can't be added to the load balanced instances of storage.DB.
+func mustConnectPrefixedStorage(ctx context.Context, applicationYamlKey, prefix string, overriddenPoolSize ...int) storage.DB {
+	var cfg storageConfig
+	appCfg.MustLoadFromKey(applicationYamlKey, &cfg)
+	url := cfg.WintrStorage.URL
+	if len(cfg.WintrStorage.URLs) != 0 {
+		url = cfg.WintrStorage.URLs[0]
+	}
+	opts, err := redis.ParseURL(url)
+	log.Panic(errors.Wrapf(err, "invalid redis url for the key prefix %v", prefix)) //nolint:revive // That's intended.
+	if opts.Username == "" {
+		opts.Username = cfg.WintrStorage.Credentials.User
+	}
+	if opts.Password == "" {
+		opts.Password = cfg.WintrStorage.Credentials.Password
+	}
+	if opts.ClientName == "" {
+		opts.ClientName = applicationYamlKey
+	}
+	opts.MaxRetries = redisMaxRetries
+	opts.MinRetryBackoff, opts.MaxRetryBackoff = 10*stdlibtime.Millisecond, stdlibtime.Second //nolint:gomnd // .
+	opts.DialTimeout, opts.ConnMaxIdleTime = 2*stdlibtime.Second, 60*stdlibtime.Second        //nolint:gomnd // .
+	opts.ReadTimeout, opts.WriteTimeout = 30*stdlibtime.Second, 30*stdlibtime.Second          //nolint:gomnd // .
+	opts.ContextTimeoutEnabled, opts.PoolFIFO, opts.MinIdleConns = true, true, 1
+	if opts.PoolSize = cfg.WintrStorage.ConnectionsPerCore * runtime.GOMAXPROCS(-1); opts.PoolSize == 0 {
+		opts.PoolSize = redisConnectionsPerCore * runtime.GOMAXPROCS(-1)
+	}
+	if len(overriddenPoolSize) == 1 {
+		opts.PoolSize = overriddenPoolSize[0]
+	}
+	opts.MaxIdleConns = opts.PoolSize
+	client := redis.NewClient(opts)
+	client.AddHook(&keyPrefixHook{prefix: prefix})
+	log.Panic(errors.Wrapf(client.Ping(ctx).Err(), "failed to ping redis for the key prefix %v", prefix))
+
+	return &prefixedDB{Client: client}
+}
+
+func (db *prefixedDB) IsRW(ctx context.Context) bool {
+	err := db.Set(ctx, fmt.Sprintf("rw-check-%v", time.Now().UnixNano()), "", stdlibtime.Minute).Err()
+	log.Error(errors.Wrap(err, "prefixed storage rw-check failed"))
+
+	return err == nil
+}
+
+func (*keyPrefixHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *keyPrefixHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := h.prefixKeys(cmd); err != nil {
+			cmd.SetErr(err)
+
+			return err
+		}
+		err := next(ctx, cmd)
+		h.trimKeys(cmd)
+
+		return err
+	}
+}
+
+func (h *keyPrefixHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := h.prefixKeys(cmd); err != nil {
+				cmd.SetErr(err)
+
+				return err
+			}
+		}
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			h.trimKeys(cmd)
+		}
+
+		return err
+	}
+}
+
+// The commands with keys we don't know the position of are rejected, so that no key ever escapes the prefix.
+//
+//nolint:funlen,gocognit,revive // .
+func (h *keyPrefixHook) prefixKeys(cmd redis.Cmder) error {
+	args := cmd.Args()
+	switch name := cmd.Name(); {
+	case noKeyCommands[name]:
+		return nil
+	case singleKeyCommands[name]:
+		return h.prefixArgs(args, 1, 2, 1) //nolint:gomnd // .
+	case twoKeysCommands[name]:
+		return h.prefixArgs(args, 1, 3, 1) //nolint:gomnd // .
+	case allKeysCommands[name]:
+		return h.prefixArgs(args, 1, len(args), 1)
+	case name == "mset" || name == "msetnx":
+		return h.prefixArgs(args, 1, len(args), 2) //nolint:gomnd // .
+	case scriptCommands[name]:
+		if len(args) < 3 { //nolint:gomnd // .
+			return errors.Wrapf(errUnsupportedPrefixedCommand, "%v", cmd.Args())
+		}
+		numKeys, err := strconv.Atoi(fmt.Sprint(args[2]))
+		if err != nil || numKeys < 0 || 3+numKeys > len(args) {
+			return errors.Wrapf(errUnsupportedPrefixedCommand, "invalid numkeys in %v", cmd.Args())
+		}
+
+		return h.prefixArgs(args, 3, 3+numKeys, 1) //nolint:gomnd // .
+	case name == "scan":
+		for ix := 2; ix < len(args)-1; ix++ {
+			if strings.EqualFold(fmt.Sprint(args[ix]), "match") {
+				return h.prefixArgs(args, ix+1, ix+2, 1) //nolint:gomnd // .
+			}
+		}
+
+		return errors.Wrapf(errUnsupportedPrefixedCommand, "scan without match: %v", cmd.Args())
+	case name == "keys":
+		return h.prefixArgs(args, 1, 2, 1) //nolint:gomnd // .
+	default:
+		return errors.Wrapf(errUnsupportedPrefixedCommand, "%v", name)
+	}
+}
+
+func (h *keyPrefixHook) prefixArgs(args []any, from, to, step int) error {
+	if to > len(args) {
+		return errors.Wrapf(errUnsupportedPrefixedCommand, "missing keys in %v", args)
+	}
+	for ix := from; ix < to; ix += step {
+		key, isString := args[ix].(string)
+		if !isString {
+			return errors.Wrapf(errUnsupportedPrefixedCommand, "non string key %#v", args[ix])
+		}
+		args[ix] = h.prefix + key
+	}
+
+	return nil
+}
+
+func (h *keyPrefixHook) trimKeys(cmd redis.Cmder) {
+	if cmd.Err() != nil {
+		return
+	}
+	switch typedCmd := cmd.(type) {
+	case *redis.ScanCmd:
+		if cmd.Name() != "scan" {
+			return
+		}
+		keys, cursor := typedCmd.Val()
+		typedCmd.SetVal(h.trimPrefix(keys), cursor)
+	case *redis.StringSliceCmd:
+		if cmd.Name() != "keys" {
+			return
+		}
+		typedCmd.SetVal(h.trimPrefix(typedCmd.Val()))
+	}
+}
+
+func (h *keyPrefixHook) trimPrefix(keys []string) []string {
+	for ix := range keys {
+		keys[ix] = strings.TrimPrefix(keys[ix], h.prefix)
+	}
+
+	return keys
+}
@@ -21,6 +21,7 @@ import (
 )
 
 func (r *repository) GetAdoptionSummary(ctx context.Context, userID string) (as *AdoptionSummary, err error) {
+	r = r.forTenant(ctx)
 	if as = new(AdoptionSummary); ctx.Err() != nil {
 		return nil, errors.Wrap(ctx.Err(), "context failed")
 	}
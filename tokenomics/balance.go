@@ -25,6 +25,7 @@ import (
 func (r *repository) GetBalanceSummary( //nolint:lll // .
 	ctx context.Context, userID string,
 ) (*BalanceSummary, error) {
+	r = r.forTenant(ctx)
 	id, err := GetOrInitInternalID(ctx, r.db, userID, r.cfg.WelcomeBonusV2Amount)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to getOrInitInternalID for userID:%v", userID)
@@ -63,15 +64,15 @@ func (r *repository) GetBalanceSummary( //nolint:lll // .
 
 	return &BalanceSummary{
 		Balances: Balances[string]{
-			Total:                              fmt.Sprintf(floatToStringFormatter, soloStandard+soloPreStaking+t1Standard+t1PreStaking+t2Standard+t2PreStaking),
-			TotalNoPreStakingBonus:             fmt.Sprintf(floatToStringFormatter, res[0].BalanceSolo+res[0].BalanceT0+res[0].BalanceT1+res[0].BalanceT2),
-			Standard:                           fmt.Sprintf(floatToStringFormatter, soloStandard+t1Standard+t2Standard),
-			PreStaking:                         fmt.Sprintf(floatToStringFormatter, soloPreStaking+t1PreStaking+t2PreStaking),
-			T1:                                 fmt.Sprintf(floatToStringFormatter, t1Standard+t1PreStaking),
-			T2:                                 fmt.Sprintf(floatToStringFormatter, t2Standard+t2PreStaking),
-			TotalReferrals:                     fmt.Sprintf(floatToStringFormatter, t1Standard+t1PreStaking+t2Standard+t2PreStaking),
-			TotalMiningBlockchain:              fmt.Sprintf(floatToStringFormatter, res[0].BalanceSoloEthereum+res[0].BalanceT0Ethereum+res[0].BalanceT1Ethereum+res[0].BalanceT2Ethereum),                                                                                                                     //nolint:lll // .
-			TotalMainnetRewardPoolContribution: fmt.Sprintf(floatToStringFormatter, res[0].BalanceSoloEthereumMainnetRewardPoolContribution+res[0].BalanceT0EthereumMainnetRewardPoolContribution+res[0].BalanceT1EthereumMainnetRewardPoolContribution+res[0].BalanceT2EthereumMainnetRewardPoolContribution), //nolint:lll // .
+			Total:                              formatAmount(soloStandard + soloPreStaking + t1Standard + t1PreStaking + t2Standard + t2PreStaking),
+			TotalNoPreStakingBonus:             formatAmount(res[0].BalanceSolo + res[0].BalanceT0 + res[0].BalanceT1 + res[0].BalanceT2),
+			Standard:                           formatAmount(soloStandard + t1Standard + t2Standard),
+			PreStaking:                         formatAmount(soloPreStaking + t1PreStaking + t2PreStaking),
+			T1:                                 formatAmount(t1Standard + t1PreStaking),
+			T2:                                 formatAmount(t2Standard + t2PreStaking),
+			TotalReferrals:                     formatAmount(t1Standard + t1PreStaking + t2Standard + t2PreStaking),
+			TotalMiningBlockchain:              formatAmount(res[0].BalanceSoloEthereum + res[0].BalanceT0Ethereum + res[0].BalanceT1Ethereum + res[0].BalanceT2Ethereum),                                                                                                                     //nolint:lll // .
+			TotalMainnetRewardPoolContribution: formatAmount(res[0].BalanceSoloEthereumMainnetRewardPoolContribution + res[0].BalanceT0EthereumMainnetRewardPoolContribution + res[0].BalanceT1EthereumMainnetRewardPoolContribution + res[0].BalanceT2EthereumMainnetRewardPoolContribution), //nolint:lll // .
 		},
 	}, nil
 }
@@ -79,6 +80,7 @@ func (r *repository) GetBalanceSummary( //nolint:lll // .
 func (r *repository) GetBalanceHistory( //nolint:funlen,gocognit,revive,gocyclo,cyclop,revive // Better to be grouped together.
 	ctx context.Context, userID string, start, end *time.Time, utcOffset stdlibtime.Duration, limit, offset uint64,
 ) ([]*BalanceHistoryEntry, error) {
+	r = r.forTenant(ctx)
 	if ctx.Err() != nil {
 		return nil, errors.Wrap(ctx.Err(), "unexpected deadline")
 	}
@@ -202,7 +204,7 @@ func (r *repository) processBalanceHistory(
 				Balance: new(BalanceHistoryBalanceDiff),
 			}
 		}
-		var total float64
+		var total model.Amount
 		if bal.BalanceTotalSlashed >= 0 {
 			total = bal.BalanceTotalMinted - bal.BalanceTotalSlashed
 		} else {
@@ -210,7 +212,7 @@ func (r *repository) processBalanceHistory(
 		}
 		parents[parentFormat].children[childFormat].Balance.amount = total
 		parents[parentFormat].children[childFormat].Balance.Negative = total < 0
-		parents[parentFormat].children[childFormat].Balance.Amount = fmt.Sprintf(floatToStringFormatter, math.Abs(total))
+		parents[parentFormat].children[childFormat].Balance.Amount = formatAmount(max(total, -total))
 	}
 	sort.Strings(parentKeys)
 	history := make([]*BalanceHistoryEntry, 0, len(parents))
@@ -245,7 +247,7 @@ func (r *repository) processBalanceHistory(
 			parents[pKey].setBalanceDiffBonus(prevParent.Balance.amount)
 		}
 		parents[pKey].Balance.Negative = parents[pKey].Balance.amount < 0
-		parents[pKey].Balance.Amount = fmt.Sprintf(floatToStringFormatter, math.Abs(parents[pKey].Balance.amount))
+		parents[pKey].Balance.Amount = formatAmount(max(parents[pKey].Balance.amount, -parents[pKey].Balance.amount))
 		if !startDateIsBeforeEndDate {
 			sort.SliceStable(parents[pKey].BalanceHistoryEntry.TimeSeries, func(i, j int) bool {
 				return parents[pKey].BalanceHistoryEntry.TimeSeries[i].Time.After(parents[pKey].BalanceHistoryEntry.TimeSeries[j].Time)
@@ -265,8 +267,8 @@ func (r *repository) processBalanceHistory(
 	return history
 }
 
-func (e *BalanceHistoryEntry) setBalanceDiffBonus(from float64) {
-	to := e.Balance.amount
+func (e *BalanceHistoryEntry) setBalanceDiffBonus(fromAmount model.Amount) {
+	from, to := fromAmount.Float64(), e.Balance.amount.Float64()
 	if from < 0 && to > 0 {
 		e.Balance.Bonus = roundFloat64(((from - to) / from) * 100)
 	} else if from != 0 {
@@ -279,7 +281,7 @@ func (s *completedTasksSource) Process(ctx context.Context, message *messagebrok
 	if ctx.Err() != nil || len(message.Value) == 0 {
 		return errors.Wrap(ctx.Err(), "unexpected deadline while processing message")
 	}
-	if s.cfg.Tenant == doctorXTenant {
+	if s.cfg.Capabilities.CompletedTasksPrizesDisabled {
 		return nil
 	}
 	const requiredCompletedTasks, adoptionMultiplicationFactor = 6, 168
@@ -307,8 +309,10 @@ func (s *completedTasksSource) Process(ctx context.Context, message *messagebrok
 
 		return errors.Wrapf(dErr, "SetNX failed for completed_tasks_ice_prize_dupl_guard, userID: %v", val.UserID)
 	}
+	credited := false
 	defer func() {
-		if err != nil {
+		// Once the prize is credited, the dupl guard must stay, even if something fails afterwards.
+		if err != nil && !credited {
 			undoCtx, cancelUndo := context.WithTimeout(context.Background(), requestDeadline)
 			defer cancelUndo()
 			err = multierror.Append( //nolint:wrapcheck // .
@@ -333,17 +337,30 @@ func (s *completedTasksSource) Process(ctx context.Context, message *messagebrok
 		}
 		prize = s.cfg.BaseMiningRate(time.Now(), res[0].CreatedAt) * adoptionMultiplicationFactor
 	}
+	amount, err := model.NewAmount(prize)
+	if err != nil {
+		return errors.Wrapf(err, "invalid prize %v for userID:%v", prize, val.UserID)
+	}
+	if err = s.db.HIncrBy(ctx, model.SerializedUsersKey(id), "balance_solo_pending", int64(amount)).Err(); err != nil {
+		return errors.Wrapf(err, "failed to incr balance_solo_pending for userID:%v by %v", val.UserID, prize)
+	}
+	credited = true
+	entry := newLedgerEntry(id, val.UserID, dwh.CompletedTasksLedgerReason, fmt.Sprintf("%v:%v:%v", message.Topic, message.Key, val.Type), amount)
 
-	return errors.Wrapf(s.db.HIncrByFloat(ctx, model.SerializedUsersKey(id), "balance_solo_pending", prize).Err(),
-		"failed to incr balance_solo_pending for userID:%v by %v", val.UserID, prize)
+	return errors.Wrapf(s.insertLedgerEntries(ctx, entry), "failed to insert the completed tasks ledger entry for userID:%v", val.UserID)
 }
 
+// ApplyPreStaking splits the amount into its standard part and its pre-staking part, with the pre-staking bonus applied.
+//
 //nolint:gomnd // .
-func ApplyPreStaking(amount, preStakingAllocation, preStakingBonus float64) (float64, float64) {
-	standardAmount := (amount * (100 - preStakingAllocation)) / 100
-	preStakingAmount := (amount * (100 + preStakingBonus) * preStakingAllocation) / 10000
+func ApplyPreStaking(amount model.Amount, preStakingAllocation, preStakingBonus float64) (standardAmount, preStakingAmount model.Amount) {
+	allocated := model.Amount(math.Round(float64(amount) * preStakingAllocation / 100))
+
+	return amount - allocated, model.Amount(math.Round(float64(allocated) * (100 + preStakingBonus) / 100))
+}
 
-	return standardAmount, preStakingAmount
+func formatAmount(amount model.Amount) string {
+	return fmt.Sprintf(floatToStringFormatter, amount.Float64())
 }
 
 func daysInMonth(t *time.Time) uint64 {
@@ -11,9 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
 	"github.com/ice-blockchain/wintr/time"
 )
 
+func ice(amount float64) model.Amount {
+	return model.MustNewAmount(amount)
+}
+
 func TestCalculateDates_Factor1(t *testing.T) {
 	t.Parallel()
 	repo := &repository{cfg: &Config{
@@ -500,33 +505,33 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 	history := []*dwh.BalanceHistory{
 		{
 			CreatedAt:           time.New(now.Add(-1 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(-2 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  28.,
+			BalanceTotalMinted:  ice(28.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(-3 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  32.,
+			BalanceTotalMinted:  ice(32.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(-4 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  31.,
+			BalanceTotalMinted:  ice(31.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(-5 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(-6 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 17.,
+			BalanceTotalSlashed: ice(17.),
 		},
 	}
 	/******************************************************************************************************************************************************
@@ -542,7 +547,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: stdlibtime.Date(2023, 5, 30, 0, 0, 0, 0, location),
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   -17.,
+				amount:   ice(-17.),
 				Amount:   "17.00",
 				Bonus:    0.,
 				Negative: true,
@@ -551,7 +556,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: stdlibtime.Date(2023, 5, 30, 0, 0, 0, 0, location),
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -17.,
+						amount:   ice(-17.),
 						Amount:   "17.00",
 						Bonus:    0.,
 						Negative: true,
@@ -563,7 +568,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: stdlibtime.Date(2023, 5, 31, 0, 0, 0, 0, location),
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    247.06,
 				Negative: false,
@@ -572,7 +577,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: stdlibtime.Date(2023, 5, 31, 0, 0, 0, 0, location),
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    247.06,
 						Negative: false,
@@ -584,7 +589,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 1, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   31.,
+				amount:   ice(31.),
 				Amount:   "31.00",
 				Bonus:    24,
 				Negative: false,
@@ -593,7 +598,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 1, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31.,
+						amount:   ice(31.),
 						Amount:   "31.00",
 						Bonus:    24,
 						Negative: false,
@@ -605,7 +610,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 2, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   32.,
+				amount:   ice(32.),
 				Amount:   "32.00",
 				Bonus:    3.23,
 				Negative: false,
@@ -614,7 +619,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 2, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32.,
+						amount:   ice(32.),
 						Amount:   "32.00",
 						Bonus:    3.23,
 						Negative: false,
@@ -626,7 +631,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 3, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   28.,
+				amount:   ice(28.),
 				Amount:   "28.00",
 				Bonus:    -12.5,
 				Negative: false,
@@ -635,7 +640,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 3, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28.,
+						amount:   ice(28.),
 						Amount:   "28.00",
 						Bonus:    -12.5,
 						Negative: false,
@@ -647,7 +652,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 4, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.,
+				amount:   ice(25.),
 				Amount:   "25.00",
 				Bonus:    -10.71,
 				Negative: false,
@@ -656,7 +661,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 4, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.,
+						amount:   ice(25.),
 						Amount:   "25.00",
 						Bonus:    -10.71,
 						Negative: false,
@@ -680,7 +685,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: stdlibtime.Date(2023, 5, 31, 0, 0, 0, 0, location),
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    247.06,
 				Negative: false,
@@ -689,7 +694,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: stdlibtime.Date(2023, 5, 31, 0, 0, 0, 0, location),
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    0,
 						Negative: false,
@@ -701,7 +706,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 1, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   31.,
+				amount:   ice(31.),
 				Amount:   "31.00",
 				Bonus:    24,
 				Negative: false,
@@ -710,7 +715,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 1, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31.,
+						amount:   ice(31.),
 						Amount:   "31.00",
 						Bonus:    24,
 						Negative: false,
@@ -722,7 +727,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 2, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   32.,
+				amount:   ice(32.),
 				Amount:   "32.00",
 				Bonus:    3.23,
 				Negative: false,
@@ -731,7 +736,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 2, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32.,
+						amount:   ice(32.),
 						Amount:   "32.00",
 						Bonus:    3.23,
 						Negative: false,
@@ -743,7 +748,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 3, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   28.,
+				amount:   ice(28.),
 				Amount:   "28.00",
 				Bonus:    -12.5,
 				Negative: false,
@@ -752,7 +757,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 3, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28.,
+						amount:   ice(28.),
 						Amount:   "28.00",
 						Bonus:    -12.5,
 						Negative: false,
@@ -764,7 +769,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 4, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.,
+				amount:   ice(25.),
 				Amount:   "25.00",
 				Bonus:    -10.71,
 				Negative: false,
@@ -773,7 +778,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 4, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.,
+						amount:   ice(25.),
 						Amount:   "25.00",
 						Bonus:    -10.71,
 						Negative: false,
@@ -794,7 +799,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 4, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.,
+				amount:   ice(25.),
 				Amount:   "25.00",
 				Bonus:    -10.71,
 				Negative: false,
@@ -803,7 +808,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 4, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.,
+						amount:   ice(25.),
 						Amount:   "25.00",
 						Bonus:    -10.71,
 						Negative: false,
@@ -815,7 +820,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 3, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   28.,
+				amount:   ice(28.),
 				Amount:   "28.00",
 				Bonus:    -12.5,
 				Negative: false,
@@ -824,7 +829,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 3, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28.,
+						amount:   ice(28.),
 						Amount:   "28.00",
 						Bonus:    -12.5,
 						Negative: false,
@@ -836,7 +841,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 2, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   32.,
+				amount:   ice(32.),
 				Amount:   "32.00",
 				Bonus:    3.23,
 				Negative: false,
@@ -845,7 +850,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 2, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32.,
+						amount:   ice(32.),
 						Amount:   "32.00",
 						Bonus:    3.23,
 						Negative: false,
@@ -857,7 +862,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 1, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   31.,
+				amount:   ice(31.),
 				Amount:   "31.00",
 				Bonus:    24,
 				Negative: false,
@@ -866,7 +871,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 1, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31.,
+						amount:   ice(31.),
 						Amount:   "31.00",
 						Bonus:    24,
 						Negative: false,
@@ -878,7 +883,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 5, 31, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    247.06,
 				Negative: false,
@@ -887,7 +892,7 @@ func TestProcessBalanceHistory_ChildIsHour_AfterBeforeLimits(t *testing.T) {
 				{
 					Time: stdlibtime.Date(2023, 5, 31, 0, 0, 0, 0, location),
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    0,
 						Negative: false,
@@ -921,152 +926,152 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 	history := []*dwh.BalanceHistory{
 		{
 			CreatedAt:           time.New(now.Add(1 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(2 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  28.,
+			BalanceTotalMinted:  ice(28.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(3 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  32.,
+			BalanceTotalMinted:  ice(32.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(4 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  31.,
+			BalanceTotalMinted:  ice(31.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(5 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(6 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  12.,
+			BalanceTotalMinted:  ice(12.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(7 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  29.,
+			BalanceTotalMinted:  ice(29.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(8 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 5.,
+			BalanceTotalSlashed: ice(5.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(9 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 19.,
+			BalanceTotalSlashed: ice(19.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(10 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 20.,
+			BalanceTotalSlashed: ice(20.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(11 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  13.,
+			BalanceTotalMinted:  ice(13.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(12 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(13 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(14 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(15 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  5.,
+			BalanceTotalMinted:  ice(5.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(16 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(17 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(18 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  30.,
+			BalanceTotalMinted:  ice(30.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(19 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  28.,
+			BalanceTotalMinted:  ice(28.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(20 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  32.,
+			BalanceTotalMinted:  ice(32.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(21 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  31.,
+			BalanceTotalMinted:  ice(31.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(22 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(23 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 17.,
+			BalanceTotalSlashed: ice(17.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(24 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(25 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(26 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(27 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  10.,
+			BalanceTotalMinted:  ice(10.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(28 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(29 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  10.,
+			BalanceTotalMinted:  ice(10.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(30 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 	}
@@ -1083,7 +1088,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    0,
 				Negative: false,
@@ -1092,7 +1097,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    0,
 						Negative: false,
@@ -1116,7 +1121,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 12, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   29.0,
+				amount:   ice(29.0),
 				Amount:   "29.00",
 				Bonus:    141.67,
 				Negative: false,
@@ -1125,7 +1130,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 12, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   29.0,
+						amount:   ice(29.0),
 						Amount:   "29.00",
 						Bonus:    141.67,
 						Negative: false,
@@ -1137,7 +1142,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 11, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   12.0,
+				amount:   ice(12.0),
 				Amount:   "12.00",
 				Bonus:    -52,
 				Negative: false,
@@ -1146,7 +1151,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 11, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   12.0,
+						amount:   ice(12.0),
 						Amount:   "12.00",
 						Bonus:    -52,
 						Negative: false,
@@ -1158,7 +1163,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 10, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    -19.35,
 				Negative: false,
@@ -1167,7 +1172,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 10, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    -19.35,
 						Negative: false,
@@ -1179,7 +1184,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 9, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   31.0,
+				amount:   ice(31.0),
 				Amount:   "31.00",
 				Bonus:    -3.13,
 				Negative: false,
@@ -1188,7 +1193,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 9, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31.0,
+						amount:   ice(31.0),
 						Amount:   "31.00",
 						Bonus:    -3.13,
 						Negative: false,
@@ -1200,7 +1205,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 8, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   32.0,
+				amount:   ice(32.0),
 				Amount:   "32.00",
 				Bonus:    14.29,
 				Negative: false,
@@ -1209,7 +1214,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 8, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32.0,
+						amount:   ice(32.0),
 						Amount:   "32.00",
 						Bonus:    14.29,
 						Negative: false,
@@ -1221,7 +1226,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 7, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   28.0,
+				amount:   ice(28.0),
 				Amount:   "28.00",
 				Bonus:    12,
 				Negative: false,
@@ -1230,7 +1235,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 7, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28.0,
+						amount:   ice(28.0),
 						Amount:   "28.00",
 						Bonus:    12,
 						Negative: false,
@@ -1242,7 +1247,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.0,
+				amount:   ice(25.0),
 				Amount:   "25.00",
 				Bonus:    0,
 				Negative: false,
@@ -1251,7 +1256,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.0,
+						amount:   ice(25.0),
 						Amount:   "25.00",
 						Bonus:    0,
 						Negative: false,
@@ -1275,7 +1280,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 7, 5, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   20.0,
+				amount:   ice(20.0),
 				Amount:   "20.00",
 				Bonus:    100,
 				Negative: false,
@@ -1284,7 +1289,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 7, 5, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   20.0,
+						amount:   ice(20.0),
 						Amount:   "20.00",
 						Bonus:    100,
 						Negative: false,
@@ -1296,7 +1301,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 7, 4, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   10.0,
+				amount:   ice(10.0),
 				Amount:   "10.00",
 				Bonus:    -50,
 				Negative: false,
@@ -1305,7 +1310,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 7, 4, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   10.0,
+						amount:   ice(10.0),
 						Amount:   "10.00",
 						Bonus:    -50,
 						Negative: false,
@@ -1317,7 +1322,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 7, 3, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   20.0,
+				amount:   ice(20.0),
 				Amount:   "20.00",
 				Bonus:    100,
 				Negative: false,
@@ -1326,7 +1331,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 7, 3, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   20.0,
+						amount:   ice(20.0),
 						Amount:   "20.00",
 						Bonus:    100,
 						Negative: false,
@@ -1338,7 +1343,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 7, 2, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   10.0,
+				amount:   ice(10.0),
 				Amount:   "10.00",
 				Bonus:    -33.33,
 				Negative: false,
@@ -1347,7 +1352,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 7, 2, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   10.0,
+						amount:   ice(10.0),
 						Amount:   "10.00",
 						Bonus:    -33.33,
 						Negative: false,
@@ -1359,7 +1364,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 7, 1, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   15.0,
+				amount:   ice(15.0),
 				Amount:   "15.00",
 				Bonus:    -25.00,
 				Negative: false,
@@ -1368,7 +1373,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 7, 1, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   15.0,
+						amount:   ice(15.0),
 						Amount:   "15.00",
 						Bonus:    -25.00,
 						Negative: false,
@@ -1380,7 +1385,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 30, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   20.0,
+				amount:   ice(20.0),
 				Amount:   "20.00",
 				Bonus:    33.33,
 				Negative: false,
@@ -1389,7 +1394,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 30, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   20.0,
+						amount:   ice(20.0),
 						Amount:   "20.00",
 						Bonus:    33.33,
 						Negative: false,
@@ -1401,7 +1406,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 29, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   15.0,
+				amount:   ice(15.0),
 				Amount:   "15.00",
 				Bonus:    188.24,
 				Negative: false,
@@ -1410,7 +1415,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 29, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   15.0,
+						amount:   ice(15.0),
 						Amount:   "15.00",
 						Bonus:    188.24,
 						Negative: false,
@@ -1422,7 +1427,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 28, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   -17.0,
+				amount:   ice(-17.0),
 				Amount:   "17.00",
 				Bonus:    -168,
 				Negative: true,
@@ -1431,7 +1436,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 28, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -17.0,
+						amount:   ice(-17.0),
 						Amount:   "17.00",
 						Bonus:    -168,
 						Negative: true,
@@ -1443,7 +1448,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 27, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.0,
+				amount:   ice(25.0),
 				Amount:   "25.00",
 				Bonus:    -19.35,
 				Negative: false,
@@ -1452,7 +1457,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 27, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.0,
+						amount:   ice(25.0),
 						Amount:   "25.00",
 						Bonus:    -19.35,
 						Negative: false,
@@ -1464,7 +1469,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 26, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   31.0,
+				amount:   ice(31.0),
 				Amount:   "31.00",
 				Bonus:    -3.13,
 				Negative: false,
@@ -1473,7 +1478,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 26, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31.0,
+						amount:   ice(31.0),
 						Amount:   "31.00",
 						Bonus:    -3.13,
 						Negative: false,
@@ -1485,7 +1490,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 25, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   32.0,
+				amount:   ice(32.0),
 				Amount:   "32.00",
 				Bonus:    14.29,
 				Negative: false,
@@ -1494,7 +1499,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 25, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32.0,
+						amount:   ice(32.0),
 						Amount:   "32.00",
 						Bonus:    14.29,
 						Negative: false,
@@ -1506,7 +1511,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 24, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   28.0,
+				amount:   ice(28.0),
 				Amount:   "28.00",
 				Bonus:    -6.67,
 				Negative: false,
@@ -1515,7 +1520,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 24, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28.0,
+						amount:   ice(28.0),
 						Amount:   "28.00",
 						Bonus:    -6.67,
 						Negative: false,
@@ -1527,7 +1532,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 23, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   30.0,
+				amount:   ice(30.0),
 				Amount:   "30.00",
 				Bonus:    20,
 				Negative: false,
@@ -1536,7 +1541,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 23, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   30.0,
+						amount:   ice(30.0),
 						Amount:   "30.00",
 						Bonus:    20,
 						Negative: false,
@@ -1548,7 +1553,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 22, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.0,
+				amount:   ice(25.0),
 				Amount:   "25.00",
 				Bonus:    25,
 				Negative: false,
@@ -1557,7 +1562,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 22, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.0,
+						amount:   ice(25.0),
 						Amount:   "25.00",
 						Bonus:    25,
 						Negative: false,
@@ -1569,7 +1574,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 21, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   20.0,
+				amount:   ice(20.0),
 				Amount:   "20.00",
 				Bonus:    300,
 				Negative: false,
@@ -1578,7 +1583,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 21, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   20.0,
+						amount:   ice(20.0),
 						Amount:   "20.00",
 						Bonus:    300,
 						Negative: false,
@@ -1590,7 +1595,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 20, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   5.0,
+				amount:   ice(5.0),
 				Amount:   "5.00",
 				Bonus:    -66.67,
 				Negative: false,
@@ -1599,7 +1604,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 20, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   5.0,
+						amount:   ice(5.0),
 						Amount:   "5.00",
 						Bonus:    -66.67,
 						Negative: false,
@@ -1611,7 +1616,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 19, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   15.0,
+				amount:   ice(15.0),
 				Amount:   "15.00",
 				Bonus:    -0,
 				Negative: false,
@@ -1620,7 +1625,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 19, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   15.0,
+						amount:   ice(15.0),
 						Amount:   "15.00",
 						Bonus:    -0,
 						Negative: false,
@@ -1632,7 +1637,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 18, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   15.0,
+				amount:   ice(15.0),
 				Amount:   "15.00",
 				Bonus:    -0,
 				Negative: false,
@@ -1641,7 +1646,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 18, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   15.0,
+						amount:   ice(15.0),
 						Amount:   "15.00",
 						Bonus:    -0,
 						Negative: false,
@@ -1653,7 +1658,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 17, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   15.0,
+				amount:   ice(15.0),
 				Amount:   "15.00",
 				Bonus:    15.38,
 				Negative: false,
@@ -1662,7 +1667,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 17, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   15.0,
+						amount:   ice(15.0),
 						Amount:   "15.00",
 						Bonus:    15.38,
 						Negative: false,
@@ -1674,7 +1679,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 16, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   13.0,
+				amount:   ice(13.0),
 				Amount:   "13.00",
 				Bonus:    165,
 				Negative: false,
@@ -1683,7 +1688,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 16, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   13.0,
+						amount:   ice(13.0),
 						Amount:   "13.00",
 						Bonus:    165,
 						Negative: false,
@@ -1695,7 +1700,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 15, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   -20.0,
+				amount:   ice(-20.0),
 				Amount:   "20.00",
 				Bonus:    5.26,
 				Negative: true,
@@ -1704,7 +1709,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 15, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -20.0,
+						amount:   ice(-20.0),
 						Amount:   "20.00",
 						Bonus:    5.26,
 						Negative: true,
@@ -1716,7 +1721,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 14, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   -19.0,
+				amount:   ice(-19.0),
 				Amount:   "19.00",
 				Bonus:    280,
 				Negative: true,
@@ -1725,7 +1730,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 14, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -19.0,
+						amount:   ice(-19.0),
 						Amount:   "19.00",
 						Bonus:    280,
 						Negative: true,
@@ -1737,7 +1742,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 13, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   -5.0,
+				amount:   ice(-5.0),
 				Amount:   "5.00",
 				Bonus:    -117.24,
 				Negative: true,
@@ -1746,7 +1751,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 13, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -5.0,
+						amount:   ice(-5.0),
 						Amount:   "5.00",
 						Bonus:    -117.24,
 						Negative: true,
@@ -1758,7 +1763,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 12, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   29.0,
+				amount:   ice(29.0),
 				Amount:   "29.00",
 				Bonus:    141.67,
 				Negative: false,
@@ -1767,7 +1772,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 12, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   29.0,
+						amount:   ice(29.0),
 						Amount:   "29.00",
 						Bonus:    141.67,
 						Negative: false,
@@ -1779,7 +1784,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 11, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   12.0,
+				amount:   ice(12.0),
 				Amount:   "12.00",
 				Bonus:    -52,
 				Negative: false,
@@ -1788,7 +1793,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 11, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   12.0,
+						amount:   ice(12.0),
 						Amount:   "12.00",
 						Bonus:    -52,
 						Negative: false,
@@ -1800,7 +1805,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 10, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    -19.35,
 				Negative: false,
@@ -1809,7 +1814,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 10, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    -19.35,
 						Negative: false,
@@ -1821,7 +1826,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 9, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   31.0,
+				amount:   ice(31.0),
 				Amount:   "31.00",
 				Bonus:    -3.13,
 				Negative: false,
@@ -1830,7 +1835,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 9, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31.0,
+						amount:   ice(31.0),
 						Amount:   "31.00",
 						Bonus:    -3.13,
 						Negative: false,
@@ -1842,7 +1847,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 8, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   32.0,
+				amount:   ice(32.0),
 				Amount:   "32.00",
 				Bonus:    14.29,
 				Negative: false,
@@ -1851,7 +1856,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 8, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32.0,
+						amount:   ice(32.0),
 						Amount:   "32.00",
 						Bonus:    14.29,
 						Negative: false,
@@ -1863,7 +1868,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 7, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   28.0,
+				amount:   ice(28.0),
 				Amount:   "28.00",
 				Bonus:    12,
 				Negative: false,
@@ -1872,7 +1877,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 7, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28.0,
+						amount:   ice(28.0),
 						Amount:   "28.00",
 						Bonus:    12,
 						Negative: false,
@@ -1884,7 +1889,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.0,
+				amount:   ice(25.0),
 				Amount:   "25.00",
 				Bonus:    0,
 				Negative: false,
@@ -1893,7 +1898,7 @@ func TestProcessBalanceHistory_ChildIsHour_ProdLimits(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.0,
+						amount:   ice(25.0),
 						Amount:   "25.00",
 						Bonus:    0,
 						Negative: false,
@@ -1928,152 +1933,152 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 	history := []*dwh.BalanceHistory{
 		{
 			CreatedAt:           time.New(now.Add(1 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(2 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  28.,
+			BalanceTotalMinted:  ice(28.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(3 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  32.,
+			BalanceTotalMinted:  ice(32.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(4 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  31.,
+			BalanceTotalMinted:  ice(31.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(5 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(6 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  12.,
+			BalanceTotalMinted:  ice(12.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(7 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  29.,
+			BalanceTotalMinted:  ice(29.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(8 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 5.,
+			BalanceTotalSlashed: ice(5.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(9 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 19.,
+			BalanceTotalSlashed: ice(19.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(10 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 20.,
+			BalanceTotalSlashed: ice(20.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(11 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  13.,
+			BalanceTotalMinted:  ice(13.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(12 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(13 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(14 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(15 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  5.,
+			BalanceTotalMinted:  ice(5.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(16 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(17 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(18 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  30.,
+			BalanceTotalMinted:  ice(30.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(19 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  28.,
+			BalanceTotalMinted:  ice(28.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(20 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  32.,
+			BalanceTotalMinted:  ice(32.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(21 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  31.,
+			BalanceTotalMinted:  ice(31.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(22 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(23 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 17.,
+			BalanceTotalSlashed: ice(17.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(24 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(25 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(26 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(27 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  10.,
+			BalanceTotalMinted:  ice(10.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(28 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(29 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  10.,
+			BalanceTotalMinted:  ice(10.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(30 * repo.cfg.GlobalAggregationInterval.Child).Truncate(repo.cfg.GlobalAggregationInterval.Child)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 	}
@@ -2090,7 +2095,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   485,
+				amount:   ice(485),
 				Amount:   "485.00",
 				Bonus:    0,
 				Negative: false,
@@ -2099,7 +2104,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 25, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -20,
+						amount:   ice(-20),
 						Amount:   "20.00",
 						Bonus:    5.26,
 						Negative: true,
@@ -2109,7 +2114,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 24, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -19,
+						amount:   ice(-19),
 						Amount:   "19.00",
 						Bonus:    280,
 						Negative: true,
@@ -2119,7 +2124,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 23, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   -5,
+						amount:   ice(-5),
 						Amount:   "5.00",
 						Bonus:    -117.24,
 						Negative: true,
@@ -2129,7 +2134,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 22, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   29,
+						amount:   ice(29),
 						Amount:   "29.00",
 						Bonus:    141.67,
 						Negative: false,
@@ -2139,7 +2144,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 21, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   12,
+						amount:   ice(12),
 						Amount:   "12.00",
 						Bonus:    -52.0,
 						Negative: false,
@@ -2149,7 +2154,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 20, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    -19.35,
 						Negative: false,
@@ -2159,7 +2164,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 19, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31,
+						amount:   ice(31),
 						Amount:   "31.00",
 						Bonus:    -3.13,
 						Negative: false,
@@ -2169,7 +2174,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 18, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32,
+						amount:   ice(32),
 						Amount:   "32.00",
 						Bonus:    14.29,
 						Negative: false,
@@ -2179,7 +2184,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 17, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28,
+						amount:   ice(28),
 						Amount:   "28.00",
 						Bonus:    12.,
 						Negative: false,
@@ -2189,7 +2194,7 @@ func TestProcessBalanceHistory_Staging(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 5, 5, 16, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    0.,
 						Negative: false,
@@ -2223,152 +2228,152 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 	history := []*dwh.BalanceHistory{
 		{
 			CreatedAt:           time.New(now.Add(1 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(2 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  28.,
+			BalanceTotalMinted:  ice(28.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(3 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  32.,
+			BalanceTotalMinted:  ice(32.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(4 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  31.,
+			BalanceTotalMinted:  ice(31.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(5 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(6 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  12.,
+			BalanceTotalMinted:  ice(12.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(7 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  29.,
+			BalanceTotalMinted:  ice(29.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(8 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 5.,
+			BalanceTotalSlashed: ice(5.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(9 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 19.,
+			BalanceTotalSlashed: ice(19.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(10 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 20.,
+			BalanceTotalSlashed: ice(20.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(11 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  13.,
+			BalanceTotalMinted:  ice(13.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(12 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(13 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(14 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(15 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  5.,
+			BalanceTotalMinted:  ice(5.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(16 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(17 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(18 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  30.,
+			BalanceTotalMinted:  ice(30.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(19 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  28.,
+			BalanceTotalMinted:  ice(28.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(20 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  32.,
+			BalanceTotalMinted:  ice(32.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(21 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  31.,
+			BalanceTotalMinted:  ice(31.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(22 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  25.,
+			BalanceTotalMinted:  ice(25.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(23 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
 			BalanceTotalMinted:  0.,
-			BalanceTotalSlashed: 17.,
+			BalanceTotalSlashed: ice(17.),
 		},
 		{
 			CreatedAt:           time.New(now.Add(24 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(25 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(26 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  15.,
+			BalanceTotalMinted:  ice(15.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(27 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  10.,
+			BalanceTotalMinted:  ice(10.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(28 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(29 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  10.,
+			BalanceTotalMinted:  ice(10.),
 			BalanceTotalSlashed: 0.,
 		},
 		{
 			CreatedAt:           time.New(now.Add(30 * repo.cfg.GlobalAggregationInterval.Parent).Truncate(repo.cfg.GlobalAggregationInterval.Parent)),
-			BalanceTotalMinted:  20.,
+			BalanceTotalMinted:  ice(20.),
 			BalanceTotalSlashed: 0.,
 		},
 	}
@@ -2385,7 +2390,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    0,
 				Negative: false,
@@ -2394,7 +2399,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    0,
 						Negative: false,
@@ -2418,7 +2423,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 12, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   29.0,
+				amount:   ice(29.0),
 				Amount:   "29.00",
 				Bonus:    141.67,
 				Negative: false,
@@ -2427,7 +2432,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 12, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   29.0,
+						amount:   ice(29.0),
 						Amount:   "29.00",
 						Bonus:    141.67,
 						Negative: false,
@@ -2439,7 +2444,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 11, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   12.0,
+				amount:   ice(12.0),
 				Amount:   "12.00",
 				Bonus:    -52,
 				Negative: false,
@@ -2448,7 +2453,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 11, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   12.0,
+						amount:   ice(12.0),
 						Amount:   "12.00",
 						Bonus:    -52,
 						Negative: false,
@@ -2460,7 +2465,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 10, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25,
+				amount:   ice(25),
 				Amount:   "25.00",
 				Bonus:    -19.35,
 				Negative: false,
@@ -2469,7 +2474,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 10, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25,
+						amount:   ice(25),
 						Amount:   "25.00",
 						Bonus:    -19.35,
 						Negative: false,
@@ -2481,7 +2486,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 9, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   31.0,
+				amount:   ice(31.0),
 				Amount:   "31.00",
 				Bonus:    -3.13,
 				Negative: false,
@@ -2490,7 +2495,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 9, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   31.0,
+						amount:   ice(31.0),
 						Amount:   "31.00",
 						Bonus:    -3.13,
 						Negative: false,
@@ -2502,7 +2507,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 8, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   32.0,
+				amount:   ice(32.0),
 				Amount:   "32.00",
 				Bonus:    14.29,
 				Negative: false,
@@ -2511,7 +2516,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 8, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   32.0,
+						amount:   ice(32.0),
 						Amount:   "32.00",
 						Bonus:    14.29,
 						Negative: false,
@@ -2523,7 +2528,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 7, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   28.0,
+				amount:   ice(28.0),
 				Amount:   "28.00",
 				Bonus:    12,
 				Negative: false,
@@ -2532,7 +2537,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 7, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   28.0,
+						amount:   ice(28.0),
 						Amount:   "28.00",
 						Bonus:    12,
 						Negative: false,
@@ -2544,7 +2549,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 		{
 			Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 			Balance: &BalanceHistoryBalanceDiff{
-				amount:   25.0,
+				amount:   ice(25.0),
 				Amount:   "25.00",
 				Bonus:    0,
 				Negative: false,
@@ -2553,7 +2558,7 @@ func TestProcessBalanceHistory_ChildIsHour_Location(t *testing.T) {
 				{
 					Time: *time.New(stdlibtime.Date(2023, 6, 6, 0, 0, 0, 0, location)).Time,
 					Balance: &BalanceHistoryBalanceDiff{
-						amount:   25.0,
+						amount:   ice(25.0),
 						Amount:   "25.00",
 						Bonus:    0,
 						Negative: false,
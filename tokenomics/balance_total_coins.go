@@ -8,7 +8,6 @@ import (
 	"math/rand"
 	"net/http"
 	"strings"
-	"sync/atomic"
 	stdlibtime "time"
 
 	"github.com/alitto/pond"
@@ -351,7 +350,6 @@ func (r *repository) enhanceWithBlockchainCoinStats(res *TotalCoinsSummary) *Tot
 func (r *repository) startBlockchainCoinStatsJSONSyncer(ctx context.Context) {
 	ticker := stdlibtime.NewTicker(10 * stdlibtime.Minute) //nolint:gomnd // .
 	defer ticker.Stop()
-	r.cfg.blockchainCoinStatsJSON = new(atomic.Pointer[blockchainCoinStatsJSON])
 	log.Panic(errors.Wrap(r.syncBlockchainCoinStatsJSON(ctx), "failed to syncBlockchainCoinStatsJSON"))
 
 	for {
@@ -14,7 +14,9 @@ import (
 
 	"github.com/ice-blockchain/eskimo/users"
 	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
 	extrabonusnotifier "github.com/ice-blockchain/freezer/extra-bonus-notifier"
+	"github.com/ice-blockchain/freezer/model"
 	detailedCoinMetrics "github.com/ice-blockchain/freezer/tokenomics/detailed_coin_metrics"
 	messagebroker "github.com/ice-blockchain/wintr/connectors/message_broker"
 	storagev2 "github.com/ice-blockchain/wintr/connectors/storage/v2"
@@ -34,6 +36,12 @@ const (
 	NegativeMiningRateType MiningRateType = "negative"
 	NoneMiningRateType     MiningRateType = "none"
 )
+const (
+	DailyTopMinersPeriod   TopMinersPeriod = "daily"
+	WeeklyTopMinersPeriod  TopMinersPeriod = "weekly"
+	MonthlyTopMinersPeriod TopMinersPeriod = "monthly"
+	AllTimeTopMinersPeriod TopMinersPeriod = "all-time"
+)
 const (
 	ArbitrumBlockchainNetworkType BlockchainNetworkType = "arbitrum"
 	BNBBlockchainNetworkType      BlockchainNetworkType = "bnb"
@@ -51,6 +59,7 @@ var (
 	ErrRaceCondition                                   = errors.New("race condition")
 	ErrGlobalRankHidden                                = errors.New("global rank is hidden")
 	ErrDecreasingPreStakingAllocationOrYearsNotAllowed = errors.New("decreasing pre-staking allocation or years not allowed")
+	ErrUnknownTenant                                   = errors.New("unknown tenant")
 	PreStakingBonusesPerYear                           = map[uint8]float64{
 		0: 0,
 		1: 35,
@@ -88,13 +97,14 @@ type (
 		CurrentLevelIndex *uint8              `json:"currentLevelIndex,omitempty" example:"0"`
 		Levels            []*MiningBoostLevel `json:"levels"`
 	}
-	MiningRateType string
-	Miner          struct {
+	MiningRateType  string
+	TopMinersPeriod string
+	Miner           struct {
 		Balance           string `json:"balance,omitempty" example:"12345.6334"`
 		UserID            string `json:"userId,omitempty" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
 		Username          string `json:"username,omitempty" example:"jdoe"`
 		ProfilePictureURL string `json:"profilePictureUrl,omitempty" example:"https://somecdn.com/p1.jpg"`
-		balance           float64
+		balance           model.Amount
 	}
 	BalanceSummary struct {
 		Balances[string]
@@ -114,10 +124,10 @@ type (
 		miningBlockchainAccountAddress     string
 	}
 	BalanceHistoryBalanceDiff struct {
-		Amount   string  `json:"amount" example:"1,243.02"`
-		amount   float64 //nolint:revive // That's intended.
-		Bonus    float64 `json:"bonus" example:"120.00"`
-		Negative bool    `json:"negative" example:"true"`
+		Amount   string       `json:"amount" example:"1,243.02"`
+		amount   model.Amount //nolint:revive // That's intended.
+		Bonus    float64      `json:"bonus" example:"120.00"`
+		Negative bool         `json:"negative" example:"true"`
 	}
 	BalanceHistoryEntry struct {
 		Time       stdlibtime.Time            `json:"time" swaggertype:"string" example:"2022-01-03T16:20:52.156534Z"`
@@ -217,6 +227,79 @@ type (
 	RankingSummary struct {
 		GlobalRank uint64 `json:"globalRank" example:"12333"`
 	}
+	UserDebugSummary struct {
+		User                               *model.User               `json:"user"`
+		CreatedAt                          *time.Time                `json:"createdAt,omitempty" example:"2022-01-03T16:20:52.156534Z"`
+		MiningBoostLevelIndex              *model.FlexibleUint64     `json:"miningBoostLevelIndex,omitempty" swaggertype:"integer" example:"1"`
+		T0                                 *UserDebugSummaryReferral `json:"t0,omitempty"`
+		TMinus1                            *UserDebugSummaryReferral `json:"tMinus1,omitempty"`
+		NextKYCStep                        *users.KYCStep            `json:"nextKycStep,omitempty" example:"3"`
+		LatestDevice                       string                    `json:"latestDevice,omitempty" example:"android:1.2.3"`
+		MaxMiningSessionDuration           string                    `json:"maxMiningSessionDuration" example:"24h0m0s"`
+		ID                                 int64                     `json:"id" example:"123"`
+		BaseMiningRate                     float64                   `json:"baseMiningRate" example:"16"`
+		NegativeMiningRate                 float64                   `json:"negativeMiningRate" example:"0.5"`
+		EthereumDistributionBalance        float64                   `json:"ethereumDistributionBalance" example:"100.5"`
+		MiningStreak                       uint64                    `json:"miningStreak" example:"2"`
+		RemainingFreeMiningSessions        uint64                    `json:"remainingFreeMiningSessions" example:"1"`
+		Mining                             bool                      `json:"mining" example:"true"`
+		Slashing                           bool                      `json:"slashing" example:"false"`
+		SlashingDisabled                   bool                      `json:"slashingDisabled" example:"false"`
+		EligibleForEthereumDistribution    bool                      `json:"eligibleForEthereumDistribution" example:"true"`
+		EligibleForEthereumDistributionNow bool                      `json:"eligibleForEthereumDistributionNow" example:"false"`
+	}
+	UserDebugSummaryReferral struct {
+		UserID   string `json:"userId,omitempty" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		Username string `json:"username,omitempty" example:"jdoe"`
+		ID       int64  `json:"id" example:"123"`
+		// Negative IDs are the ones the miner hasn't processed yet.
+		Confirmed bool `json:"confirmed" example:"true"`
+		Mining    bool `json:"mining" example:"true"`
+	}
+	LedgerEntry struct {
+		Time *time.Time `json:"time" swaggertype:"string" example:"2022-01-03T16:20:52.156534Z"`
+		// One of `mining`, `slashing`, `welcome_bonus`, `t1_pending`, `t2_pending`, `resurrection`, `ethereum_distribution`, `completed_tasks`.
+		Reason string `json:"reason" example:"completed_tasks"`
+		// The message, user or day that caused the balance mutation.
+		Source string  `json:"source,omitempty" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		Amount float64 `json:"amount" example:"-1.5"`
+	}
+	Ledger struct {
+		Entries []*LedgerEntry `json:"entries"`
+		// Pass it back to get the next (older) page. It's 0 if there's nothing left.
+		Cursor uint64 `json:"cursor" example:"1641226852156534000"`
+	}
+	TeamReferral struct {
+		UserID            string `json:"userId" example:"did:ethr:0x4B73C58370AEfcEf86A6021afCDe5673511376B2"`
+		Username          string `json:"username,omitempty" example:"jdoe"`
+		ProfilePictureURL string `json:"profilePictureUrl,omitempty" example:"https://somecdn.com/p1.jpg"`
+		// How much the referral earned for the user so far.
+		Contribution string `json:"contribution" example:"1234.12"`
+		// The rate at which that contribution is currently being slashed. It's 0 if the referral is not slashed.
+		SlashingRate string `json:"slashingRate" example:"1.23"`
+	}
+	TeamEarningsEntry struct {
+		Date *time.Time `json:"date" swaggertype:"string" example:"2022-01-03T00:00:00Z"`
+		T1   string     `json:"t1" example:"12.34"`
+		T2   string     `json:"t2" example:"1.23"`
+	}
+	TeamSummary struct {
+		// How many T1 referrals actually count for the user's mining rate, capped by its mining boost level.
+		ActiveT1Referrals int32 `json:"activeT1Referrals" example:"5"`
+		ActiveT2Referrals int32 `json:"activeT2Referrals" example:"10"`
+		TotalT1Referrals  int32 `json:"totalT1Referrals" example:"11"`
+		TotalT2Referrals  int32 `json:"totalT2Referrals" example:"22"`
+		// The T1 referrals cap of the user's mining boost level. It's missing if there's no cap.
+		MaxT1Referrals *uint64 `json:"maxT1Referrals,omitempty" example:"20"`
+		T1             string  `json:"t1" example:"1234.12"`
+		T2             string  `json:"t2" example:"123.12"`
+		// What the team earned the user each day, oldest first.
+		Earnings            []*TeamEarningsEntry `json:"earnings"`
+		TopT1Referrals      []*TeamReferral      `json:"topT1Referrals"`
+		TopT2Referrals      []*TeamReferral      `json:"topT2Referrals"`
+		SlashingT1Referrals []*TeamReferral      `json:"slashingT1Referrals"`
+		SlashingT2Referrals []*TeamReferral      `json:"slashingT2Referrals"`
+	}
 	IceStats struct {
 		CirculatingSupply     float64 `json:"circulatingSupply"`
 		TotalSupply           float64 `json:"totalSupply"`
@@ -225,16 +308,35 @@ type (
 		TradingVolume24       float64 `json:"24hTradingVolume"`
 		FullyDilutedMarketCap float64 `json:"fullyDilutedMarketCap"`
 	}
+	// Capabilities are the features that are turned on/off for a tenant.
+	Capabilities struct {
+		// The mining phase is over, so balances are not mined, slashed or moved between referrals anymore; they're only distributed.
+		DistributionMode             bool `yaml:"distributionMode" mapstructure:"distributionMode"`
+		MiningDisabled               bool `yaml:"miningDisabled" mapstructure:"miningDisabled"`
+		MiningBoostDisabled          bool `yaml:"miningBoostDisabled" mapstructure:"miningBoostDisabled"`
+		MiningRatesHidden            bool `yaml:"miningRatesHidden" mapstructure:"miningRatesHidden"`
+		CompletedTasksPrizesDisabled bool `yaml:"completedTasksPrizesDisabled" mapstructure:"completedTasksPrizesDisabled"`
+	}
 	ReadRepository interface {
 		GetMiningBoostSummary(ctx context.Context, userID string) (*MiningBoostSummary, error)
 		GetBalanceSummary(ctx context.Context, userID string) (*BalanceSummary, error)
 		GetTotalCoinsSummary(ctx context.Context, days uint64, utcOffset stdlibtime.Duration) (*TotalCoinsSummary, error)
 		GetRankingSummary(ctx context.Context, userID string) (*RankingSummary, error)
 		GetTopMiners(ctx context.Context, keyword string, limit, offset uint64) (topMiners []*Miner, nextOffset uint64, err error)
+		GetHistoricalTopMiners(ctx context.Context, period TopMinersPeriod, country string, limit, offset uint64) (topMiners []*Miner, nextOffset uint64, err error) //nolint:lll // .
 		GetMiningSummary(ctx context.Context, userID string) (*MiningSummary, error)
 		GetPreStakingSummary(ctx context.Context, userID string) (*PreStakingSummary, error)
 		GetBalanceHistory(ctx context.Context, userID string, start, end *time.Time, utcOffset stdlibtime.Duration, limit, offset uint64) ([]*BalanceHistoryEntry, error) //nolint:lll // .
 		GetAdoptionSummary(ctx context.Context, userID string) (*AdoptionSummary, error)
+		GetUserDebugSummary(ctx context.Context, userID string, collectorSettings *coindistribution.CollectorSettings) (*UserDebugSummary, error)
+		GetLedger(ctx context.Context, userID string, cursor, limit uint64) (*Ledger, error)
+		GetTeamSummary(ctx context.Context, userID string, days uint64) (*TeamSummary, error)
+		TenantCapabilities(ctx context.Context) *Capabilities
+		TenantResolver
+	}
+	// TenantResolver resolves the requests to a known tenant (see TenantHandler).
+	TenantResolver interface {
+		ContextWithTenant(ctx context.Context, tenant string) (context.Context, error)
 	}
 	WriteRepository interface {
 		StartNewMiningSession(ctx context.Context, ms *MiningSummary, rollbackNegativeMiningProgress *bool, skipKYCSteps []users.KYCStep) error
@@ -267,7 +369,12 @@ const (
 	userHashCodeCtxValueKey             = "userHashCodeCtxValueKey"
 	authorizationCtxValueKey            = "authorizationCtxValueKey"
 	xAccountMetadataCtxValueKey         = "xAccountMetadataCtxValueKey"
+	tenantCtxValueKey                   = "tenantCtxValueKey"
+	tenantClaim                         = "tenant"
+	tenantMessageHeader                 = "tenant"
 	requestDeadline                     = 25 * stdlibtime.Second
+	historicalTopMinersCacheDuration    = 10 * stdlibtime.Minute
+	teamSummaryReferralsLimit           = 10
 
 	floatToStringFormatter = "%.2f"
 
@@ -280,8 +387,6 @@ const (
 	totalCoinStatsDetailsLockDuration = 1 * stdlibtime.Minute
 	totalCoinStatsDetailsKey          = "totalCoinStatsDetailsData"
 	miningBoostPricePrecision         = 4 // 4 digits after floating point.
-
-	doctorXTenant = "doctorx"
 )
 
 type (
@@ -316,6 +421,7 @@ type (
 		dwh                               dwh.Client
 		mb                                messagebroker.Client
 		pictureClient                     picture.Client
+		tenantDBs                         map[string]storage.DB
 	}
 
 	processor struct {
@@ -373,6 +479,7 @@ type (
 		} `json:"coinsAddedHistory"`
 	}
 	Config struct {
+		tenants                 map[string]*Config
 		disableAdvancedTeam     *atomic.Pointer[[]string]
 		kycConfigJSON           *atomic.Pointer[kycConfigJSON]
 		blockchainCoinStatsJSON *atomic.Pointer[blockchainCoinStatsJSON]
@@ -433,13 +540,22 @@ type (
 			T1 uint32 `yaml:"t1"`
 			T2 uint32 `yaml:"t2"`
 		} `yaml:"referralBonusMiningRates"`
-		AdminUsers                                   []string `yaml:"adminUsers" mapstructure:"adminUsers"`
-		Tenant                                       string   `yaml:"tenant"`
-		DefaultReferralName                          string   `yaml:"defaultReferralName"`
-		SlashingFloor                                float64  `yaml:"slashingFloor" mapstructure:"slashingFloor"`
-		WelcomeBonusV2Amount                         float64  `yaml:"welcomeBonusV2Amount" mapstructure:"welcomeBonusV2Amount"`
-		T1ReferralsAllowedWithoutAnyMiningBoostLevel bool     `yaml:"t1ReferralsAllowedWithoutAnyMiningBoostLevel" mapstructure:"t1ReferralsAllowedWithoutAnyMiningBoostLevel"`
-		TasksV2Enabled                               bool     `yaml:"tasksV2Enabled" mapstructure:"tasksV2Enabled"`
+		// Per tenant overlays of this configuration, applied to the requests resolved to that tenant (see TenantHandler)
+		// and, for the tenant of the deployment (see Tenant), to everything else (see MustLoadConfig).
+		Tenants                                      map[string]any `yaml:"tenants" mapstructure:"tenants"`
+		Capabilities                                 Capabilities   `yaml:"capabilities" mapstructure:"capabilities"`
+		AdminUsers                                   []string       `yaml:"adminUsers" mapstructure:"adminUsers"`
+		Tenant                                       string         `yaml:"tenant"`
+		RedisKeyPrefix                               string         `yaml:"redisKeyPrefix" mapstructure:"redisKeyPrefix"`
+		DefaultReferralName                          string         `yaml:"defaultReferralName"`
+		SlashingFloor                                float64        `yaml:"slashingFloor" mapstructure:"slashingFloor"`
+		WelcomeBonusV2Amount                         float64        `yaml:"welcomeBonusV2Amount" mapstructure:"welcomeBonusV2Amount"`
+		T1ReferralsAllowedWithoutAnyMiningBoostLevel bool           `yaml:"t1ReferralsAllowedWithoutAnyMiningBoostLevel" mapstructure:"t1ReferralsAllowedWithoutAnyMiningBoostLevel"`
+		TasksV2Enabled                               bool           `yaml:"tasksV2Enabled" mapstructure:"tasksV2Enabled"`
+		EthereumDistributionFrequency                struct {
+			Min stdlibtime.Duration `yaml:"min"`
+			Max stdlibtime.Duration `yaml:"max"`
+		} `yaml:"ethereumDistributionFrequency" mapstructure:"ethereumDistributionFrequency"`
 	}
 )
 
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package tokenomics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/eskimo/users"
+	coindistribution "github.com/ice-blockchain/freezer/coin-distribution"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/terror"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+//nolint:funlen // A lot of derived values.
+func (r *repository) GetUserDebugSummary(
+	ctx context.Context, userID string, collectorSettings *coindistribution.CollectorSettings,
+) (*UserDebugSummary, error) {
+	r = r.forTenant(ctx)
+	id, err := GetInternalID(ctx, r.db, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to getInternalID for userID:%v", userID)
+	}
+	usr, err := storage.Get[struct {
+		model.User
+		model.CreatedAtField
+		model.MiningBoostLevelIndexField
+		model.LatestDeviceField
+	}](ctx, r.db, model.SerializedUsersKey(id))
+	if err != nil || len(usr) == 0 {
+		if err == nil {
+			err = errors.Wrapf(ErrRelationNotFound, "missing state for id:%v", id)
+		}
+
+		return nil, errors.Wrapf(err, "failed to get debug state for id:%v", id)
+	}
+	now := time.Now()
+	state := usr[0]
+	state.ID = id
+	// The index can be stale, if the levels were reconfigured since, so it's checked instead of trusted.
+	levels, boostLevel := *r.cfg.MiningBoost.levels.Load(), state.MiningBoostLevelIndexField
+	if boostLevel.MiningBoostLevelIndex != nil && uint64(*boostLevel.MiningBoostLevelIndex) >= uint64(len(levels)) {
+		log.Warn(fmt.Sprintf("mining boost level index %v out of range [0,%v) for id:%v", *boostLevel.MiningBoostLevelIndex, len(levels), id))
+		boostLevel.MiningBoostLevelIndex = nil
+	}
+	maxMiningSessionDuration := r.cfg.maxMiningSessionDuration(boostLevel)
+	summary := &UserDebugSummary{
+		User:                        &state.User,
+		CreatedAt:                   state.CreatedAt,
+		MiningBoostLevelIndex:       state.MiningBoostLevelIndex,
+		LatestDevice:                state.LatestDevice,
+		MaxMiningSessionDuration:    maxMiningSessionDuration.String(),
+		ID:                          id,
+		BaseMiningRate:              r.cfg.BaseMiningRate(now, state.CreatedAt),
+		NegativeMiningRate:          (state.SlashingRateSolo + state.SlashingRateT0 + state.SlashingRateT1 + state.SlashingRateT2).Float64(),
+		MiningStreak:                r.calculateMiningStreak(now, state.MiningSessionSoloStartedAt, state.MiningSessionSoloEndedAt),
+		RemainingFreeMiningSessions: r.calculateRemainingFreeMiningSessions(now, state.MiningSessionSoloLastStartedAt, state.MiningSessionSoloEndedAt, maxMiningSessionDuration), //nolint:lll // .
+		Mining:                      !state.MiningSessionSoloEndedAt.IsNil() && state.MiningSessionSoloEndedAt.After(*now.Time),
+		SlashingDisabled:            (state.BalanceSolo+state.BalanceT0+state.BalanceT1+state.BalanceT2) <= r.cfg.SlashingFloorAmount() || (boostLevel.MiningBoostLevelIndex != nil && levels[*boostLevel.MiningBoostLevelIndex].SlashingDisabled), //nolint:lll // .
+	}
+	summary.Slashing = summary.NegativeMiningRate > 0 && !summary.SlashingDisabled
+	if summary.T0, err = r.getUserDebugSummaryReferral(ctx, state.IDT0, now); err != nil {
+		return nil, errors.Wrapf(err, "failed to get t0 for id:%v", id)
+	}
+	if r.isAdvancedTeamEnabled(state.LatestDevice) {
+		if summary.TMinus1, err = r.getUserDebugSummaryReferral(ctx, state.IDTMinus1, now); err != nil {
+			return nil, errors.Wrapf(err, "failed to get t-1 for id:%v", id)
+		}
+	}
+	summary.NextKYCStep = r.nextKYCStep(ctx, &getCurrentMiningSession{
+		StartOrExtendMiningSession: StartOrExtendMiningSession{
+			MiningSessionSoloLastStartedAtField: state.MiningSessionSoloLastStartedAtField,
+			DeserializedUsersKey:                state.DeserializedUsersKey,
+		},
+		KYCState:          state.KYCState,
+		LatestDeviceField: state.LatestDeviceField,
+		UserIDField:       state.UserIDField,
+	})
+	if collectorSettings != nil && !collectorSettings.EndDate.IsNil() {
+		miningBlockchainAccountAddress := state.MiningBlockchainAccountAddress
+		if !state.KYCStepPassedCorrectly(users.QuizKYCStep) {
+			miningBlockchainAccountAddress = coindistribution.SkipEthereumAddressValidation
+		}
+		standardBalance := state.BalanceTotalStandard + state.BalanceTotalPreStaking
+		standardBalance -= state.BalanceSoloEthereum + state.BalanceT0Ethereum + state.BalanceT1Ethereum + state.BalanceT2Ethereum
+		summary.EthereumDistributionBalance = coindistribution.CalculateEthereumDistributionICEBalance(standardBalance, r.cfg.EthereumDistributionFrequency.Min, r.cfg.EthereumDistributionFrequency.Max, now, collectorSettings.EndDate).Float64() //nolint:lll // .
+		summary.EligibleForEthereumDistribution = coindistribution.IsEligibleForEthereumDistribution(
+			collectorSettings.MinMiningStreaksRequired,
+			standardBalance,
+			collectorSettings.MinBalanceRequired,
+			miningBlockchainAccountAddress,
+			state.Country,
+			collectorSettings.DeniedCountries,
+			now,
+			now,
+			state.MiningSessionSoloStartedAt,
+			state.MiningSessionSoloEndedAt,
+			collectorSettings.EndDate,
+			r.cfg.MiningSessionDuration.Max,
+			r.cfg.EthereumDistributionFrequency.Min,
+			r.cfg.EthereumDistributionFrequency.Max)
+		summary.EligibleForEthereumDistributionNow = !collectorSettings.StartDate.IsNil() && coindistribution.IsEligibleForEthereumDistributionNow(
+			id,
+			now,
+			state.SoloLastEthereumCoinDistributionProcessedAt,
+			collectorSettings.StartDate,
+			collectorSettings.LatestDate,
+			r.cfg.EthereumDistributionFrequency.Min,
+			r.cfg.EthereumDistributionFrequency.Max)
+	}
+
+	return summary, nil
+}
+
+func (r *repository) getUserDebugSummaryReferral(ctx context.Context, id int64, now *time.Time) (*UserDebugSummaryReferral, error) {
+	if id == 0 {
+		return nil, nil //nolint:nilnil // Nope.
+	}
+	ref := &UserDebugSummaryReferral{ID: id, Confirmed: id > 0}
+	if id < 0 {
+		id *= -1
+	}
+	res, err := storage.Get[struct {
+		model.UserIDField
+		model.UsernameField
+		model.MiningSessionSoloEndedAtField
+	}](ctx, r.db, model.SerializedUsersKey(id))
+	if err != nil || len(res) == 0 {
+		return ref, errors.Wrapf(err, "failed to get referral state for id:%v", id)
+	}
+	ref.UserID = res[0].UserID
+	ref.Username = res[0].Username
+	ref.Mining = !res[0].MiningSessionSoloEndedAt.IsNil() && res[0].MiningSessionSoloEndedAt.After(*now.Time)
+
+	return ref, nil
+}
+
+// Same as what validateKYC does to figure out which step the user would be forwarded to on the next mining session start.
+func (r *repository) nextKYCStep(ctx context.Context, state *getCurrentMiningSession) *users.KYCStep {
+	if r.cfg.kycConfigJSON == nil || r.cfg.kycConfigJSON.Load() == nil || r.livenessLoadDistributionStartDate.IsNil() {
+		return nil
+	}
+	tErr := terror.As(r.checkNextKYCStep(ctx, state, true))
+	if tErr == nil {
+		return nil
+	}
+	if steps, ok := tErr.Data["kycSteps"].([]users.KYCStep); ok && len(steps) > 0 {
+		return &steps[0]
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@ type (
 )
 
 func (r *repository) ClaimExtraBonus(ctx context.Context, ebs *ExtraBonusSummary) error {
+	r = r.forTenant(ctx)
 	if ctx.Err() != nil {
 		return errors.Wrap(ctx.Err(), "unexpected deadline")
 	}
@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"sync/atomic"
 	stdlibtime "time"
 
 	"github.com/goccy/go-json"
@@ -34,7 +33,6 @@ func init() { //nolint:gochecknoinits // It's the only way to tweak the client.
 func (r *repository) startKYCConfigJSONSyncer(ctx context.Context) {
 	ticker := stdlibtime.NewTicker(stdlibtime.Minute) //nolint:gosec,gomnd // Not an  issue.
 	defer ticker.Stop()
-	r.cfg.kycConfigJSON = new(atomic.Pointer[kycConfigJSON])
 	log.Panic(errors.Wrap(r.syncKYCConfigJSON(ctx), "failed to syncKYCConfigJSON"))
 
 	for {
@@ -553,7 +551,7 @@ func (r *repository) overrideKYCStateWithEskimoKYCState(ctx context.Context, use
 			usr.ProfilePictureName = r.pictureClient.StripDownloadURL(usr.ProfilePictureName)
 			// We cant reset it to proper value in miner cuz we have active users who has zero total refs in state
 			// until they start next mining, we'll lose pending value in such case.
-			usr.BalanceT1WelcomeBonusPending = float64(usr.TotalT1Referrals) * r.cfg.WelcomeBonusV2Amount
+			usr.BalanceT1WelcomeBonusPending = model.Amount(usr.TotalT1Referrals) * r.cfg.WelcomeBonusV2()
 			return usr.KycFaceAvailable, multierror.Append(
 				errors.Wrapf(r.updateUsernameKeywords(ctx, state.ID, state.Username, usr.Username), "failed to updateUsernameKeywords for oldUser:%#v, user:%#v", state, usr),                         //nolint:lll // .
 				errors.Wrapf(r.updateReferredBy(ctx, state.ID, &state.IDT0, &state.IDTMinus1, state.UserID, usr.ReferredBy, state.BalanceForTMinus1), "failed to updateReferredBy for user:%#v", usr), //nolint:lll // .
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package tokenomics
+
+import (
+	"context"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+func (r *repository) GetLedger(ctx context.Context, userID string, cursor, limit uint64) (*Ledger, error) {
+	r = r.forTenant(ctx)
+	id, err := GetInternalID(ctx, r.db, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to getInternalID for userID:%v", userID)
+	}
+	var before stdlibtime.Time
+	if cursor != 0 {
+		before = stdlibtime.Unix(0, int64(cursor)).UTC()
+	}
+	entries, err := r.dwh.SelectLedger(ctx, id, before, limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to SelectLedger for id:%v,cursor:%v,limit:%v", id, cursor, limit)
+	}
+	ledger := &Ledger{Entries: make([]*LedgerEntry, 0, len(entries))}
+	for _, entry := range entries {
+		ledger.Entries = append(ledger.Entries, &LedgerEntry{
+			Time:   entry.CreatedAt,
+			Reason: string(entry.Reason),
+			Source: entry.Source,
+			Amount: entry.Amount.Float64(),
+		})
+	}
+	if uint64(len(entries)) >= limit && len(entries) > 0 {
+		ledger.Cursor = uint64(entries[len(entries)-1].CreatedAt.UnixNano())
+	}
+
+	return ledger, nil
+}
+
+func newLedgerEntry(id int64, userID string, reason dwh.LedgerReason, source string, amount model.Amount) *dwh.LedgerEntry {
+	if id < 0 {
+		id *= -1
+	}
+
+	return &dwh.LedgerEntry{
+		CreatedAt: time.Now(),
+		Reason:    reason,
+		Source:    source,
+		UserID:    userID,
+		ID:        id,
+		Amount:    amount,
+	}
+}
+
+// The balance mutations are already persisted by the time we get here, so, if the entries can't be inserted,
+// they're postponed, for the miner to insert them later on.
+func (r *repository) insertLedgerEntries(ctx context.Context, entries ...*dwh.LedgerEntry) error {
+	if err := r.dwh.InsertLedger(ctx, entries); err != nil {
+		log.Error(errors.Wrapf(err, "failed to insert %v ledger entries, postponing them", len(entries)))
+
+		return errors.Wrapf(dwh.PostponeLedger(ctx, r.db, entries), "failed to postpone %v ledger entries", len(entries))
+	}
+
+	return nil
+}
+
+func (r *repository) getLedgerUserID(ctx context.Context, id int64) (string, error) {
+	if id < 0 {
+		id *= -1
+	}
+	res, err := storage.Get[struct{ model.UserIDField }](ctx, r.db, model.SerializedUsersKey(id))
+	if err != nil || len(res) == 0 {
+		if err == nil {
+			err = errors.Wrapf(ErrRelationNotFound, "missing state for id:%v", id)
+		}
+
+		return "", errors.Wrapf(err, "failed to get the userID for id:%v", id)
+	}
+
+	return res[0].UserID, nil
+}
@@ -11,15 +11,19 @@ import (
 	"strings"
 	stdlibtime "time"
 
+	"github.com/goccy/go-json"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
 	"github.com/ice-blockchain/freezer/model"
 	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/log"
 	"github.com/ice-blockchain/wintr/time"
 )
 
 func (r *repository) GetRankingSummary(ctx context.Context, userID string) (*RankingSummary, error) { //nolint:funlen // .
+	r = r.forTenant(ctx)
 	id, err := GetOrInitInternalID(ctx, r.db, userID, r.cfg.WelcomeBonusV2Amount)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to getOrInitInternalID for userID:%v", userID)
@@ -68,6 +72,7 @@ var everythingNotAllowedInUsernamePattern = regexp.MustCompile(everythingNotAllo
 
 //nolint:funlen // .
 func (r *repository) GetTopMiners(ctx context.Context, keyword string, limit, offset uint64) (topMiners []*Miner, nextOffset uint64, err error) {
+	r = r.forTenant(ctx)
 	var (
 		ids           []string
 		sortTopMiners func(int, int) bool
@@ -136,7 +141,7 @@ func (r *repository) GetTopMiners(ctx context.Context, keyword string, limit, of
 				total = 0
 			}
 			topMiners = append(topMiners, &Miner{
-				Balance:           fmt.Sprintf(floatToStringFormatter, total),
+				Balance:           formatAmount(total),
 				balance:           total,
 				UserID:            topMiner.UserID,
 				Username:          topMiner.Username,
@@ -158,8 +163,147 @@ func (r *repository) GetTopMiners(ctx context.Context, keyword string, limit, of
 	return topMiners, nextOffset, nil
 }
 
+// GetHistoricalTopMiners returns the leaderboard built from the balance history, optionally for a single country.
+// For periods other than all-time, the users are ranked by how much they minted in that period,
+// otherwise they're ranked by their latest balance.
+//
+//nolint:funlen // .
+func (r *repository) GetHistoricalTopMiners(
+	ctx context.Context, period TopMinersPeriod, country string, limit, offset uint64,
+) (topMiners []*Miner, nextOffset uint64, err error) {
+	r = r.forTenant(ctx)
+	since, err := r.topMinersPeriodStart(period, time.Now())
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid period:%v", period)
+	}
+	rows, err := r.getCachedHistoricalTopMiners(ctx, period, country, limit, offset)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get cached historical top miners for period:%v,country:%v,offset:%v,limit:%v", period, country, offset, limit) //nolint:lll // .
+	}
+	if rows == nil {
+		if rows, err = r.dwh.SelectTopMiners(ctx, since, country, limit, offset); err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to SelectTopMiners for period:%v,country:%v,offset:%v,limit:%v", period, country, offset, limit)
+		}
+		if err = r.cacheHistoricalTopMiners(ctx, period, country, limit, offset, rows); err != nil {
+			log.Error(errors.Wrapf(err, "failed to cache historical top miners for period:%v,country:%v,offset:%v,limit:%v", period, country, offset, limit))
+		}
+	}
+	if uint64(len(rows)) == limit {
+		nextOffset = offset + limit
+	}
+	topMiners = make([]*Miner, 0, len(rows))
+	if len(rows) == 0 {
+		return topMiners, nextOffset, nil
+	}
+	keys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, model.SerializedUsersKey(row.ID))
+	}
+	// The ranking preferences and the profile details are always read from the source of truth, so they're never stale.
+	type miner struct {
+		model.DeserializedUsersKey
+		model.UserIDField
+		model.UsernameField
+		model.ProfilePictureNameField
+		model.HideRankingField
+	}
+	usrs, err := storage.Get[miner](ctx, r.db, keys...)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get miners for ids:%#v", keys)
+	}
+	usrsByID := make(map[int64]*miner, len(usrs))
+	for _, usr := range usrs {
+		usrsByID[usr.ID] = usr
+	}
+	for _, row := range rows {
+		usr, found := usrsByID[row.ID]
+		if !found || usr.HideRanking {
+			continue
+		}
+		topMiners = append(topMiners, &Miner{
+			Balance:           formatAmount(row.Amount),
+			balance:           row.Amount,
+			UserID:            usr.UserID,
+			Username:          usr.Username,
+			ProfilePictureURL: r.pictureClient.DownloadURL(usr.ProfilePictureName),
+		})
+	}
+
+	return topMiners, nextOffset, nil
+}
+
+// ValidateTopMinersFilters validates the filters of the top miners leaderboard and returns the normalized country code.
+func ValidateTopMinersFilters(keyword string, period TopMinersPeriod, country string) (string, error) {
+	switch period {
+	case "", DailyTopMinersPeriod, WeeklyTopMinersPeriod, MonthlyTopMinersPeriod, AllTimeTopMinersPeriod:
+	default:
+		return country, errors.Errorf("invalid period `%v`", period)
+	}
+	if country = strings.ToUpper(strings.TrimSpace(country)); country != "" &&
+		(len(country) != 2 || strings.IndexFunc(country, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0) {
+		return country, errors.Errorf("invalid country `%v`", country)
+	}
+	if keyword != "" && (period != "" || country != "") {
+		return country, errors.New("keyword can't be combined with period or country")
+	}
+
+	return country, nil
+}
+
+// The periods are aligned to the history aggregation interval, so `daily` means the current day, `weekly` the last 7 days, and so on.
+func (r *repository) topMinersPeriodStart(period TopMinersPeriod, now *time.Time) (stdlibtime.Time, error) {
+	var days int64
+	switch period {
+	case DailyTopMinersPeriod:
+		days = 1
+	case WeeklyTopMinersPeriod:
+		days = 7 //nolint:gomnd // .
+	case MonthlyTopMinersPeriod:
+		days = 30 //nolint:gomnd // .
+	case AllTimeTopMinersPeriod:
+		return stdlibtime.Time{}, nil
+	default:
+		return stdlibtime.Time{}, errors.Errorf("unsupported top miners period %v", period)
+	}
+
+	return now.Truncate(r.cfg.GlobalAggregationInterval.Parent).Add(-stdlibtime.Duration(days-1) * r.cfg.GlobalAggregationInterval.Parent), nil
+}
+
+func historicalTopMinersCacheKey(period TopMinersPeriod, country string, limit, offset uint64) string {
+	return fmt.Sprintf("top_miners_history:%v:%v:%v:%v", period, country, limit, offset)
+}
+
+func (r *repository) getCachedHistoricalTopMiners(
+	ctx context.Context, period TopMinersPeriod, country string, limit, offset uint64,
+) ([]*dwh.TopMiner, error) {
+	val, err := r.db.Get(ctx, historicalTopMinersCacheKey(period, country, limit, offset)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to get cached value")
+	}
+	rows := make([]*dwh.TopMiner, 0, limit)
+
+	return rows, errors.Wrapf(json.UnmarshalContext(ctx, val, &rows), "failed to unmarshal %v", string(val))
+}
+
+func (r *repository) cacheHistoricalTopMiners(
+	ctx context.Context, period TopMinersPeriod, country string, limit, offset uint64, rows []*dwh.TopMiner,
+) error {
+	val, err := json.MarshalContext(ctx, rows)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %#v", rows)
+	}
+
+	return errors.Wrap(r.db.SetEx(ctx, historicalTopMinersCacheKey(period, country, limit, offset), val, historicalTopMinersCacheDuration).Err(),
+		"failed to set cached value")
+}
+
 //nolint:funlen // .
 func (r *repository) GetMiningSummary(ctx context.Context, userID string) (*MiningSummary, error) {
+	r = r.forTenant(ctx)
 	id, err := GetOrInitInternalID(ctx, r.db, userID, r.cfg.WelcomeBonusV2Amount)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to getOrInitInternalID for userID:%v", userID)
@@ -218,32 +362,42 @@ func (r *repository) GetMiningSummary(ctx context.Context, userID string) (*Mini
 	if r.isAdvancedTeamEnabled(ms[0].LatestDevice) {
 		t2 = ms[0].ActiveT2Referrals
 	}
-	slashingIsOff := (ms[0].BalanceSolo+ms[0].BalanceT0+ms[0].BalanceT1+ms[0].BalanceT2) <= r.cfg.SlashingFloor || (ms[0].MiningBoostLevelIndex != nil && (*r.cfg.MiningBoost.levels.Load())[*ms[0].MiningBoostLevelIndex].SlashingDisabled)
+	slashingIsOff := (ms[0].BalanceSolo+ms[0].BalanceT0+ms[0].BalanceT1+ms[0].BalanceT2) <= r.cfg.SlashingFloorAmount() || (ms[0].MiningBoostLevelIndex != nil && (*r.cfg.MiningBoost.levels.Load())[*ms[0].MiningBoostLevelIndex].SlashingDisabled)
 	maxMiningSessionDuration := r.cfg.maxMiningSessionDuration(ms[0].MiningBoostLevelIndexField)
-	activeT1Referrals := int32(0)
-	if ms[0].MiningBoostLevelIndex != nil {
-		if ms[0].IsVerified() && ms[0].VerifiedT1Referrals >= (*r.cfg.MiningBoost.levels.Load())[len(*r.cfg.MiningBoost.levels.Load())-1].MaxT1Referrals {
-			activeT1Referrals = int32((*r.cfg.MiningBoost.levels.Load())[int(*ms[0].MiningBoostLevelIndex)].MaxT1Referrals)
-		} else {
-			activeT1Referrals = int32(math.Min(float64((*r.cfg.MiningBoost.levels.Load())[int(*ms[0].MiningBoostLevelIndex)].MaxT1Referrals), float64(ms[0].ActiveT1Referrals)))
-		}
-	} else {
-		if r.cfg.T1ReferralsAllowedWithoutAnyMiningBoostLevel {
-			activeT1Referrals = ms[0].ActiveT1Referrals
-		}
-	}
+	activeT1Referrals := r.activeT1Referrals(ms[0].MiningBoostLevelIndexField, ms[0].IsVerified(), ms[0].VerifiedT1Referrals, ms[0].ActiveT1Referrals)
 
 	return &MiningSummary{
 		MiningStreak:                r.calculateMiningStreak(now, ms[0].MiningSessionSoloStartedAt, ms[0].MiningSessionSoloEndedAt),
 		MiningSession:               r.calculateMiningSession(now, ms[0].MiningSessionSoloLastStartedAt, ms[0].MiningSessionSoloEndedAt, maxMiningSessionDuration),
 		RemainingFreeMiningSessions: r.calculateRemainingFreeMiningSessions(now, ms[0].MiningSessionSoloLastStartedAt, ms[0].MiningSessionSoloEndedAt, maxMiningSessionDuration),
-		MiningRates:                 r.calculateMiningRateSummaries(t0, extraBonus, ms[0].PreStakingAllocation, ms[0].PreStakingBonus, activeT1Referrals, t2, r.cfg.BaseMiningRate(now, ms[0].CreatedAt), negativeMiningRate, ms[0].BalanceTotalStandard+ms[0].BalanceTotalPreStaking, now, ms[0].MiningSessionSoloEndedAt, slashingIsOff), //nolint:lll // .
+		MiningRates:                 r.calculateMiningRateSummaries(t0, extraBonus, ms[0].PreStakingAllocation, ms[0].PreStakingBonus, activeT1Referrals, t2, r.cfg.BaseMiningRate(now, ms[0].CreatedAt), negativeMiningRate.Float64(), (ms[0].BalanceTotalStandard + ms[0].BalanceTotalPreStaking).Float64(), now, ms[0].MiningSessionSoloEndedAt, slashingIsOff), //nolint:lll // .
 		ExtraBonusSummary:           ExtraBonusSummary{AvailableExtraBonus: extraBonus},
 		MiningStarted:               !ms[0].MiningSessionSoloStartedAt.IsNil(),
 		KYCStepBlocked:              ms[0].KYCStepBlocked,
 	}, nil
 }
 
+// The T1 referrals that count for the mining rate are capped by the user's mining boost level,
+// unless the user is verified and has enough verified T1 referrals for the highest level.
+func (r *repository) activeT1Referrals(
+	miningBoostLevelIndex model.MiningBoostLevelIndexField, verified bool, verifiedT1Referrals uint64, activeT1Referrals int32,
+) int32 {
+	if miningBoostLevelIndex.MiningBoostLevelIndex == nil {
+		if r.cfg.T1ReferralsAllowedWithoutAnyMiningBoostLevel {
+			return activeT1Referrals
+		}
+
+		return 0
+	}
+	levels := *r.cfg.MiningBoost.levels.Load()
+	maxT1Referrals := levels[int(*miningBoostLevelIndex.MiningBoostLevelIndex)].MaxT1Referrals
+	if verified && verifiedT1Referrals >= levels[len(levels)-1].MaxT1Referrals {
+		return int32(maxT1Referrals)
+	}
+
+	return int32(math.Min(float64(maxT1Referrals), float64(activeT1Referrals)))
+}
+
 func (r *repository) isT0Online(ctx context.Context, idT0 int64, now *time.Time) (uint16, error) {
 	if idT0 == 0 {
 		return 0, nil
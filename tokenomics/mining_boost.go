@@ -33,6 +33,7 @@ import (
 )
 
 func (r *repository) GetMiningBoostSummary(ctx context.Context, userID string) (*MiningBoostSummary, error) {
+	r = r.forTenant(ctx)
 	id, err := GetOrInitInternalID(ctx, r.db, userID, r.cfg.WelcomeBonusV2Amount)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to getOrInitInternalID for userID:%v", userID)
@@ -73,6 +74,7 @@ func (r *repository) GetMiningBoostSummary(ctx context.Context, userID string) (
 }
 
 func (r *repository) InitializeMiningBoostUpgrade(ctx context.Context, miningBoostLevelIndex uint8, userID string) (*PendingMiningBoostUpgrade, error) {
+	r = r.forTenant(ctx)
 	if miningBoostLevelIndex > uint8(len(r.cfg.MiningBoost.Levels)-1) {
 		return nil, errors.New("mining boost already at max level")
 	}
@@ -121,6 +123,7 @@ func (r *repository) InitializeMiningBoostUpgrade(ctx context.Context, miningBoo
 }
 
 func (r *repository) FinalizeMiningBoostUpgrade(ctx context.Context, network BlockchainNetworkType, txHash, userID string) (*PendingMiningBoostUpgrade, error) {
+	r = r.forTenant(ctx)
 	if network != BNBBlockchainNetworkType && network != EthereumBlockchainNetworkType && network != ArbitrumBlockchainNetworkType {
 		return nil, errors.Errorf("invalid network %v", network)
 	}
@@ -368,10 +371,6 @@ const iceFlakesDenomination = 1_000_000_000_000_000_000
 func (r *repository) startICEPriceSyncer(ctx context.Context) {
 	ticker := stdlibtime.NewTicker(10 * stdlibtime.Minute) //nolint:gosec,gomnd // Not an  issue.
 	defer ticker.Stop()
-	r.cfg.MiningBoost.icePrice = new(atomic.Pointer[float64])
-	r.cfg.MiningBoost.levels = new(atomic.Pointer[[]*MiningBoostLevel])
-	r.cfg.MiningBoost.networkEndpointCurrentLBIndex = make(map[BlockchainNetworkType]*atomic.Uint64, len(r.cfg.MiningBoost.NetworkEndpoints))
-	r.cfg.MiningBoost.networkClients = make(map[BlockchainNetworkType][]*ethclient.Client, len(r.cfg.MiningBoost.NetworkEndpoints))
 	for network, endpoints := range r.cfg.MiningBoost.NetworkEndpoints {
 		clients := make([]*ethclient.Client, 0, len(endpoints))
 		for ix, endpoint := range endpoints {
@@ -402,7 +401,9 @@ func (r *repository) syncICEPrice(ctx context.Context) error {
 		return errors.Wrap(err, "failed to fetchICEPrice")
 	}
 	r.cfg.MiningBoost.icePrice.Store(&stats.Price)
-	r.cfg.MiningBoost.levels.Store(r.buildMiningBoostLevels())
+	for _, cfg := range r.cfg.withTenants() {
+		cfg.MiningBoost.levels.Store(cfg.buildMiningBoostLevels())
+	}
 
 	return nil
 }
@@ -452,12 +453,12 @@ func FetchICEPrice(ctx context.Context) (*IceStats, error) {
 	}
 }
 
-func (r *repository) buildMiningBoostLevels() *[]*MiningBoostLevel {
-	levels := make([]*MiningBoostLevel, 0, len(r.cfg.MiningBoost.Levels))
-	for dollars, level := range r.cfg.MiningBoost.Levels {
+func (c *Config) buildMiningBoostLevels() *[]*MiningBoostLevel {
+	levels := make([]*MiningBoostLevel, 0, len(c.MiningBoost.Levels))
+	for dollars, level := range c.MiningBoost.Levels {
 		clone := *level
-		clone.icePrice = math.Floor(dollars / *r.cfg.MiningBoost.icePrice.Load() * math.Pow10(miningBoostPricePrecision)) / math.Pow10(miningBoostPricePrecision)
-		clone.ICEPrice = strconv.FormatFloat(clone.icePrice*(1+(float64(r.cfg.MiningBoost.PriceDelta)/100)), 'f', miningBoostPricePrecision, 64)
+		clone.icePrice = math.Floor(dollars / *c.MiningBoost.icePrice.Load() * math.Pow10(miningBoostPricePrecision)) / math.Pow10(miningBoostPricePrecision)
+		clone.ICEPrice = strconv.FormatFloat(clone.icePrice*(1+(float64(c.MiningBoost.PriceDelta)/100)), 'f', miningBoostPricePrecision, 64)
 		levels = append(levels, &clone)
 	}
 	sort.SliceStable(levels, func(ii, jj int) bool { return levels[ii].icePrice < levels[jj].icePrice })
@@ -5,6 +5,7 @@ package tokenomics
 import (
 	"context"
 	"fmt"
+	"strings"
 	stdlibtime "time"
 
 	"github.com/goccy/go-json"
@@ -58,6 +59,7 @@ type (
 func (r *repository) StartNewMiningSession( //nolint:funlen,gocognit // A lot of handling.
 	ctx context.Context, ms *MiningSummary, rollbackNegativeMiningProgress *bool, skipKYCSteps []users.KYCStep,
 ) error {
+	r = r.forTenant(ctx)
 	userID := *ms.MiningSession.UserID
 	id, err := GetOrInitInternalID(ctx, r.db, userID, r.cfg.WelcomeBonusV2Amount)
 	if err != nil {
@@ -97,7 +99,7 @@ func (r *repository) StartNewMiningSession( //nolint:funlen,gocognit // A lot of
 	if err = r.validateKYC(ctx, userID, old[0], skipKYCSteps); err != nil {
 		return err
 	}
-	shouldRollback, err := r.validateRollbackNegativeMiningProgress(old[0].PreStakingAllocation, old[0].PreStakingBonus, old[0].SlashingRateSolo, old[0].SlashingRateT0, old[0].SlashingRateT1, old[0].SlashingRateT2, old[0].MiningSessionSoloEndedAt, old[0].ResurrectSoloUsedAt, now, rollbackNegativeMiningProgress, old[0].BalanceSoloEthereum > 0 && old[0].BalanceSoloEthereum >= old[0].BalanceSolo) //nolint:lll // .
+	shouldRollback, err := r.validateRollbackNegativeMiningProgress(old[0].PreStakingAllocation, old[0].PreStakingBonus, old[0].SlashingRateSolo.Float64(), old[0].SlashingRateT0.Float64(), old[0].SlashingRateT1.Float64(), old[0].SlashingRateT2.Float64(), old[0].MiningSessionSoloEndedAt, old[0].ResurrectSoloUsedAt, now, rollbackNegativeMiningProgress, old[0].BalanceSoloEthereum > 0 && old[0].BalanceSoloEthereum >= old[0].BalanceSolo) //nolint:lll // .
 	if err != nil {
 		return err
 	}
@@ -278,7 +280,7 @@ func (r *repository) sendMiningSessionMessage(ctx context.Context, ms *MiningSes
 	}
 	msg := &messagebroker.Message{
 		Timestamp: *ms.LastNaturalMiningStartedAt.Time,
-		Headers:   map[string]string{"producer": "freezer"},
+		Headers:   map[string]string{"producer": "freezer", tenantMessageHeader: r.cfg.Tenant},
 		Key:       *ms.UserID,
 		Topic:     r.cfg.MessageBroker.Topics[2].Name,
 		Value:     valueBytes,
@@ -294,6 +296,9 @@ func (s *miningSessionsTableSource) Process(ctx context.Context, msg *messagebro
 	if ctx.Err() != nil || len(msg.Value) == 0 {
 		return errors.Wrap(ctx.Err(), "unexpected deadline while processing message")
 	}
+	if tenant := msg.Headers[tenantMessageHeader]; tenant != "" && !strings.EqualFold(tenant, s.cfg.Tenant) {
+		return nil // It's processed by the deployment of that tenant.
+	}
 	ms := new(MiningSession)
 	if err := json.UnmarshalContext(ctx, msg.Value, ms); err != nil || ms.UserID == nil || ms.StartedAt.IsNil() {
 		return errors.Wrapf(err, "process: cannot unmarshall %v into %#v", string(msg.Value), ms)
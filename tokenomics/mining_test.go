@@ -45,3 +45,40 @@ func TestRepositoryCalculateMiningSession(t *testing.T) {
 	assert.EqualValues(t, time.New(start.Add(boostedSessionDuration).Add(repo.cfg.MiningSessionDuration.Max)), actual.EndedAt)
 	assert.True(t, *actual.Free)
 }
+
+func TestRepositoryTopMinersPeriodStart(t *testing.T) {
+	t.Parallel()
+	cfg := new(Config)
+	cfg.GlobalAggregationInterval.Parent = 24 * stdlibtime.Hour
+	repo := &repository{cfg: cfg}
+	now := time.New(stdlibtime.Date(2024, 3, 10, 15, 4, 5, 0, stdlibtime.UTC))
+
+	since, err := repo.topMinersPeriodStart(DailyTopMinersPeriod, now)
+	assert.NoError(t, err)
+	assert.Equal(t, stdlibtime.Date(2024, 3, 10, 0, 0, 0, 0, stdlibtime.UTC), since)
+	since, err = repo.topMinersPeriodStart(WeeklyTopMinersPeriod, now)
+	assert.NoError(t, err)
+	assert.Equal(t, stdlibtime.Date(2024, 3, 4, 0, 0, 0, 0, stdlibtime.UTC), since)
+	since, err = repo.topMinersPeriodStart(MonthlyTopMinersPeriod, now)
+	assert.NoError(t, err)
+	assert.Equal(t, stdlibtime.Date(2024, 2, 10, 0, 0, 0, 0, stdlibtime.UTC), since)
+	since, err = repo.topMinersPeriodStart(AllTimeTopMinersPeriod, now)
+	assert.NoError(t, err)
+	assert.True(t, since.IsZero())
+	_, err = repo.topMinersPeriodStart("yearly", now)
+	assert.Error(t, err)
+}
+
+func TestValidateTopMinersFilters(t *testing.T) {
+	t.Parallel()
+
+	country, err := ValidateTopMinersFilters("", WeeklyTopMinersPeriod, " us ")
+	assert.NoError(t, err)
+	assert.Equal(t, "US", country)
+	_, err = ValidateTopMinersFilters("", "yearly", "")
+	assert.Error(t, err)
+	_, err = ValidateTopMinersFilters("", "", "USA")
+	assert.Error(t, err)
+	_, err = ValidateTopMinersFilters("jdoe", "", "US")
+	assert.Error(t, err)
+}
@@ -20,6 +20,7 @@ type (
 )
 
 func (r *repository) GetPreStakingSummary(ctx context.Context, userID string) (*PreStakingSummary, error) {
+	r = r.forTenant(ctx)
 	ps, _, err := r.getPreStaking(ctx, userID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to getPreStaking for userID:%v", userID)
@@ -52,6 +53,7 @@ func (r *repository) getPreStaking(ctx context.Context, userID string) (*preStak
 }
 
 func (r *repository) StartOrUpdatePreStaking(ctx context.Context, st *PreStakingSummary) error {
+	r = r.forTenant(ctx)
 	existing, id, err := r.getPreStaking(ctx, st.UserID)
 	if err != nil && !errors.Is(err, ErrNotFound) {
 		return errors.Wrapf(err, "failed to getPreStaking for userID:%v", st.UserID)
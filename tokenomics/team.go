@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package tokenomics
+
+import (
+	"context"
+	stdlibtime "time"
+
+	"github.com/pkg/errors"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+//nolint:funlen // .
+func (r *repository) GetTeamSummary(ctx context.Context, userID string, days uint64) (*TeamSummary, error) {
+	r = r.forTenant(ctx)
+	id, err := GetInternalID(ctx, r.db, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to getInternalID for userID:%v", userID)
+	}
+	res, err := storage.Get[struct {
+		model.KYCState
+		model.MiningBoostLevelIndexField
+		model.LatestDeviceField
+		model.BalanceT0Field
+		model.BalanceT1Field
+		model.BalanceT2Field
+		model.PreStakingAllocationField
+		model.PreStakingBonusField
+		model.VerifiedT1ReferralsField
+		model.ActiveT1ReferralsField
+		model.ActiveT2ReferralsField
+		model.TotalT1ReferralsField
+		model.TotalT2ReferralsField
+	}](ctx, r.db, model.SerializedUsersKey(id))
+	if err != nil || len(res) == 0 {
+		if err == nil {
+			err = errors.Wrapf(ErrRelationNotFound, "missing state for id:%v", id)
+		}
+
+		return nil, errors.Wrapf(err, "failed to get teamSummary for id:%v", id)
+	}
+	usr := res[0]
+	advancedTeamEnabled := r.isAdvancedTeamEnabled(usr.LatestDevice)
+	if !advancedTeamEnabled {
+		usr.BalanceT2, usr.ActiveT2Referrals, usr.TotalT2Referrals = 0, 0, 0
+	}
+	t1Standard, t1PreStaking := ApplyPreStaking(usr.BalanceT0+usr.BalanceT1, usr.PreStakingAllocation, usr.PreStakingBonus)
+	t2Standard, t2PreStaking := ApplyPreStaking(usr.BalanceT2, usr.PreStakingAllocation, usr.PreStakingBonus)
+	summary := &TeamSummary{
+		ActiveT1Referrals:   r.activeT1Referrals(usr.MiningBoostLevelIndexField, usr.IsVerified(), usr.VerifiedT1Referrals, usr.ActiveT1Referrals),
+		ActiveT2Referrals:   usr.ActiveT2Referrals,
+		TotalT1Referrals:    usr.TotalT1Referrals,
+		TotalT2Referrals:    usr.TotalT2Referrals,
+		MaxT1Referrals:      r.maxT1Referrals(usr.MiningBoostLevelIndexField),
+		T1:                  formatAmount(t1Standard + t1PreStaking),
+		T2:                  formatAmount(t2Standard + t2PreStaking),
+		TopT1Referrals:      make([]*TeamReferral, 0),
+		TopT2Referrals:      make([]*TeamReferral, 0),
+		SlashingT1Referrals: make([]*TeamReferral, 0),
+		SlashingT2Referrals: make([]*TeamReferral, 0),
+	}
+	today := time.Now().Truncate(r.cfg.GlobalAggregationInterval.Parent)
+	history, err := r.dwh.SelectTeamEarnings(ctx, id, today.Add(-stdlibtime.Duration(days)*r.cfg.GlobalAggregationInterval.Parent))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to SelectTeamEarnings for id:%v,days:%v", id, days)
+	}
+	summary.Earnings = teamEarnings(history, usr.PreStakingAllocation, usr.PreStakingBonus, advancedTeamEnabled)
+	// Only the referrals with a recent snapshot are considered, that's what `currently` means for the slashing ones.
+	since := today.Add(-r.cfg.GlobalAggregationInterval.Parent)
+	if summary.TopT1Referrals, err = r.teamReferrals(ctx, id, dwh.T1ReferralTier, since, false); err != nil {
+		return nil, errors.Wrapf(err, "failed to get top t1 referrals for id:%v", id)
+	}
+	if summary.SlashingT1Referrals, err = r.teamReferrals(ctx, id, dwh.T1ReferralTier, since, true); err != nil {
+		return nil, errors.Wrapf(err, "failed to get slashing t1 referrals for id:%v", id)
+	}
+	if !advancedTeamEnabled {
+		return summary, nil
+	}
+	if summary.TopT2Referrals, err = r.teamReferrals(ctx, id, dwh.T2ReferralTier, since, false); err != nil {
+		return nil, errors.Wrapf(err, "failed to get top t2 referrals for id:%v", id)
+	}
+	if summary.SlashingT2Referrals, err = r.teamReferrals(ctx, id, dwh.T2ReferralTier, since, true); err != nil {
+		return nil, errors.Wrapf(err, "failed to get slashing t2 referrals for id:%v", id)
+	}
+
+	return summary, nil
+}
+
+func (r *repository) maxT1Referrals(miningBoostLevelIndex model.MiningBoostLevelIndexField) *uint64 {
+	if miningBoostLevelIndex.MiningBoostLevelIndex == nil {
+		if r.cfg.T1ReferralsAllowedWithoutAnyMiningBoostLevel {
+			return nil
+		}
+		var noReferralsAllowed uint64
+
+		return &noReferralsAllowed
+	}
+	maxT1Referrals := (*r.cfg.MiningBoost.levels.Load())[int(*miningBoostLevelIndex.MiningBoostLevelIndex)].MaxT1Referrals
+
+	return &maxT1Referrals
+}
+
+// The snapshots hold the cumulated team balances, so the earnings of a day are the difference to the previous snapshot.
+func teamEarnings(
+	history []*dwh.TeamEarnings, preStakingAllocation, preStakingBonus float64, advancedTeamEnabled bool,
+) []*TeamEarningsEntry {
+	entries := make([]*TeamEarningsEntry, 0, len(history))
+	for ix := 1; ix < len(history); ix++ {
+		t1 := (history[ix].BalanceT0 + history[ix].BalanceT1) - (history[ix-1].BalanceT0 + history[ix-1].BalanceT1)
+		var t2 model.Amount
+		if advancedTeamEnabled {
+			t2 = history[ix].BalanceT2 - history[ix-1].BalanceT2
+		}
+		t1Standard, t1PreStaking := ApplyPreStaking(t1, preStakingAllocation, preStakingBonus)
+		t2Standard, t2PreStaking := ApplyPreStaking(t2, preStakingAllocation, preStakingBonus)
+		entries = append(entries, &TeamEarningsEntry{
+			Date: history[ix].CreatedAt,
+			T1:   formatAmount(t1Standard + t1PreStaking),
+			T2:   formatAmount(t2Standard + t2PreStaking),
+		})
+	}
+
+	return entries
+}
+
+func (r *repository) teamReferrals(
+	ctx context.Context, id int64, tier dwh.ReferralTier, since stdlibtime.Time, slashingOnly bool,
+) ([]*TeamReferral, error) {
+	referrals, err := r.dwh.SelectReferrals(ctx, id, tier, since, slashingOnly, teamSummaryReferralsLimit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to SelectReferrals for id:%v,tier:%v,slashingOnly:%v", id, tier, slashingOnly)
+	}
+	teamReferrals := make([]*TeamReferral, 0, len(referrals))
+	for _, ref := range referrals {
+		teamReferrals = append(teamReferrals, &TeamReferral{
+			UserID:            ref.UserID,
+			Username:          ref.Username,
+			ProfilePictureURL: r.pictureClient.DownloadURL(ref.ProfilePictureName),
+			Contribution:      formatAmount(ref.Contribution),
+			SlashingRate:      formatAmount(ref.SlashingRate),
+		})
+	}
+
+	return teamReferrals, nil
+}
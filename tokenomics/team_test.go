@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package tokenomics
+
+import (
+	"sync/atomic"
+	"testing"
+	stdlibtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+	"github.com/ice-blockchain/freezer/model"
+	"github.com/ice-blockchain/wintr/time"
+)
+
+func TestTeamEarnings(t *testing.T) {
+	t.Parallel()
+	day := func(d int) *time.Time {
+		return time.New(stdlibtime.Date(2024, 1, d, 0, 0, 0, 0, stdlibtime.UTC))
+	}
+	history := []*dwh.TeamEarnings{
+		{CreatedAt: day(1), BalanceT0: ice(1), BalanceT1: ice(10), BalanceT2: ice(5)},
+		{CreatedAt: day(2), BalanceT0: ice(2), BalanceT1: ice(15), BalanceT2: ice(6)},
+		{CreatedAt: day(3), BalanceT0: ice(2), BalanceT1: ice(14), BalanceT2: ice(8)},
+	}
+
+	entries := teamEarnings(history, 0, 0, true)
+	require.Len(t, entries, 2)
+	assert.Equal(t, day(2), entries[0].Date)
+	assert.Equal(t, "6.00", entries[0].T1)
+	assert.Equal(t, "1.00", entries[0].T2)
+	assert.Equal(t, "-1.00", entries[1].T1)
+	assert.Equal(t, "2.00", entries[1].T2)
+
+	entries = teamEarnings(history, 100, 100, false)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "12.00", entries[0].T1)
+	assert.Equal(t, "0.00", entries[0].T2)
+	assert.Empty(t, teamEarnings(history[:1], 0, 0, true))
+}
+
+func TestRepositoryActiveT1Referrals(t *testing.T) {
+	t.Parallel()
+	cfg := new(Config)
+	cfg.MiningBoost.levels = new(atomic.Pointer[[]*MiningBoostLevel])
+	cfg.MiningBoost.levels.Store(&[]*MiningBoostLevel{{MaxT1Referrals: 5}, {MaxT1Referrals: 20}})
+	repo := &repository{cfg: cfg}
+	level := func(ix uint64) model.MiningBoostLevelIndexField {
+		return model.MiningBoostLevelIndexField{MiningBoostLevelIndex: (*model.FlexibleUint64)(&ix)}
+	}
+
+	assert.EqualValues(t, 0, repo.activeT1Referrals(model.MiningBoostLevelIndexField{}, false, 0, 7))
+	assert.EqualValues(t, 0, *repo.maxT1Referrals(model.MiningBoostLevelIndexField{}))
+	assert.EqualValues(t, 5, repo.activeT1Referrals(level(0), false, 0, 7))
+	assert.EqualValues(t, 3, repo.activeT1Referrals(level(0), false, 0, 3))
+	assert.EqualValues(t, 5, repo.activeT1Referrals(level(0), true, 20, 3))
+	assert.EqualValues(t, 3, repo.activeT1Referrals(level(0), true, 19, 3))
+	assert.EqualValues(t, 20, *repo.maxT1Referrals(level(1)))
+
+	cfg.T1ReferralsAllowedWithoutAnyMiningBoostLevel = true
+	assert.EqualValues(t, 7, repo.activeT1Referrals(model.MiningBoostLevelIndexField{}, false, 0, 7))
+	assert.Nil(t, repo.maxT1Referrals(model.MiningBoostLevelIndexField{}))
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package tokenomics
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/ice-blockchain/freezer/model"
+	appCfg "github.com/ice-blockchain/wintr/config"
+	"github.com/ice-blockchain/wintr/connectors/storage/v3"
+	"github.com/ice-blockchain/wintr/log"
+	"github.com/ice-blockchain/wintr/server"
+)
+
+// MustLoadConfig loads the configuration of the tenant of the deployment (see Config.Tenant),
+// which is the base configuration with the tenant's overlay, if any, applied on top.
+func MustLoadConfig(applicationYamlKey string, cfg *Config) {
+	appCfg.MustLoadFromKey(applicationYamlKey, cfg)
+	if tenant := strings.ToLower(cfg.Tenant); tenant != "" {
+		appCfg.MustLoadFromKey(applicationYamlKey+".tenants."+tenant, cfg)
+	}
+}
+
+// TenantHandler serves the requests with the configuration (and the redis keys) of the tenant from the `tenant` claim of the verified token.
+// Requests without it are served for the tenant of the deployment. Requests for unknown tenants are rejected.
+func TenantHandler[REQ, RESP any](
+	tenants TenantResolver,
+	handleRequest func(context.Context, *server.Request[REQ, RESP]) (*server.Response[RESP], *server.Response[server.ErrorResponse]),
+) func(context.Context, *server.Request[REQ, RESP]) (*server.Response[RESP], *server.Response[server.ErrorResponse]) {
+	return func(ctx context.Context, req *server.Request[REQ, RESP]) (*server.Response[RESP], *server.Response[server.ErrorResponse]) {
+		if claim, found := req.AuthenticatedUser.Claims[tenantClaim]; found {
+			tenant, _ := claim.(string) //nolint:errcheck // Non string claims are rejected as unknown tenants.
+			tenantCtx, err := tenants.ContextWithTenant(ctx, tenant)
+			if err != nil {
+				return nil, server.Forbidden(errors.Wrapf(err, "tenant claim %#v is not allowed", claim))
+			}
+			ctx = tenantCtx
+		}
+
+		return handleRequest(ctx, req)
+	}
+}
+
+func (r *repository) ContextWithTenant(ctx context.Context, tenant string) (context.Context, error) {
+	if _, err := r.cfg.ForTenant(tenant); err != nil {
+		return nil, err
+	}
+
+	return contextWithTenant(ctx, tenant), nil
+}
+
+func contextWithTenant(ctx context.Context, tenant string) context.Context {
+	if tenant = strings.ToLower(strings.TrimSpace(tenant)); tenant == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, tenantCtxValueKey, tenant) //nolint:revive,staticcheck // Not an issue.
+}
+
+func tenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantCtxValueKey).(string) //nolint:errcheck // Not needed.
+
+	return tenant
+}
+
+// MustConnectStorage connects to the redis of the tenant of the configuration (see Config.RedisKeyPrefix).
+func MustConnectStorage(ctx context.Context, cfg *Config, applicationYamlKey string, overriddenPoolSize ...int) storage.DB {
+	return model.MustConnectStorage(ctx, applicationYamlKey, cfg.RedisKeyPrefix, overriddenPoolSize...)
+}
+
+// ForTenant returns the configuration of the provided tenant, which is the base configuration with the tenant's overlay applied on top.
+// The base configuration is returned for the tenant of the deployment (or for no tenant at all) and ErrUnknownTenant for unknown ones.
+func (c *Config) ForTenant(tenant string) (*Config, error) {
+	tenant = strings.ToLower(strings.TrimSpace(tenant))
+	if tenant == "" || tenant == strings.ToLower(c.Tenant) {
+		return c, nil
+	}
+	if cfg, found := c.tenants[tenant]; found {
+		return cfg, nil
+	}
+
+	return nil, errors.Wrapf(ErrUnknownTenant, "tenant %v", tenant)
+}
+
+// DWHTenant is the tenant the history and the ledger of the users of the tenant are stored under in the dwh.
+// Just like for the redis keys, the tenant without a redis key prefix owns the data stored before there were tenants.
+func (c *Config) DWHTenant() string {
+	if c.RedisKeyPrefix == "" {
+		return ""
+	}
+
+	return strings.ToLower(c.Tenant)
+}
+
+// The tenant in the context is always a known one, because it is only set via ContextWithTenant.
+func (r *repository) tenantCfg(ctx context.Context) *Config {
+	cfg, err := r.cfg.ForTenant(tenantFromContext(ctx))
+	log.Panic(err)
+
+	return cfg
+}
+
+func (r *repository) TenantCapabilities(ctx context.Context) *Capabilities {
+	return &r.tenantCfg(ctx).Capabilities
+}
+
+// Every tenant gets its own copy of the whole configuration, so that overlays never leak into the base configuration,
+// but the state synced at runtime (ice price, kyc config json, etc) is shared. Only the mining boost levels are tenant specific,
+// because they depend on the tenant's `mining-boost.levels` and `mining-boost.priceDelta`.
+// Tenants other than the one of the deployment must have their own redis key prefix.
+func (c *Config) mustLoadTenants(applicationYamlKey string) {
+	c.initSharedState()
+	c.tenants = make(map[string]*Config, len(c.Tenants))
+	for name := range c.Tenants {
+		name = strings.ToLower(name)
+		if name == strings.ToLower(c.Tenant) {
+			continue
+		}
+		cfg := new(Config)
+		appCfg.MustLoadFromKey(applicationYamlKey, cfg)
+		appCfg.MustLoadFromKey(applicationYamlKey+".tenants."+name, cfg)
+		if cfg.RedisKeyPrefix == "" || cfg.RedisKeyPrefix == c.RedisKeyPrefix {
+			log.Panic(errors.Errorf("tenant %v must have its own redisKeyPrefix", name))
+		}
+		cfg.Tenant, cfg.Tenants = name, nil
+		cfg.disableAdvancedTeam = c.disableAdvancedTeam
+		cfg.kycConfigJSON = c.kycConfigJSON
+		cfg.blockchainCoinStatsJSON = c.blockchainCoinStatsJSON
+		cfg.MiningBoost.icePrice = c.MiningBoost.icePrice
+		cfg.MiningBoost.networkClients = c.MiningBoost.networkClients
+		cfg.MiningBoost.networkEndpointCurrentLBIndex = c.MiningBoost.networkEndpointCurrentLBIndex
+		cfg.MiningBoost.levels = new(atomic.Pointer[[]*MiningBoostLevel])
+		c.tenants[name] = cfg
+	}
+}
+
+func (c *Config) initSharedState() {
+	c.disableAdvancedTeam = new(atomic.Pointer[[]string])
+	c.kycConfigJSON = new(atomic.Pointer[kycConfigJSON])
+	c.blockchainCoinStatsJSON = new(atomic.Pointer[blockchainCoinStatsJSON])
+	c.MiningBoost.icePrice = new(atomic.Pointer[float64])
+	c.MiningBoost.levels = new(atomic.Pointer[[]*MiningBoostLevel])
+	c.MiningBoost.networkEndpointCurrentLBIndex = make(map[BlockchainNetworkType]*atomic.Uint64, len(c.MiningBoost.NetworkEndpoints))
+	c.MiningBoost.networkClients = make(map[BlockchainNetworkType][]*ethclient.Client, len(c.MiningBoost.NetworkEndpoints))
+}
+
+func (c *Config) withTenants() []*Config {
+	cfgs := append(make([]*Config, 0, 1+len(c.tenants)), c)
+	for _, cfg := range c.tenants {
+		cfgs = append(cfgs, cfg)
+	}
+
+	return cfgs
+}
+
+func (r *repository) mustConnectTenants(ctx context.Context) {
+	r.tenantDBs = make(map[string]storage.DB, len(r.cfg.tenants))
+	for name, cfg := range r.cfg.tenants {
+		r.tenantDBs[name] = MustConnectStorage(ctx, cfg, applicationYamlKey)
+		model.MustMigrateAmounts(ctx, r.tenantDBs[name])
+	}
+}
+
+func (r *repository) closeTenants() error {
+	errs := make([]error, 0, len(r.tenantDBs))
+	for name, db := range r.tenantDBs {
+		errs = append(errs, errors.Wrapf(db.Close(), "failed to close the db of tenant %v", name))
+	}
+
+	return multierror.Append(nil, errs...).ErrorOrNil() //nolint:wrapcheck // .
+}
+
+// The requests are served with the configuration, the redis keys and the dwh data of the tenant they were resolved to.
+func (r *repository) forTenant(ctx context.Context) *repository {
+	cfg := r.tenantCfg(ctx)
+	if cfg == r.cfg {
+		return r
+	}
+	clone := *r
+	clone.cfg = cfg
+	clone.dwh = r.dwh.ForTenant(cfg.DWHTenant())
+	if db, found := r.tenantDBs[cfg.Tenant]; found {
+		clone.db = db
+	}
+
+	return &clone
+}
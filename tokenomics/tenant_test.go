@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: ice License 1.0
+
+package tokenomics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
+)
+
+func TestRepositoryForTenant(t *testing.T) {
+	t.Parallel()
+
+	doctorx := &Config{Tenant: "doctorx", RedisKeyPrefix: "doctorx:", Capabilities: Capabilities{DistributionMode: true, MiningDisabled: true}}
+	cfg := &Config{Tenant: "generic", tenants: map[string]*Config{"doctorx": doctorx}}
+	repo := &repository{cfg: cfg, dwh: new(tenantDWH)}
+
+	ctx, err := repo.ContextWithTenant(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrUnknownTenant)
+	assert.Nil(t, ctx)
+	for _, tenant := range []string{"", " ", "generic", "Generic"} {
+		ctx, err = repo.ContextWithTenant(context.Background(), tenant)
+		require.NoError(t, err)
+		assert.Same(t, repo, repo.forTenant(ctx))
+	}
+	assert.Same(t, repo, repo.forTenant(context.Background()))
+	ctx, err = repo.ContextWithTenant(context.Background(), " DoctorX")
+	require.NoError(t, err)
+	tenantRepo := repo.forTenant(ctx)
+	assert.Same(t, doctorx, tenantRepo.cfg)
+	assert.Equal(t, "doctorx", tenantRepo.dwh.(*tenantDWH).tenant) //nolint:forcetypeassert // We know it.
+	assert.Same(t, cfg, repo.cfg)
+	assert.Empty(t, repo.dwh.(*tenantDWH).tenant) //nolint:forcetypeassert // We know it.
+
+	assert.False(t, repo.TenantCapabilities(context.Background()).MiningDisabled)
+	assert.True(t, repo.TenantCapabilities(ctx).MiningDisabled)
+	assert.True(t, repo.TenantCapabilities(ctx).DistributionMode)
+}
+
+func TestConfigDWHTenant(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, (&Config{Tenant: "generic"}).DWHTenant())
+	assert.Equal(t, "doctorx", (&Config{Tenant: "DoctorX", RedisKeyPrefix: "doctorx:"}).DWHTenant())
+}
+
+type tenantDWH struct {
+	dwh.Client
+	tenant string
+}
+
+func (t *tenantDWH) ForTenant(tenant string) dwh.Client {
+	return &tenantDWH{tenant: tenant}
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"sync/atomic"
 	stdlibtime "time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -18,7 +17,7 @@ import (
 
 	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
 	extrabonusnotifier "github.com/ice-blockchain/freezer/extra-bonus-notifier"
-	appCfg "github.com/ice-blockchain/wintr/config"
+	"github.com/ice-blockchain/freezer/model"
 	messagebroker "github.com/ice-blockchain/wintr/connectors/message_broker"
 	storagev2 "github.com/ice-blockchain/wintr/connectors/storage/v2"
 	"github.com/ice-blockchain/wintr/connectors/storage/v3"
@@ -29,23 +28,28 @@ import (
 
 func New(ctx context.Context, _ context.CancelFunc) Repository {
 	var cfg Config
-	appCfg.MustLoadFromKey(applicationYamlKey, &cfg)
+	MustLoadConfig(applicationYamlKey, &cfg)
+	cfg.mustLoadTenants(applicationYamlKey)
 
-	db := storage.MustConnect(ctx, applicationYamlKey)
-	dwhClient := dwh.MustConnect(ctx, applicationYamlKey)
+	db := MustConnectStorage(ctx, &cfg, applicationYamlKey)
+	model.MustMigrateAmounts(ctx, db)
+	dwhClient := dwh.MustConnect(ctx, applicationYamlKey, cfg.DWHTenant())
 	repo := &repository{
-		cfg:                           &cfg,
-		extraBonusStartDate:           extrabonusnotifier.MustGetExtraBonusStartDate(ctx, db),
-		extraBonusIndicesDistribution: extrabonusnotifier.MustGetExtraBonusIndicesDistribution(ctx, db),
-		shutdown: func() error {
-			return multierror.Append(db.Close(), dwhClient.Close()).ErrorOrNil()
-		},
-		db:            db,
-		dwh:           dwhClient,
-		pictureClient: picture.New(applicationYamlKey),
+		cfg:                               &cfg,
+		extraBonusStartDate:               extrabonusnotifier.MustGetExtraBonusStartDate(ctx, db),
+		extraBonusIndicesDistribution:     extrabonusnotifier.MustGetExtraBonusIndicesDistribution(ctx, db),
+		livenessLoadDistributionStartDate: mustGetLivenessLoadDistributionStartDate(ctx, db),
+		db:                                db,
+		dwh:                               dwhClient,
+		pictureClient:                     picture.New(applicationYamlKey),
+	}
+	repo.mustConnectTenants(ctx)
+	repo.shutdown = func() error {
+		return multierror.Append(db.Close(), dwhClient.Close(), repo.closeTenants()).ErrorOrNil()
 	}
 	go repo.startICEPriceSyncer(ctx)
 	go repo.startDisableAdvancedTeamCfgSyncer(ctx)
+	go repo.startKYCConfigJSONSyncer(ctx)
 	go repo.startBlockchainCoinStatsJSONSyncer(ctx)
 
 	now := time.Now()
@@ -59,16 +63,18 @@ func New(ctx context.Context, _ context.CancelFunc) Repository {
 
 func StartProcessor(ctx context.Context, cancel context.CancelFunc) Processor {
 	var cfg Config
-	appCfg.MustLoadFromKey(applicationYamlKey, &cfg)
-	dwhClient := dwh.MustConnect(ctx, applicationYamlKey)
+	MustLoadConfig(applicationYamlKey, &cfg)
+	cfg.mustLoadTenants(applicationYamlKey)
+	dwhClient := dwh.MustConnect(ctx, applicationYamlKey, cfg.DWHTenant())
 	prc := &processor{repository: &repository{
 		cfg:           &cfg,
-		db:            storage.MustConnect(context.Background(), applicationYamlKey),
+		db:            MustConnectStorage(context.Background(), &cfg, applicationYamlKey),
 		globalDB:      storagev2.MustConnect(context.Background(), globalDDL, applicationYamlKey),
 		mb:            messagebroker.MustConnect(context.Background(), applicationYamlKey),
 		dwh:           dwhClient,
 		pictureClient: picture.New(applicationYamlKey),
 	}}
+	model.MustMigrateAmounts(ctx, prc.db)
 	//nolint:contextcheck // It's intended. Cuz we want to close everything gracefully.
 	mbConsumer := messagebroker.MustConnectAndStartConsuming(context.Background(), cancel, applicationYamlKey,
 		&usersTableSource{processor: prc},
@@ -183,7 +189,6 @@ func (r *repository) checkDBHealth(ctx context.Context) error {
 func (r *repository) startDisableAdvancedTeamCfgSyncer(ctx context.Context) {
 	ticker := stdlibtime.NewTicker(5 * stdlibtime.Minute) //nolint:gosec,gomnd // Not an  issue.
 	defer ticker.Stop()
-	r.cfg.disableAdvancedTeam = new(atomic.Pointer[[]string])
 	log.Panic(errors.Wrap(r.syncDisableAdvancedTeamCfg(ctx), "failed to syncDisableAdvancedTeamCfg"))
 
 	for {
@@ -351,3 +356,13 @@ func (c *Config) globalAggregationIntervalParentDateFormat() string {
 		return ""
 	}
 }
+
+// SlashingFloorAmount is SlashingFloor, as a model.Amount.
+func (c *Config) SlashingFloorAmount() model.Amount {
+	return model.MustNewAmount(c.SlashingFloor)
+}
+
+// WelcomeBonusV2 is WelcomeBonusV2Amount, as a model.Amount.
+func (c *Config) WelcomeBonusV2() model.Amount {
+	return model.MustNewAmount(c.WelcomeBonusV2Amount)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/ice-blockchain/eskimo/users"
+	dwh "github.com/ice-blockchain/freezer/bookkeeper/storage"
 	"github.com/ice-blockchain/freezer/model"
 	messagebroker "github.com/ice-blockchain/wintr/connectors/message_broker"
 	"github.com/ice-blockchain/wintr/connectors/storage/v3"
@@ -113,8 +114,8 @@ func (s *usersTableSource) deleteUser(ctx context.Context, usr *users.User) erro
 			}
 		}
 		if idTMinus1Key := model.SerializedUsersKey(dbUserAfterMiningStopped[0].IDTMinus1); idTMinus1Key != "" {
-			if amount := dbUserAfterMiningStopped[0].BalanceForTMinus1; amount > 0.0 {
-				if err = pipeliner.HIncrByFloat(ctx, idTMinus1Key, "balance_t2_pending", -amount).Err(); err != nil {
+			if amount := dbUserAfterMiningStopped[0].BalanceForTMinus1; amount > 0 {
+				if err = pipeliner.HIncrBy(ctx, idTMinus1Key, "balance_t2_pending", -int64(amount)).Err(); err != nil {
 					return err
 				}
 			}
@@ -149,6 +150,14 @@ func (s *usersTableSource) deleteUser(ctx context.Context, usr *users.User) erro
 			errs = append(errs, errors.Wrapf(err, "failed to run `%#v`", result.FullName()))
 		}
 	}
+	if amount := dbUserAfterMiningStopped[0].BalanceForTMinus1; amount > 0 && dbUserAfterMiningStopped[0].IDTMinus1 != 0 && len(errs) == 0 {
+		if tMinus1UserID, gErr := s.getLedgerUserID(ctx, dbUserAfterMiningStopped[0].IDTMinus1); gErr != nil {
+			errs = append(errs, errors.Wrapf(gErr, "failed to get the tMinus1 of id:%v for the ledger", id))
+		} else {
+			entry := newLedgerEntry(dbUserAfterMiningStopped[0].IDTMinus1, tMinus1UserID, dwh.T2PendingLedgerReason, usr.ID, -amount)
+			errs = append(errs, errors.Wrapf(s.insertLedgerEntries(ctx, entry), "failed to insert the ledger entry for the tMinus1 of id:%v", id))
+		}
+	}
 	errs = append(errs, errors.Wrapf(s.dwh.DeleteUserInfo(ctx, id), "failed to delete clickhouse information for user id:%v,id:%v", usr.ID, id))
 
 	return errors.Wrapf(multierror.Append(nil, errs...).ErrorOrNil(), "failed to delete userID:%v,id:%v", usr.ID, id)
@@ -237,7 +246,7 @@ func (s *usersTableSource) replaceUser(ctx context.Context, usr *users.User) err
 	).ErrorOrNil()
 }
 
-func (r *repository) updateReferredBy(ctx context.Context, id int64, oldIDT0, oldTMinus1 *int64, userID, referredBy string, balanceForTMinus1 float64) error {
+func (r *repository) updateReferredBy(ctx context.Context, id int64, oldIDT0, oldTMinus1 *int64, userID, referredBy string, balanceForTMinus1 model.Amount) error {
 	if referredBy == userID ||
 		referredBy == "" ||
 		referredBy == r.cfg.DefaultReferralName ||
@@ -272,13 +281,19 @@ func (r *repository) updateReferredBy(ctx context.Context, id int64, oldIDT0, ol
 				return errors.Wrapf(err3, "failed to get users entry for tMinus1ID:%v", t0Referral[0].IDT0)
 			} else if len(tMinus1Referral) == 1 {
 				newPartialState.IDTMinus1 = -tMinus1Referral[0].ID
-				if balanceForTMinus1 > 0.0 {
-					results, err4 := r.db.TxPipelined(ctx, func(pipeliner redis.Pipeliner) error {
-						if *oldTMinus1 < 0 {
-							*oldTMinus1 *= -1
+				if balanceForTMinus1 > 0 {
+					if *oldTMinus1 < 0 {
+						*oldTMinus1 *= -1
+					}
+					var oldTMinus1UserID string
+					if *oldTMinus1 != 0 {
+						if oldTMinus1UserID, err = r.getLedgerUserID(ctx, *oldTMinus1); err != nil {
+							return errors.Wrapf(err, "failed to get the old tMinus1 of id:%v for the ledger", id)
 						}
+					}
+					results, err4 := r.db.TxPipelined(ctx, func(pipeliner redis.Pipeliner) error {
 						if oldIdTMinus1Key := model.SerializedUsersKey(oldTMinus1); oldIdTMinus1Key != "" {
-							if err = pipeliner.HIncrByFloat(ctx, oldIdTMinus1Key, "balance_t2_pending", -balanceForTMinus1).Err(); err != nil {
+							if err = pipeliner.HIncrBy(ctx, oldIdTMinus1Key, "balance_t2_pending", -int64(balanceForTMinus1)).Err(); err != nil {
 								return err
 							}
 						}
@@ -287,7 +302,7 @@ func (r *repository) updateReferredBy(ctx context.Context, id int64, oldIDT0, ol
 							newTMinus1 *= -1
 						}
 						if newIdTMinus1Key := model.SerializedUsersKey(newTMinus1); newIdTMinus1Key != "" {
-							if err = pipeliner.HIncrByFloat(ctx, newIdTMinus1Key, "balance_t2_pending", balanceForTMinus1).Err(); err != nil {
+							if err = pipeliner.HIncrBy(ctx, newIdTMinus1Key, "balance_t2_pending", int64(balanceForTMinus1)).Err(); err != nil {
 								return err
 							}
 						}
@@ -306,6 +321,14 @@ func (r *repository) updateReferredBy(ctx context.Context, id int64, oldIDT0, ol
 					if mErrs := multierror.Append(nil, errs...); mErrs.ErrorOrNil() != nil {
 						return errors.Wrapf(mErrs.ErrorOrNil(), "failed to move t2 balances for id:%v,id:%v", userID, id)
 					}
+					ledgerEntries := append(make([]*dwh.LedgerEntry, 0, 1+1),
+						newLedgerEntry(tMinus1Referral[0].ID, tMinus1Referral[0].UserID, dwh.T2PendingLedgerReason, userID, balanceForTMinus1))
+					if *oldTMinus1 != 0 {
+						ledgerEntries = append(ledgerEntries, newLedgerEntry(*oldTMinus1, oldTMinus1UserID, dwh.T2PendingLedgerReason, userID, -balanceForTMinus1))
+					}
+					if err = r.insertLedgerEntries(ctx, ledgerEntries...); err != nil {
+						return errors.Wrapf(err, "failed to insert the t2 ledger entries for id:%v", id)
+					}
 				}
 			}
 		}
@@ -315,7 +338,7 @@ func (r *repository) updateReferredBy(ctx context.Context, id int64, oldIDT0, ol
 			localIDT0 *= -1
 		}
 		results, err4 := r.db.TxPipelined(ctx, func(pipeliner redis.Pipeliner) error {
-			if innerErr := pipeliner.HIncrBy(ctx, model.SerializedUsersKey(localIDT0), "balance_t1_welcome_bonus_pending", int64(r.cfg.WelcomeBonusV2Amount)).Err(); innerErr != nil {
+			if innerErr := pipeliner.HIncrBy(ctx, model.SerializedUsersKey(localIDT0), "balance_t1_welcome_bonus_pending", int64(r.cfg.WelcomeBonusV2())).Err(); innerErr != nil {
 				return innerErr
 			}
 			if innerErr := pipeliner.HIncrBy(ctx, model.SerializedUsersKey(localIDT0), "total_t1_referrals", 1).Err(); innerErr != nil {
@@ -336,11 +359,11 @@ func (r *repository) updateReferredBy(ctx context.Context, id int64, oldIDT0, ol
 		if mErrs := multierror.Append(nil, errs...); mErrs.ErrorOrNil() != nil {
 			return errors.Wrapf(mErrs.ErrorOrNil(), "failed to run TxPipelined for id:%v,id:%v", userID, id)
 		}
-
 		*oldIDT0 = newPartialState.IDT0
 		*oldTMinus1 = newPartialState.IDTMinus1
 
-		return nil
+		return errors.Wrapf(r.insertLedgerEntries(ctx, newLedgerEntry(localIDT0, referredBy, dwh.WelcomeBonusLedgerReason, userID, r.cfg.WelcomeBonusV2())),
+			"failed to insert the welcome bonus ledger entry for id:%v", id)
 	}
 	*oldIDT0 = newPartialState.IDT0
 	*oldTMinus1 = newPartialState.IDTMinus1
@@ -472,7 +495,7 @@ func GetOrInitInternalID(ctx context.Context, db storage.DB, userID string, welc
 		if err == nil {
 			accessibleKeys = append(make([]string, 0, 1), model.SerializedUsersKey(id))
 			for err = errors.New("init"); ctx.Err() == nil && err != nil; {
-				if err = initUserScript.EvalSha(ctx, db, accessibleKeys, userID, strconv.FormatFloat(welcomeBonus, 'f', 1, 64)).Err(); err == nil || errors.Is(err, redis.Nil) || strings.Contains(err.Error(), "race condition") {
+				if err = initUserScript.EvalSha(ctx, db, accessibleKeys, userID, model.MustNewAmount(welcomeBonus)).Err(); err == nil || errors.Is(err, redis.Nil) || strings.Contains(err.Error(), "race condition") {
 					if err != nil && strings.Contains(err.Error(), "race condition") {
 						log.Error(errors.Wrapf(err, "[2]race condition while evaling initUserScript for userID:%v", userID))
 					}